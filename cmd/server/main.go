@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"github.com/beliaev-aa/notifications/internal/app"
 	"github.com/beliaev-aa/notifications/internal/config"
 	"github.com/beliaev-aa/notifications/internal/utils"
@@ -36,9 +37,12 @@ func main() {
 		}
 	}
 
-	application := app.NewApp(cfg, logger)
+	application, err := app.NewApp(cfg, logger)
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to initialize application")
+	}
 
-	if err = application.Run(); err != nil {
+	if err = application.Run(context.Background()); err != nil {
 		logger.WithError(err).Fatal("Error running notification server")
 	}
 }
@@ -0,0 +1,158 @@
+package deadletter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// fileExtension расширение файлов недоставленных уведомлений
+const fileExtension = ".json"
+
+// validID ограничивает идентификаторы, чтобы идентификатор из запроса не мог указать на файл вне каталога
+var validID = regexp.MustCompile(`^[0-9a-f]{1,64}$`)
+
+// FileStore хранит недоставленные уведомления в каталоге, по одному JSON файлу на уведомление
+// Файл сначала пишется во временный и затем атомарно переименовывается
+type FileStore struct {
+	dir    string
+	logger *logrus.Logger
+	mu     sync.Mutex
+}
+
+// NewFileStore создает хранилище в каталоге dir, создавая каталог при необходимости
+func NewFileStore(dir string, logger *logrus.Logger) (port.DeadLetterStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create dead letter directory %s: %w", dir, err)
+	}
+
+	return &FileStore{
+		dir:    dir,
+		logger: logger,
+	}, nil
+}
+
+// Add сохраняет недоставленное уведомление
+func (s *FileStore) Add(letter port.DeadLetter) error {
+	if !validID.MatchString(letter.ID) {
+		return fmt.Errorf("invalid dead letter id %q", letter.ID)
+	}
+
+	data, err := json.MarshalIndent(letter, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode dead letter: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.path(letter.ID)
+	tmpPath := path + ".tmp"
+	if err = os.WriteFile(tmpPath, data, 0o640); err != nil {
+		return fmt.Errorf("failed to write dead letter: %w", err)
+	}
+	if err = os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write dead letter: %w", err)
+	}
+
+	return nil
+}
+
+// List возвращает все недоставленные уведомления в порядке возникновения ошибки
+// Поврежденные файлы пропускаются
+func (s *FileStore) List() ([]port.DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dead letter directory: %w", err)
+	}
+
+	letters := make([]port.DeadLetter, 0, len(entries))
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), fileExtension)
+		if entry.IsDir() || !ok || !validID.MatchString(id) {
+			continue
+		}
+
+		letter, readErr := s.read(id)
+		if readErr != nil {
+			s.logger.WithError(readErr).WithFields(logrus.Fields{
+				"file": entry.Name(),
+			}).Warn("Skipping unreadable dead letter")
+			continue
+		}
+		letters = append(letters, letter)
+	}
+
+	sort.SliceStable(letters, func(i, j int) bool {
+		return letters[i].FailedAt.Before(letters[j].FailedAt)
+	})
+
+	return letters, nil
+}
+
+// Get возвращает недоставленное уведомление по идентификатору
+func (s *FileStore) Get(id string) (port.DeadLetter, error) {
+	if !validID.MatchString(id) {
+		return port.DeadLetter{}, fmt.Errorf("dead letter %q: %w", id, port.ErrNotFound)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.read(id)
+}
+
+// Delete удаляет недоставленное уведомление
+func (s *FileStore) Delete(id string) error {
+	if !validID.MatchString(id) {
+		return fmt.Errorf("dead letter %q: %w", id, port.ErrNotFound)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := os.Remove(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("dead letter %q: %w", id, port.ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete dead letter: %w", err)
+	}
+
+	return nil
+}
+
+// read читает файл недоставленного уведомления, вызывается под блокировкой
+func (s *FileStore) read(id string) (port.DeadLetter, error) {
+	data, err := os.ReadFile(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return port.DeadLetter{}, fmt.Errorf("dead letter %q: %w", id, port.ErrNotFound)
+	}
+	if err != nil {
+		return port.DeadLetter{}, fmt.Errorf("failed to read dead letter: %w", err)
+	}
+
+	var letter port.DeadLetter
+	if err = json.Unmarshal(data, &letter); err != nil {
+		return port.DeadLetter{}, fmt.Errorf("failed to decode dead letter: %w", err)
+	}
+
+	return letter, nil
+}
+
+// path возвращает путь к файлу недоставленного уведомления
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.dir, id+fileExtension)
+}
@@ -0,0 +1,187 @@
+package deadletter
+
+import (
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+	return logger
+}
+
+func newTestLetter(id string, failedAt time.Time) port.DeadLetter {
+	return port.DeadLetter{
+		ID:         id,
+		EventID:    "event" + id,
+		Key:        "DEMO-1",
+		Project:    "demo",
+		Payload:    &parser.YoutrackWebhookPayload{Issue: parser.YoutrackIssue{IDReadable: "DEMO-1", Summary: "Summary"}},
+		Target:     port.NotificationTarget{Channel: port.ChannelTelegram, ChatID: "chat"},
+		LastError:  "telegram API error: status 400",
+		Attempts:   3,
+		ReceivedAt: failedAt.Add(-time.Minute),
+		FailedAt:   failedAt,
+	}
+}
+
+func openStore(t *testing.T, dir string) port.DeadLetterStore {
+	t.Helper()
+	store, err := NewFileStore(dir, newTestLogger())
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	return store
+}
+
+func TestNewFileStore(t *testing.T) {
+	type testCase struct {
+		name          string
+		dir           func(t *testing.T) string
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "Creates_Missing_Directory",
+			dir: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "nested", "dead-letters")
+			},
+			expectedError: false,
+		},
+		{
+			name: "Directory_Path_Is_File",
+			dir: func(t *testing.T) string {
+				path := filepath.Join(t.TempDir(), "file")
+				if err := os.WriteFile(path, []byte("x"), 0o600); err != nil {
+					t.Fatalf("failed to create file: %v", err)
+				}
+				return path
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store, err := NewFileStore(tc.dir(t), newTestLogger())
+
+			if tc.expectedError {
+				if err == nil {
+					t.Error("expected error, got: nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if store == nil {
+				t.Error("expected store to be created, got: nil")
+			}
+		})
+	}
+}
+
+func TestFileStore_AddListGetDelete(t *testing.T) {
+	dir := t.TempDir()
+	store := openStore(t, dir)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	second := newTestLetter("b2", base.Add(time.Minute))
+	first := newTestLetter("a1", base)
+	for _, letter := range []port.DeadLetter{second, first} {
+		if err := store.Add(letter); err != nil {
+			t.Fatalf("failed to add letter: %v", err)
+		}
+	}
+
+	// Хранилище переживает перезапуск
+	store = openStore(t, dir)
+
+	letters, err := store.List()
+	if err != nil {
+		t.Fatalf("failed to list letters: %v", err)
+	}
+	if diff := cmp.Diff([]port.DeadLetter{first, second}, letters); diff != "" {
+		t.Errorf("letters mismatch (-want +got):\n%s", diff)
+	}
+
+	letter, err := store.Get("b2")
+	if err != nil {
+		t.Fatalf("failed to get letter: %v", err)
+	}
+	if diff := cmp.Diff(second, letter); diff != "" {
+		t.Errorf("letter mismatch (-want +got):\n%s", diff)
+	}
+
+	if err = store.Delete("a1"); err != nil {
+		t.Fatalf("failed to delete letter: %v", err)
+	}
+	if _, err = store.Get("a1"); !errors.Is(err, port.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got: %v", err)
+	}
+	if err = store.Delete("a1"); !errors.Is(err, port.ErrNotFound) {
+		t.Errorf("expected ErrNotFound on repeated delete, got: %v", err)
+	}
+}
+
+func TestFileStore_InvalidID(t *testing.T) {
+	type testCase struct {
+		name string
+		id   string
+	}
+
+	testCases := []testCase{
+		{name: "Empty_ID", id: ""},
+		{name: "Path_Traversal", id: "../config"},
+		{name: "Uppercase_ID", id: "ABC"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := openStore(t, t.TempDir())
+
+			if err := store.Add(newTestLetter(tc.id, time.Now())); err == nil {
+				t.Error("expected Add to fail for invalid id, got: nil")
+			}
+			if _, err := store.Get(tc.id); !errors.Is(err, port.ErrNotFound) {
+				t.Errorf("expected ErrNotFound from Get, got: %v", err)
+			}
+			if err := store.Delete(tc.id); !errors.Is(err, port.ErrNotFound) {
+				t.Errorf("expected ErrNotFound from Delete, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestFileStore_List_SkipsCorruptedFiles(t *testing.T) {
+	dir := t.TempDir()
+	store := openStore(t, dir)
+
+	letter := newTestLetter("a1", time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	if err := store.Add(letter); err != nil {
+		t.Fatalf("failed to add letter: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "b2.json"), []byte("{broken"), 0o600); err != nil {
+		t.Fatalf("failed to write corrupted file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o600); err != nil {
+		t.Fatalf("failed to write unrelated file: %v", err)
+	}
+
+	letters, err := store.List()
+	if err != nil {
+		t.Fatalf("failed to list letters: %v", err)
+	}
+	if len(letters) != 1 || letters[0].ID != "a1" {
+		t.Errorf("expected only valid letter a1, got: %+v", letters)
+	}
+}
@@ -0,0 +1,170 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"unicode/utf8"
+)
+
+// Ограничения Discord на длину сообщения webhook в символах
+const (
+	discordMaxEmbedLength       = 6000
+	discordMaxTitleLength       = 256
+	discordMaxDescriptionLength = 4096
+	discordMaxFieldValueLength  = 1024
+	discordMaxFooterLength      = 2048
+)
+
+// Значение поля embed, если значение не указано: Discord не принимает поля с пустым значением
+const discordEmptyFieldValue = "—"
+
+// discordMessage описывает сообщение webhook Discord
+type discordMessage struct {
+	Content         string                 `json:"content,omitempty"`
+	Embeds          []discordEmbed         `json:"embeds"`
+	AllowedMentions discordAllowedMentions `json:"allowed_mentions"`
+}
+
+// discordEmbed описывает embed сообщения Discord
+type discordEmbed struct {
+	Title       string              `json:"title,omitempty"`
+	URL         string              `json:"url,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Color       int                 `json:"color"`
+	Author      *discordEmbedAuthor `json:"author,omitempty"`
+	Fields      []discordEmbedField `json:"fields"`
+	Footer      *discordEmbedFooter `json:"footer,omitempty"`
+}
+
+// discordEmbedAuthor описывает автора embed, в нем указывается проект
+type discordEmbedAuthor struct {
+	Name string `json:"name"`
+}
+
+// discordEmbedField описывает поле embed
+type discordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// discordEmbedFooter описывает подвал embed, в нем указывается автор изменения
+type discordEmbedFooter struct {
+	Text string `json:"text"`
+}
+
+// discordAllowedMentions ограничивает упоминания, которые Discord отправит участникам
+// Пустой список запрещает упоминания из текста задачи и комментария, включая @everyone
+type discordAllowedMentions struct {
+	Parse []string `json:"parse"`
+}
+
+// DiscordMentionFormatter форматирует упоминания для Discord
+// Webhook не знает ID пользователей Discord, поэтому пользователи указываются по имени
+type DiscordMentionFormatter struct{}
+
+// FormatMention форматирует упоминание пользователя для Discord
+func (f *DiscordMentionFormatter) FormatMention(user parser.YoutrackUser) string {
+	return escapeBackslashMarkdown(extractUserName(&user))
+}
+
+// FormatDiscord форматирует payload для Discord канала в сообщение с embed:
+// заголовок со ссылкой на задачу, поля состояния, приоритета и исполнителя, цвет по приоритету
+// и подвал с автором изменения. Текст сокращается до ограничений Discord
+func FormatDiscord(payload *parser.YoutrackWebhookPayload) string {
+	mentionFormatter := &DiscordMentionFormatter{}
+
+	mention := ""
+	if payload.Issue.Assignee != nil {
+		mention = mentionFormatter.FormatMention(*payload.Issue.Assignee)
+	}
+
+	changed := extractChangeWithEscaper(payload.Changes, mention, markdownValueExtractor(mentionFormatter, escapeBackslashMarkdown, "\n"), escapeBackslashMarkdown, discordBold)
+
+	message := discordMessage{AllowedMentions: discordAllowedMentions{Parse: []string{}}}
+	if changed != nil {
+		message.Content = truncateText(changed.header, port.DiscordMaxContentLength)
+	}
+
+	state := escapeBackslashMarkdown(extractFieldValue(payload.Issue.State))
+	if changed != nil && changed.field == State {
+		state = changed.value
+	}
+
+	priority := escapeBackslashMarkdown(extractFieldValue(payload.Issue.Priority))
+	if changed != nil && changed.field == Priority {
+		priority = changed.value
+	}
+
+	assignee := mention
+	if changed != nil && changed.field == Assignee {
+		assignee = changed.value
+	}
+
+	embed := discordEmbed{
+		Title: truncateText(payload.Issue.Summary, discordMaxTitleLength),
+		URL:   payload.Issue.URL,
+		Color: priorityColor(extractFieldValue(payload.Issue.Priority)),
+		Fields: []discordEmbedField{
+			discordField("📊 Состояние", state),
+			discordField("⚡️ Приоритет", priority),
+			discordField("👤 Назначена", assignee),
+		},
+	}
+
+	if project := extractFieldValue(payload.Project); project != "" {
+		embed.Author = &discordEmbedAuthor{Name: truncateText(fmt.Sprintf("📁 %s", project), discordMaxTitleLength)}
+	}
+
+	if updater := extractUserName(payload.Updater); updater != "" {
+		embed.Footer = &discordEmbedFooter{Text: truncateText(fmt.Sprintf("✏️ Автор изменения: %s", updater), discordMaxFooterLength)}
+	}
+
+	if changed != nil && changed.field == Comment {
+		description := fmt.Sprintf("**💬 Комментарий:**\n%s", changed.value)
+		maxLength := discordMaxEmbedLength - discordEmbedLength(embed)
+		if maxLength > discordMaxDescriptionLength {
+			maxLength = discordMaxDescriptionLength
+		}
+		embed.Description = truncateText(description, maxLength)
+	}
+
+	message.Embeds = []discordEmbed{embed}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return message.Content
+	}
+
+	return string(data)
+}
+
+// discordBold выделяет заголовок изменения задачи полужирным шрифтом
+func discordBold(text string) string {
+	return "**" + text + "**"
+}
+
+// discordField создает поле embed, сокращая значение до ограничения Discord
+func discordField(name string, value string) discordEmbedField {
+	if value == "" {
+		value = discordEmptyFieldValue
+	}
+	return discordEmbedField{Name: name, Value: truncateText(value, discordMaxFieldValueLength), Inline: true}
+}
+
+// discordEmbedLength возвращает длину текста embed, которая учитывается в общем ограничении Discord
+func discordEmbedLength(embed discordEmbed) int {
+	length := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+	for _, field := range embed.Fields {
+		length += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+	}
+	if embed.Author != nil {
+		length += utf8.RuneCountInString(embed.Author.Name)
+	}
+	if embed.Footer != nil {
+		length += utf8.RuneCountInString(embed.Footer.Text)
+	}
+	return length
+}
@@ -0,0 +1,229 @@
+package formatter
+
+import (
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/google/go-cmp/cmp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFormatDiscord(t *testing.T) {
+	type testCase struct {
+		name            string
+		payload         *parser.YoutrackWebhookPayload
+		expectedMessage discordMessage
+	}
+
+	projectName := "TestProject"
+	issueSummary := "Fix *bold* styles"
+	issueURL := "https://youtrack.test/issue/PROJ-123"
+	statePresentation := "В работе"
+	priorityName := "Major"
+	assigneeFullName := "John_Doe"
+	updaterFullName := "Jane Smith"
+
+	newPayload := func(changes ...parser.YoutrackChange) *parser.YoutrackWebhookPayload {
+		return &parser.YoutrackWebhookPayload{
+			Project: &parser.YoutrackFieldValue{Name: &projectName},
+			Issue: parser.YoutrackIssue{
+				Summary:  issueSummary,
+				URL:      issueURL,
+				State:    &parser.YoutrackFieldValue{Presentation: &statePresentation},
+				Priority: &parser.YoutrackFieldValue{Name: &priorityName},
+				Assignee: &parser.YoutrackUser{FullName: &assigneeFullName},
+			},
+			Updater: &parser.YoutrackUser{FullName: &updaterFullName},
+			Changes: changes,
+		}
+	}
+
+	newEmbed := func(state, priority, assignee, description string) discordEmbed {
+		return discordEmbed{
+			Title:       "Fix *bold* styles",
+			URL:         issueURL,
+			Description: description,
+			Color:       0xf57c00,
+			Author:      &discordEmbedAuthor{Name: "📁 TestProject"},
+			Fields: []discordEmbedField{
+				{Name: "📊 Состояние", Value: state, Inline: true},
+				{Name: "⚡️ Приоритет", Value: priority, Inline: true},
+				{Name: "👤 Назначена", Value: assignee, Inline: true},
+			},
+			Footer: &discordEmbedFooter{Text: "✏️ Автор изменения: Jane Smith"},
+		}
+	}
+
+	noMentions := discordAllowedMentions{Parse: []string{}}
+
+	testCases := []testCase{
+		{
+			name:    "Format_Discord_Without_Changes",
+			payload: newPayload(),
+			expectedMessage: discordMessage{
+				Embeds:          []discordEmbed{newEmbed("В работе", "Major", "John\\_Doe", "")},
+				AllowedMentions: noMentions,
+			},
+		},
+		{
+			name: "Format_Discord_State_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    State,
+				OldValue: []byte(`{"name": "To Do", "presentation": "К выполнению"}`),
+				NewValue: []byte(`{"name": "In Progress", "presentation": "В работе"}`),
+			}),
+			expectedMessage: discordMessage{
+				Content:         "**📊 Изменен статус задачи**",
+				Embeds:          []discordEmbed{newEmbed("К выполнению → В работе", "Major", "John\\_Doe", "")},
+				AllowedMentions: noMentions,
+			},
+		},
+		{
+			name: "Format_Discord_Priority_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Priority,
+				OldValue: []byte(`null`),
+				NewValue: []byte(`{"name": "Major"}`),
+			}),
+			expectedMessage: discordMessage{
+				Content:         "**⚡ Изменен приоритет задачи**",
+				Embeds:          []discordEmbed{newEmbed("В работе", "(Не установлен) → Major", "John\\_Doe", "")},
+				AllowedMentions: noMentions,
+			},
+		},
+		{
+			name: "Format_Discord_Assignee_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Assignee,
+				OldValue: []byte(`{"fullName": "Old Owner"}`),
+				NewValue: []byte(`{"fullName": "John_Doe"}`),
+			}),
+			expectedMessage: discordMessage{
+				Content:         "**👤 Изменен исполнитель задачи**",
+				Embeds:          []discordEmbed{newEmbed("В работе", "Major", "Old Owner → John\\_Doe", "")},
+				AllowedMentions: noMentions,
+			},
+		},
+		{
+			name: "Format_Discord_Comment_With_Mentions",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Comment,
+				NewValue: []byte(`{"text": "Please check \\*this\\* @everyone", "mentionedUsers": [{"fullName": "Ann Lee"}]}`),
+			}),
+			expectedMessage: discordMessage{
+				Content: "**💬 Добавлен комментарий**",
+				Embeds: []discordEmbed{newEmbed("В работе", "Major", "John\\_Doe",
+					"**💬 Комментарий:**\nPlease check \\*this\\* @everyone\n[Упомянуты: Ann Lee]")},
+				AllowedMentions: noMentions,
+			},
+		},
+		{
+			name: "Format_Discord_Without_Project_Priority_And_Updater",
+			payload: &parser.YoutrackWebhookPayload{
+				Issue: parser.YoutrackIssue{Summary: "Summary"},
+			},
+			expectedMessage: discordMessage{
+				Embeds: []discordEmbed{{
+					Title: "Summary",
+					Color: defaultPriorityColor,
+					Fields: []discordEmbedField{
+						{Name: "📊 Состояние", Value: discordEmptyFieldValue, Inline: true},
+						{Name: "⚡️ Приоритет", Value: discordEmptyFieldValue, Inline: true},
+						{Name: "👤 Назначена", Value: discordEmptyFieldValue, Inline: true},
+					},
+				}},
+				AllowedMentions: noMentions,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := FormatDiscord(tc.payload)
+
+			var message discordMessage
+			if err := json.Unmarshal([]byte(result), &message); err != nil {
+				t.Fatalf("expected JSON message, got: %q (%v)", result, err)
+			}
+
+			if diff := cmp.Diff(tc.expectedMessage, message); diff != "" {
+				t.Errorf("message mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFormatDiscord_Limits(t *testing.T) {
+	projectName := "TestProject"
+	summary := strings.Repeat("Очень длинное название задачи. ", 20)
+	state := strings.Repeat("Состояние ", 200)
+	updater := "Jane Smith"
+	commentValue, _ := json.Marshal(map[string]string{"text": strings.Repeat("Длинный комментарий. ", 500)})
+
+	payload := &parser.YoutrackWebhookPayload{
+		Project: &parser.YoutrackFieldValue{Name: &projectName},
+		Issue: parser.YoutrackIssue{
+			Summary: summary,
+			State:   &parser.YoutrackFieldValue{Name: &state},
+		},
+		Updater: &parser.YoutrackUser{FullName: &updater},
+		Changes: []parser.YoutrackChange{{Field: Comment, NewValue: commentValue}},
+	}
+
+	var message discordMessage
+	if err := json.Unmarshal([]byte(FormatDiscord(payload)), &message); err != nil {
+		t.Fatalf("expected JSON message, got: %v", err)
+	}
+
+	embed := message.Embeds[0]
+	if length := utf8.RuneCountInString(embed.Title); length != discordMaxTitleLength {
+		t.Errorf("expected title length %d, got: %d", discordMaxTitleLength, length)
+	}
+	if length := utf8.RuneCountInString(embed.Fields[0].Value); length != discordMaxFieldValueLength {
+		t.Errorf("expected field value length %d, got: %d", discordMaxFieldValueLength, length)
+	}
+	if length := utf8.RuneCountInString(embed.Description); length > discordMaxDescriptionLength {
+		t.Errorf("expected description length at most %d, got: %d", discordMaxDescriptionLength, length)
+	}
+	if length := discordEmbedLength(embed); length > discordMaxEmbedLength {
+		t.Errorf("expected embed length at most %d, got: %d", discordMaxEmbedLength, length)
+	}
+	if !strings.HasSuffix(embed.Description, "…") {
+		t.Error("expected truncated description to end with ellipsis")
+	}
+}
+
+func TestDiscordMentionFormatter_FormatMention(t *testing.T) {
+	type testCase struct {
+		name           string
+		user           parser.YoutrackUser
+		expectedResult string
+	}
+
+	fullName := "John_Doe"
+	login := "john"
+
+	testCases := []testCase{
+		{
+			name:           "Format_Mention_By_Name",
+			user:           parser.YoutrackUser{FullName: &fullName, Login: &login},
+			expectedResult: "John\\_Doe",
+		},
+		{
+			name:           "Format_Mention_All_Nil",
+			user:           parser.YoutrackUser{},
+			expectedResult: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := (&DiscordMentionFormatter{}).FormatMention(tc.user)
+
+			if result != tc.expectedResult {
+				t.Errorf("expected result %q, got: %q", tc.expectedResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"strings"
+)
+
+// Тема письма, если у задачи нет названия
+const emailDefaultSubject = "Уведомление YouTrack"
+
+// emailRow описывает строку таблицы полей задачи в HTML письме
+type emailRow struct {
+	title string
+	value string
+}
+
+// FormatEmail форматирует payload для email канала в письмо с HTML и текстовой версией
+// Тема письма содержит идентификатор и название задачи, а Thread - идентификатор задачи для объединения писем в цепочку
+// Текстовая версия совпадает с форматированием по умолчанию
+func FormatEmail(payload *parser.YoutrackWebhookPayload) string {
+	message := port.EmailMessage{
+		Subject: emailSubject(payload.Issue),
+		Thread:  payload.Issue.IDReadable,
+		Text:    strings.TrimSpace(formatDefault(payload)),
+		HTML:    formatEmailHTML(payload),
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return message.Text
+	}
+
+	return string(data)
+}
+
+// emailSubject возвращает тему письма: одинаковая тема для всех писем задачи помогает почтовым клиентам объединять их в цепочку
+func emailSubject(issue parser.YoutrackIssue) string {
+	subject := issue.Summary
+	if subject == "" {
+		subject = emailDefaultSubject
+	}
+	if issue.IDReadable != "" {
+		subject = fmt.Sprintf("[%s] %s", issue.IDReadable, subject)
+	}
+	return subject
+}
+
+// formatEmailHTML форматирует HTML версию письма: заголовок изменения, ссылку на задачу,
+// таблицу полей с цветом по приоритету и текст комментария
+func formatEmailHTML(payload *parser.YoutrackWebhookPayload) string {
+	assignee := extractUserName(payload.Issue.Assignee)
+	changed := extractChangeWithEscaper(payload.Changes, assignee, extractChangeValue, plainText, plainText)
+
+	state := extractFieldValue(payload.Issue.State)
+	if changed != nil && changed.field == State {
+		state = changed.value
+	}
+
+	priority := extractFieldValue(payload.Issue.Priority)
+	if changed != nil && changed.field == Priority {
+		priority = changed.value
+	}
+
+	if changed != nil && changed.field == Assignee {
+		assignee = changed.value
+	}
+
+	var builder strings.Builder
+	builder.WriteString(`<!DOCTYPE html><html><body style="font-family: Arial, sans-serif; font-size: 14px; color: #212121;">`)
+
+	if changed != nil {
+		fmt.Fprintf(&builder, `<h3 style="margin: 0 0 12px;">%s</h3>`, escapeHTML(changed.header))
+	}
+
+	summary := escapeHTML(payload.Issue.Summary)
+	if payload.Issue.URL != "" {
+		summary = fmt.Sprintf(`<a href="%s">%s</a>`, escapeHTML(payload.Issue.URL), summary)
+	}
+	fmt.Fprintf(&builder, `<p style="margin: 0 0 12px; font-size: 16px;">%s</p>`, summary)
+
+	rows := []emailRow{
+		{title: "📁 Проект", value: extractFieldValue(payload.Project)},
+		{title: "📊 Состояние", value: state},
+		{title: "⚡️ Приоритет", value: priority},
+		{title: "👤 Назначена", value: assignee},
+		{title: "✏️ Автор изменения", value: extractUserName(payload.Updater)},
+	}
+
+	fmt.Fprintf(&builder, `<table cellpadding="4" cellspacing="0" style="border-collapse: collapse; border-left: 4px solid #%06x;">`,
+		priorityColor(extractFieldValue(payload.Issue.Priority)))
+	for _, row := range rows {
+		fmt.Fprintf(&builder, `<tr><td style="color: #757575; padding-right: 16px;">%s</td><td>%s</td></tr>`,
+			escapeHTML(row.title), escapeHTML(row.value))
+	}
+	builder.WriteString(`</table>`)
+
+	if changed != nil && changed.field == Comment {
+		fmt.Fprintf(&builder, `<p style="margin: 12px 0 0;"><b>💬 Комментарий:</b><br>%s</p>`, escapeHTML(changed.value))
+	}
+
+	builder.WriteString(`</body></html>`)
+
+	return builder.String()
+}
@@ -0,0 +1,130 @@
+package formatter
+
+import (
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"strings"
+	"testing"
+)
+
+func TestFormatEmail(t *testing.T) {
+	type testCase struct {
+		name             string
+		payload          *parser.YoutrackWebhookPayload
+		expectedSubject  string
+		expectedThread   string
+		expectedHTML     []string
+		unexpectedHTML   []string
+		expectedTextPart string
+	}
+
+	projectName := "TestProject"
+	issueURL := "https://youtrack.test/issue/PROJ-123"
+	statePresentation := "В работе"
+	priorityName := "Critical"
+	assigneeFullName := "John Doe"
+	updaterFullName := "Jane Smith"
+
+	newPayload := func(changes ...parser.YoutrackChange) *parser.YoutrackWebhookPayload {
+		return &parser.YoutrackWebhookPayload{
+			Project: &parser.YoutrackFieldValue{Name: &projectName},
+			Issue: parser.YoutrackIssue{
+				IDReadable: "PROJ-123",
+				Summary:    "Fix <script> & styles",
+				URL:        issueURL,
+				State:      &parser.YoutrackFieldValue{Presentation: &statePresentation},
+				Priority:   &parser.YoutrackFieldValue{Name: &priorityName},
+				Assignee:   &parser.YoutrackUser{FullName: &assigneeFullName},
+			},
+			Updater: &parser.YoutrackUser{FullName: &updaterFullName},
+			Changes: changes,
+		}
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Format_Email_Without_Changes",
+			payload:         newPayload(),
+			expectedSubject: "[PROJ-123] Fix <script> & styles",
+			expectedThread:  "PROJ-123",
+			expectedHTML: []string{
+				`<a href="https://youtrack.test/issue/PROJ-123">Fix &lt;script&gt; &amp; styles</a>`,
+				`border-left: 4px solid #d32f2f;`,
+				`<td>TestProject</td>`,
+				`<td>В работе</td>`,
+				`<td>John Doe</td>`,
+				`<td>Jane Smith</td>`,
+			},
+			unexpectedHTML:   []string{"<h3", "Комментарий"},
+			expectedTextPart: "Задача: Fix <script> & styles",
+		},
+		{
+			name: "Format_Email_State_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    State,
+				OldValue: []byte(`{"name": "To Do", "presentation": "К выполнению"}`),
+				NewValue: []byte(`{"name": "In Progress", "presentation": "В работе"}`),
+			}),
+			expectedSubject:  "[PROJ-123] Fix <script> & styles",
+			expectedThread:   "PROJ-123",
+			expectedHTML:     []string{`<h3 style="margin: 0 0 12px;">📊 Изменен статус задачи</h3>`, `<td>К выполнению → В работе</td>`},
+			expectedTextPart: "Изменения: Состояние: К выполнению → В работе",
+		},
+		{
+			name: "Format_Email_Comment",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Comment,
+				NewValue: []byte(`{"text": "Line <1>\nLine 2", "mentionedUsers": [{"fullName": "Ann Lee"}]}`),
+			}),
+			expectedSubject:  "[PROJ-123] Fix <script> & styles",
+			expectedThread:   "PROJ-123",
+			expectedHTML:     []string{`<b>💬 Комментарий:</b><br>Line &lt;1&gt;<br>Line 2 [Упомянуты: Ann Lee]</p>`},
+			expectedTextPart: "Изменения: Комментарий: Line <1>\nLine 2 [Упомянуты: Ann Lee]",
+		},
+		{
+			name: "Format_Email_Without_Issue_ID_And_Summary",
+			payload: &parser.YoutrackWebhookPayload{
+				Issue: parser.YoutrackIssue{},
+			},
+			expectedSubject:  "Уведомление YouTrack",
+			expectedHTML:     []string{`border-left: 4px solid #9e9e9e;`},
+			unexpectedHTML:   []string{"<a href"},
+			expectedTextPart: "Проект:",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := FormatEmail(tc.payload)
+
+			var message port.EmailMessage
+			if err := json.Unmarshal([]byte(result), &message); err != nil {
+				t.Fatalf("expected JSON message, got: %q (%v)", result, err)
+			}
+
+			if message.Subject != tc.expectedSubject {
+				t.Errorf("expected subject %q, got: %q", tc.expectedSubject, message.Subject)
+			}
+			if message.Thread != tc.expectedThread {
+				t.Errorf("expected thread %q, got: %q", tc.expectedThread, message.Thread)
+			}
+			if message.Text != strings.TrimSpace(formatDefault(tc.payload)) {
+				t.Errorf("expected text to match default format, got: %q", message.Text)
+			}
+			if !strings.Contains(message.Text, tc.expectedTextPart) {
+				t.Errorf("expected text to contain %q, got: %q", tc.expectedTextPart, message.Text)
+			}
+			for _, part := range tc.expectedHTML {
+				if !strings.Contains(message.HTML, part) {
+					t.Errorf("expected HTML to contain %q, got: %q", part, message.HTML)
+				}
+			}
+			for _, part := range tc.unexpectedHTML {
+				if strings.Contains(message.HTML, part) {
+					t.Errorf("expected HTML not to contain %q, got: %q", part, message.HTML)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package formatter
+
+import (
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"strings"
+)
+
+// FormatFile форматирует payload для file канала в запись архива
+// Запись содержит название проекта, идентификатор задачи, текст в форматировании по умолчанию и исходный payload,
+// поэтому по архиву можно восстановить и отправленный текст, и событие YouTrack, из которого он получен
+func FormatFile(payload *parser.YoutrackWebhookPayload) string {
+	message := port.FileMessage{
+		Issue: payload.Issue.IDReadable,
+		Text:  strings.TrimSpace(formatDefault(payload)),
+	}
+	if payload.Project != nil && payload.Project.Name != nil {
+		message.Project = *payload.Project.Name
+	}
+
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return message.Text
+	}
+	message.Payload = rawPayload
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return message.Text
+	}
+
+	return string(data)
+}
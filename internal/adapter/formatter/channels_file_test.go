@@ -0,0 +1,93 @@
+package formatter
+
+import (
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/google/go-cmp/cmp"
+	"strings"
+	"testing"
+)
+
+func TestFormatFile(t *testing.T) {
+	type testCase struct {
+		name            string
+		payload         *parser.YoutrackWebhookPayload
+		expectedProject string
+		expectedIssue   string
+		expectedText    []string
+	}
+
+	projectName := "TestProject"
+	projectPresentation := "Тестовый проект"
+	stateName := "In Progress"
+	updaterLogin := "jane"
+
+	testCases := []testCase{
+		{
+			name: "Format_File_Full_Payload",
+			payload: &parser.YoutrackWebhookPayload{
+				Project: &parser.YoutrackFieldValue{Name: &projectName, Presentation: &projectPresentation},
+				Issue: parser.YoutrackIssue{
+					IDReadable: "PROJ-1",
+					Summary:    "Fix <b>bold</b> styles",
+					URL:        "https://youtrack.test/issue/PROJ-1",
+					State:      &parser.YoutrackFieldValue{Name: &stateName},
+				},
+				Updater: &parser.YoutrackUser{Login: &updaterLogin},
+				Changes: []parser.YoutrackChange{{
+					Field:    State,
+					OldValue: json.RawMessage(`{"name":"Open"}`),
+					NewValue: json.RawMessage(`{"name":"In Progress"}`),
+				}},
+			},
+			expectedProject: "TestProject",
+			expectedIssue:   "PROJ-1",
+			expectedText: []string{
+				"Проект: Тестовый проект",
+				"Задача: Fix <b>bold</b> styles",
+				"Ссылка: https://youtrack.test/issue/PROJ-1",
+				"Автор изменения: jane",
+			},
+		},
+		{
+			name:         "Format_File_Empty_Payload",
+			payload:      &parser.YoutrackWebhookPayload{},
+			expectedText: []string{"Проект: "},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := FormatFile(tc.payload)
+
+			var message port.FileMessage
+			if err := json.Unmarshal([]byte(result), &message); err != nil {
+				t.Fatalf("expected JSON file message, got: %q (%v)", result, err)
+			}
+
+			if message.Project != tc.expectedProject {
+				t.Errorf("expected project %q, got: %q", tc.expectedProject, message.Project)
+			}
+			if message.Issue != tc.expectedIssue {
+				t.Errorf("expected issue %q, got: %q", tc.expectedIssue, message.Issue)
+			}
+			if strings.HasPrefix(message.Text, "\n") {
+				t.Errorf("expected text without leading new line, got: %q", message.Text)
+			}
+			for _, expected := range tc.expectedText {
+				if !strings.Contains(message.Text, expected) {
+					t.Errorf("expected text to contain %q, got: %q", expected, message.Text)
+				}
+			}
+
+			var payload parser.YoutrackWebhookPayload
+			if err := json.Unmarshal(message.Payload, &payload); err != nil {
+				t.Fatalf("expected JSON payload, got: %q (%v)", string(message.Payload), err)
+			}
+			if diff := cmp.Diff(*tc.payload, payload); diff != "" {
+				t.Errorf("payload mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"html"
+	"strings"
+)
+
+// Формат formatted_body сообщения Matrix с HTML разметкой
+const matrixHTMLFormat = "org.matrix.custom.html"
+
+// matrixMessage описывает содержимое события m.room.message
+// body - текст для клиентов без поддержки HTML и для уведомлений, formatted_body - HTML версия сообщения
+type matrixMessage struct {
+	MsgType       string         `json:"msgtype"`
+	Body          string         `json:"body"`
+	Format        string         `json:"format,omitempty"`
+	FormattedBody string         `json:"formatted_body,omitempty"`
+	Mentions      matrixMentions `json:"m.mentions"`
+}
+
+// matrixMentions описывает пользователей, которые получат уведомление об упоминании
+// Пустой список запрещает клиентам искать упоминания в тексте задачи и комментария
+type matrixMentions struct {
+	UserIDs []string `json:"user_ids,omitempty"`
+}
+
+// MatrixMentionFormatter форматирует упоминания для Matrix
+// Пользователи, для которых известен Matrix ID, упоминаются ссылкой matrix.to (pill), остальные - по имени
+// Упомянутые пользователи запоминаются для m.mentions, поэтому форматирование создается на каждое сообщение
+type MatrixMentionFormatter struct {
+	userIDs   map[string]string
+	mentioned []string
+}
+
+// NewMatrixMentionFormatter создает форматирование упоминаний по Matrix ID пользователей
+// Ключ userIDs - логин или email пользователя в YouTrack
+func NewMatrixMentionFormatter(userIDs map[string]string) *MatrixMentionFormatter {
+	return &MatrixMentionFormatter{userIDs: userIDs}
+}
+
+// FormatMention форматирует упоминание пользователя для HTML сообщения Matrix
+func (f *MatrixMentionFormatter) FormatMention(user parser.YoutrackUser) string {
+	name := extractUserName(&user)
+
+	userID := ""
+	if user.Login != nil && f.userIDs[*user.Login] != "" {
+		userID = f.userIDs[*user.Login]
+	} else if user.Email != nil && f.userIDs[*user.Email] != "" {
+		userID = f.userIDs[*user.Email]
+	}
+	if userID == "" {
+		return escapeHTML(name)
+	}
+
+	if name == "" {
+		name = userID
+	}
+	f.addMentioned(userID)
+
+	return fmt.Sprintf(`<a href="https://matrix.to/#/%s">%s</a>`, html.EscapeString(userID), escapeHTML(name))
+}
+
+// Mentioned возвращает Matrix ID упомянутых пользователей в порядке упоминания
+func (f *MatrixMentionFormatter) Mentioned() []string {
+	return f.mentioned
+}
+
+// addMentioned запоминает упомянутого пользователя без повторов
+func (f *MatrixMentionFormatter) addMentioned(userID string) {
+	for _, mentioned := range f.mentioned {
+		if mentioned == userID {
+			return
+		}
+	}
+	f.mentioned = append(f.mentioned, userID)
+}
+
+// NewMatrixFormatter возвращает форматирование payload для Matrix канала в содержимое события m.room.message
+// Упоминания пользователей, Matrix ID которых указан в userIDs, формируются как pill и попадают в m.mentions
+func NewMatrixFormatter(userIDs map[string]string) func(payload *parser.YoutrackWebhookPayload) string {
+	return func(payload *parser.YoutrackWebhookPayload) string {
+		return formatMatrix(payload, NewMatrixMentionFormatter(userIDs))
+	}
+}
+
+// formatMatrix форматирует payload в JSON содержимого сообщения Matrix
+// Текстовая версия совпадает с форматированием по умолчанию, HTML версия содержит заголовок изменения,
+// ссылку на задачу, поля задачи и текст комментария
+func formatMatrix(payload *parser.YoutrackWebhookPayload, mentionFormatter *MatrixMentionFormatter) string {
+	message := matrixMessage{
+		MsgType:       "m.text",
+		Body:          strings.TrimSpace(formatDefault(payload)),
+		Format:        matrixHTMLFormat,
+		FormattedBody: formatMatrixHTML(payload, mentionFormatter),
+	}
+	message.Mentions.UserIDs = mentionFormatter.Mentioned()
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return message.Body
+	}
+
+	return string(data)
+}
+
+// formatMatrixHTML форматирует HTML версию сообщения Matrix
+// Используются только теги, которые клиенты Matrix отображают в formatted_body
+func formatMatrixHTML(payload *parser.YoutrackWebhookPayload, mentionFormatter MentionFormatter) string {
+	mention := ""
+	if payload.Issue.Assignee != nil {
+		mention = mentionFormatter.FormatMention(*payload.Issue.Assignee)
+	}
+
+	changed := extractChangeWithEscaper(payload.Changes, mention, markdownValueExtractor(mentionFormatter, escapeHTML, "<br>"), escapeHTML, escapeHTML)
+
+	state := escapeHTML(extractFieldValue(payload.Issue.State))
+	if changed != nil && changed.field == State {
+		state = changed.value
+	}
+
+	priority := escapeHTML(extractFieldValue(payload.Issue.Priority))
+	if changed != nil && changed.field == Priority {
+		priority = changed.value
+	}
+
+	assignee := mention
+	if changed != nil && changed.field == Assignee {
+		assignee = changed.value
+	}
+
+	var builder strings.Builder
+
+	if changed != nil {
+		fmt.Fprintf(&builder, "<h4>%s</h4>", changed.header)
+	}
+
+	summary := escapeHTML(payload.Issue.Summary)
+	if payload.Issue.URL != "" {
+		summary = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(payload.Issue.URL), summary)
+	}
+	fmt.Fprintf(&builder, "<p><b>%s</b></p>", summary)
+
+	fmt.Fprintf(&builder, "<p><b>📁 Проект:</b> %s<br>", escapeHTML(extractFieldValue(payload.Project)))
+	fmt.Fprintf(&builder, "<b>📊 Состояние:</b> %s<br>", state)
+	fmt.Fprintf(&builder, "<b>⚡️ Приоритет:</b> %s<br>", priority)
+	fmt.Fprintf(&builder, "<b>👤 Назначена:</b> %s<br>", assignee)
+	fmt.Fprintf(&builder, "<b>✏️ Автор изменения:</b> %s</p>", escapeHTML(extractUserName(payload.Updater)))
+
+	if changed != nil && changed.field == Comment {
+		fmt.Fprintf(&builder, "<blockquote>%s</blockquote>", changed.value)
+	}
+
+	return builder.String()
+}
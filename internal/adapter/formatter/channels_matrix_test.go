@@ -0,0 +1,197 @@
+package formatter
+
+import (
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/google/go-cmp/cmp"
+	"strings"
+	"testing"
+)
+
+func TestFormatMatrix(t *testing.T) {
+	type testCase struct {
+		name                  string
+		userIDs               map[string]string
+		payload               *parser.YoutrackWebhookPayload
+		expectedFormattedBody string
+		expectedMentions      []string
+	}
+
+	projectName := "TestProject"
+	issueSummary := "Fix <script> & styles"
+	issueURL := "https://youtrack.test/issue/PROJ-123"
+	statePresentation := "В работе"
+	priorityName := "High"
+	assigneeFullName := "John Doe"
+	assigneeLogin := "john"
+	updaterFullName := "Jane Smith"
+
+	newPayload := func(changes ...parser.YoutrackChange) *parser.YoutrackWebhookPayload {
+		return &parser.YoutrackWebhookPayload{
+			Project: &parser.YoutrackFieldValue{Name: &projectName},
+			Issue: parser.YoutrackIssue{
+				Summary:  issueSummary,
+				URL:      issueURL,
+				State:    &parser.YoutrackFieldValue{Presentation: &statePresentation},
+				Priority: &parser.YoutrackFieldValue{Name: &priorityName},
+				Assignee: &parser.YoutrackUser{FullName: &assigneeFullName, Login: &assigneeLogin},
+			},
+			Updater: &parser.YoutrackUser{FullName: &updaterFullName},
+			Changes: changes,
+		}
+	}
+
+	fields := func(state, priority, assignee string) string {
+		return `<p><b><a href="https://youtrack.test/issue/PROJ-123">Fix &lt;script&gt; &amp; styles</a></b></p>` +
+			"<p><b>📁 Проект:</b> TestProject<br>" +
+			"<b>📊 Состояние:</b> " + state + "<br>" +
+			"<b>⚡️ Приоритет:</b> " + priority + "<br>" +
+			"<b>👤 Назначена:</b> " + assignee + "<br>" +
+			"<b>✏️ Автор изменения:</b> Jane Smith</p>"
+	}
+
+	johnPill := `<a href="https://matrix.to/#/@john:example.com">John Doe</a>`
+
+	testCases := []testCase{
+		{
+			name:                  "Format_Matrix_Without_Changes",
+			payload:               newPayload(),
+			expectedFormattedBody: fields("В работе", "High", "John Doe"),
+		},
+		{
+			name: "Format_Matrix_State_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    State,
+				OldValue: []byte(`{"name": "To Do", "presentation": "К выполнению"}`),
+				NewValue: []byte(`{"name": "In Progress", "presentation": "В работе"}`),
+			}),
+			expectedFormattedBody: "<h4>📊 Изменен статус задачи</h4>" + fields("К выполнению → В работе", "High", "John Doe"),
+		},
+		{
+			name: "Format_Matrix_Priority_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Priority,
+				OldValue: []byte(`null`),
+				NewValue: []byte(`{"name": "High"}`),
+			}),
+			expectedFormattedBody: "<h4>⚡ Изменен приоритет задачи</h4>" + fields("В работе", "(Не установлен) → High", "John Doe"),
+		},
+		{
+			name:    "Format_Matrix_Assignee_Change_With_User_ID",
+			userIDs: map[string]string{"john": "@john:example.com"},
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Assignee,
+				OldValue: []byte(`{"fullName": "Old <Owner>"}`),
+				NewValue: []byte(`{"fullName": "John Doe", "login": "john"}`),
+			}),
+			expectedFormattedBody: "<h4>👤 Изменен исполнитель задачи</h4>" + fields("В работе", "High", "Old &lt;Owner&gt; → "+johnPill),
+			expectedMentions:      []string{"@john:example.com"},
+		},
+		{
+			name:    "Format_Matrix_Comment_With_Mentions",
+			userIDs: map[string]string{"ann@example.com": "@ann:example.com", "john": "@john:example.com"},
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Comment,
+				NewValue: []byte(`{"text": "Please check \\*a < b\\*\nThanks", "mentionedUsers": [{"fullName": "Ann Lee", "email": "ann@example.com"}, {"fullName": "Bob"}, {"fullName": "John Doe", "login": "john"}]}`),
+			}),
+			expectedFormattedBody: "<h4>💬 Добавлен комментарий</h4>" + fields("В работе", "High", johnPill) +
+				`<blockquote>Please check *a &lt; b*<br>Thanks<br>[Упомянуты: <a href="https://matrix.to/#/@ann:example.com">Ann Lee</a>, Bob, ` + johnPill + `]</blockquote>`,
+			expectedMentions: []string{"@john:example.com", "@ann:example.com"},
+		},
+		{
+			name: "Format_Matrix_Without_Project_And_Assignee",
+			payload: &parser.YoutrackWebhookPayload{
+				Issue: parser.YoutrackIssue{Summary: "Summary"},
+			},
+			expectedFormattedBody: "<p><b>Summary</b></p><p><b>📁 Проект:</b> <br><b>📊 Состояние:</b> <br>" +
+				"<b>⚡️ Приоритет:</b> <br><b>👤 Назначена:</b> <br><b>✏️ Автор изменения:</b> </p>",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := NewMatrixFormatter(tc.userIDs)(tc.payload)
+
+			var message matrixMessage
+			if err := json.Unmarshal([]byte(result), &message); err != nil {
+				t.Fatalf("expected JSON message, got: %q (%v)", result, err)
+			}
+
+			expectedMessage := matrixMessage{
+				MsgType:       "m.text",
+				Body:          strings.TrimSpace(formatDefault(tc.payload)),
+				Format:        matrixHTMLFormat,
+				FormattedBody: tc.expectedFormattedBody,
+				Mentions:      matrixMentions{UserIDs: tc.expectedMentions},
+			}
+			if diff := cmp.Diff(expectedMessage, message); diff != "" {
+				t.Errorf("message mismatch (-want +got):\n%s", diff)
+			}
+
+			// Без упоминаний m.mentions передается пустым объектом, чтобы клиенты не искали упоминания в тексте
+			if len(tc.expectedMentions) == 0 && !strings.Contains(result, `"m.mentions":{}`) {
+				t.Errorf("expected empty m.mentions, got: %s", result)
+			}
+		})
+	}
+}
+
+func TestMatrixMentionFormatter_FormatMention(t *testing.T) {
+	type testCase struct {
+		name              string
+		userIDs           map[string]string
+		user              parser.YoutrackUser
+		expectedResult    string
+		expectedMentioned []string
+	}
+
+	fullName := "John <Doe>"
+	login := "john"
+	email := "john@example.com"
+
+	testCases := []testCase{
+		{
+			name:              "Format_Mention_By_Login",
+			userIDs:           map[string]string{"john": "@john:example.com", "john@example.com": "@email:example.com"},
+			user:              parser.YoutrackUser{FullName: &fullName, Login: &login, Email: &email},
+			expectedResult:    `<a href="https://matrix.to/#/@john:example.com">John &lt;Doe&gt;</a>`,
+			expectedMentioned: []string{"@john:example.com"},
+		},
+		{
+			name:              "Format_Mention_By_Email",
+			userIDs:           map[string]string{"john@example.com": "@email:example.com"},
+			user:              parser.YoutrackUser{FullName: &fullName, Login: &login, Email: &email},
+			expectedResult:    `<a href="https://matrix.to/#/@email:example.com">John &lt;Doe&gt;</a>`,
+			expectedMentioned: []string{"@email:example.com"},
+		},
+		{
+			name:              "Format_Mention_Without_Name",
+			userIDs:           map[string]string{"john@example.com": "@email:example.com"},
+			user:              parser.YoutrackUser{Email: &email},
+			expectedResult:    `<a href="https://matrix.to/#/@email:example.com">@email:example.com</a>`,
+			expectedMentioned: []string{"@email:example.com"},
+		},
+		{
+			name:           "Format_Mention_Unknown_User_By_Name",
+			userIDs:        map[string]string{"jane": "@jane:example.com"},
+			user:           parser.YoutrackUser{FullName: &fullName, Login: &login},
+			expectedResult: "John &lt;Doe&gt;",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			formatter := NewMatrixMentionFormatter(tc.userIDs)
+
+			if result := formatter.FormatMention(tc.user); result != tc.expectedResult {
+				t.Errorf("expected %q, got: %q", tc.expectedResult, result)
+			}
+			// Повторное упоминание не дублирует пользователя в m.mentions
+			formatter.FormatMention(tc.user)
+
+			if diff := cmp.Diff(tc.expectedMentioned, formatter.Mentioned()); diff != "" {
+				t.Errorf("mentioned mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
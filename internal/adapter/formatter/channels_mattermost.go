@@ -0,0 +1,109 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+)
+
+// mattermostMessage описывает сообщение Mattermost: заголовок изменения и вложение с полями задачи
+type mattermostMessage struct {
+	Text        string                 `json:"text,omitempty"`
+	Attachments []mattermostAttachment `json:"attachments"`
+}
+
+// mattermostAttachment описывает вложение сообщения Mattermost
+type mattermostAttachment struct {
+	Fallback  string            `json:"fallback"`
+	Color     string            `json:"color"`
+	Title     string            `json:"title"`
+	TitleLink string            `json:"title_link,omitempty"`
+	Text      string            `json:"text,omitempty"`
+	Fields    []mattermostField `json:"fields"`
+}
+
+// mattermostField описывает поле вложения Mattermost
+type mattermostField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// MattermostMentionFormatter форматирует упоминания для Mattermost
+type MattermostMentionFormatter struct{}
+
+// FormatMention форматирует упоминание пользователя для Mattermost
+// Логин YouTrack используется как имя пользователя Mattermost, без логина пользователь указывается по имени
+func (f *MattermostMentionFormatter) FormatMention(user parser.YoutrackUser) string {
+	if user.Login != nil && *user.Login != "" {
+		return fmt.Sprintf("@%s", *user.Login)
+	}
+	return escapeBackslashMarkdown(extractUserName(&user))
+}
+
+// FormatMattermost форматирует payload для Mattermost канала в сообщение с вложением,
+// цвет которого зависит от приоритета задачи
+func FormatMattermost(payload *parser.YoutrackWebhookPayload) string {
+	mentionFormatter := &MattermostMentionFormatter{}
+
+	mention := ""
+	if payload.Issue.Assignee != nil {
+		mention = mentionFormatter.FormatMention(*payload.Issue.Assignee)
+	}
+
+	changed := extractChangeWithEscaper(payload.Changes, mention, markdownValueExtractor(mentionFormatter, escapeBackslashMarkdown, "\n"), escapeBackslashMarkdown, plainText)
+
+	attachment := mattermostAttachment{
+		Fallback:  payload.Issue.Summary,
+		Color:     mattermostPriorityColor(extractFieldValue(payload.Issue.Priority)),
+		Title:     payload.Issue.Summary,
+		TitleLink: payload.Issue.URL,
+	}
+
+	message := mattermostMessage{}
+	if changed != nil {
+		message.Text = fmt.Sprintf("#### %s", changed.header)
+		attachment.Fallback = fmt.Sprintf("%s: %s", changed.header, payload.Issue.Summary)
+	}
+
+	state := escapeBackslashMarkdown(extractFieldValue(payload.Issue.State))
+	if changed != nil && changed.field == State {
+		state = changed.value
+	}
+
+	priority := escapeBackslashMarkdown(extractFieldValue(payload.Issue.Priority))
+	if changed != nil && changed.field == Priority {
+		priority = changed.value
+	}
+
+	assignee := mention
+	if changed != nil && changed.field == Assignee {
+		assignee = changed.value
+	}
+
+	attachment.Fields = []mattermostField{
+		{Title: "📁 Проект", Value: escapeBackslashMarkdown(extractFieldValue(payload.Project)), Short: true},
+		{Title: "📊 Состояние", Value: state, Short: true},
+		{Title: "⚡️ Приоритет", Value: priority, Short: true},
+		{Title: "👤 Назначена", Value: assignee, Short: true},
+		{Title: "✏️ Автор изменения", Value: escapeBackslashMarkdown(extractUserName(payload.Updater)), Short: true},
+	}
+
+	if changed != nil && changed.field == Comment {
+		attachment.Text = fmt.Sprintf("**💬 Комментарий:**\n%s", changed.value)
+	}
+
+	message.Attachments = []mattermostAttachment{attachment}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return attachment.Fallback
+	}
+
+	return string(data)
+}
+
+// mattermostPriorityColor возвращает цвет вложения Mattermost для приоритета задачи в формате #rrggbb
+func mattermostPriorityColor(priority string) string {
+	return fmt.Sprintf("#%06x", priorityColor(priority))
+}
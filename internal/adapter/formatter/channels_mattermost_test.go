@@ -0,0 +1,240 @@
+package formatter
+
+import (
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestFormatMattermost(t *testing.T) {
+	type testCase struct {
+		name            string
+		payload         *parser.YoutrackWebhookPayload
+		expectedMessage mattermostMessage
+	}
+
+	projectName := "Test_Project"
+	issueSummary := "Fix *bold* styles"
+	issueURL := "https://youtrack.test/issue/PROJ-123"
+	statePresentation := "В работе"
+	priorityName := "Critical"
+	assigneeFullName := "John Doe"
+	assigneeLogin := "john.doe"
+	updaterFullName := "Jane Smith"
+
+	newPayload := func(changes ...parser.YoutrackChange) *parser.YoutrackWebhookPayload {
+		return &parser.YoutrackWebhookPayload{
+			Project: &parser.YoutrackFieldValue{Name: &projectName},
+			Issue: parser.YoutrackIssue{
+				Summary:  issueSummary,
+				URL:      issueURL,
+				State:    &parser.YoutrackFieldValue{Presentation: &statePresentation},
+				Priority: &parser.YoutrackFieldValue{Name: &priorityName},
+				Assignee: &parser.YoutrackUser{FullName: &assigneeFullName, Login: &assigneeLogin},
+			},
+			Updater: &parser.YoutrackUser{FullName: &updaterFullName},
+			Changes: changes,
+		}
+	}
+
+	fields := func(state, priority, assignee string) []mattermostField {
+		return []mattermostField{
+			{Title: "📁 Проект", Value: "Test\\_Project", Short: true},
+			{Title: "📊 Состояние", Value: state, Short: true},
+			{Title: "⚡️ Приоритет", Value: priority, Short: true},
+			{Title: "👤 Назначена", Value: assignee, Short: true},
+			{Title: "✏️ Автор изменения", Value: "Jane Smith", Short: true},
+		}
+	}
+
+	testCases := []testCase{
+		{
+			name:    "Format_Mattermost_Without_Changes",
+			payload: newPayload(),
+			expectedMessage: mattermostMessage{
+				Attachments: []mattermostAttachment{{
+					Fallback:  "Fix *bold* styles",
+					Color:     "#d32f2f",
+					Title:     "Fix *bold* styles",
+					TitleLink: issueURL,
+					Fields:    fields("В работе", "Critical", "@john.doe"),
+				}},
+			},
+		},
+		{
+			name: "Format_Mattermost_State_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    State,
+				OldValue: []byte(`{"name": "To Do", "presentation": "К_выполнению"}`),
+				NewValue: []byte(`{"name": "In Progress", "presentation": "В работе"}`),
+			}),
+			expectedMessage: mattermostMessage{
+				Text: "#### 📊 Изменен статус задачи",
+				Attachments: []mattermostAttachment{{
+					Fallback:  "📊 Изменен статус задачи: Fix *bold* styles",
+					Color:     "#d32f2f",
+					Title:     "Fix *bold* styles",
+					TitleLink: issueURL,
+					Fields:    fields("К\\_выполнению → В работе", "Critical", "@john.doe"),
+				}},
+			},
+		},
+		{
+			name: "Format_Mattermost_Priority_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Priority,
+				OldValue: []byte(`null`),
+				NewValue: []byte(`{"name": "Critical"}`),
+			}),
+			expectedMessage: mattermostMessage{
+				Text: "#### ⚡ Изменен приоритет задачи",
+				Attachments: []mattermostAttachment{{
+					Fallback:  "⚡ Изменен приоритет задачи: Fix *bold* styles",
+					Color:     "#d32f2f",
+					Title:     "Fix *bold* styles",
+					TitleLink: issueURL,
+					Fields:    fields("В работе", "(Не установлен) → Critical", "@john.doe"),
+				}},
+			},
+		},
+		{
+			name: "Format_Mattermost_Assignee_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Assignee,
+				OldValue: []byte(`{"fullName": "Old_Owner"}`),
+				NewValue: []byte(`{"fullName": "John Doe", "login": "john.doe"}`),
+			}),
+			expectedMessage: mattermostMessage{
+				Text: "#### 👤 Изменен исполнитель задачи",
+				Attachments: []mattermostAttachment{{
+					Fallback:  "👤 Изменен исполнитель задачи: Fix *bold* styles",
+					Color:     "#d32f2f",
+					Title:     "Fix *bold* styles",
+					TitleLink: issueURL,
+					Fields:    fields("В работе", "Critical", "Old\\_Owner → @john.doe"),
+				}},
+			},
+		},
+		{
+			name: "Format_Mattermost_Comment_With_Mentions",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Comment,
+				NewValue: []byte(`{"text": "Please check \\*a_b\\*", "mentionedUsers": [{"fullName": "Ann Lee", "login": "ann_lee"}, {"fullName": "Bob"}]}`),
+			}),
+			expectedMessage: mattermostMessage{
+				Text: "#### 💬 Добавлен комментарий",
+				Attachments: []mattermostAttachment{{
+					Fallback:  "💬 Добавлен комментарий: Fix *bold* styles",
+					Color:     "#d32f2f",
+					Title:     "Fix *bold* styles",
+					TitleLink: issueURL,
+					Text:      "**💬 Комментарий:**\nPlease check \\*a\\_b\\*\n[Упомянуты: @ann_lee, Bob]",
+					Fields:    fields("В работе", "Critical", "@john.doe"),
+				}},
+			},
+		},
+		{
+			name: "Format_Mattermost_Without_Project_And_Priority",
+			payload: &parser.YoutrackWebhookPayload{
+				Issue: parser.YoutrackIssue{Summary: "Summary"},
+			},
+			expectedMessage: mattermostMessage{
+				Attachments: []mattermostAttachment{{
+					Fallback: "Summary",
+					Color:    "#9e9e9e",
+					Title:    "Summary",
+					Fields: []mattermostField{
+						{Title: "📁 Проект", Value: "", Short: true},
+						{Title: "📊 Состояние", Value: "", Short: true},
+						{Title: "⚡️ Приоритет", Value: "", Short: true},
+						{Title: "👤 Назначена", Value: "", Short: true},
+						{Title: "✏️ Автор изменения", Value: "", Short: true},
+					},
+				}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := FormatMattermost(tc.payload)
+
+			var message mattermostMessage
+			if err := json.Unmarshal([]byte(result), &message); err != nil {
+				t.Fatalf("expected JSON message, got: %q (%v)", result, err)
+			}
+
+			if diff := cmp.Diff(tc.expectedMessage, message); diff != "" {
+				t.Errorf("message mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMattermostPriorityColor(t *testing.T) {
+	type testCase struct {
+		name          string
+		priority      string
+		expectedColor string
+	}
+
+	testCases := []testCase{
+		{name: "Show_Stopper", priority: "Show-stopper", expectedColor: "#d32f2f"},
+		{name: "Critical", priority: "Critical", expectedColor: "#d32f2f"},
+		{name: "Major", priority: "Major", expectedColor: "#f57c00"},
+		{name: "High_Lower_Case", priority: "high", expectedColor: "#f57c00"},
+		{name: "Normal", priority: "Normal", expectedColor: "#1976d2"},
+		{name: "Minor", priority: "Minor", expectedColor: "#9e9e9e"},
+		{name: "Unknown", priority: "Someday", expectedColor: "#9e9e9e"},
+		{name: "Empty", priority: "", expectedColor: "#9e9e9e"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if color := mattermostPriorityColor(tc.priority); color != tc.expectedColor {
+				t.Errorf("expected color %q, got: %q", tc.expectedColor, color)
+			}
+		})
+	}
+}
+
+func TestMattermostMentionFormatter_FormatMention(t *testing.T) {
+	type testCase struct {
+		name           string
+		user           parser.YoutrackUser
+		expectedResult string
+	}
+
+	fullName := "John_Doe"
+	login := "john"
+	email := "john@example.com"
+
+	testCases := []testCase{
+		{
+			name:           "Format_Mention_By_Login",
+			user:           parser.YoutrackUser{FullName: &fullName, Login: &login, Email: &email},
+			expectedResult: "@john",
+		},
+		{
+			name:           "Format_Mention_Without_Login_By_Name",
+			user:           parser.YoutrackUser{FullName: &fullName, Email: &email},
+			expectedResult: "John\\_Doe",
+		},
+		{
+			name:           "Format_Mention_All_Nil",
+			user:           parser.YoutrackUser{},
+			expectedResult: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := (&MattermostMentionFormatter{}).FormatMention(tc.user)
+
+			if result != tc.expectedResult {
+				t.Errorf("expected result %q, got: %q", tc.expectedResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package formatter
+
+import (
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"unicode/utf8"
+)
+
+const (
+	// Тип вложения Adaptive Card в сообщении Microsoft Teams
+	msteamsCardContentType = "application/vnd.microsoft.card.adaptive"
+	// Схема и версия Adaptive Card, поддерживаемые webhook Microsoft Teams
+	msteamsCardSchema  = "http://adaptivecards.io/schemas/adaptive-card.json"
+	msteamsCardVersion = "1.4"
+	// Окончание текста комментария, сокращенного до допустимого размера сообщения
+	msteamsTruncatedSuffix = "…"
+)
+
+// msteamsMessage описывает сообщение webhook Microsoft Teams с вложением Adaptive Card
+type msteamsMessage struct {
+	Type        string              `json:"type"`
+	Attachments []msteamsAttachment `json:"attachments"`
+}
+
+// msteamsAttachment описывает вложение сообщения Microsoft Teams
+type msteamsAttachment struct {
+	ContentType string      `json:"contentType"`
+	ContentURL  *string     `json:"contentUrl"`
+	Content     msteamsCard `json:"content"`
+}
+
+// msteamsCard описывает Adaptive Card
+type msteamsCard struct {
+	Schema  string           `json:"$schema"`
+	Type    string           `json:"type"`
+	Version string           `json:"version"`
+	Body    []msteamsElement `json:"body"`
+	Actions []msteamsAction  `json:"actions,omitempty"`
+}
+
+// msteamsElement описывает элемент Adaptive Card: TextBlock или FactSet
+type msteamsElement struct {
+	Type      string        `json:"type"`
+	Text      string        `json:"text,omitempty"`
+	Size      string        `json:"size,omitempty"`
+	Weight    string        `json:"weight,omitempty"`
+	Wrap      bool          `json:"wrap,omitempty"`
+	Separator bool          `json:"separator,omitempty"`
+	Facts     []msteamsFact `json:"facts,omitempty"`
+}
+
+// msteamsFact описывает пару название-значение в FactSet
+type msteamsFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// msteamsAction описывает действие Adaptive Card
+type msteamsAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// FormatMSTeams форматирует payload для Microsoft Teams канала в сообщение с Adaptive Card:
+// заголовок изменения, FactSet с полями задачи, текст комментария и ссылка на задачу
+// Если сообщение превышает допустимый размер, текст комментария сокращается
+func FormatMSTeams(payload *parser.YoutrackWebhookPayload) string {
+	assignee := extractUserName(payload.Issue.Assignee)
+	changed := extractChangeWithEscaper(payload.Changes, assignee, extractChangeValue, plainText, plainText)
+
+	state := extractFieldValue(payload.Issue.State)
+	if changed != nil && changed.field == State {
+		state = changed.value
+	}
+
+	priority := extractFieldValue(payload.Issue.Priority)
+	if changed != nil && changed.field == Priority {
+		priority = changed.value
+	}
+
+	if changed != nil && changed.field == Assignee {
+		assignee = changed.value
+	}
+
+	comment := ""
+	if changed != nil && changed.field == Comment {
+		comment = changed.value
+	}
+
+	card := msteamsCard{
+		Schema:  msteamsCardSchema,
+		Type:    "AdaptiveCard",
+		Version: msteamsCardVersion,
+	}
+	if payload.Issue.URL != "" {
+		card.Actions = []msteamsAction{{Type: "Action.OpenUrl", Title: "Open in YouTrack", URL: payload.Issue.URL}}
+	}
+
+	header := ""
+	if changed != nil {
+		header = changed.header
+	}
+
+	facts := []msteamsFact{
+		{Title: "📁 Проект", Value: extractFieldValue(payload.Project)},
+		{Title: "📋 Задача", Value: payload.Issue.Summary},
+		{Title: "🔗 Ссылка", Value: payload.Issue.URL},
+		{Title: "📊 Состояние", Value: state},
+		{Title: "⚡️ Приоритет", Value: priority},
+		{Title: "👤 Назначена", Value: assignee},
+		{Title: "✏️ Автор изменения", Value: extractUserName(payload.Updater)},
+	}
+
+	data := marshalMSTeams(card, header, facts, comment)
+	for len(data) > port.MSTeamsMaxPayloadSize && comment != "" {
+		comment = truncateMSTeamsText(comment, len(comment)-(len(data)-port.MSTeamsMaxPayloadSize))
+		data = marshalMSTeams(card, header, facts, comment)
+	}
+
+	return string(data)
+}
+
+// marshalMSTeams собирает тело Adaptive Card и возвращает JSON сообщения Microsoft Teams
+func marshalMSTeams(card msteamsCard, header string, facts []msteamsFact, comment string) []byte {
+	if header != "" {
+		card.Body = append(card.Body, msteamsElement{Type: "TextBlock", Text: header, Size: "Medium", Weight: "Bolder", Wrap: true})
+	}
+
+	card.Body = append(card.Body, msteamsElement{Type: "FactSet", Facts: facts})
+
+	if comment != "" {
+		card.Body = append(card.Body,
+			msteamsElement{Type: "TextBlock", Text: "💬 Комментарий:", Weight: "Bolder", Separator: true},
+			msteamsElement{Type: "TextBlock", Text: comment, Wrap: true},
+		)
+	}
+
+	message := msteamsMessage{
+		Type: "message",
+		Attachments: []msteamsAttachment{{
+			ContentType: msteamsCardContentType,
+			Content:     card,
+		}},
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return []byte(header)
+	}
+
+	return data
+}
+
+// truncateMSTeamsText сокращает текст до maxBytes байт с учетом окончания, не разрывая символы UTF-8
+// Если места не остается, возвращается пустая строка
+func truncateMSTeamsText(text string, maxBytes int) string {
+	maxBytes -= len(msteamsTruncatedSuffix)
+	if maxBytes <= 0 {
+		return ""
+	}
+	if len(text) <= maxBytes {
+		maxBytes = len(text) - 1
+	}
+
+	for maxBytes > 0 && !utf8.RuneStart(text[maxBytes]) {
+		maxBytes--
+	}
+
+	return text[:maxBytes] + msteamsTruncatedSuffix
+}
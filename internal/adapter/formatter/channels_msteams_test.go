@@ -0,0 +1,210 @@
+package formatter
+
+import (
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/google/go-cmp/cmp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFormatMSTeams(t *testing.T) {
+	type testCase struct {
+		name         string
+		payload      *parser.YoutrackWebhookPayload
+		expectedBody []msteamsElement
+	}
+
+	projectName := "TestProject"
+	issueSummary := "Fix *bold* styles"
+	issueURL := "https://youtrack.test/issue/PROJ-123"
+	statePresentation := "В работе"
+	priorityName := "High"
+	assigneeFullName := "John Doe"
+	updaterFullName := "Jane Smith"
+
+	newPayload := func(changes ...parser.YoutrackChange) *parser.YoutrackWebhookPayload {
+		return &parser.YoutrackWebhookPayload{
+			Project: &parser.YoutrackFieldValue{Name: &projectName},
+			Issue: parser.YoutrackIssue{
+				Summary:  issueSummary,
+				URL:      issueURL,
+				State:    &parser.YoutrackFieldValue{Presentation: &statePresentation},
+				Priority: &parser.YoutrackFieldValue{Name: &priorityName},
+				Assignee: &parser.YoutrackUser{FullName: &assigneeFullName},
+			},
+			Updater: &parser.YoutrackUser{FullName: &updaterFullName},
+			Changes: changes,
+		}
+	}
+
+	factSet := func(state, priority, assignee string) msteamsElement {
+		return msteamsElement{
+			Type: "FactSet",
+			Facts: []msteamsFact{
+				{Title: "📁 Проект", Value: "TestProject"},
+				{Title: "📋 Задача", Value: "Fix *bold* styles"},
+				{Title: "🔗 Ссылка", Value: issueURL},
+				{Title: "📊 Состояние", Value: state},
+				{Title: "⚡️ Приоритет", Value: priority},
+				{Title: "👤 Назначена", Value: assignee},
+				{Title: "✏️ Автор изменения", Value: "Jane Smith"},
+			},
+		}
+	}
+
+	header := func(text string) msteamsElement {
+		return msteamsElement{Type: "TextBlock", Text: text, Size: "Medium", Weight: "Bolder", Wrap: true}
+	}
+
+	testCases := []testCase{
+		{
+			name:         "Format_MSTeams_Without_Changes",
+			payload:      newPayload(),
+			expectedBody: []msteamsElement{factSet("В работе", "High", "John Doe")},
+		},
+		{
+			name: "Format_MSTeams_State_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    State,
+				OldValue: []byte(`{"name": "To Do", "presentation": "К выполнению"}`),
+				NewValue: []byte(`{"name": "In Progress", "presentation": "В работе"}`),
+			}),
+			expectedBody: []msteamsElement{
+				header("📊 Изменен статус задачи"),
+				factSet("К выполнению → В работе", "High", "John Doe"),
+			},
+		},
+		{
+			name: "Format_MSTeams_Priority_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Priority,
+				OldValue: []byte(`null`),
+				NewValue: []byte(`{"name": "High"}`),
+			}),
+			expectedBody: []msteamsElement{
+				header("⚡ Изменен приоритет задачи"),
+				factSet("В работе", "(Не установлен) → High", "John Doe"),
+			},
+		},
+		{
+			name: "Format_MSTeams_Assignee_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Assignee,
+				OldValue: []byte(`{"fullName": "Old Owner"}`),
+				NewValue: []byte(`{"fullName": "John Doe"}`),
+			}),
+			expectedBody: []msteamsElement{
+				header("👤 Изменен исполнитель задачи"),
+				factSet("В работе", "High", "Old Owner → John Doe"),
+			},
+		},
+		{
+			name: "Format_MSTeams_Comment",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Comment,
+				NewValue: []byte(`{"text": "Please check \\*this\\*", "mentionedUsers": [{"fullName": "Ann Lee"}]}`),
+			}),
+			expectedBody: []msteamsElement{
+				header("💬 Добавлен комментарий"),
+				factSet("В работе", "High", "John Doe"),
+				{Type: "TextBlock", Text: "💬 Комментарий:", Weight: "Bolder", Separator: true},
+				{Type: "TextBlock", Text: "Please check *this* [Упомянуты: Ann Lee]", Wrap: true},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := FormatMSTeams(tc.payload)
+
+			var message msteamsMessage
+			if err := json.Unmarshal([]byte(result), &message); err != nil {
+				t.Fatalf("expected JSON message, got: %q (%v)", result, err)
+			}
+
+			expected := msteamsMessage{
+				Type: "message",
+				Attachments: []msteamsAttachment{{
+					ContentType: msteamsCardContentType,
+					Content: msteamsCard{
+						Schema:  msteamsCardSchema,
+						Type:    "AdaptiveCard",
+						Version: msteamsCardVersion,
+						Body:    tc.expectedBody,
+						Actions: []msteamsAction{{Type: "Action.OpenUrl", Title: "Open in YouTrack", URL: issueURL}},
+					},
+				}},
+			}
+			if diff := cmp.Diff(expected, message); diff != "" {
+				t.Errorf("message mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFormatMSTeams_Truncates_Long_Comment(t *testing.T) {
+	projectName := "TestProject"
+	commentText := strings.Repeat("Длинный комментарий. ", 3000)
+	commentValue, _ := json.Marshal(map[string]string{"text": commentText})
+
+	payload := &parser.YoutrackWebhookPayload{
+		Project: &parser.YoutrackFieldValue{Name: &projectName},
+		Issue:   parser.YoutrackIssue{Summary: "Summary"},
+		Changes: []parser.YoutrackChange{{Field: Comment, NewValue: commentValue}},
+	}
+
+	result := FormatMSTeams(payload)
+
+	if len(result) > port.MSTeamsMaxPayloadSize {
+		t.Errorf("expected message size at most %d, got: %d", port.MSTeamsMaxPayloadSize, len(result))
+	}
+
+	var message msteamsMessage
+	if err := json.Unmarshal([]byte(result), &message); err != nil {
+		t.Fatalf("expected JSON message, got: %v", err)
+	}
+	if len(message.Attachments) != 1 || message.Attachments[0].Content.Actions != nil {
+		t.Fatalf("expected single attachment without actions, got: %+v", message.Attachments)
+	}
+
+	body := message.Attachments[0].Content.Body
+	comment := body[len(body)-1].Text
+	if !strings.HasSuffix(comment, msteamsTruncatedSuffix) {
+		t.Errorf("expected truncated comment to end with %q", msteamsTruncatedSuffix)
+	}
+	if !strings.HasPrefix(commentText, strings.TrimSuffix(comment, msteamsTruncatedSuffix)) {
+		t.Error("expected truncated comment to be a prefix of the original comment")
+	}
+}
+
+func TestTruncateMSTeamsText(t *testing.T) {
+	type testCase struct {
+		name     string
+		text     string
+		maxBytes int
+		expected string
+	}
+
+	testCases := []testCase{
+		{name: "Truncate_ASCII", text: "abcdefghij", maxBytes: 8, expected: "abcde…"},
+		{name: "Truncate_On_Rune_Boundary", text: "абвгд", maxBytes: 8, expected: "аб…"},
+		{name: "Shorter_Than_Limit_Still_Shrinks", text: "abcdef", maxBytes: 20, expected: "abcde…"},
+		{name: "No_Room_For_Text", text: "abcdef", maxBytes: 3, expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := truncateMSTeamsText(tc.text, tc.maxBytes)
+
+			if result != tc.expected {
+				t.Errorf("expected %q, got: %q", tc.expected, result)
+			}
+			if !utf8.ValidString(result) {
+				t.Errorf("expected valid UTF-8, got: %q", result)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"strings"
+)
+
+// Заголовок push уведомления, если у задачи нет идентификатора и названия
+const pushDefaultTitle = "YouTrack"
+
+// Метки push уведомления по измененному полю, названия меток совпадают с короткими именами emoji в ntfy
+var pushChangeTags = map[string]string{
+	State:    "bar_chart",
+	Priority: "zap",
+	Assignee: "bust_in_silhouette",
+	Comment:  "speech_balloon",
+}
+
+// FormatPush форматирует payload для push каналов (ntfy, Gotify) в уведомление
+// Заголовок содержит идентификатор и название задачи, приоритет соответствует приоритету задачи,
+// а нажатие на уведомление открывает задачу. Текст уведомления без разметки содержит заголовок изменения,
+// поля задачи и текст комментария
+func FormatPush(payload *parser.YoutrackWebhookPayload) string {
+	assignee := extractUserName(payload.Issue.Assignee)
+	changed := extractChangeWithEscaper(payload.Changes, assignee, extractChangeValue, plainText, plainText)
+
+	message := port.PushMessage{
+		Title:    pushTitle(payload.Issue),
+		Message:  truncateText(formatPushMessage(payload, changed, assignee), port.PushMaxMessageLength),
+		Priority: pushPriority(extractFieldValue(payload.Issue.Priority)),
+		Tags:     pushTags(payload, changed),
+		Click:    payload.Issue.URL,
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return message.Message
+	}
+
+	return string(data)
+}
+
+// pushTitle возвращает заголовок push уведомления: идентификатор и название задачи
+func pushTitle(issue parser.YoutrackIssue) string {
+	title := strings.Join(strings.Fields(issue.IDReadable+" "+issue.Summary), " ")
+	if title == "" {
+		return pushDefaultTitle
+	}
+	return title
+}
+
+// pushTags возвращает метки push уведомления: значок изменения и название проекта
+func pushTags(payload *parser.YoutrackWebhookPayload, changed *Changed) []string {
+	var tags []string
+	if changed != nil {
+		tags = append(tags, pushChangeTags[changed.field])
+	}
+	if project := extractFieldValue(payload.Project); project != "" {
+		tags = append(tags, project)
+	}
+	return tags
+}
+
+// formatPushMessage форматирует текст push уведомления без разметки
+func formatPushMessage(payload *parser.YoutrackWebhookPayload, changed *Changed, assignee string) string {
+	state := extractFieldValue(payload.Issue.State)
+	if changed != nil && changed.field == State {
+		state = changed.value
+	}
+
+	priority := extractFieldValue(payload.Issue.Priority)
+	if changed != nil && changed.field == Priority {
+		priority = changed.value
+	}
+
+	if changed != nil && changed.field == Assignee {
+		assignee = changed.value
+	}
+
+	var lines []string
+	if changed != nil {
+		lines = append(lines, changed.header)
+	}
+
+	lines = append(lines,
+		fmt.Sprintf("📁 Проект: %s", extractFieldValue(payload.Project)),
+		fmt.Sprintf("📊 Состояние: %s", state),
+		fmt.Sprintf("⚡️ Приоритет: %s", priority),
+		fmt.Sprintf("👤 Назначена: %s", assignee),
+		fmt.Sprintf("✏️ Автор изменения: %s", extractUserName(payload.Updater)),
+	)
+
+	if changed != nil && changed.field == Comment {
+		lines = append(lines, "", changed.value)
+	}
+
+	return strings.Join(lines, "\n")
+}
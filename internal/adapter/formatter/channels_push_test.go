@@ -0,0 +1,157 @@
+package formatter
+
+import (
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/google/go-cmp/cmp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFormatPush(t *testing.T) {
+	type testCase struct {
+		name            string
+		payload         *parser.YoutrackWebhookPayload
+		expectedMessage port.PushMessage
+	}
+
+	projectName := "TestProject"
+	statePresentation := "В работе"
+	priorityName := "Critical"
+	minorPriority := "Minor"
+	assigneeFullName := "John Doe"
+	updaterFullName := "Jane Smith"
+
+	newPayload := func(changes ...parser.YoutrackChange) *parser.YoutrackWebhookPayload {
+		return &parser.YoutrackWebhookPayload{
+			Project: &parser.YoutrackFieldValue{Name: &projectName},
+			Issue: parser.YoutrackIssue{
+				IDReadable: "PROJ-123",
+				Summary:    "Fix login\npage",
+				URL:        "https://youtrack.test/issue/PROJ-123",
+				State:      &parser.YoutrackFieldValue{Presentation: &statePresentation},
+				Priority:   &parser.YoutrackFieldValue{Name: &priorityName},
+				Assignee:   &parser.YoutrackUser{FullName: &assigneeFullName},
+			},
+			Updater: &parser.YoutrackUser{FullName: &updaterFullName},
+			Changes: changes,
+		}
+	}
+
+	fields := func(state, priority, assignee string) string {
+		return "📁 Проект: TestProject\n" +
+			"📊 Состояние: " + state + "\n" +
+			"⚡️ Приоритет: " + priority + "\n" +
+			"👤 Назначена: " + assignee + "\n" +
+			"✏️ Автор изменения: Jane Smith"
+	}
+
+	testCases := []testCase{
+		{
+			name:    "Format_Push_Without_Changes",
+			payload: newPayload(),
+			expectedMessage: port.PushMessage{
+				Title:    "PROJ-123 Fix login page",
+				Message:  fields("В работе", "Critical", "John Doe"),
+				Priority: port.PushPriorityMax,
+				Tags:     []string{"TestProject"},
+				Click:    "https://youtrack.test/issue/PROJ-123",
+			},
+		},
+		{
+			name: "Format_Push_Priority_Change",
+			payload: func() *parser.YoutrackWebhookPayload {
+				payload := newPayload(parser.YoutrackChange{
+					Field:    Priority,
+					OldValue: []byte(`{"name": "Normal"}`),
+					NewValue: []byte(`{"name": "Minor"}`),
+				})
+				payload.Issue.Priority = &parser.YoutrackFieldValue{Name: &minorPriority}
+				return payload
+			}(),
+			expectedMessage: port.PushMessage{
+				Title:    "PROJ-123 Fix login page",
+				Message:  "⚡ Изменен приоритет задачи\n" + fields("В работе", "Normal → Minor", "John Doe"),
+				Priority: port.PushPriorityLow,
+				Tags:     []string{"zap", "TestProject"},
+				Click:    "https://youtrack.test/issue/PROJ-123",
+			},
+		},
+		{
+			name: "Format_Push_Assignee_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Assignee,
+				OldValue: []byte(`{"login": "old_owner"}`),
+				NewValue: []byte(`{"fullName": "John Doe"}`),
+			}),
+			expectedMessage: port.PushMessage{
+				Title:    "PROJ-123 Fix login page",
+				Message:  "👤 Изменен исполнитель задачи\n" + fields("В работе", "Critical", "old_owner → John Doe"),
+				Priority: port.PushPriorityMax,
+				Tags:     []string{"bust_in_silhouette", "TestProject"},
+				Click:    "https://youtrack.test/issue/PROJ-123",
+			},
+		},
+		{
+			name: "Format_Push_Comment",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Comment,
+				NewValue: []byte(`{"text": "Please check \\*this\\*", "mentionedUsers": [{"fullName": "Ann Lee"}]}`),
+			}),
+			expectedMessage: port.PushMessage{
+				Title:    "PROJ-123 Fix login page",
+				Message:  "💬 Добавлен комментарий\n" + fields("В работе", "Critical", "John Doe") + "\n\nPlease check *this* [Упомянуты: Ann Lee]",
+				Priority: port.PushPriorityMax,
+				Tags:     []string{"speech_balloon", "TestProject"},
+				Click:    "https://youtrack.test/issue/PROJ-123",
+			},
+		},
+		{
+			name:    "Format_Push_Empty_Payload",
+			payload: &parser.YoutrackWebhookPayload{},
+			expectedMessage: port.PushMessage{
+				Title:    "YouTrack",
+				Message:  "📁 Проект: \n📊 Состояние: \n⚡️ Приоритет: \n👤 Назначена: \n✏️ Автор изменения: ",
+				Priority: port.PushPriorityDefault,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := FormatPush(tc.payload)
+
+			var message port.PushMessage
+			if err := json.Unmarshal([]byte(result), &message); err != nil {
+				t.Fatalf("expected JSON message, got: %q (%v)", result, err)
+			}
+
+			if diff := cmp.Diff(tc.expectedMessage, message); diff != "" {
+				t.Errorf("unexpected message (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFormatPush_LongComment(t *testing.T) {
+	comment, _ := json.Marshal(map[string]string{"text": strings.Repeat("a", port.PushMaxMessageLength)})
+
+	result := FormatPush(&parser.YoutrackWebhookPayload{
+		Issue:   parser.YoutrackIssue{Summary: "Summary"},
+		Changes: []parser.YoutrackChange{{Field: Comment, NewValue: comment}},
+	})
+
+	var message port.PushMessage
+	if err := json.Unmarshal([]byte(result), &message); err != nil {
+		t.Fatalf("expected JSON message, got: %q (%v)", result, err)
+	}
+
+	if length := utf8.RuneCountInString(message.Message); length != port.PushMaxMessageLength {
+		t.Errorf("expected message of %d characters, got: %d", port.PushMaxMessageLength, length)
+	}
+	if !strings.HasSuffix(message.Message, "…") {
+		t.Errorf("expected truncated message to end with ellipsis")
+	}
+}
@@ -0,0 +1,129 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+)
+
+// slackMessage описывает сообщение Slack: текст для уведомлений и блоки Block Kit для отображения
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+// slackBlock описывает блок Block Kit
+type slackBlock struct {
+	Type   string       `json:"type"`
+	Text   *slackText   `json:"text,omitempty"`
+	Fields []*slackText `json:"fields,omitempty"`
+}
+
+// slackText описывает текстовый объект Block Kit
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SlackMentionFormatter форматирует упоминания для Slack
+// Пользователи, для которых известен ID в Slack, упоминаются как <@USERID>, остальные - по имени
+type SlackMentionFormatter struct {
+	userIDs map[string]string
+}
+
+// NewSlackMentionFormatter создает форматирование упоминаний по ID пользователей Slack
+// Ключ userIDs - логин или email пользователя в YouTrack
+func NewSlackMentionFormatter(userIDs map[string]string) *SlackMentionFormatter {
+	return &SlackMentionFormatter{userIDs: userIDs}
+}
+
+// FormatMention форматирует упоминание пользователя для Slack
+func (f *SlackMentionFormatter) FormatMention(user parser.YoutrackUser) string {
+	if user.Login != nil && f.userIDs[*user.Login] != "" {
+		return fmt.Sprintf("<@%s>", f.userIDs[*user.Login])
+	}
+	if user.Email != nil && f.userIDs[*user.Email] != "" {
+		return fmt.Sprintf("<@%s>", f.userIDs[*user.Email])
+	}
+	return escapeSlack(extractUserName(&user))
+}
+
+// NewSlackFormatter возвращает форматирование payload для Slack канала в сообщение Block Kit
+// Упоминания пользователей, ID которых указан в userIDs, формируются как <@USERID>
+func NewSlackFormatter(userIDs map[string]string) func(payload *parser.YoutrackWebhookPayload) string {
+	mentionFormatter := NewSlackMentionFormatter(userIDs)
+	return func(payload *parser.YoutrackWebhookPayload) string {
+		return formatSlack(payload, mentionFormatter)
+	}
+}
+
+// formatSlack форматирует payload в JSON сообщения Slack с блоками для проекта, задачи, состояния,
+// приоритета, исполнителя и комментария
+func formatSlack(payload *parser.YoutrackWebhookPayload, mentionFormatter MentionFormatter) string {
+	mention := ""
+	if payload.Issue.Assignee != nil {
+		mention = mentionFormatter.FormatMention(*payload.Issue.Assignee)
+	}
+
+	changed := extractChangeWithEscaper(payload.Changes, mention, markdownValueExtractor(mentionFormatter, escapeSlack, "\n"), escapeSlack, plainText)
+
+	message := slackMessage{Text: escapeSlack(payload.Issue.Summary)}
+	if changed != nil {
+		message.Text = fmt.Sprintf("%s: %s", changed.header, message.Text)
+		message.Blocks = append(message.Blocks, slackBlock{
+			Type: "header",
+			Text: &slackText{Type: "plain_text", Text: changed.header},
+		})
+	}
+
+	issue := escapeSlack(payload.Issue.Summary)
+	if payload.Issue.URL != "" {
+		issue = fmt.Sprintf("<%s|%s>", payload.Issue.URL, issue)
+	}
+
+	state := escapeSlack(extractFieldValue(payload.Issue.State))
+	if changed != nil && changed.field == State {
+		state = changed.value
+	}
+
+	priority := escapeSlack(extractFieldValue(payload.Issue.Priority))
+	if changed != nil && changed.field == Priority {
+		priority = changed.value
+	}
+
+	assignee := mention
+	if changed != nil && changed.field == Assignee {
+		assignee = changed.value
+	}
+
+	message.Blocks = append(message.Blocks, slackBlock{
+		Type: "section",
+		Fields: []*slackText{
+			slackField("📁 Проект", escapeSlack(extractFieldValue(payload.Project))),
+			slackField("📋 Задача", issue),
+			slackField("📊 Состояние", state),
+			slackField("⚡️ Приоритет", priority),
+			slackField("👤 Назначена", assignee),
+			slackField("✏️ Автор изменения", escapeSlack(extractUserName(payload.Updater))),
+		},
+	})
+
+	if changed != nil && changed.field == Comment {
+		message.Blocks = append(message.Blocks, slackBlock{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: fmt.Sprintf("*💬 Комментарий:*\n%s", changed.value)},
+		})
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return message.Text
+	}
+
+	return string(data)
+}
+
+// slackField создает поле секции Block Kit с заголовком и значением
+func slackField(title string, value string) *slackText {
+	return &slackText{Type: "mrkdwn", Text: fmt.Sprintf("*%s:*\n%s", title, value)}
+}
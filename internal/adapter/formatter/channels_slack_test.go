@@ -0,0 +1,221 @@
+package formatter
+
+import (
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestFormatSlack(t *testing.T) {
+	type testCase struct {
+		name            string
+		userIDs         map[string]string
+		payload         *parser.YoutrackWebhookPayload
+		expectedMessage slackMessage
+	}
+
+	projectName := "TestProject"
+	issueSummary := "Fix <script> & styles"
+	issueURL := "https://youtrack.test/issue/PROJ-123"
+	statePresentation := "В работе"
+	priorityName := "High"
+	assigneeFullName := "John Doe"
+	assigneeLogin := "john"
+	updaterFullName := "Jane Smith"
+
+	newPayload := func(changes ...parser.YoutrackChange) *parser.YoutrackWebhookPayload {
+		return &parser.YoutrackWebhookPayload{
+			Project: &parser.YoutrackFieldValue{Name: &projectName},
+			Issue: parser.YoutrackIssue{
+				Summary:  issueSummary,
+				URL:      issueURL,
+				State:    &parser.YoutrackFieldValue{Presentation: &statePresentation},
+				Priority: &parser.YoutrackFieldValue{Name: &priorityName},
+				Assignee: &parser.YoutrackUser{FullName: &assigneeFullName, Login: &assigneeLogin},
+			},
+			Updater: &parser.YoutrackUser{FullName: &updaterFullName},
+			Changes: changes,
+		}
+	}
+
+	fields := func(state, priority, assignee string) slackBlock {
+		return slackBlock{
+			Type: "section",
+			Fields: []*slackText{
+				{Type: "mrkdwn", Text: "*📁 Проект:*\nTestProject"},
+				{Type: "mrkdwn", Text: "*📋 Задача:*\n<https://youtrack.test/issue/PROJ-123|Fix &lt;script&gt; &amp; styles>"},
+				{Type: "mrkdwn", Text: "*📊 Состояние:*\n" + state},
+				{Type: "mrkdwn", Text: "*⚡️ Приоритет:*\n" + priority},
+				{Type: "mrkdwn", Text: "*👤 Назначена:*\n" + assignee},
+				{Type: "mrkdwn", Text: "*✏️ Автор изменения:*\nJane Smith"},
+			},
+		}
+	}
+
+	header := func(text string) slackBlock {
+		return slackBlock{Type: "header", Text: &slackText{Type: "plain_text", Text: text}}
+	}
+
+	testCases := []testCase{
+		{
+			name:    "Format_Slack_Without_Changes",
+			payload: newPayload(),
+			expectedMessage: slackMessage{
+				Text:   "Fix &lt;script&gt; &amp; styles",
+				Blocks: []slackBlock{fields("В работе", "High", "John Doe")},
+			},
+		},
+		{
+			name: "Format_Slack_State_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    State,
+				OldValue: []byte(`{"name": "To Do", "presentation": "К выполнению"}`),
+				NewValue: []byte(`{"name": "In Progress", "presentation": "В работе"}`),
+			}),
+			expectedMessage: slackMessage{
+				Text: "📊 Изменен статус задачи: Fix &lt;script&gt; &amp; styles",
+				Blocks: []slackBlock{
+					header("📊 Изменен статус задачи"),
+					fields("К выполнению → В работе", "High", "John Doe"),
+				},
+			},
+		},
+		{
+			name: "Format_Slack_Priority_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Priority,
+				OldValue: []byte(`null`),
+				NewValue: []byte(`{"name": "High"}`),
+			}),
+			expectedMessage: slackMessage{
+				Text: "⚡ Изменен приоритет задачи: Fix &lt;script&gt; &amp; styles",
+				Blocks: []slackBlock{
+					header("⚡ Изменен приоритет задачи"),
+					fields("В работе", "(Не установлен) → High", "John Doe"),
+				},
+			},
+		},
+		{
+			name:    "Format_Slack_Assignee_Change_With_User_ID",
+			userIDs: map[string]string{"john": "U0JOHN"},
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Assignee,
+				OldValue: []byte(`{"fullName": "Old <Owner>"}`),
+				NewValue: []byte(`{"fullName": "John Doe", "login": "john"}`),
+			}),
+			expectedMessage: slackMessage{
+				Text: "👤 Изменен исполнитель задачи: Fix &lt;script&gt; &amp; styles",
+				Blocks: []slackBlock{
+					header("👤 Изменен исполнитель задачи"),
+					fields("В работе", "High", "Old &lt;Owner&gt; → <@U0JOHN>"),
+				},
+			},
+		},
+		{
+			name:    "Format_Slack_Comment_With_Mentions",
+			userIDs: map[string]string{"ann@example.com": "U0ANN", "john": "U0JOHN"},
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Comment,
+				NewValue: []byte(`{"text": "Please check \\*a < b\\*", "mentionedUsers": [{"fullName": "Ann Lee", "email": "ann@example.com"}, {"fullName": "Bob"}]}`),
+			}),
+			expectedMessage: slackMessage{
+				Text: "💬 Добавлен комментарий: Fix &lt;script&gt; &amp; styles",
+				Blocks: []slackBlock{
+					header("💬 Добавлен комментарий"),
+					fields("В работе", "High", "<@U0JOHN>"),
+					{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "*💬 Комментарий:*\nPlease check *a &lt; b*\n[Упомянуты: <@U0ANN>, Bob]"}},
+				},
+			},
+		},
+		{
+			name: "Format_Slack_Without_Project_And_Assignee",
+			payload: &parser.YoutrackWebhookPayload{
+				Issue: parser.YoutrackIssue{Summary: "Summary"},
+			},
+			expectedMessage: slackMessage{
+				Text: "Summary",
+				Blocks: []slackBlock{{
+					Type: "section",
+					Fields: []*slackText{
+						{Type: "mrkdwn", Text: "*📁 Проект:*\n"},
+						{Type: "mrkdwn", Text: "*📋 Задача:*\nSummary"},
+						{Type: "mrkdwn", Text: "*📊 Состояние:*\n"},
+						{Type: "mrkdwn", Text: "*⚡️ Приоритет:*\n"},
+						{Type: "mrkdwn", Text: "*👤 Назначена:*\n"},
+						{Type: "mrkdwn", Text: "*✏️ Автор изменения:*\n"},
+					},
+				}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := NewSlackFormatter(tc.userIDs)(tc.payload)
+
+			var message slackMessage
+			if err := json.Unmarshal([]byte(result), &message); err != nil {
+				t.Fatalf("expected JSON message, got: %q (%v)", result, err)
+			}
+
+			if diff := cmp.Diff(tc.expectedMessage, message); diff != "" {
+				t.Errorf("message mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSlackMentionFormatter_FormatMention(t *testing.T) {
+	type testCase struct {
+		name           string
+		userIDs        map[string]string
+		user           parser.YoutrackUser
+		expectedResult string
+	}
+
+	fullName := "John <Doe>"
+	login := "john"
+	email := "john@example.com"
+
+	testCases := []testCase{
+		{
+			name:           "Format_Mention_By_Login",
+			userIDs:        map[string]string{"john": "U0JOHN", "john@example.com": "U0EMAIL"},
+			user:           parser.YoutrackUser{FullName: &fullName, Login: &login, Email: &email},
+			expectedResult: "<@U0JOHN>",
+		},
+		{
+			name:           "Format_Mention_By_Email",
+			userIDs:        map[string]string{"john@example.com": "U0EMAIL"},
+			user:           parser.YoutrackUser{FullName: &fullName, Login: &login, Email: &email},
+			expectedResult: "<@U0EMAIL>",
+		},
+		{
+			name:           "Format_Mention_Unknown_User_By_Name",
+			userIDs:        map[string]string{"jane": "U0JANE"},
+			user:           parser.YoutrackUser{FullName: &fullName, Login: &login},
+			expectedResult: "John &lt;Doe&gt;",
+		},
+		{
+			name:           "Format_Mention_Without_User_IDs",
+			user:           parser.YoutrackUser{Login: &login},
+			expectedResult: "john",
+		},
+		{
+			name:           "Format_Mention_All_Nil",
+			user:           parser.YoutrackUser{},
+			expectedResult: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := NewSlackMentionFormatter(tc.userIDs).FormatMention(tc.user)
+
+			if result != tc.expectedResult {
+				t.Errorf("expected result %q, got: %q", tc.expectedResult, result)
+			}
+		})
+	}
+}
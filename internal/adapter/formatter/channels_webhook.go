@@ -0,0 +1,18 @@
+package formatter
+
+import (
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+)
+
+// FormatWebhook форматирует payload для webhook канала: передает сам payload в JSON
+// Тело запроса формирует канал по шаблону цели, поэтому форматирование не зависит от получателя
+// Если шаблон цели не задан, этот JSON отправляется как тело запроса
+func FormatWebhook(payload *parser.YoutrackWebhookPayload) string {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return formatDefault(payload)
+	}
+
+	return string(data)
+}
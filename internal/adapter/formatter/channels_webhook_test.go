@@ -0,0 +1,59 @@
+package formatter
+
+import (
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestFormatWebhook(t *testing.T) {
+	type testCase struct {
+		name    string
+		payload *parser.YoutrackWebhookPayload
+	}
+
+	projectName := "TestProject"
+	stateName := "In Progress"
+	updaterLogin := "jane"
+
+	testCases := []testCase{
+		{
+			name: "Format_Webhook_Full_Payload",
+			payload: &parser.YoutrackWebhookPayload{
+				Project: &parser.YoutrackFieldValue{Name: &projectName},
+				Issue: parser.YoutrackIssue{
+					IDReadable: "PROJ-1",
+					Summary:    "Fix <b>bold</b> styles",
+					URL:        "https://youtrack.test/issue/PROJ-1",
+					State:      &parser.YoutrackFieldValue{Name: &stateName},
+				},
+				Updater: &parser.YoutrackUser{Login: &updaterLogin},
+				Changes: []parser.YoutrackChange{{
+					Field:    State,
+					OldValue: json.RawMessage(`{"name":"Open"}`),
+					NewValue: json.RawMessage(`{"name":"In Progress"}`),
+				}},
+			},
+		},
+		{
+			name:    "Format_Webhook_Empty_Payload",
+			payload: &parser.YoutrackWebhookPayload{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := FormatWebhook(tc.payload)
+
+			var payload parser.YoutrackWebhookPayload
+			if err := json.Unmarshal([]byte(result), &payload); err != nil {
+				t.Fatalf("expected JSON payload, got: %q (%v)", result, err)
+			}
+
+			if diff := cmp.Diff(*tc.payload, payload); diff != "" {
+				t.Errorf("payload mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
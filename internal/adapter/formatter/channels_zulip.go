@@ -0,0 +1,112 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"strings"
+)
+
+// Тема сообщения Zulip, если у задачи нет идентификатора и названия
+const zulipDefaultTopic = "YouTrack"
+
+// ZulipMentionFormatter форматирует упоминания для Zulip
+// Zulip упоминает пользователя по полному имени, без полного имени пользователь указывается по логину без упоминания
+type ZulipMentionFormatter struct{}
+
+// FormatMention форматирует упоминание пользователя для Zulip
+func (f *ZulipMentionFormatter) FormatMention(user parser.YoutrackUser) string {
+	if user.FullName != nil && *user.FullName != "" {
+		return fmt.Sprintf("@**%s**", *user.FullName)
+	}
+	return escapeZulip(extractUserName(&user))
+}
+
+// FormatZulip форматирует payload для Zulip канала в сообщение с темой по задаче
+// Тема содержит идентификатор и название задачи, поэтому уведомления одной задачи собираются в одной теме потока.
+// Текст сообщения в Markdown Zulip содержит заголовок изменения, ссылку на задачу, поля задачи и комментарий
+func FormatZulip(payload *parser.YoutrackWebhookPayload) string {
+	message := port.ZulipMessage{
+		Topic:   zulipTopic(payload.Issue),
+		Content: truncateText(formatZulipContent(payload), port.ZulipMaxContentLength),
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return message.Content
+	}
+
+	return string(data)
+}
+
+// zulipTopic возвращает тему сообщения по задаче: идентификатор и название, сокращенные до ограничения Zulip
+// Переводы строк в названии заменяются пробелами, так как тема Zulip однострочная
+func zulipTopic(issue parser.YoutrackIssue) string {
+	topic := strings.Join(strings.Fields(issue.IDReadable+" "+issue.Summary), " ")
+	if topic == "" {
+		return zulipDefaultTopic
+	}
+	return truncateText(topic, port.ZulipMaxTopicLength)
+}
+
+// formatZulipContent форматирует текст сообщения Zulip
+func formatZulipContent(payload *parser.YoutrackWebhookPayload) string {
+	mentionFormatter := &ZulipMentionFormatter{}
+
+	mention := ""
+	if payload.Issue.Assignee != nil {
+		mention = mentionFormatter.FormatMention(*payload.Issue.Assignee)
+	}
+
+	changed := extractChangeWithEscaper(payload.Changes, mention, markdownValueExtractor(mentionFormatter, escapeZulip, "\n"), escapeZulip, zulipHeading)
+
+	state := escapeZulip(extractFieldValue(payload.Issue.State))
+	if changed != nil && changed.field == State {
+		state = changed.value
+	}
+
+	priority := escapeZulip(extractFieldValue(payload.Issue.Priority))
+	if changed != nil && changed.field == Priority {
+		priority = changed.value
+	}
+
+	assignee := mention
+	if changed != nil && changed.field == Assignee {
+		assignee = changed.value
+	}
+
+	var lines []string
+	if changed != nil {
+		lines = append(lines, changed.header)
+	}
+
+	summary := escapeZulip(payload.Issue.Summary)
+	if payload.Issue.URL != "" {
+		summary = fmt.Sprintf("[%s](%s)", summary, payload.Issue.URL)
+	}
+	lines = append(lines,
+		fmt.Sprintf("**%s**", summary),
+		fmt.Sprintf("**📁 Проект:** %s", escapeZulip(extractFieldValue(payload.Project))),
+		fmt.Sprintf("**📊 Состояние:** %s", state),
+		fmt.Sprintf("**⚡️ Приоритет:** %s", priority),
+		fmt.Sprintf("**👤 Назначена:** %s", assignee),
+		fmt.Sprintf("**✏️ Автор изменения:** %s", escapeZulip(extractUserName(payload.Updater))),
+	)
+
+	if changed != nil && changed.field == Comment {
+		lines = append(lines, "", "**💬 Комментарий:**", zulipQuote(changed.value))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// zulipHeading оформляет заголовок изменения задачи заголовком Markdown
+func zulipHeading(text string) string {
+	return "#### " + text
+}
+
+// zulipQuote оформляет текст цитатой Markdown, каждая строка начинается с "> "
+func zulipQuote(text string) string {
+	return "> " + strings.ReplaceAll(text, "\n", "\n> ")
+}
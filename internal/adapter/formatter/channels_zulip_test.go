@@ -0,0 +1,192 @@
+package formatter
+
+import (
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFormatZulip(t *testing.T) {
+	type testCase struct {
+		name            string
+		payload         *parser.YoutrackWebhookPayload
+		expectedTopic   string
+		expectedContent string
+	}
+
+	projectName := "TestProject"
+	issueSummary := "Fix *bold* styles"
+	issueURL := "https://youtrack.test/issue/PROJ-123"
+	statePresentation := "В работе"
+	priorityName := "High"
+	assigneeFullName := "John Doe"
+	assigneeLogin := "john"
+	updaterFullName := "Jane Smith"
+
+	newPayload := func(changes ...parser.YoutrackChange) *parser.YoutrackWebhookPayload {
+		return &parser.YoutrackWebhookPayload{
+			Project: &parser.YoutrackFieldValue{Name: &projectName},
+			Issue: parser.YoutrackIssue{
+				IDReadable: "PROJ-123",
+				Summary:    issueSummary,
+				URL:        issueURL,
+				State:      &parser.YoutrackFieldValue{Presentation: &statePresentation},
+				Priority:   &parser.YoutrackFieldValue{Name: &priorityName},
+				Assignee:   &parser.YoutrackUser{FullName: &assigneeFullName, Login: &assigneeLogin},
+			},
+			Updater: &parser.YoutrackUser{FullName: &updaterFullName},
+			Changes: changes,
+		}
+	}
+
+	fields := func(state, priority, assignee string) string {
+		return "**[Fix \\*bold\\* styles](https://youtrack.test/issue/PROJ-123)**\n" +
+			"**📁 Проект:** TestProject\n" +
+			"**📊 Состояние:** " + state + "\n" +
+			"**⚡️ Приоритет:** " + priority + "\n" +
+			"**👤 Назначена:** " + assignee + "\n" +
+			"**✏️ Автор изменения:** Jane Smith"
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Format_Zulip_Without_Changes",
+			payload:         newPayload(),
+			expectedTopic:   "PROJ-123 Fix *bold* styles",
+			expectedContent: fields("В работе", "High", "@**John Doe**"),
+		},
+		{
+			name: "Format_Zulip_State_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    State,
+				OldValue: []byte(`{"name": "To Do", "presentation": "К выполнению"}`),
+				NewValue: []byte(`{"name": "In Progress", "presentation": "В работе"}`),
+			}),
+			expectedTopic:   "PROJ-123 Fix *bold* styles",
+			expectedContent: "#### 📊 Изменен статус задачи\n" + fields("К выполнению → В работе", "High", "@**John Doe**"),
+		},
+		{
+			name: "Format_Zulip_Assignee_Change",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Assignee,
+				OldValue: []byte(`{"login": "old_owner"}`),
+				NewValue: []byte(`{"fullName": "John Doe", "login": "john"}`),
+			}),
+			expectedTopic:   "PROJ-123 Fix *bold* styles",
+			expectedContent: "#### 👤 Изменен исполнитель задачи\n" + fields("В работе", "High", "old\\_owner → @**John Doe**"),
+		},
+		{
+			name: "Format_Zulip_Comment_With_Mentions",
+			payload: newPayload(parser.YoutrackChange{
+				Field:    Comment,
+				NewValue: []byte(`{"text": "Please check \\*a > b\\*\nThanks", "mentionedUsers": [{"fullName": "Ann Lee"}, {"login": "bob_k"}]}`),
+			}),
+			expectedTopic: "PROJ-123 Fix *bold* styles",
+			expectedContent: "#### 💬 Добавлен комментарий\n" + fields("В работе", "High", "@**John Doe**") +
+				"\n\n**💬 Комментарий:**\n> Please check \\*a \\> b\\*\n> Thanks\n> [Упомянуты: @**Ann Lee**, bob\\_k]",
+		},
+		{
+			name: "Format_Zulip_Topic_Truncated",
+			payload: &parser.YoutrackWebhookPayload{
+				Issue: parser.YoutrackIssue{
+					IDReadable: "PROJ-7",
+					Summary:    "Very long summary\nthat spans several lines and does not fit into the Zulip topic",
+				},
+			},
+			expectedTopic: "PROJ-7 Very long summary that spans several lines and does …",
+			expectedContent: "**Very long summary\nthat spans several lines and does not fit into the Zulip topic**\n" +
+				"**📁 Проект:** \n**📊 Состояние:** \n**⚡️ Приоритет:** \n**👤 Назначена:** \n**✏️ Автор изменения:** ",
+		},
+		{
+			name:          "Format_Zulip_Default_Topic",
+			payload:       &parser.YoutrackWebhookPayload{},
+			expectedTopic: "YouTrack",
+			expectedContent: "****\n" +
+				"**📁 Проект:** \n**📊 Состояние:** \n**⚡️ Приоритет:** \n**👤 Назначена:** \n**✏️ Автор изменения:** ",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := FormatZulip(tc.payload)
+
+			var message port.ZulipMessage
+			if err := json.Unmarshal([]byte(result), &message); err != nil {
+				t.Fatalf("expected JSON message, got: %q (%v)", result, err)
+			}
+
+			if message.Topic != tc.expectedTopic {
+				t.Errorf("expected topic %q, got: %q", tc.expectedTopic, message.Topic)
+			}
+			if length := utf8.RuneCountInString(message.Topic); length > port.ZulipMaxTopicLength {
+				t.Errorf("expected topic not longer than %d characters, got: %d", port.ZulipMaxTopicLength, length)
+			}
+			if message.Content != tc.expectedContent {
+				t.Errorf("expected content:\n%s\ngot:\n%s", tc.expectedContent, message.Content)
+			}
+		})
+	}
+}
+
+func TestFormatZulip_LongComment(t *testing.T) {
+	comment, _ := json.Marshal(map[string]string{"text": strings.Repeat("a", port.ZulipMaxContentLength)})
+
+	result := FormatZulip(&parser.YoutrackWebhookPayload{
+		Issue:   parser.YoutrackIssue{Summary: "Summary"},
+		Changes: []parser.YoutrackChange{{Field: Comment, NewValue: comment}},
+	})
+
+	var message port.ZulipMessage
+	if err := json.Unmarshal([]byte(result), &message); err != nil {
+		t.Fatalf("expected JSON message, got: %q (%v)", result, err)
+	}
+
+	if length := utf8.RuneCountInString(message.Content); length != port.ZulipMaxContentLength {
+		t.Errorf("expected content of %d characters, got: %d", port.ZulipMaxContentLength, length)
+	}
+	if !strings.HasSuffix(message.Content, "…") {
+		t.Errorf("expected truncated content to end with ellipsis, got: %q", message.Content[len(message.Content)-10:])
+	}
+}
+
+func TestZulipMentionFormatter_FormatMention(t *testing.T) {
+	type testCase struct {
+		name           string
+		user           parser.YoutrackUser
+		expectedResult string
+	}
+
+	fullName := "John Doe"
+	login := "john_doe"
+
+	testCases := []testCase{
+		{
+			name:           "Format_Mention_By_Full_Name",
+			user:           parser.YoutrackUser{FullName: &fullName, Login: &login},
+			expectedResult: "@**John Doe**",
+		},
+		{
+			name:           "Format_Login_Without_Full_Name",
+			user:           parser.YoutrackUser{Login: &login},
+			expectedResult: "john\\_doe",
+		},
+		{
+			name:           "Format_Empty_User",
+			user:           parser.YoutrackUser{},
+			expectedResult: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			formatter := &ZulipMentionFormatter{}
+
+			if result := formatter.FormatMention(tc.user); result != tc.expectedResult {
+				t.Errorf("expected %q, got: %q", tc.expectedResult, result)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package formatter
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
 	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
 	"strings"
 )
@@ -20,6 +21,32 @@ const (
 	State    string = "State"
 )
 
+// Цвет оформления уведомления для приоритета, не найденного в priorityColors (серый)
+const defaultPriorityColor = 0x9e9e9e
+
+// Цвета оформления уведомлений (RGB) по названию приоритета задачи в нижнем регистре
+var priorityColors = map[string]int{
+	"show-stopper": 0xd32f2f,
+	"critical":     0xd32f2f,
+	"major":        0xf57c00,
+	"high":         0xf57c00,
+	"normal":       0x1976d2,
+	"minor":        defaultPriorityColor,
+	"low":          defaultPriorityColor,
+}
+
+// Приоритеты push уведомлений по названию приоритета задачи в нижнем регистре
+// Приоритет, не найденный в pushPriorities, отправляется с обычным приоритетом
+var pushPriorities = map[string]int{
+	"show-stopper": port.PushPriorityMax,
+	"critical":     port.PushPriorityMax,
+	"major":        port.PushPriorityHigh,
+	"high":         port.PushPriorityHigh,
+	"normal":       port.PushPriorityDefault,
+	"minor":        port.PushPriorityLow,
+	"low":          port.PushPriorityLow,
+}
+
 // Переводы полей
 var fieldTranslations = map[string]string{
 	Assignee: "Назначена",
@@ -58,6 +85,34 @@ func translateFieldName(field string) string {
 	return field
 }
 
+// priorityColor возвращает цвет оформления уведомления (RGB) для приоритета задачи
+func priorityColor(priority string) int {
+	if color, exists := priorityColors[strings.ToLower(priority)]; exists {
+		return color
+	}
+	return defaultPriorityColor
+}
+
+// pushPriority возвращает приоритет push уведомления для приоритета задачи
+func pushPriority(priority string) int {
+	if pushPriority, exists := pushPriorities[strings.ToLower(priority)]; exists {
+		return pushPriority
+	}
+	return port.PushPriorityDefault
+}
+
+// truncateText сокращает текст до maxLength символов, заменяя окончание многоточием
+func truncateText(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+	if maxLength <= 0 {
+		return ""
+	}
+	return string(runes[:maxLength-1]) + "…"
+}
+
 // extractFieldValue извлекает значение поля
 func extractFieldValue(field *parser.YoutrackFieldValue) string {
 	if field == nil {
@@ -169,6 +224,45 @@ func extractChangedFromChangesWithExtractor(changes []parser.YoutrackChange, men
 	return changed
 }
 
+// extractChangeWithEscaper извлекает информацию об отслеживаемом изменении в разметке канала
+// Значения полей экранируются функцией escape, упоминание исполнителя и комментарий, извлеченный valueExtractor,
+// остаются без изменений. Заголовок оформляется функцией headerStyle
+func extractChangeWithEscaper(changes []parser.YoutrackChange, mention string, valueExtractor ChangeValueExtractor, escape func(string) string, headerStyle func(string) string) *Changed {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	var changed *Changed
+	for _, change := range changes {
+		var header, value string
+
+		switch change.Field {
+		case Assignee:
+			header = "Изменен исполнитель задачи"
+			value = fmt.Sprintf("%s → %s", escape(valueExtractor(change.OldValue, change.Field)), mention)
+		case Comment:
+			header = "Добавлен комментарий"
+			value = valueExtractor(change.NewValue, change.Field)
+		case Priority:
+			header = "Изменен приоритет задачи"
+			value = fmt.Sprintf("%s → %s", escape(valueExtractor(change.OldValue, change.Field)), escape(valueExtractor(change.NewValue, change.Field)))
+		case State:
+			header = "Изменен статус задачи"
+			value = fmt.Sprintf("%s → %s", escape(valueExtractor(change.OldValue, change.Field)), escape(valueExtractor(change.NewValue, change.Field)))
+		default:
+			continue
+		}
+
+		changed = &Changed{
+			field:  change.Field,
+			header: headerStyle(fmt.Sprintf("%s %s", getFieldIcon(change.Field), header)),
+			value:  value,
+		}
+	}
+
+	return changed
+}
+
 // extractCommentText извлекает текст комментария с упомянутыми пользователями
 func extractCommentText(comment parser.YoutrackCommentValue) string {
 	text := comment.Text
@@ -2,6 +2,7 @@ package formatter
 
 import (
 	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
 	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
 	"strings"
 	"testing"
@@ -539,3 +540,138 @@ func TestExtractCommentText(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractChangeWithEscaper(t *testing.T) {
+	type testCase struct {
+		name     string
+		changes  []parser.YoutrackChange
+		expected *Changed
+	}
+
+	bold := func(text string) string { return "**" + text + "**" }
+
+	testCases := []testCase{
+		{
+			name:     "No_Changes",
+			expected: nil,
+		},
+		{
+			name: "Untracked_Change_Ignored",
+			changes: []parser.YoutrackChange{
+				{Field: "Summary", OldValue: json.RawMessage(`"old"`), NewValue: json.RawMessage(`"new"`)},
+			},
+			expected: nil,
+		},
+		{
+			name: "State_Values_Escaped",
+			changes: []parser.YoutrackChange{
+				{Field: State, OldValue: json.RawMessage(`{"name":"To_Do"}`), NewValue: json.RawMessage(`{"name":"In_Progress"}`)},
+			},
+			expected: &Changed{field: State, header: "**📊 Изменен статус задачи**", value: "To\\_Do → In\\_Progress"},
+		},
+		{
+			name: "Assignee_Mention_Not_Escaped",
+			changes: []parser.YoutrackChange{
+				{Field: Assignee, OldValue: json.RawMessage(`{"fullName":"old_user"}`), NewValue: json.RawMessage(`{"fullName":"new_user"}`)},
+			},
+			expected: &Changed{field: Assignee, header: "**👤 Изменен исполнитель задачи**", value: "old\\_user → @new_user"},
+		},
+		{
+			name: "Comment_Uses_Value_Extractor",
+			changes: []parser.YoutrackChange{
+				{Field: Comment, NewValue: json.RawMessage(`{"text":"fix_it"}`)},
+			},
+			expected: &Changed{field: Comment, header: "**💬 Добавлен комментарий**", value: "fix\\_it"},
+		},
+		{
+			name: "Last_Tracked_Change_Wins",
+			changes: []parser.YoutrackChange{
+				{Field: State, OldValue: json.RawMessage(`{"name":"Open"}`), NewValue: json.RawMessage(`{"name":"Done"}`)},
+				{Field: Priority, OldValue: json.RawMessage(`{"name":"Normal"}`), NewValue: json.RawMessage(`{"name":"Major"}`)},
+			},
+			expected: &Changed{field: Priority, header: "**⚡ Изменен приоритет задачи**", value: "Normal → Major"},
+		},
+	}
+
+	valueExtractor := markdownValueExtractor(&DiscordMentionFormatter{}, escapeBackslashMarkdown, "\n")
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := extractChangeWithEscaper(tc.changes, "@new_user", valueExtractor, escapeBackslashMarkdown, bold)
+			if (result == nil) != (tc.expected == nil) || (result != nil && *result != *tc.expected) {
+				t.Errorf("expected %+v, got: %+v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	type testCase struct {
+		name      string
+		text      string
+		maxLength int
+		expected  string
+	}
+
+	testCases := []testCase{
+		{name: "Text_Within_Limit", text: "Задача", maxLength: 6, expected: "Задача"},
+		{name: "Text_Truncated", text: "Длинная задача", maxLength: 8, expected: "Длинная…"},
+		{name: "Zero_Limit", text: "Задача", maxLength: 0, expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := truncateText(tc.text, tc.maxLength); result != tc.expected {
+				t.Errorf("expected %q, got: %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestPriorityColor(t *testing.T) {
+	type testCase struct {
+		name          string
+		priority      string
+		expectedColor int
+	}
+
+	testCases := []testCase{
+		{name: "Critical", priority: "Critical", expectedColor: 0xd32f2f},
+		{name: "Major_Lower_Case", priority: "major", expectedColor: 0xf57c00},
+		{name: "Normal", priority: "Normal", expectedColor: 0x1976d2},
+		{name: "Unknown", priority: "Someday", expectedColor: defaultPriorityColor},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if color := priorityColor(tc.priority); color != tc.expectedColor {
+				t.Errorf("expected color %#06x, got: %#06x", tc.expectedColor, color)
+			}
+		})
+	}
+}
+
+func TestPushPriority(t *testing.T) {
+	type testCase struct {
+		name             string
+		priority         string
+		expectedPriority int
+	}
+
+	testCases := []testCase{
+		{name: "Show_Stopper", priority: "Show-stopper", expectedPriority: port.PushPriorityMax},
+		{name: "Critical", priority: "Critical", expectedPriority: port.PushPriorityMax},
+		{name: "Major_Lower_Case", priority: "major", expectedPriority: port.PushPriorityHigh},
+		{name: "Normal", priority: "Normal", expectedPriority: port.PushPriorityDefault},
+		{name: "Minor", priority: "Minor", expectedPriority: port.PushPriorityLow},
+		{name: "Unknown", priority: "Someday", expectedPriority: port.PushPriorityDefault},
+		{name: "Empty", priority: "", expectedPriority: port.PushPriorityDefault},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if priority := pushPriority(tc.priority); priority != tc.expectedPriority {
+				t.Errorf("expected priority %d, got: %d", tc.expectedPriority, priority)
+			}
+		})
+	}
+}
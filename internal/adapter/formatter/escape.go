@@ -1,6 +1,25 @@
 package formatter
 
-import "strings"
+import (
+	"html"
+	"strings"
+)
+
+// Замена спецсимволов разметки Slack mrkdwn на HTML сущности
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Экранирование спецсимволов Markdown Mattermost и Discord обратной косой чертой
+var backslashMarkdownEscaper = strings.NewReplacer(
+	"\\", "\\\\", "*", "\\*", "_", "\\_", "~", "\\~", "`", "\\`",
+	"[", "\\[", "]", "\\]", "|", "\\|", "#", "\\#", ">", "\\>",
+)
+
+// Экранирование спецсимволов Markdown Zulip обратной косой чертой
+// Экранирование * также не позволяет тексту задачи упомянуть пользователя через @**Имя**
+var zulipEscaper = strings.NewReplacer(
+	"\\", "\\\\", "*", "\\*", "_", "\\_", "`", "\\`",
+	"[", "\\[", "]", "\\]", "#", "\\#", ">", "\\>",
+)
 
 // getFieldIcon возвращает иконку для поля
 func getFieldIcon(field string) string {
@@ -43,3 +62,28 @@ func escapeMarkdownV2URL(url string) string {
 	result = strings.ReplaceAll(result, ")", "\\)")
 	return result
 }
+
+// escapeSlack экранирует текст для разметки Slack mrkdwn
+func escapeSlack(text string) string {
+	return slackEscaper.Replace(text)
+}
+
+// escapeBackslashMarkdown экранирует текст для Markdown Mattermost и Discord
+func escapeBackslashMarkdown(text string) string {
+	return backslashMarkdownEscaper.Replace(text)
+}
+
+// escapeHTML экранирует текст для HTML письма и сообщения Matrix, переводы строк заменяются на <br>
+func escapeHTML(text string) string {
+	return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+}
+
+// escapeZulip экранирует текст для Markdown Zulip
+func escapeZulip(text string) string {
+	return zulipEscaper.Replace(text)
+}
+
+// plainText возвращает текст без изменений, используется каналами без разметки
+func plainText(text string) string {
+	return text
+}
@@ -219,3 +219,149 @@ func TestEscapeMarkdownV2LinkText(t *testing.T) {
 		})
 	}
 }
+
+func TestEscapeSlack(t *testing.T) {
+	type testCase struct {
+		name     string
+		input    string
+		expected string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "Escape_Control_Characters",
+			input:    "a < b && c > d",
+			expected: "a &lt; b &amp;&amp; c &gt; d",
+		},
+		{
+			name:     "Keep_Markdown_Characters",
+			input:    "*bold* _italic_ `code`",
+			expected: "*bold* _italic_ `code`",
+		},
+		{
+			name:     "Empty_String",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := escapeSlack(tc.input); result != tc.expected {
+				t.Errorf("expected %q, got: %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestEscapeBackslashMarkdown(t *testing.T) {
+	type testCase struct {
+		name     string
+		input    string
+		expected string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "Escape_Markdown_Characters",
+			input:    "*bold* _italic_ ~strike~ `code`",
+			expected: "\\*bold\\* \\_italic\\_ \\~strike\\~ \\`code\\`",
+		},
+		{
+			name:     "Escape_Links_Tables_And_Headers",
+			input:    "# [link] | > quote",
+			expected: "\\# \\[link\\] \\| \\> quote",
+		},
+		{
+			name:     "Escape_Backslash",
+			input:    "C:\\path",
+			expected: "C:\\\\path",
+		},
+		{
+			name:     "Escape_Discord_Strike_And_Spoiler",
+			input:    "~~strike~~ ||spoiler||",
+			expected: "\\~\\~strike\\~\\~ \\|\\|spoiler\\|\\|",
+		},
+		{
+			name:     "Keep_Plain_Text",
+			input:    "Задача (PROJ-1) & 100%",
+			expected: "Задача (PROJ-1) & 100%",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := escapeBackslashMarkdown(tc.input); result != tc.expected {
+				t.Errorf("expected %q, got: %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestEscapeHTML(t *testing.T) {
+	type testCase struct {
+		name     string
+		input    string
+		expected string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "Escape_HTML_Characters",
+			input:    `<b>"Tom" & 'Jerry'</b>`,
+			expected: "&lt;b&gt;&#34;Tom&#34; &amp; &#39;Jerry&#39;&lt;/b&gt;",
+		},
+		{
+			name:     "Escape_HTML_Attributes",
+			input:    `<a href="x">Tom & Jerry</a>`,
+			expected: "&lt;a href=&#34;x&#34;&gt;Tom &amp; Jerry&lt;/a&gt;",
+		},
+		{
+			name:     "Replace_Newlines",
+			input:    "Line 1\nLine 2",
+			expected: "Line 1<br>Line 2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := escapeHTML(tc.input); result != tc.expected {
+				t.Errorf("expected %q, got: %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestEscapeZulip(t *testing.T) {
+	type testCase struct {
+		name     string
+		input    string
+		expected string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "Escape_Markdown_Characters",
+			input:    "*bold* _italic_ `code` [link] #tag > quote \\",
+			expected: "\\*bold\\* \\_italic\\_ \\`code\\` \\[link\\] \\#tag \\> quote \\\\",
+		},
+		{
+			name:     "Escape_Mention_Syntax",
+			input:    "@**John Doe**",
+			expected: "@\\*\\*John Doe\\*\\*",
+		},
+		{
+			name:     "Keep_Plain_Text",
+			input:    "Plain text: 100% (ok)",
+			expected: "Plain text: 100% (ok)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := escapeZulip(tc.input); result != tc.expected {
+				t.Errorf("expected %q, got: %q", tc.expected, result)
+			}
+		})
+	}
+}
@@ -107,11 +107,18 @@ func extractChangeValueMarkdown(value json.RawMessage, field string, commentExtr
 // extractCommentTextMarkdown извлекает текст комментария с упомянутыми пользователями для Markdown форматов
 // Использует MentionFormatter для форматирования упоминаний
 func extractCommentTextMarkdown(comment parser.YoutrackCommentValue, formatter MentionFormatter) string {
+	return extractEscapedCommentText(comment, formatter, plainText, "\n")
+}
+
+// extractEscapedCommentText извлекает текст комментария, экранированный функцией escape, с упомянутыми пользователями
+// Упоминания форматируются MentionFormatter без экранирования и отделяются от текста строкой separator
+func extractEscapedCommentText(comment parser.YoutrackCommentValue, formatter MentionFormatter, escape func(string) string, separator string) string {
 	text := comment.Text
 
 	for needle, replaced := range replaceSpecialCharsMap {
 		text = strings.ReplaceAll(text, needle, replaced)
 	}
+	text = escape(text)
 
 	if len(comment.MentionedUsers) > 0 {
 		var mentionNames []string
@@ -122,9 +129,21 @@ func extractCommentTextMarkdown(comment parser.YoutrackCommentValue, formatter M
 			}
 		}
 		if len(mentionNames) > 0 {
-			text += "\n" + fmt.Sprintf("[Упомянуты: %s]", strings.Join(mentionNames, ", "))
+			text += separator + fmt.Sprintf("[Упомянуты: %s]", strings.Join(mentionNames, ", "))
 		}
 	}
 
 	return text
 }
+
+// markdownValueExtractor возвращает функцию извлечения значений изменений для каналов с разметкой
+// Текст комментария экранируется функцией escape, упоминания отделяются от него строкой separator
+func markdownValueExtractor(formatter MentionFormatter, escape func(string) string, separator string) ChangeValueExtractor {
+	commentExtractor := func(comment parser.YoutrackCommentValue) string {
+		return extractEscapedCommentText(comment, formatter, escape, separator)
+	}
+
+	return func(value json.RawMessage, field string) string {
+		return extractChangeValueMarkdown(value, field, commentExtractor)
+	}
+}
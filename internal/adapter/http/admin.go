@@ -0,0 +1,179 @@
+package http
+
+import (
+	"crypto/subtle"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
+)
+
+const (
+	// codeNotFound код ошибки для отсутствующего объекта
+	codeNotFound = "not_found"
+	// codeConflict код ошибки для операции, невозможной при текущей конфигурации
+	codeConflict = "conflict"
+)
+
+// adminErrorMappings определяет соответствие ошибок административного API HTTP ответам
+var adminErrorMappings = []errorMapping{
+	{err: port.ErrNotFound, status: http.StatusNotFound, code: codeNotFound, message: "Dead letter not found"},
+	{err: port.ErrConflict, status: http.StatusConflict, code: codeConflict},
+	{err: port.ErrUnavailable, status: http.StatusServiceUnavailable, code: codeServiceUnavailable, message: "Service unavailable"},
+}
+
+// unauthorizedMapping используется при отсутствии или неверном токене администратора
+var unauthorizedMapping = errorMapping{
+	status:  http.StatusUnauthorized,
+	code:    codeUnauthorized,
+	message: "Unauthorized",
+}
+
+// deadLetterListResponse описывает ответ со списком недоставленных уведомлений
+type deadLetterListResponse struct {
+	DeadLetters []port.DeadLetter `json:"dead_letters"`
+	Count       int               `json:"count"`
+}
+
+// deleteAllResponse описывает ответ на удаление всех недоставленных уведомлений
+type deleteAllResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// AdminHandler обрабатывает запросы административного API
+type AdminHandler struct {
+	deadLetterService port.DeadLetterService
+	logger            *logrus.Logger
+}
+
+// NewAdminHandler создает новый обработчик административного API
+func NewAdminHandler(deadLetterService port.DeadLetterService, logger *logrus.Logger) *AdminHandler {
+	return &AdminHandler{
+		deadLetterService: deadLetterService,
+		logger:            logger,
+	}
+}
+
+// ListDeadLetters возвращает список недоставленных уведомлений без исходного payload
+func (h *AdminHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	letters, err := h.deadLetterService.List()
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	for i := range letters {
+		letters[i].Payload = nil
+	}
+
+	writeJSON(w, http.StatusOK, deadLetterListResponse{DeadLetters: letters, Count: len(letters)}, h.logger)
+}
+
+// GetDeadLetter возвращает недоставленное уведомление вместе с исходным payload
+func (h *AdminHandler) GetDeadLetter(w http.ResponseWriter, r *http.Request) {
+	letter, err := h.deadLetterService.Get(chi.URLParam(r, "id"))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, letter, h.logger)
+}
+
+// RequeueDeadLetter ставит недоставленное уведомление в очередь доставки
+func (h *AdminHandler) RequeueDeadLetter(w http.ResponseWriter, r *http.Request) {
+	if err := h.deadLetterService.Requeue(chi.URLParam(r, "id")); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, port.RequeueResult{Requeued: 1}, h.logger)
+}
+
+// RequeueAllDeadLetters ставит в очередь доставки все недоставленные уведомления
+func (h *AdminHandler) RequeueAllDeadLetters(w http.ResponseWriter, r *http.Request) {
+	result, err := h.deadLetterService.RequeueAll()
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, result, h.logger)
+}
+
+// DeleteDeadLetter удаляет недоставленное уведомление
+func (h *AdminHandler) DeleteDeadLetter(w http.ResponseWriter, r *http.Request) {
+	if err := h.deadLetterService.Delete(chi.URLParam(r, "id")); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteAllDeadLetters удаляет все недоставленные уведомления
+func (h *AdminHandler) DeleteAllDeadLetters(w http.ResponseWriter, r *http.Request) {
+	deleted, err := h.deadLetterService.DeleteAll()
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, deleteAllResponse{Deleted: deleted}, h.logger)
+}
+
+// writeError логирует ошибку административного запроса и записывает JSON ответ
+// Для конфликта в ответ попадает причина, чтобы администратор понимал, что исправить в конфигурации
+func (h *AdminHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	mapping := resolveAdminError(err)
+	if mapping.status == http.StatusConflict {
+		mapping.message = err.Error()
+	}
+
+	entry := h.logger.WithError(err).WithFields(logrus.Fields{
+		"path":   r.URL.Path,
+		"status": mapping.status,
+	})
+	if mapping.status >= http.StatusInternalServerError {
+		entry.Error("Admin request failed")
+	} else {
+		entry.Warn("Admin request rejected")
+	}
+
+	writeErrorResponse(w, r, mapping, h.logger)
+}
+
+// resolveAdminError возвращает HTTP соответствие для ошибки административного API
+func resolveAdminError(err error) errorMapping {
+	for _, mapping := range adminErrorMappings {
+		if errors.Is(err, mapping.err) {
+			return mapping
+		}
+	}
+	return errorMapping{
+		status:  http.StatusInternalServerError,
+		code:    codeInternalError,
+		message: "Failed to process admin request",
+	}
+}
+
+// adminAuth проверяет Bearer токен администратора в заголовке Authorization
+func adminAuth(token string, logger *logrus.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				logger.WithFields(logrus.Fields{
+					"path":        r.URL.Path,
+					"remote_addr": r.RemoteAddr,
+				}).Warn("Admin request unauthorized")
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeErrorResponse(w, r, unauthorizedMapping, logger)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,327 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testAdminToken = "admin-secret"
+
+func TestAdminAPI_Authorization(t *testing.T) {
+	type testCase struct {
+		name           string
+		authorization  string
+		expectedStatus int
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Missing_Token",
+			authorization:  "",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Wrong_Token",
+			authorization:  "Bearer wrong",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Not_Bearer_Scheme",
+			authorization:  "Basic " + testAdminToken,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Valid_Token",
+			authorization:  "Bearer " + testAdminToken,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockService := mocks.NewMockDeadLetterService(ctrl)
+			if tc.expectedStatus == http.StatusOK {
+				mockService.EXPECT().List().Return(nil, nil)
+			}
+
+			router := NewRouter(mocks.NewMockWebhookService(ctrl), mockService, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, testAdminToken, logger)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/dead-letters", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatus {
+				t.Errorf("expected status code %d, got: %d", tc.expectedStatus, recorder.Code)
+			}
+			if tc.expectedStatus == http.StatusUnauthorized && recorder.Header().Get("WWW-Authenticate") != "Bearer" {
+				t.Errorf("expected WWW-Authenticate header, got: %q", recorder.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestAdminAPI_Routes(t *testing.T) {
+	type testCase struct {
+		name           string
+		method         string
+		path           string
+		setupMock      func(mockService *mocks.MockDeadLetterService)
+		expectedStatus int
+		expectedBody   string
+	}
+
+	letter := port.DeadLetter{
+		ID:        "a1",
+		Key:       "DEMO-1",
+		Project:   "demo",
+		Payload:   &parser.YoutrackWebhookPayload{Issue: parser.YoutrackIssue{IDReadable: "DEMO-1"}},
+		Target:    port.NotificationTarget{Channel: port.ChannelTelegram, ChatID: "chat"},
+		LastError: "chat not found",
+		Attempts:  1,
+	}
+
+	testCases := []testCase{
+		{
+			name:   "List_Omits_Payload",
+			method: http.MethodGet,
+			path:   "/admin/dead-letters",
+			setupMock: func(mockService *mocks.MockDeadLetterService) {
+				mockService.EXPECT().List().Return([]port.DeadLetter{letter}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"count":1`,
+		},
+		{
+			name:   "Get_Includes_Payload",
+			method: http.MethodGet,
+			path:   "/admin/dead-letters/a1",
+			setupMock: func(mockService *mocks.MockDeadLetterService) {
+				mockService.EXPECT().Get("a1").Return(letter, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"payload":{`,
+		},
+		{
+			name:   "Get_Not_Found",
+			method: http.MethodGet,
+			path:   "/admin/dead-letters/b2",
+			setupMock: func(mockService *mocks.MockDeadLetterService) {
+				mockService.EXPECT().Get("b2").Return(port.DeadLetter{}, fmt.Errorf("dead letter %q: %w", "b2", port.ErrNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `"code":"not_found"`,
+		},
+		{
+			name:   "Requeue_One",
+			method: http.MethodPost,
+			path:   "/admin/dead-letters/a1/requeue",
+			setupMock: func(mockService *mocks.MockDeadLetterService) {
+				mockService.EXPECT().Requeue("a1").Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedBody:   `"requeued":1`,
+		},
+		{
+			name:   "Requeue_Conflict_Returns_Reason",
+			method: http.MethodPost,
+			path:   "/admin/dead-letters/a1/requeue",
+			setupMock: func(mockService *mocks.MockDeadLetterService) {
+				mockService.EXPECT().Requeue("a1").Return(fmt.Errorf("%w: chat ID for channel %q is not configured", port.ErrConflict, "telegram"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `chat ID for channel \"telegram\" is not configured`,
+		},
+		{
+			name:   "Requeue_Queue_Full",
+			method: http.MethodPost,
+			path:   "/admin/dead-letters/a1/requeue",
+			setupMock: func(mockService *mocks.MockDeadLetterService) {
+				mockService.EXPECT().Requeue("a1").Return(&port.UnavailableError{Reason: "delivery queue is full"})
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `"code":"service_unavailable"`,
+		},
+		{
+			name:   "Requeue_All",
+			method: http.MethodPost,
+			path:   "/admin/dead-letters/requeue",
+			setupMock: func(mockService *mocks.MockDeadLetterService) {
+				mockService.EXPECT().RequeueAll().Return(port.RequeueResult{
+					Requeued: 2,
+					Failed:   []port.RequeueFailure{{ID: "c3", Error: "conflict"}},
+				}, nil)
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedBody:   `"requeued":2,"failed":[{"id":"c3","error":"conflict"}]`,
+		},
+		{
+			name:   "Delete_One",
+			method: http.MethodDelete,
+			path:   "/admin/dead-letters/a1",
+			setupMock: func(mockService *mocks.MockDeadLetterService) {
+				mockService.EXPECT().Delete("a1").Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "Delete_All",
+			method: http.MethodDelete,
+			path:   "/admin/dead-letters",
+			setupMock: func(mockService *mocks.MockDeadLetterService) {
+				mockService.EXPECT().DeleteAll().Return(3, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"deleted":3`,
+		},
+		{
+			name:   "Store_Error_Returns_500",
+			method: http.MethodGet,
+			path:   "/admin/dead-letters",
+			setupMock: func(mockService *mocks.MockDeadLetterService) {
+				mockService.EXPECT().List().Return(nil, errors.New("disk failure"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `"code":"internal_error"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockService := mocks.NewMockDeadLetterService(ctrl)
+			tc.setupMock(mockService)
+
+			router := NewRouter(mocks.NewMockWebhookService(ctrl), mockService, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, testAdminToken, logger)
+
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			req.Header.Set("Authorization", "Bearer "+testAdminToken)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatus {
+				t.Errorf("expected status code %d, got: %d (body: %s)", tc.expectedStatus, recorder.Code, recorder.Body.String())
+			}
+			if tc.expectedBody != "" && !strings.Contains(recorder.Body.String(), tc.expectedBody) {
+				t.Errorf("expected body to contain %q, got: %q", tc.expectedBody, recorder.Body.String())
+			}
+			if tc.name == "List_Omits_Payload" {
+				var response deadLetterListResponse
+				if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if response.DeadLetters[0].Payload != nil {
+					t.Error("expected payload to be omitted from list")
+				}
+			}
+		})
+	}
+}
+
+func TestAdminAPI_DisabledWithoutToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	router := NewRouter(mocks.NewMockWebhookService(ctrl), mocks.NewMockDeadLetterService(ctrl), nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, "", logger)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/dead-letters", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("expected status code %d, got: %d", http.StatusNotFound, recorder.Code)
+	}
+}
+
+func TestAdminAPI_DebugVars(t *testing.T) {
+	type testCase struct {
+		name              string
+		adminToken        string
+		deadLetterService bool
+		authorization     string
+		expectedStatus    int
+	}
+
+	testCases := []testCase{
+		{
+			name:              "Valid_Token",
+			adminToken:        testAdminToken,
+			deadLetterService: true,
+			authorization:     "Bearer " + testAdminToken,
+			expectedStatus:    http.StatusOK,
+		},
+		{
+			name:           "Valid_Token_Without_Dead_Letters",
+			adminToken:     testAdminToken,
+			authorization:  "Bearer " + testAdminToken,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:              "Missing_Token",
+			adminToken:        testAdminToken,
+			deadLetterService: true,
+			expectedStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:              "Admin_API_Disabled",
+			deadLetterService: true,
+			authorization:     "Bearer ",
+			expectedStatus:    http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			var deadLetterService port.DeadLetterService
+			if tc.deadLetterService {
+				deadLetterService = mocks.NewMockDeadLetterService(ctrl)
+			}
+			router := NewRouter(mocks.NewMockWebhookService(ctrl), deadLetterService, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, tc.adminToken, logger)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/debug/vars", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatus {
+				t.Errorf("expected status code %d, got: %d", tc.expectedStatus, recorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+const (
+	// Машиночитаемые коды ошибок в JSON ответе
+	codeBadRequest           = "bad_request"
+	codeUnauthorized         = "unauthorized"
+	codeForbidden            = "forbidden"
+	codeReplayDetected       = "replay_detected"
+	codePayloadTooLarge      = "payload_too_large"
+	codeUnsupportedMediaType = "unsupported_media_type"
+	codeInvalidPayload       = "invalid_payload"
+	codeServiceUnavailable   = "service_unavailable"
+	codeInternalError        = "internal_error"
+)
+
+// errorMapping связывает типизированную ошибку сервиса с HTTP статусом и кодом ответа
+type errorMapping struct {
+	err     error
+	status  int
+	code    string
+	message string
+}
+
+// webhookErrorMappings определяет соответствие ошибок сервиса HTTP ответам
+// Ошибки, отсутствующие в списке, считаются внутренними и возвращаются как 500
+var webhookErrorMappings = []errorMapping{
+	{err: port.ErrBadRequest, status: http.StatusBadRequest, code: codeBadRequest, message: "Failed to read request body"},
+	{err: port.ErrUnauthorized, status: http.StatusUnauthorized, code: codeUnauthorized, message: "Unauthorized"},
+	{err: port.ErrForbidden, status: http.StatusForbidden, code: codeForbidden, message: "Forbidden"},
+	{err: port.ErrReplayDetected, status: http.StatusConflict, code: codeReplayDetected, message: "Replay detected"},
+	{err: port.ErrPayloadTooLarge, status: http.StatusRequestEntityTooLarge, code: codePayloadTooLarge, message: "Request body is too large"},
+	{err: port.ErrUnsupportedMediaType, status: http.StatusUnsupportedMediaType, code: codeUnsupportedMediaType, message: "Content-Type must be application/json"},
+	{err: port.ErrInvalidPayload, status: http.StatusUnprocessableEntity, code: codeInvalidPayload, message: "Invalid webhook payload"},
+	{err: port.ErrUnavailable, status: http.StatusServiceUnavailable, code: codeServiceUnavailable, message: "Service unavailable"},
+}
+
+// internalErrorMapping используется для ошибок, не имеющих явного соответствия
+var internalErrorMapping = errorMapping{
+	status:  http.StatusInternalServerError,
+	code:    codeInternalError,
+	message: "Failed to process webhook",
+}
+
+// errorResponse описывает JSON тело ответа с ошибкой
+type errorResponse struct {
+	Error errorBody `json:"error"`
+}
+
+// errorBody содержит код, описание ошибки и идентификатор запроса
+type errorBody struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// resolveError возвращает HTTP соответствие для ошибки сервиса
+func resolveError(err error) errorMapping {
+	for _, mapping := range webhookErrorMappings {
+		if errors.Is(err, mapping.err) {
+			return mapping
+		}
+	}
+	return internalErrorMapping
+}
+
+// writeError записывает JSON ответ с ошибкой
+func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, mapping errorMapping) {
+	writeErrorResponse(w, r, mapping, h.logger)
+}
+
+// writeErrorResponse записывает JSON ответ с ошибкой и идентификатором запроса
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, mapping errorMapping, logger *logrus.Logger) {
+	writeJSON(w, mapping.status, errorResponse{
+		Error: errorBody{
+			Code:      mapping.code,
+			Message:   mapping.message,
+			RequestID: middleware.GetReqID(r.Context()),
+		},
+	}, logger)
+}
+
+// writeJSON записывает JSON ответ с указанным статусом
+func writeJSON(w http.ResponseWriter, status int, body interface{}, logger *logrus.Logger) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.WithError(err).Error("Failed to write JSON response")
+	}
+}
@@ -0,0 +1,153 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveError(t *testing.T) {
+	type testCase struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Bad_Request",
+			err:            fmt.Errorf("%w: %w", port.ErrBadRequest, errors.New("read error")),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   codeBadRequest,
+		},
+		{
+			name:           "Unauthorized",
+			err:            fmt.Errorf("%w: signature mismatch", port.ErrUnauthorized),
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   codeUnauthorized,
+		},
+		{
+			name:           "Forbidden",
+			err:            port.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   codeForbidden,
+		},
+		{
+			name:           "Replay_Detected",
+			err:            port.ErrReplayDetected,
+			expectedStatus: http.StatusConflict,
+			expectedCode:   codeReplayDetected,
+		},
+		{
+			name:           "Payload_Too_Large",
+			err:            port.ErrPayloadTooLarge,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedCode:   codePayloadTooLarge,
+		},
+		{
+			name:           "Unsupported_Media_Type",
+			err:            port.ErrUnsupportedMediaType,
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedCode:   codeUnsupportedMediaType,
+		},
+		{
+			name:           "Invalid_Payload",
+			err:            fmt.Errorf("%w: unexpected end of JSON input", port.ErrInvalidPayload),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedCode:   codeInvalidPayload,
+		},
+		{
+			name:           "Service_Unavailable",
+			err:            port.ErrUnavailable,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedCode:   codeServiceUnavailable,
+		},
+		{
+			name:           "Unknown_Error_Is_Internal",
+			err:            errors.New("unexpected"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   codeInternalError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mapping := resolveError(tc.err)
+
+			if mapping.status != tc.expectedStatus {
+				t.Errorf("expected status %d, got: %d", tc.expectedStatus, mapping.status)
+			}
+			if mapping.code != tc.expectedCode {
+				t.Errorf("expected code %q, got: %q", tc.expectedCode, mapping.code)
+			}
+		})
+	}
+}
+
+func TestHandler_WriteError(t *testing.T) {
+	type testCase struct {
+		name              string
+		requestID         string
+		expectedRequestID string
+	}
+
+	testCases := []testCase{
+		{
+			name:              "With_Request_ID",
+			requestID:         "req-42",
+			expectedRequestID: "req-42",
+		},
+		{
+			name:              "Without_Request_ID",
+			requestID:         "",
+			expectedRequestID: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			handler := NewHandler(nil, nil, 0, config.WebhookResponseConfig{}, logger)
+
+			req := httptest.NewRequest("POST", "/webhook/youtrack", nil)
+			if tc.requestID != "" {
+				req = req.WithContext(context.WithValue(req.Context(), middleware.RequestIDKey, tc.requestID))
+			}
+			recorder := httptest.NewRecorder()
+
+			handler.writeError(recorder, req, resolveError(port.ErrInvalidPayload))
+
+			if recorder.Code != http.StatusUnprocessableEntity {
+				t.Errorf("expected status code %d, got: %d", http.StatusUnprocessableEntity, recorder.Code)
+			}
+			if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json; charset=utf-8" {
+				t.Errorf("expected JSON content type, got: %q", contentType)
+			}
+
+			var response errorResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if response.Error.Code != codeInvalidPayload {
+				t.Errorf("expected code %q, got: %q", codeInvalidPayload, response.Error.Code)
+			}
+			if response.Error.Message == "" {
+				t.Error("expected message to be set")
+			}
+			if response.Error.RequestID != tc.expectedRequestID {
+				t.Errorf("expected request id %q, got: %q", tc.expectedRequestID, response.Error.RequestID)
+			}
+		})
+	}
+}
@@ -1,28 +1,67 @@
 package http
 
 import (
+	"encoding/json"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/config"
 	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/sirupsen/logrus"
+	"math"
+	"mime"
 	"net/http"
+	"strconv"
 )
 
 // Handler обрабатывает HTTP запросы
 type Handler struct {
 	webhookService port.WebhookService
+	healthReporter port.HealthReporter
+	maxBodySize    int64
+	responsePolicy responsePolicy
 	logger         *logrus.Logger
 }
 
+// healthResponse описывает JSON ответ проверки состояния сервиса
+type healthResponse struct {
+	Status   string               `json:"status"`
+	Channels []port.ChannelHealth `json:"channels,omitempty"`
+}
+
 // NewHandler создает новый HTTP handler
-func NewHandler(webhookService port.WebhookService, logger *logrus.Logger) *Handler {
+// maxBodySize ограничивает размер тела webhook запроса в байтах, значение <= 0 отключает ограничение
+// healthReporter может быть nil - в этом случае состояние каналов в ответ /health не добавляется
+// responseCfg определяет код ответа webhook по результату доставки, незаданные коды заменяются значениями по умолчанию
+func NewHandler(webhookService port.WebhookService, healthReporter port.HealthReporter, maxBodySize int64, responseCfg config.WebhookResponseConfig, logger *logrus.Logger) *Handler {
 	return &Handler{
 		webhookService: webhookService,
+		healthReporter: healthReporter,
+		maxBodySize:    maxBodySize,
+		responsePolicy: newResponsePolicy(responseCfg),
 		logger:         logger,
 	}
 }
 
 // Health обрабатывает запрос проверки состояния сервиса
+// Разомкнутый выключатель канала переводит статус в degraded, но код ответа остается 200:
+// сервис продолжает принимать события, а недоступен только API мессенджера
 func (h *Handler) Health(w http.ResponseWriter, _ *http.Request) {
-	_, err := w.Write([]byte("ok"))
+	response := healthResponse{Status: "ok"}
+	if h.healthReporter != nil {
+		response.Channels = h.healthReporter.ChannelHealth()
+		for _, channel := range response.Channels {
+			if channel.State != port.CircuitClosed {
+				response.Status = "degraded"
+			}
+		}
+	}
+
+	data, err := json.Marshal(response)
+	if err == nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, err = w.Write(data)
+	}
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to write health response")
 		http.Error(w, "Failed to write health response", http.StatusInternalServerError)
@@ -32,18 +71,81 @@ func (h *Handler) Health(w http.ResponseWriter, _ *http.Request) {
 
 // YoutrackWebhook обрабатывает POST запросы из webhook YouTrack
 func (h *Handler) YoutrackWebhook(w http.ResponseWriter, r *http.Request) {
+	// Токен источника из пути запроса передаем в сервис через контекст
+	if token := chi.URLParam(r, "token"); token != "" {
+		r = r.WithContext(port.ContextWithWebhookToken(r.Context(), token))
+	}
+
+	// Проверяем тип содержимого и размер тела до чтения запроса
+	if !isJSONContentType(r.Header.Get("Content-Type")) {
+		h.rejectWebhook(w, r, port.ErrUnsupportedMediaType)
+		return
+	}
+	if h.maxBodySize > 0 {
+		if r.ContentLength > h.maxBodySize {
+			h.rejectWebhook(w, r, port.ErrPayloadTooLarge)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, h.maxBodySize)
+	}
+
 	// Делегируем обработку бизнес-логики
-	if err := h.webhookService.ProcessWebhook(r); err != nil {
-		h.logger.WithError(err).Error("Failed to process webhook")
-		http.Error(w, "Failed to process webhook", http.StatusBadRequest)
+	report, err := h.webhookService.ProcessWebhook(r.Context(), r)
+	if err != nil {
+		h.rejectWebhook(w, r, err)
 		return
 	}
 
-	// Отправляем успешный ответ
-	w.WriteHeader(http.StatusOK)
-	if _, err := w.Write([]byte("ok")); err != nil {
+	// Возвращаем результат по каждому адресату, код ответа определяется политикой
+	status, outcome := h.responsePolicy.resolve(report)
+	if outcome != outcomeAccepted {
+		h.logger.WithFields(logrus.Fields{
+			"status":     status,
+			"outcome":    outcome,
+			"failed":     report.Count(port.DeliveryStatusFailed),
+			"request_id": middleware.GetReqID(r.Context()),
+		}).Warn("Webhook notification was not delivered to all targets")
+	}
+
+	data, err := json.Marshal(newDeliveryReportResponse(outcome, report))
+	if err == nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		_, err = w.Write(data)
+	}
+	if err != nil {
 		h.logger.WithError(err).Error("Failed to write response")
 		http.Error(w, "Failed to write response", http.StatusInternalServerError)
 		return
 	}
 }
+
+// rejectWebhook логирует ошибку обработки webhook и возвращает JSON ответ с соответствующим статусом
+func (h *Handler) rejectWebhook(w http.ResponseWriter, r *http.Request, err error) {
+	mapping := resolveError(err)
+
+	entry := h.logger.WithError(err).WithFields(logrus.Fields{
+		"status":     mapping.status,
+		"code":       mapping.code,
+		"request_id": middleware.GetReqID(r.Context()),
+	})
+	if mapping.status >= http.StatusInternalServerError {
+		entry.Error("Failed to process webhook")
+	} else {
+		entry.Warn("Webhook request rejected")
+	}
+
+	// При временной недоступности сообщаем, через сколько секунд можно повторить запрос
+	var unavailableErr *port.UnavailableError
+	if errors.As(err, &unavailableErr) && unavailableErr.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(unavailableErr.RetryAfter.Seconds()))))
+	}
+
+	h.writeError(w, r, mapping)
+}
+
+// isJSONContentType проверяет, что тип содержимого запроса - application/json
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
@@ -2,14 +2,20 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
 	"github.com/beliaev-aa/notifications/tests/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/sirupsen/logrus"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewHandler(t *testing.T) {
@@ -38,7 +44,7 @@ func TestNewHandler(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockWebhookService := mocks.NewMockWebhookService(ctrl)
-			handler := NewHandler(mockWebhookService, tc.logger)
+			handler := NewHandler(mockWebhookService, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, tc.logger)
 
 			if tc.checkNil {
 				if handler != nil {
@@ -64,6 +70,8 @@ func TestNewHandler(t *testing.T) {
 func TestHandler_Health(t *testing.T) {
 	type testCase struct {
 		name          string
+		channels      []port.ChannelHealth
+		withReporter  bool
 		writeError    error
 		expectedError bool
 		checkResponse bool
@@ -71,15 +79,37 @@ func TestHandler_Health(t *testing.T) {
 		expectedCode  int
 	}
 
+	openedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
 	testCases := []testCase{
 		{
 			name:          "Health_Success",
 			writeError:    nil,
 			expectedError: false,
 			checkResponse: true,
-			expectedBody:  "ok",
+			expectedBody:  `{"status":"ok"}`,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "Health_All_Breakers_Closed",
+			withReporter:  true,
+			channels:      []port.ChannelHealth{{Channel: "telegram", State: port.CircuitClosed}},
+			checkResponse: true,
+			expectedBody:  `{"status":"ok","channels":[{"channel":"telegram","state":"closed","consecutive_failures":0}]}`,
 			expectedCode:  http.StatusOK,
 		},
+		{
+			name:         "Health_Open_Breaker_Is_Degraded",
+			withReporter: true,
+			channels: []port.ChannelHealth{
+				{Channel: "telegram", State: port.CircuitClosed},
+				{Channel: "vkteams", State: port.CircuitOpen, ConsecutiveFailures: 5, OpenedAt: &openedAt},
+			},
+			checkResponse: true,
+			expectedBody: `{"status":"degraded","channels":[{"channel":"telegram","state":"closed","consecutive_failures":0},` +
+				`{"channel":"vkteams","state":"open","consecutive_failures":5,"opened_at":"2024-01-01T12:00:00Z"}]}`,
+			expectedCode: http.StatusOK,
+		},
 		{
 			name:          "Health_Write_Error",
 			writeError:    errors.New("write error"),
@@ -101,7 +131,13 @@ func TestHandler_Health(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockWebhookService := mocks.NewMockWebhookService(ctrl)
-			handler := NewHandler(mockWebhookService, logger)
+			var healthReporter port.HealthReporter
+			if tc.withReporter {
+				mockHealthReporter := mocks.NewMockHealthReporter(ctrl)
+				mockHealthReporter.EXPECT().ChannelHealth().Return(tc.channels)
+				healthReporter = mockHealthReporter
+			}
+			handler := NewHandler(mockWebhookService, healthReporter, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, logger)
 
 			req := httptest.NewRequest("GET", "/health", nil)
 			recorder := httptest.NewRecorder()
@@ -147,6 +183,7 @@ func TestHandler_YoutrackWebhook(t *testing.T) {
 		expectedError bool
 		expectedCode  int
 		expectedBody  string
+		expectedRetry string
 		checkLogging  bool
 	}
 
@@ -157,8 +194,8 @@ func TestHandler_YoutrackWebhook(t *testing.T) {
 			processError:  nil,
 			writeError:    nil,
 			expectedError: false,
-			expectedCode:  http.StatusOK,
-			expectedBody:  "ok",
+			expectedCode:  http.StatusAccepted,
+			expectedBody:  `"status":"accepted"`,
 			checkLogging:  true,
 		},
 		{
@@ -167,8 +204,89 @@ func TestHandler_YoutrackWebhook(t *testing.T) {
 			processError:  errors.New("process error"),
 			writeError:    nil,
 			expectedError: true,
+			expectedCode:  http.StatusInternalServerError,
+			expectedBody:  `"code":"internal_error"`,
+			checkLogging:  true,
+		},
+		{
+			name:          "YoutrackWebhook_Unauthorized",
+			requestBody:   `{"test": "data"}`,
+			processError:  fmt.Errorf("%w: signature mismatch", port.ErrUnauthorized),
+			writeError:    nil,
+			expectedError: true,
+			expectedCode:  http.StatusUnauthorized,
+			expectedBody:  "Unauthorized",
+			checkLogging:  false,
+		},
+		{
+			name:          "YoutrackWebhook_Forbidden",
+			requestBody:   `{"test": "data"}`,
+			processError:  fmt.Errorf("%w: project is not allowed", port.ErrForbidden),
+			writeError:    nil,
+			expectedError: true,
+			expectedCode:  http.StatusForbidden,
+			expectedBody:  "Forbidden",
+			checkLogging:  false,
+		},
+		{
+			name:          "YoutrackWebhook_Replay_Detected",
+			requestBody:   `{"test": "data"}`,
+			processError:  fmt.Errorf("%w: request has already been received", port.ErrReplayDetected),
+			writeError:    nil,
+			expectedError: true,
+			expectedCode:  http.StatusConflict,
+			expectedBody:  "Replay detected",
+			checkLogging:  false,
+		},
+		{
+			name:          "YoutrackWebhook_Bad_Request",
+			requestBody:   `{"test": "data"}`,
+			processError:  fmt.Errorf("%w: %w", port.ErrBadRequest, errors.New("connection reset")),
+			writeError:    nil,
+			expectedError: true,
 			expectedCode:  http.StatusBadRequest,
-			expectedBody:  "Failed to process webhook",
+			expectedBody:  `"code":"bad_request"`,
+			checkLogging:  false,
+		},
+		{
+			name:          "YoutrackWebhook_Payload_Too_Large",
+			requestBody:   `{"test": "data"}`,
+			processError:  fmt.Errorf("%w: request body exceeds 10 bytes", port.ErrPayloadTooLarge),
+			writeError:    nil,
+			expectedError: true,
+			expectedCode:  http.StatusRequestEntityTooLarge,
+			expectedBody:  `"code":"payload_too_large"`,
+			checkLogging:  false,
+		},
+		{
+			name:          "YoutrackWebhook_Invalid_Payload",
+			requestBody:   `{"test": "data"}`,
+			processError:  fmt.Errorf("%w: unexpected end of JSON input", port.ErrInvalidPayload),
+			writeError:    nil,
+			expectedError: true,
+			expectedCode:  http.StatusUnprocessableEntity,
+			expectedBody:  `"code":"invalid_payload"`,
+			checkLogging:  false,
+		},
+		{
+			name:          "YoutrackWebhook_Service_Unavailable",
+			requestBody:   `{"test": "data"}`,
+			processError:  port.ErrUnavailable,
+			writeError:    nil,
+			expectedError: true,
+			expectedCode:  http.StatusServiceUnavailable,
+			expectedBody:  `"code":"service_unavailable"`,
+			checkLogging:  true,
+		},
+		{
+			name:          "YoutrackWebhook_Queue_Full",
+			requestBody:   `{"test": "data"}`,
+			processError:  &port.UnavailableError{Reason: "delivery queue is full", RetryAfter: 1500 * time.Millisecond},
+			writeError:    nil,
+			expectedError: true,
+			expectedCode:  http.StatusServiceUnavailable,
+			expectedBody:  `"code":"service_unavailable"`,
+			expectedRetry: "2",
 			checkLogging:  true,
 		},
 		{
@@ -187,8 +305,8 @@ func TestHandler_YoutrackWebhook(t *testing.T) {
 			processError:  nil,
 			writeError:    nil,
 			expectedError: false,
-			expectedCode:  http.StatusOK,
-			expectedBody:  "ok",
+			expectedCode:  http.StatusAccepted,
+			expectedBody:  `"status":"accepted"`,
 			checkLogging:  true,
 		},
 		{
@@ -197,8 +315,8 @@ func TestHandler_YoutrackWebhook(t *testing.T) {
 			processError:  nil,
 			writeError:    nil,
 			expectedError: false,
-			expectedCode:  http.StatusOK,
-			expectedBody:  "ok",
+			expectedCode:  http.StatusAccepted,
+			expectedBody:  `"status":"accepted"`,
 			checkLogging:  true,
 		},
 	}
@@ -214,16 +332,17 @@ func TestHandler_YoutrackWebhook(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockWebhookService := mocks.NewMockWebhookService(ctrl)
-			handler := NewHandler(mockWebhookService, logger)
+			handler := NewHandler(mockWebhookService, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, logger)
 
 			req := httptest.NewRequest("POST", "/webhook/youtrack", strings.NewReader(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
 			recorder := httptest.NewRecorder()
 
-			processCall := mockWebhookService.EXPECT().ProcessWebhook(gomock.Any())
+			processCall := mockWebhookService.EXPECT().ProcessWebhook(gomock.Any(), gomock.Any())
 			if tc.processError != nil {
-				processCall.Return(tc.processError)
+				processCall.Return(port.DeliveryReport{}, tc.processError)
 			} else {
-				processCall.Return(nil)
+				processCall.Return(port.DeliveryReport{}, nil)
 			}
 
 			if tc.writeError != nil {
@@ -246,6 +365,10 @@ func TestHandler_YoutrackWebhook(t *testing.T) {
 				}
 			}
 
+			if retryAfter := recorder.Header().Get("Retry-After"); retryAfter != tc.expectedRetry {
+				t.Errorf("expected Retry-After %q, got: %q", tc.expectedRetry, retryAfter)
+			}
+
 			if tc.checkLogging {
 				logOutput := buf.String()
 				if tc.processError != nil {
@@ -284,7 +407,7 @@ func TestHandler_Integration(t *testing.T) {
 			method:       "POST",
 			path:         "/webhook/youtrack",
 			requestBody:  `{"test": "data"}`,
-			expectedCode: http.StatusOK,
+			expectedCode: http.StatusAccepted,
 		},
 	}
 
@@ -296,7 +419,7 @@ func TestHandler_Integration(t *testing.T) {
 			logger := logrus.New()
 			logger.SetLevel(logrus.ErrorLevel)
 			mockWebhookService := mocks.NewMockWebhookService(ctrl)
-			handler := NewHandler(mockWebhookService, logger)
+			handler := NewHandler(mockWebhookService, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, logger)
 
 			var req *http.Request
 			if tc.requestBody != "" {
@@ -304,12 +427,13 @@ func TestHandler_Integration(t *testing.T) {
 			} else {
 				req = httptest.NewRequest(tc.method, tc.path, nil)
 			}
+			req.Header.Set("Content-Type", "application/json")
 			recorder := httptest.NewRecorder()
 
 			if tc.method == "GET" {
 				handler.Health(recorder, req)
 			} else {
-				mockWebhookService.EXPECT().ProcessWebhook(gomock.Any()).Return(nil)
+				mockWebhookService.EXPECT().ProcessWebhook(gomock.Any(), gomock.Any()).Return(port.DeliveryReport{}, nil)
 				handler.YoutrackWebhook(recorder, req)
 			}
 
@@ -334,28 +458,28 @@ func TestHandler_EdgeCases(t *testing.T) {
 			name:          "YoutrackWebhook_With_Very_Long_Body",
 			requestBody:   strings.Repeat("A", 10000),
 			processError:  nil,
-			expectedCode:  http.StatusOK,
+			expectedCode:  http.StatusAccepted,
 			expectedError: false,
 		},
 		{
 			name:          "YoutrackWebhook_With_Invalid_JSON",
 			requestBody:   `{invalid json}`,
-			processError:  errors.New("parse error"),
-			expectedCode:  http.StatusBadRequest,
+			processError:  fmt.Errorf("%w: parse error", port.ErrInvalidPayload),
+			expectedCode:  http.StatusUnprocessableEntity,
 			expectedError: true,
 		},
 		{
 			name:          "YoutrackWebhook_With_Unicode",
 			requestBody:   `{"message": "Сообщение с кириллицей 🚀"}`,
 			processError:  nil,
-			expectedCode:  http.StatusOK,
+			expectedCode:  http.StatusAccepted,
 			expectedError: false,
 		},
 		{
 			name:          "YoutrackWebhook_With_Special_Characters",
 			requestBody:   `{"message": "Test & <test> \"quotes\""}`,
 			processError:  nil,
-			expectedCode:  http.StatusOK,
+			expectedCode:  http.StatusAccepted,
 			expectedError: false,
 		},
 	}
@@ -368,16 +492,104 @@ func TestHandler_EdgeCases(t *testing.T) {
 			logger := logrus.New()
 			logger.SetLevel(logrus.ErrorLevel)
 			mockWebhookService := mocks.NewMockWebhookService(ctrl)
-			handler := NewHandler(mockWebhookService, logger)
+			handler := NewHandler(mockWebhookService, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, logger)
 
 			req := httptest.NewRequest("POST", "/webhook/youtrack", strings.NewReader(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
 			recorder := httptest.NewRecorder()
 
-			processCall := mockWebhookService.EXPECT().ProcessWebhook(gomock.Any())
+			processCall := mockWebhookService.EXPECT().ProcessWebhook(gomock.Any(), gomock.Any())
 			if tc.processError != nil {
-				processCall.Return(tc.processError)
+				processCall.Return(port.DeliveryReport{}, tc.processError)
 			} else {
-				processCall.Return(nil)
+				processCall.Return(port.DeliveryReport{}, nil)
+			}
+
+			handler.YoutrackWebhook(recorder, req)
+
+			if recorder.Code != tc.expectedCode {
+				t.Errorf("expected status code %d, got: %d", tc.expectedCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_YoutrackWebhook_RequestValidation(t *testing.T) {
+	type testCase struct {
+		name          string
+		contentType   string
+		requestBody   string
+		contentLength int64
+		expectProcess bool
+		expectedCode  int
+		expectedBody  string
+	}
+
+	testCases := []testCase{
+		{
+			name:          "JSON_Content_Type_Accepted",
+			contentType:   "application/json",
+			requestBody:   `{}`,
+			expectProcess: true,
+			expectedCode:  http.StatusAccepted,
+			expectedBody:  `"status":"accepted"`,
+		},
+		{
+			name:          "JSON_Content_Type_With_Charset_Accepted",
+			contentType:   "application/json; charset=utf-8",
+			requestBody:   `{}`,
+			expectProcess: true,
+			expectedCode:  http.StatusAccepted,
+			expectedBody:  `"status":"accepted"`,
+		},
+		{
+			name:          "Missing_Content_Type_Rejected",
+			contentType:   "",
+			requestBody:   `{}`,
+			expectProcess: false,
+			expectedCode:  http.StatusUnsupportedMediaType,
+			expectedBody:  `"code":"unsupported_media_type"`,
+		},
+		{
+			name:          "Form_Content_Type_Rejected",
+			contentType:   "application/x-www-form-urlencoded",
+			requestBody:   `a=b`,
+			expectProcess: false,
+			expectedCode:  http.StatusUnsupportedMediaType,
+			expectedBody:  `"code":"unsupported_media_type"`,
+		},
+		{
+			name:          "Declared_Content_Length_Exceeds_Limit",
+			contentType:   "application/json",
+			requestBody:   `{"test": "data"}`,
+			contentLength: 1024,
+			expectProcess: false,
+			expectedCode:  http.StatusRequestEntityTooLarge,
+			expectedBody:  `"code":"payload_too_large"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			mockWebhookService := mocks.NewMockWebhookService(ctrl)
+			handler := NewHandler(mockWebhookService, nil, 64, config.WebhookResponseConfig{}, logger)
+
+			req := httptest.NewRequest("POST", "/webhook/youtrack", strings.NewReader(tc.requestBody))
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+			if tc.contentLength > 0 {
+				req.ContentLength = tc.contentLength
+			}
+			recorder := httptest.NewRecorder()
+
+			if tc.expectProcess {
+				mockWebhookService.EXPECT().ProcessWebhook(gomock.Any(), gomock.Any()).Return(port.DeliveryReport{}, nil)
 			}
 
 			handler.YoutrackWebhook(recorder, req)
@@ -385,10 +597,46 @@ func TestHandler_EdgeCases(t *testing.T) {
 			if recorder.Code != tc.expectedCode {
 				t.Errorf("expected status code %d, got: %d", tc.expectedCode, recorder.Code)
 			}
+			if !strings.Contains(recorder.Body.String(), tc.expectedBody) {
+				t.Errorf("expected body to contain %q, got: %q", tc.expectedBody, recorder.Body.String())
+			}
 		})
 	}
 }
 
+func TestHandler_YoutrackWebhook_BodyLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	handler := NewHandler(mockWebhookService, nil, 8, config.WebhookResponseConfig{}, logger)
+
+	var readErr error
+	mockWebhookService.EXPECT().ProcessWebhook(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *http.Request) (port.DeliveryReport, error) {
+			_, readErr = io.ReadAll(req.Body)
+			return port.DeliveryReport{}, readErr
+		})
+
+	// Размер тела неизвестен заранее, поэтому ограничение срабатывает только при чтении
+	req := httptest.NewRequest("POST", "/webhook/youtrack", strings.NewReader(`{"test": "data"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = -1
+	recorder := httptest.NewRecorder()
+
+	handler.YoutrackWebhook(recorder, req)
+
+	var maxBytesErr *http.MaxBytesError
+	if !errors.As(readErr, &maxBytesErr) {
+		t.Fatalf("expected body read to fail with MaxBytesError, got: %v", readErr)
+	}
+	if maxBytesErr.Limit != 8 {
+		t.Errorf("expected limit 8, got: %d", maxBytesErr.Limit)
+	}
+}
+
 type errorResponseWriter struct {
 	http.ResponseWriter
 	writeError        error
@@ -0,0 +1,70 @@
+package http
+
+import (
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+)
+
+const (
+	// Итог обработки webhook запроса в JSON ответе
+	outcomeAccepted = "accepted"
+	outcomePartial  = "partial"
+	outcomeFailed   = "failed"
+)
+
+// deliveryReportResponse описывает JSON ответ webhook с результатом доставки по адресатам
+type deliveryReportResponse struct {
+	Status     string                `json:"status"`
+	Deliveries []port.DeliveryResult `json:"deliveries"`
+}
+
+// responsePolicy определяет код ответа webhook по результату доставки
+// Пропущенные адресаты не учитываются: проигнорированное событие считается принятым
+type responsePolicy struct {
+	successStatus int
+	partialStatus int
+	failedStatus  int
+}
+
+// newResponsePolicy создает политику кодов ответа, незаданные коды заменяются значениями по умолчанию
+func newResponsePolicy(cfg config.WebhookResponseConfig) responsePolicy {
+	policy := responsePolicy{
+		successStatus: cfg.SuccessStatus,
+		partialStatus: cfg.PartialStatus,
+		failedStatus:  cfg.FailedStatus,
+	}
+	if policy.successStatus == 0 {
+		policy.successStatus = config.DefaultWebhookSuccessStatus
+	}
+	if policy.partialStatus == 0 {
+		policy.partialStatus = config.DefaultWebhookPartialStatus
+	}
+	if policy.failedStatus == 0 {
+		policy.failedStatus = config.DefaultWebhookFailedStatus
+	}
+	return policy
+}
+
+// resolve возвращает код ответа и итог обработки для отчета о доставке
+func (p responsePolicy) resolve(report port.DeliveryReport) (int, string) {
+	failed := report.Count(port.DeliveryStatusFailed)
+	delivered := report.Count(port.DeliveryStatusSent) + report.Count(port.DeliveryStatusQueued)
+
+	switch {
+	case failed == 0:
+		return p.successStatus, outcomeAccepted
+	case delivered == 0:
+		return p.failedStatus, outcomeFailed
+	default:
+		return p.partialStatus, outcomePartial
+	}
+}
+
+// newDeliveryReportResponse создает JSON ответ webhook, список адресатов в ответе не бывает null
+func newDeliveryReportResponse(outcome string, report port.DeliveryReport) deliveryReportResponse {
+	deliveries := report.Deliveries
+	if deliveries == nil {
+		deliveries = []port.DeliveryResult{}
+	}
+	return deliveryReportResponse{Status: outcome, Deliveries: deliveries}
+}
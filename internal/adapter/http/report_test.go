@@ -0,0 +1,122 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResponsePolicy_Resolve(t *testing.T) {
+	type testCase struct {
+		name            string
+		cfg             config.WebhookResponseConfig
+		deliveries      []port.DeliveryResult
+		expectedStatus  int
+		expectedOutcome string
+	}
+
+	sent := port.DeliveryResult{Channel: port.ChannelTelegram, ChatID: "tg_chat", Status: port.DeliveryStatusSent}
+	queued := port.DeliveryResult{Channel: port.ChannelTelegram, ChatID: "tg_chat", Status: port.DeliveryStatusQueued}
+	skipped := port.DeliveryResult{Channel: port.ChannelVKTeams, Status: port.DeliveryStatusSkipped, Reason: port.SkipReasonMissingChatID}
+	failed := port.DeliveryResult{Channel: port.ChannelVKTeams, ChatID: "vk_chat", Status: port.DeliveryStatusFailed, ErrorClass: port.ErrorClassTransient}
+
+	testCases := []testCase{
+		{
+			name:            "Ignored_Event_Is_Accepted",
+			deliveries:      nil,
+			expectedStatus:  http.StatusAccepted,
+			expectedOutcome: outcomeAccepted,
+		},
+		{
+			name:            "Queued_And_Skipped_Are_Accepted",
+			deliveries:      []port.DeliveryResult{queued, skipped},
+			expectedStatus:  http.StatusAccepted,
+			expectedOutcome: outcomeAccepted,
+		},
+		{
+			name:            "Some_Failed_Is_Partial",
+			deliveries:      []port.DeliveryResult{sent, failed},
+			expectedStatus:  http.StatusMultiStatus,
+			expectedOutcome: outcomePartial,
+		},
+		{
+			name:            "All_Failed_Ignoring_Skipped",
+			deliveries:      []port.DeliveryResult{failed, skipped},
+			expectedStatus:  http.StatusBadGateway,
+			expectedOutcome: outcomeFailed,
+		},
+		{
+			name:            "Configured_Statuses_Used",
+			cfg:             config.WebhookResponseConfig{SuccessStatus: http.StatusOK, PartialStatus: http.StatusOK, FailedStatus: http.StatusServiceUnavailable},
+			deliveries:      []port.DeliveryResult{failed},
+			expectedStatus:  http.StatusServiceUnavailable,
+			expectedOutcome: outcomeFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, outcome := newResponsePolicy(tc.cfg).resolve(port.DeliveryReport{Deliveries: tc.deliveries})
+
+			if status != tc.expectedStatus {
+				t.Errorf("expected status %d, got: %d", tc.expectedStatus, status)
+			}
+			if outcome != tc.expectedOutcome {
+				t.Errorf("expected outcome %q, got: %q", tc.expectedOutcome, outcome)
+			}
+		})
+	}
+}
+
+func TestHandler_YoutrackWebhook_DeliveryReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	report := port.DeliveryReport{Deliveries: []port.DeliveryResult{
+		{Channel: port.ChannelTelegram, ChatID: "tg_chat", Status: port.DeliveryStatusSent},
+		{Channel: port.ChannelVKTeams, ChatID: "vk_chat", Status: port.DeliveryStatusFailed, ErrorClass: port.ErrorClassPermanent},
+	}}
+
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	mockWebhookService.EXPECT().ProcessWebhook(gomock.Any(), gomock.Any()).Return(report, nil)
+	handler := NewHandler(mockWebhookService, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, logger)
+
+	req := httptest.NewRequest("POST", "/webhook/youtrack", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	handler.YoutrackWebhook(recorder, req)
+
+	if recorder.Code != http.StatusMultiStatus {
+		t.Errorf("expected status code %d, got: %d", http.StatusMultiStatus, recorder.Code)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json; charset=utf-8" {
+		t.Errorf("expected JSON content type, got: %q", contentType)
+	}
+
+	var response deliveryReportResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if strings.Contains(recorder.Body.String(), "tg_chat") || strings.Contains(recorder.Body.String(), "vk_chat") {
+		t.Errorf("expected response without chat IDs, got: %s", recorder.Body.String())
+	}
+	expected := deliveryReportResponse{Status: outcomePartial, Deliveries: []port.DeliveryResult{
+		{Channel: port.ChannelTelegram, Status: port.DeliveryStatusSent},
+		{Channel: port.ChannelVKTeams, Status: port.DeliveryStatusFailed, ErrorClass: port.ErrorClassPermanent},
+	}}
+	if diff := cmp.Diff(expected, response); diff != "" {
+		t.Errorf("response mismatch (-want +got):\n%s", diff)
+	}
+}
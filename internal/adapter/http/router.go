@@ -1,19 +1,57 @@
 package http
 
 import (
+	"expvar"
+	"github.com/beliaev-aa/notifications/internal/config"
 	"github.com/beliaev-aa/notifications/internal/domain/port"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/sirupsen/logrus"
+	"net/http"
 )
 
 // NewRouter создает новый HTTP роутер с зарегистрированными маршрутами
-func NewRouter(webhookService port.WebhookService, logger *logrus.Logger) *chi.Mux {
+// Административный API регистрируется только при заданном adminToken, счетчики expvar доступны только в нем,
+// маршруты недоставленных уведомлений - при заданном deadLetterService
+func NewRouter(webhookService port.WebhookService, deadLetterService port.DeadLetterService, healthReporter port.HealthReporter, maxBodySize int64, responseCfg config.WebhookResponseConfig, adminToken string, logger *logrus.Logger) *chi.Mux {
 	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(requestIDHeader)
 
-	h := NewHandler(webhookService, logger)
+	h := NewHandler(webhookService, healthReporter, maxBodySize, responseCfg, logger)
 
 	r.Get("/health", h.Health)
 	r.Post("/webhook/youtrack", h.YoutrackWebhook)
+	r.Post("/webhook/youtrack/{token}", h.YoutrackWebhook)
+
+	if adminToken != "" {
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(adminAuth(adminToken, logger))
+			r.Get("/debug/vars", expvar.Handler().ServeHTTP)
+
+			if deadLetterService == nil {
+				return
+			}
+			admin := NewAdminHandler(deadLetterService, logger)
+			r.Get("/dead-letters", admin.ListDeadLetters)
+			r.Delete("/dead-letters", admin.DeleteAllDeadLetters)
+			r.Post("/dead-letters/requeue", admin.RequeueAllDeadLetters)
+			r.Get("/dead-letters/{id}", admin.GetDeadLetter)
+			r.Delete("/dead-letters/{id}", admin.DeleteDeadLetter)
+			r.Post("/dead-letters/{id}/requeue", admin.RequeueDeadLetter)
+		})
+	}
 
 	return r
 }
+
+// requestIDHeader возвращает идентификатор запроса в заголовке ответа X-Request-Id
+// Идентификатор совпадает с request_id в JSON ответе с ошибкой и в логах
+func requestIDHeader(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestID := middleware.GetReqID(r.Context()); requestID != "" {
+			w.Header().Set(middleware.RequestIDHeader, requestID)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
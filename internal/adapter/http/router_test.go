@@ -1,6 +1,9 @@
 package http
 
 import (
+	"context"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
 	"github.com/beliaev-aa/notifications/tests/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/sirupsen/logrus"
@@ -57,7 +60,7 @@ func TestNewRouter(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockWebhookService := mocks.NewMockWebhookService(ctrl)
-			router := NewRouter(mockWebhookService, tc.logger)
+			router := NewRouter(mockWebhookService, nil, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, "", tc.logger)
 
 			if tc.checkNil {
 				if router != nil {
@@ -72,10 +75,11 @@ func TestNewRouter(t *testing.T) {
 				if tc.checkRoutes {
 					for _, route := range tc.expectedRoutes {
 						req := httptest.NewRequest(route.method, route.path, nil)
+						req.Header.Set("Content-Type", "application/json")
 						recorder := httptest.NewRecorder()
 
 						if route.method == "POST" && route.path == "/webhook/youtrack" {
-							mockWebhookService.EXPECT().ProcessWebhook(gomock.Any()).Return(nil)
+							mockWebhookService.EXPECT().ProcessWebhook(gomock.Any(), gomock.Any()).Return(port.DeliveryReport{}, nil)
 						}
 
 						router.ServeHTTP(recorder, req)
@@ -109,34 +113,42 @@ func TestNewRouter_Routes(t *testing.T) {
 			requestBody:  "",
 			expectedCode: http.StatusOK,
 			checkBody:    true,
-			expectedBody: "ok",
+			expectedBody: `{"status":"ok"}`,
 		},
 		{
 			name:         "Route_Webhook_POST_Success",
 			method:       "POST",
 			path:         "/webhook/youtrack",
 			requestBody:  `{"test": "data"}`,
-			expectedCode: http.StatusOK,
+			expectedCode: http.StatusAccepted,
 			checkBody:    true,
-			expectedBody: "ok",
+			expectedBody: `{"status":"accepted","deliveries":[]}`,
 		},
 		{
 			name:         "Route_Webhook_POST_With_Empty_Body",
 			method:       "POST",
 			path:         "/webhook/youtrack",
 			requestBody:  "",
-			expectedCode: http.StatusOK,
+			expectedCode: http.StatusAccepted,
 			checkBody:    true,
-			expectedBody: "ok",
+			expectedBody: `{"status":"accepted","deliveries":[]}`,
 		},
 		{
 			name:         "Route_Webhook_POST_With_JSON",
 			method:       "POST",
 			path:         "/webhook/youtrack",
 			requestBody:  `{"project": {"name": "Test"}, "issue": {"summary": "Test Issue"}}`,
-			expectedCode: http.StatusOK,
+			expectedCode: http.StatusAccepted,
 			checkBody:    true,
-			expectedBody: "ok",
+			expectedBody: `{"status":"accepted","deliveries":[]}`,
+		},
+		{
+			name:         "Route_Debug_Vars_Not_Public",
+			method:       "GET",
+			path:         "/debug/vars",
+			requestBody:  "",
+			expectedCode: http.StatusNotFound,
+			checkBody:    false,
 		},
 		{
 			name:         "Route_Health_Wrong_Method",
@@ -172,7 +184,7 @@ func TestNewRouter_Routes(t *testing.T) {
 			logger := logrus.New()
 			logger.SetLevel(logrus.ErrorLevel)
 			mockWebhookService := mocks.NewMockWebhookService(ctrl)
-			router := NewRouter(mockWebhookService, logger)
+			router := NewRouter(mockWebhookService, nil, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, "", logger)
 
 			var req *http.Request
 			if tc.requestBody != "" {
@@ -180,10 +192,11 @@ func TestNewRouter_Routes(t *testing.T) {
 			} else {
 				req = httptest.NewRequest(tc.method, tc.path, nil)
 			}
+			req.Header.Set("Content-Type", "application/json")
 			recorder := httptest.NewRecorder()
 
 			if tc.method == "POST" && tc.path == "/webhook/youtrack" {
-				mockWebhookService.EXPECT().ProcessWebhook(gomock.Any()).Return(nil)
+				mockWebhookService.EXPECT().ProcessWebhook(gomock.Any(), gomock.Any()).Return(port.DeliveryReport{}, nil)
 			}
 
 			router.ServeHTTP(recorder, req)
@@ -222,7 +235,7 @@ func TestNewRouter_Integration(t *testing.T) {
 			processError: nil,
 			expectedCode: http.StatusOK,
 			checkBody:    true,
-			expectedBody: "ok",
+			expectedBody: `{"status":"ok"}`,
 		},
 		{
 			name:         "Integration_Webhook_Success",
@@ -230,9 +243,9 @@ func TestNewRouter_Integration(t *testing.T) {
 			path:         "/webhook/youtrack",
 			requestBody:  `{"project": {"name": "TestProject"}}`,
 			processError: nil,
-			expectedCode: http.StatusOK,
+			expectedCode: http.StatusAccepted,
 			checkBody:    true,
-			expectedBody: "ok",
+			expectedBody: `{"status":"accepted","deliveries":[]}`,
 		},
 		{
 			name:         "Integration_Webhook_Process_Error",
@@ -240,7 +253,7 @@ func TestNewRouter_Integration(t *testing.T) {
 			path:         "/webhook/youtrack",
 			requestBody:  `{"project": {"name": "TestProject"}}`,
 			processError: http.ErrBodyReadAfterClose,
-			expectedCode: http.StatusBadRequest,
+			expectedCode: http.StatusInternalServerError,
 			checkBody:    true,
 			expectedBody: "Failed to process webhook",
 		},
@@ -257,7 +270,7 @@ func TestNewRouter_Integration(t *testing.T) {
 			logger.SetLevel(logrus.ErrorLevel)
 
 			mockWebhookService := mocks.NewMockWebhookService(ctrl)
-			router := NewRouter(mockWebhookService, logger)
+			router := NewRouter(mockWebhookService, nil, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, "", logger)
 
 			var req *http.Request
 			if tc.requestBody != "" {
@@ -265,14 +278,15 @@ func TestNewRouter_Integration(t *testing.T) {
 			} else {
 				req = httptest.NewRequest(tc.method, tc.path, nil)
 			}
+			req.Header.Set("Content-Type", "application/json")
 			recorder := httptest.NewRecorder()
 
 			if tc.method == "POST" {
-				processCall := mockWebhookService.EXPECT().ProcessWebhook(gomock.Any())
+				processCall := mockWebhookService.EXPECT().ProcessWebhook(gomock.Any(), gomock.Any())
 				if tc.processError != nil {
-					processCall.Return(tc.processError)
+					processCall.Return(port.DeliveryReport{}, tc.processError)
 				} else {
-					processCall.Return(nil)
+					processCall.Return(port.DeliveryReport{}, nil)
 				}
 			}
 
@@ -320,7 +334,7 @@ func TestNewRouter_EdgeCases(t *testing.T) {
 			method:       "POST",
 			path:         "/webhook/youtrack?param=value",
 			requestBody:  `{"test": "data"}`,
-			expectedCode: http.StatusOK,
+			expectedCode: http.StatusAccepted,
 		},
 		{
 			name:         "EdgeCase_Health_With_Query_Params",
@@ -334,21 +348,21 @@ func TestNewRouter_EdgeCases(t *testing.T) {
 			method:       "POST",
 			path:         "/webhook/youtrack",
 			requestBody:  strings.Repeat("A", 10000),
-			expectedCode: http.StatusOK,
+			expectedCode: http.StatusAccepted,
 		},
 		{
 			name:         "EdgeCase_Webhook_With_Unicode",
 			method:       "POST",
 			path:         "/webhook/youtrack",
 			requestBody:  `{"message": "Сообщение с кириллицей 🚀"}`,
-			expectedCode: http.StatusOK,
+			expectedCode: http.StatusAccepted,
 		},
 		{
 			name:         "EdgeCase_Webhook_With_Special_Characters",
 			method:       "POST",
 			path:         "/webhook/youtrack",
 			requestBody:  `{"message": "Test & <test> \"quotes\""}`,
-			expectedCode: http.StatusOK,
+			expectedCode: http.StatusAccepted,
 		},
 	}
 
@@ -360,7 +374,7 @@ func TestNewRouter_EdgeCases(t *testing.T) {
 			logger := logrus.New()
 			logger.SetLevel(logrus.ErrorLevel)
 			mockWebhookService := mocks.NewMockWebhookService(ctrl)
-			router := NewRouter(mockWebhookService, logger)
+			router := NewRouter(mockWebhookService, nil, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, "", logger)
 
 			var req *http.Request
 			if tc.requestBody != "" {
@@ -368,10 +382,11 @@ func TestNewRouter_EdgeCases(t *testing.T) {
 			} else {
 				req = httptest.NewRequest(tc.method, tc.path, nil)
 			}
+			req.Header.Set("Content-Type", "application/json")
 			recorder := httptest.NewRecorder()
 
-			if tc.method == "POST" && tc.expectedCode == http.StatusOK {
-				mockWebhookService.EXPECT().ProcessWebhook(gomock.Any()).Return(nil)
+			if tc.method == "POST" && tc.expectedCode == http.StatusAccepted {
+				mockWebhookService.EXPECT().ProcessWebhook(gomock.Any(), gomock.Any()).Return(port.DeliveryReport{}, nil)
 			}
 
 			router.ServeHTTP(recorder, req)
@@ -382,3 +397,82 @@ func TestNewRouter_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRouter_WebhookTokenPath(t *testing.T) {
+	type testCase struct {
+		name          string
+		path          string
+		expectedToken string
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Token_From_Path_Passed_To_Service",
+			path:          "/webhook/youtrack/source-token",
+			expectedToken: "source-token",
+		},
+		{
+			name:          "No_Token_In_Path",
+			path:          "/webhook/youtrack",
+			expectedToken: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			mockWebhookService := mocks.NewMockWebhookService(ctrl)
+			router := NewRouter(mockWebhookService, nil, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, "", logger)
+
+			var receivedToken string
+			mockWebhookService.EXPECT().ProcessWebhook(gomock.Any(), gomock.Any()).
+				Do(func(_ context.Context, req *http.Request) {
+					receivedToken = port.WebhookTokenFromContext(req.Context())
+				}).
+				Return(port.DeliveryReport{}, nil)
+
+			req := httptest.NewRequest("POST", tc.path, strings.NewReader(`{}`))
+			req.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != http.StatusAccepted {
+				t.Errorf("expected status code %d, got: %d", http.StatusAccepted, recorder.Code)
+			}
+			if receivedToken != tc.expectedToken {
+				t.Errorf("expected token %q, got: %q", tc.expectedToken, receivedToken)
+			}
+		})
+	}
+}
+
+func TestNewRouter_RequestID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	mockWebhookService := mocks.NewMockWebhookService(ctrl)
+	mockWebhookService.EXPECT().ProcessWebhook(gomock.Any(), gomock.Any()).Return(port.DeliveryReport{}, port.ErrUnauthorized)
+
+	router := NewRouter(mockWebhookService, nil, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, "", logger)
+
+	req := httptest.NewRequest("POST", "/webhook/youtrack", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-Id", "req-123")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if got := recorder.Header().Get("X-Request-Id"); got != "req-123" {
+		t.Errorf("expected X-Request-Id header %q, got: %q", "req-123", got)
+	}
+	if !strings.Contains(recorder.Body.String(), `"request_id":"req-123"`) {
+		t.Errorf("expected body to contain request id, got: %q", recorder.Body.String())
+	}
+}
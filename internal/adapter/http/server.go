@@ -7,68 +7,87 @@ import (
 	"github.com/beliaev-aa/notifications/internal/config"
 	"github.com/beliaev-aa/notifications/internal/domain/port"
 	"github.com/sirupsen/logrus"
+	"net"
 	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 )
 
 // Server представляет HTTP сервер с поддержкой graceful shutdown
+// Сигналы остановки обрабатывает менеджер жизненного цикла приложения, который вызывает Start и Stop
 type Server struct {
-	cfg            *config.HTTPConfig
-	logger         *logrus.Logger
-	webhookService port.WebhookService
+	cfg               *config.HTTPConfig
+	adminCfg          config.AdminConfig
+	responseCfg       config.WebhookResponseConfig
+	logger            *logrus.Logger
+	webhookService    port.WebhookService
+	deadLetterService port.DeadLetterService
+	healthReporter    port.HealthReporter
+
+	srv    *http.Server
+	failed chan error
 }
 
 // NewServer создает новый экземпляр HTTP сервера
-func NewServer(cfg *config.HTTPConfig, webhookService port.WebhookService, logger *logrus.Logger) *Server {
+// deadLetterService может быть nil - в этом случае административный API не регистрируется
+// healthReporter может быть nil - в этом случае /health не сообщает состояние каналов
+func NewServer(cfg *config.HTTPConfig, adminCfg config.AdminConfig, responseCfg config.WebhookResponseConfig, webhookService port.WebhookService, deadLetterService port.DeadLetterService, healthReporter port.HealthReporter, logger *logrus.Logger) *Server {
 	return &Server{
-		cfg:            cfg,
-		webhookService: webhookService,
-		logger:         logger,
+		cfg:               cfg,
+		adminCfg:          adminCfg,
+		responseCfg:       responseCfg,
+		webhookService:    webhookService,
+		deadLetterService: deadLetterService,
+		healthReporter:    healthReporter,
+		logger:            logger,
+		failed:            make(chan error, 1),
 	}
 }
 
-// Start запускает HTTP сервер и обрабатывает graceful shutdown
-func (s *Server) Start() error {
-	router := NewRouter(s.webhookService, s.logger)
+// Start открывает адрес сервера и начинает прием запросов в фоне
+// Ошибка открытия адреса возвращается сразу, ошибка работы сервера после запуска передается в Failed
+func (s *Server) Start(_ context.Context) error {
+	router := NewRouter(s.webhookService, s.deadLetterService, s.healthReporter, s.cfg.MaxBodySize, s.responseCfg, s.adminCfg.Token, s.logger)
 
-	srv := &http.Server{
+	s.srv = &http.Server{
 		Addr:         s.cfg.Addr,
 		Handler:      router,
 		ReadTimeout:  time.Duration(s.cfg.ReadTimeout) * time.Second,
 		WriteTimeout: time.Duration(s.cfg.WriteTimeout) * time.Second,
 	}
 
-	serverErrors := make(chan error, 1)
+	addr := s.srv.Addr
+	if addr == "" {
+		addr = ":http"
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		s.logger.WithError(err).Error("Error starting web server")
+		return fmt.Errorf("failed to start server: %w", err)
+	}
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
+	s.logger.WithFields(logrus.Fields{
+		"addr": listener.Addr().String(),
+	}).Info("Starting web-server")
 
 	go func() {
-		s.logger.WithFields(logrus.Fields{
-			"addr": srv.Addr,
-		}).Info("Starting web-server")
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			s.logger.WithError(err).Error("Error starting web server")
-			serverErrors <- err
+		if serveErr := s.srv.Serve(listener); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			s.logger.WithError(serveErr).Error("Web server stopped unexpectedly")
+			s.failed <- fmt.Errorf("web server failed: %w", serveErr)
 		}
 	}()
 
-	select {
-	case err := <-serverErrors:
-		return fmt.Errorf("failed to start server: %w", err)
-	case sig := <-quit:
-		s.logger.WithFields(logrus.Fields{
-			"signal": sig.String(),
-		}).Info("Shutting down http-server...")
+	return nil
+}
+
+// Stop прекращает прием запросов и ожидает завершения начатых до истечения контекста
+func (s *Server) Stop(ctx context.Context) error {
+	if s.srv == nil {
+		return nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(s.cfg.ShutdownTimeout)*time.Second)
-	defer cancel()
+	s.logger.Info("Shutting down http-server...")
 
-	if err := srv.Shutdown(ctx); err != nil {
+	if err := s.srv.Shutdown(ctx); err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			s.logger.WithError(err).Warn("Graceful shutdown timeout exceeded, forcing shutdown")
 		} else {
@@ -80,3 +99,8 @@ func (s *Server) Start() error {
 	s.logger.Info("Http-server stopped")
 	return nil
 }
+
+// Failed возвращает канал, в который передается ошибка, если сервер прекратил работу после запуска
+func (s *Server) Failed() <-chan error {
+	return s.failed
+}
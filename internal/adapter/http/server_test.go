@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
 	"github.com/beliaev-aa/notifications/tests/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/sirupsen/logrus"
@@ -81,7 +82,7 @@ func TestNewServer(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockWebhookService := mocks.NewMockWebhookService(ctrl)
-			server := NewServer(tc.cfg, mockWebhookService, tc.logger)
+			server := NewServer(tc.cfg, config.AdminConfig{}, config.WebhookResponseConfig{}, mockWebhookService, nil, nil, tc.logger)
 
 			if tc.checkNil {
 				if server != nil {
@@ -147,24 +148,14 @@ func TestServer_Start_Configuration(t *testing.T) {
 			logger.SetLevel(logrus.ErrorLevel)
 
 			mockWebhookService := mocks.NewMockWebhookService(ctrl)
-			server := NewServer(tc.cfg, mockWebhookService, logger)
+			server := NewServer(tc.cfg, config.AdminConfig{}, config.WebhookResponseConfig{}, mockWebhookService, nil, nil, logger)
 
-			serverErrors := make(chan error, 1)
-			go func() {
-				err := server.Start()
-				if err != nil {
-					serverErrors <- err
-				}
-			}()
-
-			time.Sleep(100 * time.Millisecond)
+			if err := server.Start(context.Background()); err != nil {
+				t.Fatalf("server failed to start: %v", err)
+			}
 
-			select {
-			case err := <-serverErrors:
-				if strings.Contains(err.Error(), "failed to start server") {
-					t.Errorf("server failed to start: %v", err)
-				}
-			case <-time.After(200 * time.Millisecond):
+			if err := server.Stop(context.Background()); err != nil {
+				t.Errorf("unexpected stop error: %v", err)
 			}
 		})
 	}
@@ -196,7 +187,7 @@ func TestServer_Start_With_Router(t *testing.T) {
 			requestBody:  "",
 			expectedCode: http.StatusOK,
 			checkBody:    true,
-			expectedBody: "ok",
+			expectedBody: `{"status":"ok"}`,
 		},
 		{
 			name: "Start_Server_Webhook_Endpoint",
@@ -209,9 +200,9 @@ func TestServer_Start_With_Router(t *testing.T) {
 			method:       "POST",
 			path:         "/webhook/youtrack",
 			requestBody:  `{"test": "data"}`,
-			expectedCode: http.StatusOK,
+			expectedCode: http.StatusAccepted,
 			checkBody:    true,
-			expectedBody: "ok",
+			expectedBody: `{"status":"accepted","deliveries":[]}`,
 		},
 	}
 
@@ -224,9 +215,9 @@ func TestServer_Start_With_Router(t *testing.T) {
 			logger.SetLevel(logrus.ErrorLevel)
 
 			mockWebhookService := mocks.NewMockWebhookService(ctrl)
-			server := NewServer(tc.cfg, mockWebhookService, logger)
+			server := NewServer(tc.cfg, config.AdminConfig{}, config.WebhookResponseConfig{}, mockWebhookService, nil, nil, logger)
 
-			router := NewRouter(mockWebhookService, logger)
+			router := NewRouter(mockWebhookService, nil, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, "", logger)
 			testServer := httptest.NewServer(router)
 			defer testServer.Close()
 
@@ -236,10 +227,11 @@ func TestServer_Start_With_Router(t *testing.T) {
 			} else {
 				req = httptest.NewRequest(tc.method, tc.path, nil)
 			}
+			req.Header.Set("Content-Type", "application/json")
 			recorder := httptest.NewRecorder()
 
 			if tc.method == "POST" {
-				mockWebhookService.EXPECT().ProcessWebhook(gomock.Any()).Return(nil)
+				mockWebhookService.EXPECT().ProcessWebhook(gomock.Any(), gomock.Any()).Return(port.DeliveryReport{}, nil)
 			}
 
 			router.ServeHTTP(recorder, req)
@@ -304,32 +296,73 @@ func TestServer_Start_Error_Handling(t *testing.T) {
 			logger.SetLevel(logrus.ErrorLevel)
 
 			mockWebhookService := mocks.NewMockWebhookService(ctrl)
-			server := NewServer(tc.cfg, mockWebhookService, logger)
+			server := NewServer(tc.cfg, config.AdminConfig{}, config.WebhookResponseConfig{}, mockWebhookService, nil, nil, logger)
 
-			serverErrors := make(chan error, 1)
-			go func() {
-				err := server.Start()
-				if err != nil {
-					serverErrors <- err
-				}
+			err := server.Start(context.Background())
+			defer func() {
+				_ = server.Stop(context.Background())
 			}()
 
-			select {
-			case err := <-serverErrors:
-				if tc.expectedError {
-					if err == nil {
-						t.Error("expected error, got: nil")
-					}
-					if !strings.Contains(err.Error(), "failed to start server") {
-						t.Errorf("expected error about failed to start server, got: %v", err)
-					}
-				} else {
-					t.Errorf("unexpected error: %v", err)
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("expected error, but server started successfully")
 				}
-			case <-time.After(200 * time.Millisecond):
-				if tc.expectedError {
-					t.Error("expected error, but server started successfully")
+				if !strings.Contains(err.Error(), "failed to start server") {
+					t.Errorf("expected error about failed to start server, got: %v", err)
 				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestServer_Stop(t *testing.T) {
+	type testCase struct {
+		name  string
+		start bool
+	}
+
+	testCases := []testCase{
+		{
+			name:  "Stop_Started_Server",
+			start: true,
+		},
+		{
+			name:  "Stop_Not_Started_Server",
+			start: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			cfg := &config.HTTPConfig{Addr: "127.0.0.1:0", ShutdownTimeout: 1, ReadTimeout: 5, WriteTimeout: 5}
+			server := NewServer(cfg, config.AdminConfig{}, config.WebhookResponseConfig{}, mocks.NewMockWebhookService(ctrl), nil, nil, logger)
+
+			if tc.start {
+				if err := server.Start(context.Background()); err != nil {
+					t.Fatalf("server failed to start: %v", err)
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			if err := server.Stop(ctx); err != nil {
+				t.Errorf("unexpected stop error: %v", err)
+			}
+
+			// Штатная остановка не считается аварийным завершением
+			select {
+			case err := <-server.Failed():
+				t.Errorf("unexpected failure: %v", err)
+			default:
 			}
 		})
 	}
@@ -370,7 +403,7 @@ func TestServer_Start_Integration(t *testing.T) {
 			method:       "POST",
 			path:         "/webhook/youtrack",
 			requestBody:  `{"project": {"name": "Test"}}`,
-			expectedCode: http.StatusOK,
+			expectedCode: http.StatusAccepted,
 		},
 	}
 
@@ -383,9 +416,9 @@ func TestServer_Start_Integration(t *testing.T) {
 			logger.SetLevel(logrus.ErrorLevel)
 
 			mockWebhookService := mocks.NewMockWebhookService(ctrl)
-			server := NewServer(tc.cfg, mockWebhookService, logger)
+			server := NewServer(tc.cfg, config.AdminConfig{}, config.WebhookResponseConfig{}, mockWebhookService, nil, nil, logger)
 
-			router := NewRouter(mockWebhookService, logger)
+			router := NewRouter(mockWebhookService, nil, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, "", logger)
 			testServer := httptest.NewServer(router)
 			defer testServer.Close()
 
@@ -395,10 +428,11 @@ func TestServer_Start_Integration(t *testing.T) {
 			} else {
 				req = httptest.NewRequest(tc.method, tc.path, nil)
 			}
+			req.Header.Set("Content-Type", "application/json")
 			recorder := httptest.NewRecorder()
 
 			if tc.method == "POST" {
-				mockWebhookService.EXPECT().ProcessWebhook(gomock.Any()).Return(nil)
+				mockWebhookService.EXPECT().ProcessWebhook(gomock.Any(), gomock.Any()).Return(port.DeliveryReport{}, nil)
 			}
 
 			router.ServeHTTP(recorder, req)
@@ -435,7 +469,7 @@ func TestServer_Start_EdgeCases(t *testing.T) {
 				WriteTimeout:    5,
 			},
 			logger:       nil,
-			expectedCode: http.StatusOK,
+			expectedCode: http.StatusAccepted,
 		},
 		{
 			name: "EdgeCase_Server_With_Zero_Shutdown_Timeout",
@@ -446,7 +480,7 @@ func TestServer_Start_EdgeCases(t *testing.T) {
 				WriteTimeout:    5,
 			},
 			logger:       logrus.New(),
-			expectedCode: http.StatusOK,
+			expectedCode: http.StatusAccepted,
 		},
 		{
 			name: "EdgeCase_Server_With_Large_Timeouts",
@@ -457,7 +491,7 @@ func TestServer_Start_EdgeCases(t *testing.T) {
 				WriteTimeout:    300,
 			},
 			logger:       logrus.New(),
-			expectedCode: http.StatusOK,
+			expectedCode: http.StatusAccepted,
 		},
 	}
 
@@ -467,7 +501,7 @@ func TestServer_Start_EdgeCases(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockWebhookService := mocks.NewMockWebhookService(ctrl)
-			server := NewServer(tc.cfg, mockWebhookService, tc.logger)
+			server := NewServer(tc.cfg, config.AdminConfig{}, config.WebhookResponseConfig{}, mockWebhookService, nil, nil, tc.logger)
 
 			if server == nil {
 				t.Error("expected server to be created, got: nil")
@@ -487,7 +521,7 @@ func TestServer_Start_EdgeCases(t *testing.T) {
 				t.Errorf("expected write timeout %d, got: %d", tc.cfg.WriteTimeout, server.cfg.WriteTimeout)
 			}
 
-			router := NewRouter(mockWebhookService, tc.logger)
+			router := NewRouter(mockWebhookService, nil, nil, config.DefaultMaxBodySize, config.WebhookResponseConfig{}, "", tc.logger)
 			if router == nil {
 				t.Error("expected router to be created, got: nil")
 			}
@@ -537,7 +571,7 @@ func TestServer_Start_Context_Timeout(t *testing.T) {
 			logger.SetLevel(logrus.ErrorLevel)
 
 			mockWebhookService := mocks.NewMockWebhookService(ctrl)
-			server := NewServer(tc.cfg, mockWebhookService, logger)
+			server := NewServer(tc.cfg, config.AdminConfig{}, config.WebhookResponseConfig{}, mockWebhookService, nil, nil, logger)
 
 			ctx, cancel := context.WithTimeout(context.Background(), tc.shutdownTimeout)
 			defer cancel()
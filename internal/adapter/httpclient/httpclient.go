@@ -15,6 +15,27 @@ func NewTelegramClient(cfg config.TelegramConfig) port.HTTPClient {
 	}
 }
 
+// NewSlackClient создает HTTP клиент для Slack канала
+func NewSlackClient(cfg config.SlackConfig) port.HTTPClient {
+	return &http.Client{
+		Timeout: time.Duration(cfg.Timeout) * time.Second,
+	}
+}
+
+// NewMSTeamsClient создает HTTP клиент для Microsoft Teams канала
+func NewMSTeamsClient(cfg config.MSTeamsConfig) port.HTTPClient {
+	return &http.Client{
+		Timeout: time.Duration(cfg.Timeout) * time.Second,
+	}
+}
+
+// NewDiscordClient создает HTTP клиент для Discord канала
+func NewDiscordClient(cfg config.DiscordConfig) port.HTTPClient {
+	return &http.Client{
+		Timeout: time.Duration(cfg.Timeout) * time.Second,
+	}
+}
+
 // NewVKTeamsClient создает HTTP клиент для VK Teams канала с настройками TLS
 func NewVKTeamsClient(cfg config.VKTeamsConfig) port.HTTPClient {
 	var transport *http.Transport
@@ -36,3 +57,99 @@ func NewVKTeamsClient(cfg config.VKTeamsConfig) port.HTTPClient {
 		Transport: transport,
 	}
 }
+
+// NewMattermostClient создает HTTP клиент для Mattermost канала с настройками TLS
+// Mattermost часто разворачивается на собственном сервере, поэтому проверку сертификата можно отключить
+func NewMattermostClient(cfg config.MattermostConfig) port.HTTPClient {
+	transport := &http.Transport{}
+	if cfg.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+
+	return &http.Client{
+		Timeout:   time.Duration(cfg.Timeout) * time.Second,
+		Transport: transport,
+	}
+}
+
+// NewOutgoingWebhookClient создает HTTP клиент для webhook канала с настройками TLS
+// Цели webhook канала - внутренние сервисы, сертификаты которых могут быть выпущены собственным центром
+func NewOutgoingWebhookClient(cfg config.OutgoingWebhookConfig) port.HTTPClient {
+	transport := &http.Transport{}
+	if cfg.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+
+	return &http.Client{
+		Timeout:   time.Duration(cfg.Timeout) * time.Second,
+		Transport: transport,
+	}
+}
+
+// NewMatrixClient создает HTTP клиент для Matrix канала с настройками TLS
+// Homeserver часто разворачивается на собственном сервере, поэтому проверку сертификата можно отключить
+func NewMatrixClient(cfg config.MatrixConfig) port.HTTPClient {
+	transport := &http.Transport{}
+	if cfg.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+
+	return &http.Client{
+		Timeout:   time.Duration(cfg.Timeout) * time.Second,
+		Transport: transport,
+	}
+}
+
+// NewZulipClient создает HTTP клиент для Zulip канала с настройками TLS
+// Сервер Zulip может быть развернут с самоподписанным сертификатом, поэтому проверку сертификата можно отключить
+func NewZulipClient(cfg config.ZulipConfig) port.HTTPClient {
+	transport := &http.Transport{}
+	if cfg.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+
+	return &http.Client{
+		Timeout:   time.Duration(cfg.Timeout) * time.Second,
+		Transport: transport,
+	}
+}
+
+// NewNtfyClient создает HTTP клиент для ntfy канала с настройками TLS
+// Сервер ntfy часто разворачивается самостоятельно, поэтому проверку сертификата можно отключить
+func NewNtfyClient(cfg config.NtfyConfig) port.HTTPClient {
+	transport := &http.Transport{}
+	if cfg.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+
+	return &http.Client{
+		Timeout:   time.Duration(cfg.Timeout) * time.Second,
+		Transport: transport,
+	}
+}
+
+// NewGotifyClient создает HTTP клиент для Gotify канала с настройками TLS
+// Сервер Gotify разворачивается самостоятельно, поэтому проверку сертификата можно отключить
+func NewGotifyClient(cfg config.GotifyConfig) port.HTTPClient {
+	transport := &http.Transport{}
+	if cfg.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+
+	return &http.Client{
+		Timeout:   time.Duration(cfg.Timeout) * time.Second,
+		Transport: transport,
+	}
+}
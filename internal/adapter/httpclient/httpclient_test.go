@@ -389,3 +389,384 @@ func TestNewVKTeamsClient_StandardTransport(t *testing.T) {
 		}
 	}
 }
+
+func TestNewSlackClient(t *testing.T) {
+	type testCase struct {
+		name            string
+		cfg             config.SlackConfig
+		expectedTimeout time.Duration
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Create_Slack_Client_With_Timeout",
+			cfg:             config.SlackConfig{BotToken: "xoxb-token", Timeout: 10},
+			expectedTimeout: 10 * time.Second,
+		},
+		{
+			name:            "Create_Slack_Client_Without_Token",
+			cfg:             config.SlackConfig{Timeout: 5},
+			expectedTimeout: 5 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient, ok := NewSlackClient(tc.cfg).(*http.Client)
+			if !ok {
+				t.Fatal("expected client to be *http.Client")
+			}
+
+			if httpClient.Timeout != tc.expectedTimeout {
+				t.Errorf("expected timeout %v, got: %v", tc.expectedTimeout, httpClient.Timeout)
+			}
+			if httpClient.Transport != nil {
+				t.Error("expected Transport to be nil (using default), got: not nil")
+			}
+		})
+	}
+}
+
+func TestNewMSTeamsClient(t *testing.T) {
+	type testCase struct {
+		name            string
+		cfg             config.MSTeamsConfig
+		expectedTimeout time.Duration
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Create_MSTeams_Client_With_Timeout",
+			cfg:             config.MSTeamsConfig{Timeout: 10},
+			expectedTimeout: 10 * time.Second,
+		},
+		{
+			name:            "Create_MSTeams_Client_With_Custom_Timeout",
+			cfg:             config.MSTeamsConfig{Timeout: 5},
+			expectedTimeout: 5 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient, ok := NewMSTeamsClient(tc.cfg).(*http.Client)
+			if !ok {
+				t.Fatal("expected client to be *http.Client")
+			}
+
+			if httpClient.Timeout != tc.expectedTimeout {
+				t.Errorf("expected timeout %v, got: %v", tc.expectedTimeout, httpClient.Timeout)
+			}
+			if httpClient.Transport != nil {
+				t.Error("expected Transport to be nil (using default), got: not nil")
+			}
+		})
+	}
+}
+
+func TestNewDiscordClient(t *testing.T) {
+	type testCase struct {
+		name            string
+		cfg             config.DiscordConfig
+		expectedTimeout time.Duration
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Create_Discord_Client_With_Timeout",
+			cfg:             config.DiscordConfig{Timeout: 10},
+			expectedTimeout: 10 * time.Second,
+		},
+		{
+			name:            "Create_Discord_Client_With_Custom_Timeout",
+			cfg:             config.DiscordConfig{Timeout: 5},
+			expectedTimeout: 5 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient, ok := NewDiscordClient(tc.cfg).(*http.Client)
+			if !ok {
+				t.Fatal("expected client to be *http.Client")
+			}
+
+			if httpClient.Timeout != tc.expectedTimeout {
+				t.Errorf("expected timeout %v, got: %v", tc.expectedTimeout, httpClient.Timeout)
+			}
+			if httpClient.Transport != nil {
+				t.Error("expected Transport to be nil (using default), got: not nil")
+			}
+		})
+	}
+}
+
+func TestNewMattermostClient(t *testing.T) {
+	type testCase struct {
+		name               string
+		cfg                config.MattermostConfig
+		expectedTimeout    time.Duration
+		expectedSkipVerify bool
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Create_Mattermost_Client_With_Verification",
+			cfg:             config.MattermostConfig{BotToken: "token", Timeout: 10},
+			expectedTimeout: 10 * time.Second,
+		},
+		{
+			name:               "Create_Mattermost_Client_Insecure_Skip_Verify",
+			cfg:                config.MattermostConfig{Timeout: 5, InsecureSkipVerify: true},
+			expectedTimeout:    5 * time.Second,
+			expectedSkipVerify: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient, ok := NewMattermostClient(tc.cfg).(*http.Client)
+			if !ok {
+				t.Fatal("expected client to be *http.Client")
+			}
+
+			if httpClient.Timeout != tc.expectedTimeout {
+				t.Errorf("expected timeout %v, got: %v", tc.expectedTimeout, httpClient.Timeout)
+			}
+
+			transport, ok := httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatal("expected Transport to be *http.Transport")
+			}
+			skipVerify := transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify
+			if skipVerify != tc.expectedSkipVerify {
+				t.Errorf("expected InsecureSkipVerify %v, got: %v", tc.expectedSkipVerify, skipVerify)
+			}
+		})
+	}
+}
+
+func TestNewOutgoingWebhookClient(t *testing.T) {
+	type testCase struct {
+		name               string
+		cfg                config.OutgoingWebhookConfig
+		expectedTimeout    time.Duration
+		expectedSkipVerify bool
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Create_Outgoing_Webhook_Client_With_Verification",
+			cfg:             config.OutgoingWebhookConfig{Timeout: 10},
+			expectedTimeout: 10 * time.Second,
+		},
+		{
+			name:               "Create_Outgoing_Webhook_Client_Insecure_Skip_Verify",
+			cfg:                config.OutgoingWebhookConfig{Timeout: 5, InsecureSkipVerify: true},
+			expectedTimeout:    5 * time.Second,
+			expectedSkipVerify: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient, ok := NewOutgoingWebhookClient(tc.cfg).(*http.Client)
+			if !ok {
+				t.Fatal("expected client to be *http.Client")
+			}
+
+			if httpClient.Timeout != tc.expectedTimeout {
+				t.Errorf("expected timeout %v, got: %v", tc.expectedTimeout, httpClient.Timeout)
+			}
+
+			transport, ok := httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatal("expected Transport to be *http.Transport")
+			}
+			skipVerify := transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify
+			if skipVerify != tc.expectedSkipVerify {
+				t.Errorf("expected InsecureSkipVerify %v, got: %v", tc.expectedSkipVerify, skipVerify)
+			}
+		})
+	}
+}
+
+func TestNewMatrixClient(t *testing.T) {
+	type testCase struct {
+		name               string
+		cfg                config.MatrixConfig
+		expectedTimeout    time.Duration
+		expectedSkipVerify bool
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Create_Matrix_Client_With_Verification",
+			cfg:             config.MatrixConfig{Timeout: 10},
+			expectedTimeout: 10 * time.Second,
+		},
+		{
+			name:               "Create_Matrix_Client_Insecure_Skip_Verify",
+			cfg:                config.MatrixConfig{Timeout: 5, InsecureSkipVerify: true},
+			expectedTimeout:    5 * time.Second,
+			expectedSkipVerify: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient, ok := NewMatrixClient(tc.cfg).(*http.Client)
+			if !ok {
+				t.Fatal("expected client to be *http.Client")
+			}
+
+			if httpClient.Timeout != tc.expectedTimeout {
+				t.Errorf("expected timeout %v, got: %v", tc.expectedTimeout, httpClient.Timeout)
+			}
+
+			transport, ok := httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatal("expected Transport to be *http.Transport")
+			}
+			skipVerify := transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify
+			if skipVerify != tc.expectedSkipVerify {
+				t.Errorf("expected InsecureSkipVerify %v, got: %v", tc.expectedSkipVerify, skipVerify)
+			}
+		})
+	}
+}
+
+func TestNewZulipClient(t *testing.T) {
+	type testCase struct {
+		name               string
+		cfg                config.ZulipConfig
+		expectedTimeout    time.Duration
+		expectedSkipVerify bool
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Create_Zulip_Client_With_Verification",
+			cfg:             config.ZulipConfig{Timeout: 10},
+			expectedTimeout: 10 * time.Second,
+		},
+		{
+			name:               "Create_Zulip_Client_Insecure_Skip_Verify",
+			cfg:                config.ZulipConfig{Timeout: 5, InsecureSkipVerify: true},
+			expectedTimeout:    5 * time.Second,
+			expectedSkipVerify: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient, ok := NewZulipClient(tc.cfg).(*http.Client)
+			if !ok {
+				t.Fatal("expected client to be *http.Client")
+			}
+
+			if httpClient.Timeout != tc.expectedTimeout {
+				t.Errorf("expected timeout %v, got: %v", tc.expectedTimeout, httpClient.Timeout)
+			}
+
+			transport, ok := httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatal("expected Transport to be *http.Transport")
+			}
+			skipVerify := transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify
+			if skipVerify != tc.expectedSkipVerify {
+				t.Errorf("expected InsecureSkipVerify %v, got: %v", tc.expectedSkipVerify, skipVerify)
+			}
+		})
+	}
+}
+
+func TestNewNtfyClient(t *testing.T) {
+	type testCase struct {
+		name               string
+		cfg                config.NtfyConfig
+		expectedTimeout    time.Duration
+		expectedSkipVerify bool
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Create_Ntfy_Client_With_Verification",
+			cfg:             config.NtfyConfig{Timeout: 10},
+			expectedTimeout: 10 * time.Second,
+		},
+		{
+			name:               "Create_Ntfy_Client_Insecure_Skip_Verify",
+			cfg:                config.NtfyConfig{Timeout: 5, InsecureSkipVerify: true},
+			expectedTimeout:    5 * time.Second,
+			expectedSkipVerify: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient, ok := NewNtfyClient(tc.cfg).(*http.Client)
+			if !ok {
+				t.Fatal("expected client to be *http.Client")
+			}
+
+			if httpClient.Timeout != tc.expectedTimeout {
+				t.Errorf("expected timeout %v, got: %v", tc.expectedTimeout, httpClient.Timeout)
+			}
+
+			transport, ok := httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatal("expected Transport to be *http.Transport")
+			}
+			skipVerify := transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify
+			if skipVerify != tc.expectedSkipVerify {
+				t.Errorf("expected InsecureSkipVerify %v, got: %v", tc.expectedSkipVerify, skipVerify)
+			}
+		})
+	}
+}
+
+func TestNewGotifyClient(t *testing.T) {
+	type testCase struct {
+		name               string
+		cfg                config.GotifyConfig
+		expectedTimeout    time.Duration
+		expectedSkipVerify bool
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Create_Gotify_Client_With_Verification",
+			cfg:             config.GotifyConfig{Timeout: 10},
+			expectedTimeout: 10 * time.Second,
+		},
+		{
+			name:               "Create_Gotify_Client_Insecure_Skip_Verify",
+			cfg:                config.GotifyConfig{Timeout: 5, InsecureSkipVerify: true},
+			expectedTimeout:    5 * time.Second,
+			expectedSkipVerify: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient, ok := NewGotifyClient(tc.cfg).(*http.Client)
+			if !ok {
+				t.Fatal("expected client to be *http.Client")
+			}
+
+			if httpClient.Timeout != tc.expectedTimeout {
+				t.Errorf("expected timeout %v, got: %v", tc.expectedTimeout, httpClient.Timeout)
+			}
+
+			transport, ok := httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatal("expected Transport to be *http.Transport")
+			}
+			skipVerify := transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify
+			if skipVerify != tc.expectedSkipVerify {
+				t.Errorf("expected InsecureSkipVerify %v, got: %v", tc.expectedSkipVerify, skipVerify)
+			}
+		})
+	}
+}
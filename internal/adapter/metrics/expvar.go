@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"expvar"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"sync"
+)
+
+// expvarName имя переменной expvar, в которой публикуются счетчики сервиса
+const expvarName = "notifications"
+
+var (
+	publishOnce sync.Once
+	counters    *expvar.Map
+)
+
+// Counters реализует порт Metrics на основе стандартного пакета expvar
+// Счетчики доступны в JSON ответе /admin/debug/vars в объекте notifications
+type Counters struct {
+	vars *expvar.Map
+}
+
+// NewCounters создает счетчики сервиса
+// Переменная expvar публикуется один раз на процесс, все экземпляры разделяют общие значения
+func NewCounters() port.Metrics {
+	publishOnce.Do(func() {
+		counters = expvar.NewMap(expvarName)
+	})
+
+	return &Counters{vars: counters}
+}
+
+// Inc увеличивает счетчик с указанным названием на единицу
+func (c *Counters) Inc(name string) {
+	c.vars.Add(name, 1)
+}
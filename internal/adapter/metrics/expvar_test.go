@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"expvar"
+	"testing"
+)
+
+func TestCounters_Inc(t *testing.T) {
+	type testCase struct {
+		name          string
+		increments    []string
+		counter       string
+		expectedValue string
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Counter_Incremented",
+			increments:    []string{"test_accepted", "test_accepted", "test_duplicate"},
+			counter:       "test_accepted",
+			expectedValue: "2",
+		},
+		{
+			name:          "Counters_Shared_Between_Instances",
+			increments:    []string{"test_shared"},
+			counter:       "test_shared",
+			expectedValue: "1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range tc.increments {
+				NewCounters().Inc(name)
+			}
+
+			published, ok := expvar.Get(expvarName).(*expvar.Map)
+			if !ok {
+				t.Fatalf("expected expvar %q to be published", expvarName)
+			}
+
+			value := published.Get(tc.counter)
+			if value == nil {
+				t.Fatalf("expected counter %q to be published", tc.counter)
+			}
+			if value.String() != tc.expectedValue {
+				t.Errorf("expected counter %q to be %s, got: %s", tc.counter, tc.expectedValue, value.String())
+			}
+		})
+	}
+}
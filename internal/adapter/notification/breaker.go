@@ -0,0 +1,198 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+// circuitBreaker оборачивает канал и прекращает обращения к API канала после серии временных ошибок
+// Пока выключатель разомкнут, отправка сразу завершается ошибкой port.ErrCircuitOpen
+// По истечении openDuration выключатель переходит в полуоткрытое состояние и пропускает halfOpenProbes пробных отправок:
+// успех всех проб замыкает выключатель, временная ошибка любой из них снова размыкает его
+// Постоянные ошибки (например, неверный chat_id) означают, что API канала отвечает, и сбрасывают счетчик ошибок
+type circuitBreaker struct {
+	channel          port.NotificationChannel
+	failureThreshold int
+	openDuration     time.Duration
+	halfOpenProbes   int
+	logger           *logrus.Logger
+
+	mu        sync.Mutex
+	state     port.CircuitState
+	failures  int
+	openedAt  time.Time
+	probes    int
+	successes int
+}
+
+// newCircuitBreaker создает автоматический выключатель для канала
+func newCircuitBreaker(channel port.NotificationChannel, cfg config.CircuitBreakerPolicyConfig, logger *logrus.Logger) *circuitBreaker {
+	breaker := &circuitBreaker{
+		channel:          channel,
+		failureThreshold: cfg.FailureThreshold,
+		openDuration:     time.Duration(cfg.OpenDuration) * time.Second,
+		halfOpenProbes:   cfg.HalfOpenProbes,
+		logger:           logger,
+		state:            port.CircuitClosed,
+	}
+
+	if breaker.failureThreshold < 1 {
+		breaker.failureThreshold = 1
+	}
+	if breaker.halfOpenProbes < 1 {
+		breaker.halfOpenProbes = 1
+	}
+
+	return breaker
+}
+
+// Send отправляет уведомление через канал, если выключатель это допускает
+// Отправка, прерванная отменой контекста, не учитывается: она не говорит о состоянии API канала
+func (b *circuitBreaker) Send(ctx context.Context, chatID string, formattedMessage string) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+
+	err := b.channel.Send(ctx, chatID, formattedMessage)
+	if ctx.Err() != nil {
+		b.release()
+		return err
+	}
+	b.record(err)
+
+	return err
+}
+
+// Channel возвращает название обернутого канала
+func (b *circuitBreaker) Channel() string {
+	return b.channel.Channel()
+}
+
+// health возвращает текущее состояние выключателя
+func (b *circuitBreaker) health() port.ChannelHealth {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	health := port.ChannelHealth{
+		Channel:             b.channel.Channel(),
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+	}
+	if b.state != port.CircuitClosed {
+		openedAt := b.openedAt
+		health.OpenedAt = &openedAt
+	}
+	// Переход в полуоткрытое состояние выполняется при следующей отправке, но сообщаем о нем сразу
+	if b.state == port.CircuitOpen && !nowFunc().Before(b.openedAt.Add(b.openDuration)) {
+		health.State = port.CircuitHalfOpen
+	}
+
+	return health
+}
+
+// allow проверяет, можно ли обратиться к API канала
+// Возвращает временную ошибку доставки с рекомендуемой задержкой, если выключатель разомкнут
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == port.CircuitOpen {
+		remaining := b.openedAt.Add(b.openDuration).Sub(nowFunc())
+		if remaining > 0 {
+			return b.openError(remaining)
+		}
+		b.setState(port.CircuitHalfOpen)
+	}
+
+	if b.state == port.CircuitHalfOpen {
+		if b.probes >= b.halfOpenProbes {
+			return b.openError(0)
+		}
+		b.probes++
+	}
+
+	return nil
+}
+
+// release возвращает пробную отправку, результат которой не учитывается
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == port.CircuitHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+}
+
+// record учитывает результат отправки через канал
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	failed := err != nil && port.IsRetryable(err)
+
+	switch b.state {
+	case port.CircuitClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.failureThreshold {
+			b.open(err)
+		}
+	case port.CircuitHalfOpen:
+		if failed {
+			b.failures++
+			b.open(err)
+			return
+		}
+		b.successes++
+		if b.successes >= b.halfOpenProbes {
+			b.failures = 0
+			b.setState(port.CircuitClosed)
+		}
+	}
+}
+
+// open размыкает выключатель, вызывается под блокировкой
+func (b *circuitBreaker) open(err error) {
+	b.openedAt = nowFunc()
+	b.setState(port.CircuitOpen)
+
+	b.logger.WithFields(logrus.Fields{
+		"channel":       b.channel.Channel(),
+		"failures":      b.failures,
+		"open_duration": b.openDuration.String(),
+	}).WithError(err).Warn("Circuit breaker opened, channel API calls are suspended")
+}
+
+// setState меняет состояние выключателя и сбрасывает счетчики пробных отправок, вызывается под блокировкой
+func (b *circuitBreaker) setState(state port.CircuitState) {
+	previous := b.state
+	b.state = state
+	b.probes = 0
+	b.successes = 0
+
+	if state == port.CircuitOpen {
+		return
+	}
+
+	b.logger.WithFields(logrus.Fields{
+		"channel": b.channel.Channel(),
+		"from":    string(previous),
+		"to":      string(state),
+	}).Info("Circuit breaker state changed")
+}
+
+// openError возвращает ошибку отправки при разомкнутом выключателе
+func (b *circuitBreaker) openError(retryAfter time.Duration) error {
+	err := port.NewDeliveryError(b.channel.Channel(), 0, fmt.Errorf("%s: %w", b.channel.Channel(), port.ErrCircuitOpen))
+	err.RetryAfter = retryAfter
+	return err
+}
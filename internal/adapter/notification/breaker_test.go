@@ -0,0 +1,216 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_Send(t *testing.T) {
+	type step struct {
+		advance      time.Duration
+		channelErr   error
+		callsChannel bool
+		circuitOpen  bool
+		retryAfter   time.Duration
+		state        port.CircuitState
+	}
+
+	type testCase struct {
+		name  string
+		cfg   config.CircuitBreakerPolicyConfig
+		steps []step
+	}
+
+	transientErr := port.NewDeliveryError("vkteams", http.StatusBadGateway, errors.New("bad gateway"))
+	permanentErr := port.NewDeliveryError("vkteams", http.StatusBadRequest, errors.New("chat not found"))
+
+	cfg := config.CircuitBreakerPolicyConfig{FailureThreshold: 2, OpenDuration: 30, HalfOpenProbes: 1}
+
+	testCases := []testCase{
+		{
+			name: "Opens_After_Consecutive_Transient_Failures",
+			cfg:  cfg,
+			steps: []step{
+				{channelErr: transientErr, callsChannel: true, state: port.CircuitClosed},
+				{channelErr: transientErr, callsChannel: true, state: port.CircuitOpen},
+				{callsChannel: false, circuitOpen: true, retryAfter: 30 * time.Second, state: port.CircuitOpen},
+				{advance: 10 * time.Second, callsChannel: false, circuitOpen: true, retryAfter: 20 * time.Second, state: port.CircuitOpen},
+			},
+		},
+		{
+			name: "Success_Resets_Failure_Counter",
+			cfg:  cfg,
+			steps: []step{
+				{channelErr: transientErr, callsChannel: true, state: port.CircuitClosed},
+				{callsChannel: true, state: port.CircuitClosed},
+				{channelErr: transientErr, callsChannel: true, state: port.CircuitClosed},
+			},
+		},
+		{
+			name: "Permanent_Errors_Do_Not_Open",
+			cfg:  cfg,
+			steps: []step{
+				{channelErr: permanentErr, callsChannel: true, state: port.CircuitClosed},
+				{channelErr: permanentErr, callsChannel: true, state: port.CircuitClosed},
+				{channelErr: permanentErr, callsChannel: true, state: port.CircuitClosed},
+			},
+		},
+		{
+			name: "Successful_Probe_Closes",
+			cfg:  cfg,
+			steps: []step{
+				{channelErr: transientErr, callsChannel: true, state: port.CircuitClosed},
+				{channelErr: transientErr, callsChannel: true, state: port.CircuitOpen},
+				{advance: 30 * time.Second, callsChannel: true, state: port.CircuitClosed},
+				{channelErr: transientErr, callsChannel: true, state: port.CircuitClosed},
+			},
+		},
+		{
+			name: "Failed_Probe_Reopens",
+			cfg:  cfg,
+			steps: []step{
+				{channelErr: transientErr, callsChannel: true, state: port.CircuitClosed},
+				{channelErr: transientErr, callsChannel: true, state: port.CircuitOpen},
+				{advance: 31 * time.Second, channelErr: transientErr, callsChannel: true, state: port.CircuitOpen},
+				{callsChannel: false, circuitOpen: true, retryAfter: 30 * time.Second, state: port.CircuitOpen},
+			},
+		},
+		{
+			name: "Several_Probes_Required_To_Close",
+			cfg:  config.CircuitBreakerPolicyConfig{FailureThreshold: 1, OpenDuration: 5, HalfOpenProbes: 2},
+			steps: []step{
+				{channelErr: transientErr, callsChannel: true, state: port.CircuitOpen},
+				{advance: 5 * time.Second, callsChannel: true, state: port.CircuitHalfOpen},
+				{callsChannel: true, state: port.CircuitClosed},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+			originalNow := nowFunc
+			nowFunc = func() time.Time { return now }
+			defer func() {
+				nowFunc = originalNow
+			}()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockChannel := mocks.NewMockNotificationChannel(ctrl)
+			mockChannel.EXPECT().Channel().Return("vkteams").AnyTimes()
+			breaker := newCircuitBreaker(mockChannel, tc.cfg, logger)
+
+			for i, s := range tc.steps {
+				now = now.Add(s.advance)
+				if s.callsChannel {
+					mockChannel.EXPECT().Send(gomock.Any(), "chat", "message").Return(s.channelErr)
+				}
+
+				err := breaker.Send(context.Background(), "chat", "message")
+
+				if s.circuitOpen {
+					if !errors.Is(err, port.ErrCircuitOpen) {
+						t.Fatalf("step %d: expected circuit open error, got: %v", i, err)
+					}
+					if !port.IsRetryable(err) {
+						t.Errorf("step %d: expected circuit open error to be retryable", i)
+					}
+					if retryAfter := port.RetryAfter(err); retryAfter != s.retryAfter {
+						t.Errorf("step %d: expected retry after %s, got: %s", i, s.retryAfter, retryAfter)
+					}
+				} else if !errors.Is(err, s.channelErr) {
+					t.Fatalf("step %d: expected error %v, got: %v", i, s.channelErr, err)
+				}
+
+				if state := breaker.health().State; state != s.state {
+					t.Errorf("step %d: expected state %s, got: %s", i, s.state, state)
+				}
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker_Health(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	originalNow := nowFunc
+	nowFunc = func() time.Time { return now }
+	defer func() {
+		nowFunc = originalNow
+	}()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	mockChannel := mocks.NewMockNotificationChannel(ctrl)
+	mockChannel.EXPECT().Channel().Return("vkteams").AnyTimes()
+	mockChannel.EXPECT().Send(gomock.Any(), "chat", "message").Return(port.NewDeliveryError("vkteams", 0, errors.New("timeout")))
+	breaker := newCircuitBreaker(mockChannel, config.CircuitBreakerPolicyConfig{FailureThreshold: 1, OpenDuration: 30, HalfOpenProbes: 1}, logger)
+
+	_ = breaker.Send(context.Background(), "chat", "message")
+	openedAt := now
+
+	expected := port.ChannelHealth{Channel: "vkteams", State: port.CircuitOpen, ConsecutiveFailures: 1, OpenedAt: &openedAt}
+	if diff := cmp.Diff(expected, breaker.health()); diff != "" {
+		t.Errorf("health mismatch (-want +got):\n%s", diff)
+	}
+
+	// По истечении open_duration состояние сообщается как полуоткрытое до следующей отправки
+	now = now.Add(30 * time.Second)
+	expected.State = port.CircuitHalfOpen
+	if diff := cmp.Diff(expected, breaker.health()); diff != "" {
+		t.Errorf("health mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCircuitBreaker_Send_CanceledNotCounted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	originalNow := nowFunc
+	nowFunc = func() time.Time { return now }
+	defer func() {
+		nowFunc = originalNow
+	}()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	canceledErr := port.NewDeliveryError("vkteams", 0, context.Canceled)
+
+	mockChannel := mocks.NewMockNotificationChannel(ctrl)
+	mockChannel.EXPECT().Channel().Return("vkteams").AnyTimes()
+	mockChannel.EXPECT().Send(gomock.Any(), "chat", "message").Return(canceledErr).Times(2)
+	breaker := newCircuitBreaker(mockChannel, config.CircuitBreakerPolicyConfig{FailureThreshold: 1, OpenDuration: 30, HalfOpenProbes: 1}, logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for i := 0; i < 2; i++ {
+		if err := breaker.Send(ctx, "chat", "message"); !errors.Is(err, canceledErr) {
+			t.Fatalf("expected error %v, got: %v", canceledErr, err)
+		}
+	}
+
+	expected := port.ChannelHealth{Channel: "vkteams", State: port.CircuitClosed}
+	if diff := cmp.Diff(expected, breaker.health()); diff != "" {
+		t.Errorf("health mismatch (-want +got):\n%s", diff)
+	}
+}
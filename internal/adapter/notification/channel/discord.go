@@ -0,0 +1,155 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/adapter/ratelimit"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"time"
+	"unicode/utf8"
+)
+
+// discordRateLimitResponse описывает тело ответа Discord со статусом 429
+type discordRateLimitResponse struct {
+	RetryAfter float64 `json:"retry_after"` // Задержка перед повтором в секундах, может быть дробной
+	Global     bool    `json:"global"`
+}
+
+// DiscordChannel реализует канал отправки уведомлений через Discord
+// Адресат - URL webhook канала Discord, токен не нужен
+type DiscordChannel struct {
+	client  port.HTTPClient
+	limiter *ratelimit.Limiter
+	logger  *logrus.Logger
+}
+
+// NewDiscordChannel создает новый канал Discord
+func NewDiscordChannel(cfg config.DiscordConfig, logger *logrus.Logger, httpClient port.HTTPClient) port.NotificationChannel {
+	return &DiscordChannel{
+		client:  httpClient,
+		limiter: newRateLimiter(cfg.RateLimit, nil),
+		logger:  logger,
+	}
+}
+
+// Send отправляет уведомление в Discord
+// formattedMessage - JSON сообщения с embeds, текст в другом формате отправляется как content
+func (c *DiscordChannel) Send(ctx context.Context, chatID string, formattedMessage string) error {
+	if chatID == "" {
+		return fmt.Errorf("discord webhook URL is not configured")
+	}
+
+	jsonData, err := json.Marshal(discordPayload(formattedMessage))
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to marshal Discord payload")
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", chatID, bytes.NewBuffer(jsonData))
+	if err != nil {
+		err = redactURLError(err, urlOrigin(chatID))
+		c.logger.WithError(err).Error("Failed to create Discord request")
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	waited, err := c.limiter.Wait(ctx, chatID)
+	if err != nil {
+		return fmt.Errorf("discord rate limit wait interrupted: %w", err)
+	}
+	if waited > 0 {
+		c.logger.WithFields(logrus.Fields{
+			"target": webhookTarget,
+			"delay":  waited.String(),
+		}).Debug("Discord rate limit reached, message delayed")
+	}
+
+	resp, errSend := c.client.Do(req)
+	if errSend != nil {
+		// URL incoming webhook содержит секрет, поэтому в ошибке остается только хост
+		errSend = redactURLError(errSend, urlOrigin(chatID))
+		c.logger.WithError(errSend).Error("Failed to send Discord message")
+		return newTransportError(port.ChannelDiscord, errSend)
+	}
+	defer func(Body io.ReadCloser) {
+		if closeErr := Body.Close(); closeErr != nil {
+			c.logger.WithError(closeErr).Error("Failed to close request body")
+		}
+	}(resp.Body)
+
+	body, errRead := io.ReadAll(resp.Body)
+	if errRead != nil {
+		c.logger.WithError(errRead).Warn("Failed to read Discord response body")
+	}
+
+	// Webhook отвечает статусом 204 No Content, а с параметром wait=true - 200 OK
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		c.logger.WithFields(logrus.Fields{
+			"status_code": resp.StatusCode,
+			"response":    string(body),
+		}).Error("Discord API returned error")
+		deliveryErr := newStatusError(port.ChannelDiscord, resp, body)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			if retryAfter, global := parseDiscordRetryAfter(body); retryAfter > 0 {
+				deliveryErr.RetryAfter = retryAfter
+				c.logger.WithFields(logrus.Fields{
+					"target":      webhookTarget,
+					"retry_after": retryAfter.String(),
+					"global":      global,
+				}).Warn("Discord rate limit exceeded")
+			}
+		}
+		if deliveryErr.RetryAfter > 0 {
+			c.limiter.Block(chatID, deliveryErr.RetryAfter)
+		}
+		return deliveryErr
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"target": webhookTarget,
+		"status": resp.StatusCode,
+	}).Info("Notification sent via Discord channel")
+
+	return nil
+}
+
+// Channel возвращает название канала
+func (c *DiscordChannel) Channel() string {
+	return port.ChannelDiscord
+}
+
+// discordPayload возвращает поля сообщения для webhook Discord
+// Если сообщение не является JSON объектом, оно отправляется как content, сокращенный до ограничения Discord
+func discordPayload(formattedMessage string) map[string]json.RawMessage {
+	var payload map[string]json.RawMessage
+	if json.Unmarshal([]byte(formattedMessage), &payload) == nil && payload != nil {
+		return payload
+	}
+
+	content := formattedMessage
+	if utf8.RuneCountInString(content) > port.DiscordMaxContentLength {
+		runes := []rune(content)
+		content = string(runes[:port.DiscordMaxContentLength-1]) + "…"
+	}
+
+	text, _ := json.Marshal(content)
+	return map[string]json.RawMessage{"content": text}
+}
+
+// parseDiscordRetryAfter извлекает задержку перед повтором из тела ответа Discord со статусом 429
+// Discord указывает задержку в секундах с дробной частью, она точнее заголовка Retry-After
+func parseDiscordRetryAfter(body []byte) (time.Duration, bool) {
+	var rateLimit discordRateLimitResponse
+	if err := json.Unmarshal(body, &rateLimit); err != nil || rateLimit.RetryAfter <= 0 {
+		return 0, false
+	}
+
+	return time.Duration(rateLimit.RetryAfter * float64(time.Second)), rateLimit.Global
+}
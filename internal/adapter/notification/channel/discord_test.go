@@ -0,0 +1,240 @@
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestDiscordChannel_Send(t *testing.T) {
+	type testCase struct {
+		name              string
+		chatID            string
+		message           string
+		responseStatus    int
+		responseBody      string
+		responseHeader    http.Header
+		httpError         error
+		expectRequest     bool
+		expectedPayload   map[string]interface{}
+		expectedError     string
+		expectedRetryable bool
+		expectedRetry     time.Duration
+	}
+
+	webhookURL := "https://discord.com/api/webhooks/123/token"
+	embedMessage := `{"content":"**header**","embeds":[{"title":"Summary","color":16089088}]}`
+	embedPayload := map[string]interface{}{
+		"content": "**header**",
+		"embeds": []interface{}{
+			map[string]interface{}{"title": "Summary", "color": float64(16089088)},
+		},
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Send_Embed_Message",
+			chatID:          webhookURL,
+			message:         embedMessage,
+			responseStatus:  http.StatusNoContent,
+			expectRequest:   true,
+			expectedPayload: embedPayload,
+		},
+		{
+			name:            "Send_Plain_Text_Message",
+			chatID:          webhookURL,
+			message:         "Plain text",
+			responseStatus:  http.StatusNoContent,
+			expectRequest:   true,
+			expectedPayload: map[string]interface{}{"content": "Plain text"},
+		},
+		{
+			name:          "Send_With_Empty_ChatID",
+			message:       embedMessage,
+			expectedError: "discord webhook URL is not configured",
+		},
+		{
+			name:              "Send_HTTP_Client_Error",
+			chatID:            webhookURL,
+			message:           embedMessage,
+			httpError:         errors.New("network error"),
+			expectRequest:     true,
+			expectedError:     "failed to send message",
+			expectedRetryable: true,
+		},
+		{
+			name:           "Send_Bad_Request",
+			chatID:         webhookURL,
+			message:        embedMessage,
+			responseStatus: http.StatusBadRequest,
+			responseBody:   `{"message": "Invalid Form Body", "code": 50035}`,
+			expectRequest:  true,
+			expectedError:  "discord API error: status 400",
+		},
+		{
+			name:              "Send_Rate_Limited_Retry_After_In_Body",
+			chatID:            webhookURL,
+			message:           embedMessage,
+			responseStatus:    http.StatusTooManyRequests,
+			responseBody:      `{"message": "You are being rate limited.", "retry_after": 1.5, "global": false}`,
+			responseHeader:    http.Header{"Retry-After": []string{"2"}},
+			expectRequest:     true,
+			expectedError:     "discord API error: status 429",
+			expectedRetryable: true,
+			expectedRetry:     1500 * time.Millisecond,
+		},
+		{
+			name:              "Send_Rate_Limited_Retry_After_In_Header",
+			chatID:            webhookURL,
+			message:           embedMessage,
+			responseStatus:    http.StatusTooManyRequests,
+			responseBody:      "rate limited",
+			responseHeader:    http.Header{"Retry-After": []string{"3"}},
+			expectRequest:     true,
+			expectedError:     "discord API error: status 429",
+			expectedRetryable: true,
+			expectedRetry:     3 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			if tc.expectRequest {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					if req.URL.String() != tc.chatID {
+						t.Errorf("expected URL %q, got: %q", tc.chatID, req.URL.String())
+					}
+					if tc.expectedPayload != nil {
+						var payload map[string]interface{}
+						if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+							t.Fatalf("failed to decode request body: %v", err)
+						}
+						if diff := cmp.Diff(tc.expectedPayload, payload); diff != "" {
+							t.Errorf("payload mismatch (-want +got):\n%s", diff)
+						}
+					}
+					if tc.httpError != nil {
+						return nil, &url.Error{Op: "Post", URL: req.URL.String(), Err: tc.httpError}
+					}
+					return &http.Response{
+						StatusCode: tc.responseStatus,
+						Header:     tc.responseHeader,
+						Body:       io.NopCloser(strings.NewReader(tc.responseBody)),
+					}, nil
+				})
+			}
+
+			channel := NewDiscordChannel(config.DiscordConfig{}, logger, mockHTTPClient)
+			err := channel.Send(context.Background(), tc.chatID, tc.message)
+
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Fatalf("expected error containing %q, got: %v", tc.expectedError, err)
+			}
+			if strings.Contains(err.Error(), webhookURL) {
+				t.Errorf("expected error without webhook URL, got: %v", err)
+			}
+			if retryable := port.IsRetryable(err); retryable != tc.expectedRetryable {
+				t.Errorf("expected retryable %v, got: %v", tc.expectedRetryable, retryable)
+			}
+			if retryAfter := port.RetryAfter(err); retryAfter != tc.expectedRetry {
+				t.Errorf("expected retry after %s, got: %s", tc.expectedRetry, retryAfter)
+			}
+		})
+	}
+}
+
+func TestDiscordPayload(t *testing.T) {
+	payload := discordPayload(strings.Repeat("а", port.DiscordMaxContentLength+100))
+
+	var content string
+	if err := json.Unmarshal(payload["content"], &content); err != nil {
+		t.Fatalf("failed to decode content: %v", err)
+	}
+	if length := utf8.RuneCountInString(content); length != port.DiscordMaxContentLength {
+		t.Errorf("expected content length %d, got: %d", port.DiscordMaxContentLength, length)
+	}
+	if !strings.HasSuffix(content, "…") {
+		t.Error("expected truncated content to end with ellipsis")
+	}
+}
+
+func TestParseDiscordRetryAfter(t *testing.T) {
+	type testCase struct {
+		name           string
+		body           string
+		expectedRetry  time.Duration
+		expectedGlobal bool
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Retry_After_Seconds",
+			body:          `{"retry_after": 0.25, "global": false}`,
+			expectedRetry: 250 * time.Millisecond,
+		},
+		{
+			name:           "Retry_After_Global",
+			body:           `{"retry_after": 2, "global": true}`,
+			expectedRetry:  2 * time.Second,
+			expectedGlobal: true,
+		},
+		{
+			name: "Invalid_Body",
+			body: "rate limited",
+		},
+		{
+			name: "Missing_Retry_After",
+			body: `{"message": "You are being rate limited."}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			retryAfter, global := parseDiscordRetryAfter([]byte(tc.body))
+
+			if retryAfter != tc.expectedRetry {
+				t.Errorf("expected retry after %s, got: %s", tc.expectedRetry, retryAfter)
+			}
+			if global != tc.expectedGlobal {
+				t.Errorf("expected global %v, got: %v", tc.expectedGlobal, global)
+			}
+		})
+	}
+}
+
+func TestDiscordChannel_Channel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	channel := NewDiscordChannel(config.DiscordConfig{}, logrus.New(), mocks.NewMockHTTPClient(ctrl))
+
+	if name := channel.Channel(); name != port.ChannelDiscord {
+		t.Errorf("expected channel name %q, got: %q", port.ChannelDiscord, name)
+	}
+}
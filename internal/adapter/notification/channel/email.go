@@ -0,0 +1,351 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/adapter/ratelimit"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Тема письма, если сообщение подготовлено без форматирования email канала
+const emailDefaultSubject = "Уведомление YouTrack"
+
+// Символы идентификатора задачи, недопустимые в Message-ID
+var emailThreadUnsafeChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// EmailChannel реализует канал отправки уведомлений по электронной почте через SMTP сервер
+// Адресат - адреса получателей через запятую
+type EmailChannel struct {
+	cfg     config.EmailConfig
+	limiter *ratelimit.Limiter
+	logger  *logrus.Logger
+}
+
+// NewEmailChannel создает новый email канал
+func NewEmailChannel(cfg config.EmailConfig, logger *logrus.Logger) port.NotificationChannel {
+	return &EmailChannel{
+		cfg:     cfg,
+		limiter: newRateLimiter(cfg.RateLimit, nil),
+		logger:  logger,
+	}
+}
+
+// Send отправляет уведомление по электронной почте
+// formattedMessage - JSON письма (port.EmailMessage), текст в другом формате отправляется как текстовое письмо
+func (c *EmailChannel) Send(ctx context.Context, chatID string, formattedMessage string) error {
+	if chatID == "" {
+		return fmt.Errorf("email recipients are not configured")
+	}
+
+	recipients, err := mail.ParseAddressList(chatID)
+	if err != nil {
+		return fmt.Errorf("invalid email recipients: %w", err)
+	}
+
+	from, err := mail.ParseAddress(c.cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid email sender address: %w", err)
+	}
+
+	data, err := buildEmail(from, recipients, emailPayload(formattedMessage), time.Now())
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to build email message")
+		return fmt.Errorf("failed to build email message: %w", err)
+	}
+
+	waited, err := c.limiter.Wait(ctx, chatID)
+	if err != nil {
+		return fmt.Errorf("email rate limit wait interrupted: %w", err)
+	}
+	if waited > 0 {
+		c.logger.WithFields(logrus.Fields{
+			"recipients": len(recipients),
+			"delay":      waited.String(),
+		}).Debug("Email rate limit reached, message delayed")
+	}
+
+	addresses := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		addresses = append(addresses, recipient.Address)
+	}
+
+	if err = c.deliver(ctx, from.Address, addresses, data); err != nil {
+		c.logger.WithError(err).WithFields(logrus.Fields{
+			"host":       c.cfg.Host,
+			"recipients": len(recipients),
+		}).Error("Failed to send email message")
+		return err
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"host":       c.cfg.Host,
+		"recipients": len(recipients),
+	}).Info("Notification sent via email channel")
+
+	return nil
+}
+
+// Channel возвращает название канала
+func (c *EmailChannel) Channel() string {
+	return port.ChannelEmail
+}
+
+// deliver передает письмо SMTP серверу
+// Отмена контекста или истечение таймаута прерывает обмен с сервером
+func (c *EmailChannel) deliver(ctx context.Context, from string, recipients []string, data []byte) error {
+	timeout := time.Duration(c.cfg.Timeout) * time.Second
+	dialer := &net.Dialer{Timeout: timeout}
+
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(c.cfg.Host, strconv.Itoa(c.cfg.Port)))
+	if err != nil {
+		return newTransportError(port.ChannelEmail, err)
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer stop()
+
+	if timeout > 0 {
+		deadline := time.Now().Add(timeout)
+		if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+			deadline = ctxDeadline
+		}
+		_ = conn.SetDeadline(deadline)
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         c.cfg.Host,
+		InsecureSkipVerify: c.cfg.InsecureSkipVerify,
+	}
+	if c.cfg.TLS == config.EmailTLSImplicit {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	client, err := smtp.NewClient(conn, c.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return newSMTPError(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	if c.cfg.TLS == config.EmailTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("smtp server %s does not support STARTTLS", c.cfg.Host)
+		}
+		if err = client.StartTLS(tlsConfig); err != nil {
+			return newSMTPError(err)
+		}
+	}
+
+	if c.cfg.Username != "" {
+		if err = client.Auth(c.auth()); err != nil {
+			return newSMTPError(err)
+		}
+	}
+
+	if err = client.Mail(from); err != nil {
+		return newSMTPError(err)
+	}
+	for _, recipient := range recipients {
+		if err = client.Rcpt(recipient); err != nil {
+			return newSMTPError(err)
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return newSMTPError(err)
+	}
+	if _, err = writer.Write(data); err != nil {
+		return newSMTPError(err)
+	}
+	if err = writer.Close(); err != nil {
+		return newSMTPError(err)
+	}
+
+	// Письмо уже принято сервером, ошибка завершения сеанса не должна приводить к повторной отправке
+	if err = client.Quit(); err != nil {
+		c.logger.WithError(err).Debug("Failed to close SMTP session")
+	}
+
+	return nil
+}
+
+// auth возвращает способ авторизации на SMTP сервере
+func (c *EmailChannel) auth() smtp.Auth {
+	if c.cfg.Auth == config.EmailAuthLogin {
+		return &loginAuth{username: c.cfg.Username, password: c.cfg.Password, host: c.cfg.Host}
+	}
+	return smtp.PlainAuth("", c.cfg.Username, c.cfg.Password, c.cfg.Host)
+}
+
+// emailPayload возвращает письмо, подготовленное форматированием email канала
+// Если сообщение не является JSON письма, оно отправляется как текст с темой по умолчанию
+func emailPayload(formattedMessage string) port.EmailMessage {
+	var message port.EmailMessage
+	if json.Unmarshal([]byte(formattedMessage), &message) == nil && (message.Text != "" || message.HTML != "") {
+		if message.Subject == "" {
+			message.Subject = emailDefaultSubject
+		}
+		return message
+	}
+
+	return port.EmailMessage{Subject: emailDefaultSubject, Text: formattedMessage}
+}
+
+// buildEmail собирает письмо в формате RFC 5322
+// Если есть HTML версия, письмо отправляется как multipart/alternative с текстовой и HTML частями
+// Письма одной задачи ссылаются на общий идентификатор в In-Reply-To и References и объединяются в цепочку
+// Письмо с этим идентификатором в Message-ID не отправляется: сервис не знает, какое уведомление по задаче первое,
+// а повтор одного Message-ID почтовые сервисы считают дубликатом. Клиенты, строящие цепочку по References
+// (Thunderbird, Apple Mail), подставляют отсутствующее письмо-корень, остальные (Gmail, Outlook) объединяют
+// письма по References и неизменной теме `[ID] Название задачи`
+func buildEmail(from *mail.Address, recipients []*mail.Address, message port.EmailMessage, now time.Time) ([]byte, error) {
+	domain := from.Address[strings.LastIndex(from.Address, "@")+1:]
+
+	to := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		to = append(to, recipient.String())
+	}
+
+	var buf bytes.Buffer
+	writeHeader := func(name, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+
+	writeHeader("From", from.String())
+	writeHeader("To", strings.Join(to, ", "))
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", message.Subject))
+	writeHeader("Date", now.Format(time.RFC1123Z))
+	writeHeader("Message-ID", fmt.Sprintf("<%s@%s>", newMessageID(now), domain))
+	if thread := emailThreadUnsafeChars.ReplaceAllString(message.Thread, "-"); thread != "" {
+		threadID := fmt.Sprintf("<youtrack-%s@%s>", thread, domain)
+		writeHeader("In-Reply-To", threadID)
+		writeHeader("References", threadID)
+	}
+	writeHeader("MIME-Version", "1.0")
+
+	if message.HTML == "" {
+		writeHeader("Content-Type", "text/plain; charset=UTF-8")
+		writeHeader("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, message.Text); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	writeHeader("Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", writer.Boundary()))
+	buf.WriteString("\r\n")
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{contentType: "text/plain; charset=UTF-8", content: message.Text},
+		{contentType: "text/html; charset=UTF-8", content: message.HTML},
+	}
+	for _, part := range parts {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err = writeQuotedPrintable(partWriter, part.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
+
+// writeQuotedPrintable записывает текст в кодировке quoted-printable
+func writeQuotedPrintable(w io.Writer, text string) error {
+	writer := quotedprintable.NewWriter(w)
+	if _, err := writer.Write([]byte(text)); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// newMessageID возвращает случайную локальную часть Message-ID
+func newMessageID(now time.Time) string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return strconv.FormatInt(now.UnixNano(), 16)
+	}
+	return hex.EncodeToString(id)
+}
+
+// loginAuth реализует авторизацию AUTH LOGIN, которой нет в net/smtp
+type loginAuth struct {
+	username string
+	password string
+	host     string
+}
+
+// Start начинает авторизацию AUTH LOGIN
+// Как и smtp.PlainAuth, пароль не передается по незашифрованному соединению, кроме соединения с локальным сервером
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && !isLocalSMTPHost(server.Name) {
+		return "", nil, errors.New("unencrypted connection")
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("wrong host name")
+	}
+	return "LOGIN", nil, nil
+}
+
+// Next отвечает на запросы имени пользователя и пароля SMTP сервера
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+
+	challenge := strings.ToLower(strings.TrimSpace(string(fromServer)))
+	switch {
+	case strings.HasPrefix(challenge, "user"):
+		return []byte(a.username), nil
+	case strings.HasPrefix(challenge, "pass"):
+		return []byte(a.password), nil
+	}
+
+	return nil, fmt.Errorf("unexpected SMTP server challenge: %s", fromServer)
+}
+
+// isLocalSMTPHost определяет, что SMTP сервер запущен на локальной машине
+func isLocalSMTPHost(host string) bool {
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
@@ -0,0 +1,560 @@
+package channel
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSMTPMessage описывает письмо, принятое тестовым SMTP сервером
+type fakeSMTPMessage struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTPServer - локальный SMTP сервер для тестов email канала
+// replies переопределяет ответ на команду (например, "RCPT" - "550 5.1.1 User unknown")
+type fakeSMTPServer struct {
+	listener   net.Listener
+	extensions []string
+	replies    map[string]string
+
+	mu       sync.Mutex
+	messages []fakeSMTPMessage
+}
+
+func newFakeSMTPServer(t *testing.T, extensions []string, replies map[string]string) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start SMTP server: %v", err)
+	}
+
+	server := &fakeSMTPServer{listener: listener, extensions: extensions, replies: replies}
+	go server.serve()
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	return server
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) received() []fakeSMTPMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]fakeSMTPMessage(nil), s.messages...)
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	text := textproto.NewConn(conn)
+	reply := func(line string) {
+		_ = text.PrintfLine("%s", line)
+	}
+
+	reply("220 localhost ESMTP ready")
+
+	var message fakeSMTPMessage
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		if override, ok := s.replies[command]; ok {
+			reply(override)
+			continue
+		}
+
+		switch command {
+		case "EHLO":
+			lines := append([]string{"localhost"}, s.extensions...)
+			for i, extension := range lines {
+				separator := "-"
+				if i == len(lines)-1 {
+					separator = " "
+				}
+				reply("250" + separator + extension)
+			}
+		case "AUTH":
+			message.auth = s.authenticate(text, line)
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL":
+			message.from = smtpCommandAddress(line)
+			reply("250 2.1.0 OK")
+		case "RCPT":
+			message.to = append(message.to, smtpCommandAddress(line))
+			reply("250 2.1.5 OK")
+		case "DATA":
+			reply("354 Start mail input")
+			data, errRead := io.ReadAll(text.DotReader())
+			if errRead != nil {
+				return
+			}
+			message.data = string(data)
+			s.mu.Lock()
+			s.messages = append(s.messages, message)
+			s.mu.Unlock()
+			message = fakeSMTPMessage{}
+			reply("250 2.0.0 Queued")
+		case "QUIT":
+			reply("221 2.0.0 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+// authenticate выполняет обмен AUTH PLAIN или AUTH LOGIN и возвращает переданные учетные данные
+func (s *fakeSMTPServer) authenticate(text *textproto.Conn, line string) string {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return ""
+	}
+
+	if strings.EqualFold(fields[1], "PLAIN") && len(fields) == 3 {
+		credentials, _ := base64.StdEncoding.DecodeString(fields[2])
+		return "PLAIN " + strings.ReplaceAll(string(credentials), "\x00", " ")
+	}
+
+	var values []string
+	for _, challenge := range []string{"Username:", "Password:"} {
+		_ = text.PrintfLine("334 %s", base64.StdEncoding.EncodeToString([]byte(challenge)))
+		answer, err := text.ReadLine()
+		if err != nil {
+			return ""
+		}
+		value, _ := base64.StdEncoding.DecodeString(answer)
+		values = append(values, string(value))
+	}
+	return "LOGIN " + strings.Join(values, " ")
+}
+
+// smtpCommandAddress извлекает адрес из команды MAIL FROM или RCPT TO
+func smtpCommandAddress(line string) string {
+	start := strings.Index(line, "<")
+	end := strings.Index(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
+
+func TestEmailChannel_Send(t *testing.T) {
+	type testCase struct {
+		name              string
+		cfg               config.EmailConfig
+		extensions        []string
+		replies           map[string]string
+		chatID            string
+		message           string
+		expectedAuth      string
+		expectedFrom      string
+		expectedTo        []string
+		expectedError     string
+		expectedRetryable bool
+	}
+
+	emailMessage := `{"subject":"[PROJ-1] Исправить ошибку","thread":"PROJ-1","text":"Задача: Исправить ошибку","html":"<p>Задача</p>"}`
+	extensions := []string{"AUTH PLAIN LOGIN", "8BITMIME"}
+
+	testCases := []testCase{
+		{
+			name:         "Send_Without_Auth",
+			cfg:          config.EmailConfig{From: "YouTrack <youtrack@example.com>", TLS: config.EmailTLSNone},
+			extensions:   extensions,
+			chatID:       "manager@example.com, Team Lead <lead@example.com>",
+			message:      emailMessage,
+			expectedFrom: "youtrack@example.com",
+			expectedTo:   []string{"manager@example.com", "lead@example.com"},
+		},
+		{
+			name: "Send_With_Plain_Auth",
+			cfg: config.EmailConfig{
+				From: "youtrack@example.com", TLS: config.EmailTLSNone,
+				Username: "user", Password: "secret", Auth: config.EmailAuthPlain,
+			},
+			extensions:   extensions,
+			chatID:       "manager@example.com",
+			message:      emailMessage,
+			expectedAuth: "PLAIN  user secret",
+			expectedFrom: "youtrack@example.com",
+			expectedTo:   []string{"manager@example.com"},
+		},
+		{
+			name: "Send_With_Login_Auth",
+			cfg: config.EmailConfig{
+				From: "youtrack@example.com", TLS: config.EmailTLSNone,
+				Username: "user", Password: "secret", Auth: config.EmailAuthLogin,
+			},
+			extensions:   extensions,
+			chatID:       "manager@example.com",
+			message:      emailMessage,
+			expectedAuth: "LOGIN user secret",
+			expectedFrom: "youtrack@example.com",
+			expectedTo:   []string{"manager@example.com"},
+		},
+		{
+			name:          "Send_With_Empty_ChatID",
+			cfg:           config.EmailConfig{From: "youtrack@example.com", TLS: config.EmailTLSNone},
+			message:       emailMessage,
+			expectedError: "email recipients are not configured",
+		},
+		{
+			name:          "Send_With_Invalid_Recipients",
+			cfg:           config.EmailConfig{From: "youtrack@example.com", TLS: config.EmailTLSNone},
+			chatID:        "not an address",
+			message:       emailMessage,
+			expectedError: "invalid email recipients",
+		},
+		{
+			name:          "Send_Recipient_Rejected",
+			cfg:           config.EmailConfig{From: "youtrack@example.com", TLS: config.EmailTLSNone},
+			extensions:    extensions,
+			replies:       map[string]string{"RCPT": "550 5.1.1 User unknown"},
+			chatID:        "unknown@example.com",
+			message:       emailMessage,
+			expectedError: "550 5.1.1 User unknown",
+		},
+		{
+			name:              "Send_Temporary_Failure",
+			cfg:               config.EmailConfig{From: "youtrack@example.com", TLS: config.EmailTLSNone},
+			extensions:        extensions,
+			replies:           map[string]string{"MAIL": "451 4.3.0 Try again later"},
+			chatID:            "manager@example.com",
+			message:           emailMessage,
+			expectedError:     "451 4.3.0 Try again later",
+			expectedRetryable: true,
+		},
+		{
+			name:          "Send_StartTLS_Not_Supported",
+			cfg:           config.EmailConfig{From: "youtrack@example.com", TLS: config.EmailTLSStartTLS},
+			extensions:    extensions,
+			chatID:        "manager@example.com",
+			message:       emailMessage,
+			expectedError: "does not support STARTTLS",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			server := newFakeSMTPServer(t, tc.extensions, tc.replies)
+
+			cfg := tc.cfg
+			cfg.Host = "127.0.0.1"
+			cfg.Port = server.port()
+			cfg.Timeout = 5
+
+			channel := NewEmailChannel(cfg, logger)
+			err := channel.Send(context.Background(), tc.chatID, tc.message)
+
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing %q, got: %v", tc.expectedError, err)
+				}
+				if retryable := port.IsRetryable(err); retryable != tc.expectedRetryable {
+					t.Errorf("expected retryable %v, got: %v", tc.expectedRetryable, retryable)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			messages := server.received()
+			if len(messages) != 1 {
+				t.Fatalf("expected 1 message, got: %d", len(messages))
+			}
+			if messages[0].auth != tc.expectedAuth {
+				t.Errorf("expected auth %q, got: %q", tc.expectedAuth, messages[0].auth)
+			}
+			if messages[0].from != tc.expectedFrom {
+				t.Errorf("expected from %q, got: %q", tc.expectedFrom, messages[0].from)
+			}
+			if diff := cmp.Diff(tc.expectedTo, messages[0].to); diff != "" {
+				t.Errorf("recipients mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEmailChannel_Send_ConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to reserve port: %v", err)
+	}
+	freePort := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	channel := NewEmailChannel(config.EmailConfig{
+		Host: "127.0.0.1", Port: freePort, From: "youtrack@example.com", TLS: config.EmailTLSNone, Timeout: 5,
+	}, logger)
+	err = channel.Send(context.Background(), "manager@example.com", "text")
+
+	if err == nil || !strings.Contains(err.Error(), "failed to send message") {
+		t.Fatalf("expected transport error, got: %v", err)
+	}
+	if !port.IsRetryable(err) {
+		t.Error("expected connection error to be retryable")
+	}
+}
+
+func TestBuildEmail(t *testing.T) {
+	type testCase struct {
+		name               string
+		message            port.EmailMessage
+		expectedSubject    string
+		expectedThread     string
+		expectedMediaType  string
+		expectedPartTypes  []string
+		expectedPartBodies []string
+	}
+
+	testCases := []testCase{
+		{
+			name: "Build_Multipart_Message",
+			message: port.EmailMessage{
+				Subject: "[PROJ-1] Исправить ошибку",
+				Thread:  "PROJ-1",
+				Text:    "Задача: Исправить ошибку",
+				HTML:    "<p>Задача: Исправить ошибку</p>",
+			},
+			expectedSubject:    "[PROJ-1] Исправить ошибку",
+			expectedThread:     "<youtrack-PROJ-1@example.com>",
+			expectedMediaType:  "multipart/alternative",
+			expectedPartTypes:  []string{"text/plain; charset=UTF-8", "text/html; charset=UTF-8"},
+			expectedPartBodies: []string{"Задача: Исправить ошибку", "<p>Задача: Исправить ошибку</p>"},
+		},
+		{
+			name:              "Build_Plain_Text_Message",
+			message:           port.EmailMessage{Subject: "Уведомление YouTrack", Text: "Текст"},
+			expectedSubject:   "Уведомление YouTrack",
+			expectedMediaType: "text/plain",
+		},
+		{
+			name:               "Build_Message_With_Unsafe_Thread",
+			message:            port.EmailMessage{Subject: "Тема", Thread: "a b<c>", Text: "Текст", HTML: "<p>Текст</p>"},
+			expectedSubject:    "Тема",
+			expectedThread:     "<youtrack-a-b-c-@example.com>",
+			expectedMediaType:  "multipart/alternative",
+			expectedPartTypes:  []string{"text/plain; charset=UTF-8", "text/html; charset=UTF-8"},
+			expectedPartBodies: []string{"Текст", "<p>Текст</p>"},
+		},
+	}
+
+	from := &mail.Address{Name: "YouTrack", Address: "youtrack@example.com"}
+	recipients := []*mail.Address{{Address: "manager@example.com"}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := buildEmail(from, recipients, tc.message, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			msg, err := mail.ReadMessage(strings.NewReader(string(data)))
+			if err != nil {
+				t.Fatalf("failed to parse message: %v", err)
+			}
+
+			subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+			if err != nil || subject != tc.expectedSubject {
+				t.Errorf("expected subject %q, got: %q (%v)", tc.expectedSubject, subject, err)
+			}
+			if inReplyTo := msg.Header.Get("In-Reply-To"); inReplyTo != tc.expectedThread {
+				t.Errorf("expected In-Reply-To %q, got: %q", tc.expectedThread, inReplyTo)
+			}
+			if references := msg.Header.Get("References"); references != tc.expectedThread {
+				t.Errorf("expected References %q, got: %q", tc.expectedThread, references)
+			}
+			if messageID := msg.Header.Get("Message-ID"); !strings.HasSuffix(messageID, "@example.com>") {
+				t.Errorf("expected Message-ID in sender domain, got: %q", messageID)
+			}
+			if to := msg.Header.Get("To"); to != "<manager@example.com>" {
+				t.Errorf("expected To %q, got: %q", "<manager@example.com>", to)
+			}
+
+			mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+			if err != nil || mediaType != tc.expectedMediaType {
+				t.Fatalf("expected media type %q, got: %q (%v)", tc.expectedMediaType, mediaType, err)
+			}
+			if mediaType != "multipart/alternative" {
+				return
+			}
+
+			reader := multipart.NewReader(msg.Body, params["boundary"])
+			var partTypes, partBodies []string
+			for {
+				part, errPart := reader.NextPart()
+				if errors.Is(errPart, io.EOF) {
+					break
+				}
+				if errPart != nil {
+					t.Fatalf("failed to read part: %v", errPart)
+				}
+				body, _ := io.ReadAll(part)
+				partTypes = append(partTypes, part.Header.Get("Content-Type"))
+				partBodies = append(partBodies, string(body))
+			}
+
+			if diff := cmp.Diff(tc.expectedPartTypes, partTypes); diff != "" {
+				t.Errorf("part types mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedPartBodies, partBodies); diff != "" {
+				t.Errorf("part bodies mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildEmail_Threads_Issue_Emails(t *testing.T) {
+	from := &mail.Address{Name: "YouTrack", Address: "youtrack@example.com"}
+	recipients := []*mail.Address{{Address: "manager@example.com"}}
+	messages := []port.EmailMessage{
+		{Subject: "[PROJ-1] Исправить ошибку", Thread: "PROJ-1", Text: "Статус: Открыта"},
+		{Subject: "[PROJ-1] Исправить ошибку", Thread: "PROJ-1", Text: "Статус: Исправлена"},
+	}
+
+	headers := make([]mail.Header, 0, len(messages))
+	for i, message := range messages {
+		data, err := buildEmail(from, recipients, message, time.Date(2024, 1, 2, 3, 4, 5+i, 0, time.UTC))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		msg, err := mail.ReadMessage(strings.NewReader(string(data)))
+		if err != nil {
+			t.Fatalf("failed to parse message: %v", err)
+		}
+		headers = append(headers, msg.Header)
+	}
+
+	first, second := headers[0], headers[1]
+	if first.Get("Message-ID") == second.Get("Message-ID") {
+		t.Errorf("expected unique Message-ID for each email, got: %q", first.Get("Message-ID"))
+	}
+	threadID := "<youtrack-PROJ-1@example.com>"
+	for i, header := range headers {
+		if header.Get("In-Reply-To") != threadID || header.Get("References") != threadID {
+			t.Errorf("email %d: expected In-Reply-To and References %q, got: %q, %q", i, threadID, header.Get("In-Reply-To"), header.Get("References"))
+		}
+		if header.Get("Message-ID") == threadID {
+			t.Errorf("email %d: expected Message-ID to differ from thread root %q", i, threadID)
+		}
+	}
+	if first.Get("Subject") != second.Get("Subject") {
+		t.Errorf("expected same subject for emails of one issue, got: %q and %q", first.Get("Subject"), second.Get("Subject"))
+	}
+}
+
+func TestEmailPayload(t *testing.T) {
+	type testCase struct {
+		name            string
+		message         string
+		expectedMessage port.EmailMessage
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Email_Message_JSON",
+			message:         `{"subject":"Тема","thread":"PROJ-1","text":"Текст","html":"<p>Текст</p>"}`,
+			expectedMessage: port.EmailMessage{Subject: "Тема", Thread: "PROJ-1", Text: "Текст", HTML: "<p>Текст</p>"},
+		},
+		{
+			name:            "Email_Message_JSON_Without_Subject",
+			message:         `{"text":"Текст"}`,
+			expectedMessage: port.EmailMessage{Subject: emailDefaultSubject, Text: "Текст"},
+		},
+		{
+			name:            "Plain_Text",
+			message:         "Проект: Demo",
+			expectedMessage: port.EmailMessage{Subject: emailDefaultSubject, Text: "Проект: Demo"},
+		},
+		{
+			name:            "Other_JSON",
+			message:         `{"content":"text"}`,
+			expectedMessage: port.EmailMessage{Subject: emailDefaultSubject, Text: `{"content":"text"}`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expectedMessage, emailPayload(tc.message)); diff != "" {
+				t.Errorf("message mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoginAuth(t *testing.T) {
+	auth := &loginAuth{username: "user", password: "secret", host: "smtp.example.com"}
+
+	if _, _, err := auth.Start(&smtp.ServerInfo{Name: "smtp.example.com"}); err == nil {
+		t.Error("expected error for unencrypted connection to remote server")
+	}
+	if _, _, err := auth.Start(&smtp.ServerInfo{Name: "other.example.com", TLS: true}); err == nil {
+		t.Error("expected error for wrong host name")
+	}
+	if proto, _, err := auth.Start(&smtp.ServerInfo{Name: "smtp.example.com", TLS: true}); err != nil || proto != "LOGIN" {
+		t.Errorf("expected LOGIN auth, got: %q (%v)", proto, err)
+	}
+
+	answers := map[string]string{"Username:": "user", "Password:": "secret"}
+	for challenge, expected := range answers {
+		answer, err := auth.Next([]byte(challenge), true)
+		if err != nil || string(answer) != expected {
+			t.Errorf("expected answer %q to %q, got: %q (%v)", expected, challenge, answer, err)
+		}
+	}
+	if _, err := auth.Next([]byte("Unknown:"), true); err == nil {
+		t.Error("expected error for unknown challenge")
+	}
+}
+
+func TestEmailChannel_Channel(t *testing.T) {
+	channel := NewEmailChannel(config.EmailConfig{}, logrus.New())
+
+	if name := channel.Channel(); name != port.ChannelEmail {
+		t.Errorf("expected channel name %q, got: %q", port.ChannelEmail, name)
+	}
+}
@@ -0,0 +1,77 @@
+package channel
+
+import (
+	"errors"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"io"
+	"net"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// newTransportError создает временную ошибку доставки для запроса, на который не получен ответ
+func newTransportError(channel string, err error) *port.DeliveryError {
+	return port.NewDeliveryError(channel, 0, fmt.Errorf("failed to send message: %w", err))
+}
+
+// newStatusError создает ошибку доставки по неуспешному HTTP ответу API канала
+// Значение заголовка Retry-After (в секундах) сохраняется как рекомендуемая задержка повтора
+func newStatusError(channel string, resp *http.Response, body []byte) *port.DeliveryError {
+	deliveryErr := port.NewDeliveryError(channel, resp.StatusCode,
+		fmt.Errorf("%s API error: status %d, response: %s", channel, resp.StatusCode, string(body)))
+	deliveryErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+
+	return deliveryErr
+}
+
+// redactURLError заменяет URL запроса в ошибке HTTP клиента на safeURL
+// URL webhook и параметры запроса содержат секреты канала, которые не должны попадать в логи и ошибки доставки
+func redactURLError(err error, safeURL string) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+	return &url.Error{Op: urlErr.Op, URL: safeURL, Err: urlErr.Err}
+}
+
+// urlOrigin возвращает схему и хост URL без учетных данных, пути и параметров, в которых передается секрет webhook
+func urlOrigin(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
+
+// parseRetryAfter разбирает значение заголовка Retry-After, заданное в секундах
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// newSMTPError создает ошибку доставки по ответу SMTP сервера
+// Коды 4xx означают временный отказ, коды 5xx - постоянный. Обрыв соединения считается временной ошибкой,
+// остальные ошибки (TLS, авторизация без шифрования) - ошибками настройки, повтор которых бессмысленен
+func newSMTPError(err error) error {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		deliveryErr := port.NewDeliveryError(port.ChannelEmail, protoErr.Code, fmt.Errorf("email SMTP error: %d %s", protoErr.Code, protoErr.Msg))
+		deliveryErr.Retryable = protoErr.Code >= 400 && protoErr.Code < 500
+		return deliveryErr
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return newTransportError(port.ChannelEmail, err)
+	}
+
+	return fmt.Errorf("email delivery failed: %w", err)
+}
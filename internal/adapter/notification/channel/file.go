@@ -0,0 +1,323 @@
+package channel
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Формат времени ротации в имени ротированного файла архива
+const fileRotatedTimeLayout = "20060102T150405.000"
+
+// fileRecord описывает одну строку архива уведомлений
+type fileRecord struct {
+	Timestamp time.Time       `json:"timestamp"`
+	EventID   string          `json:"event_id,omitempty"`
+	Project   string          `json:"project"`
+	Issue     string          `json:"issue,omitempty"`
+	Channel   string          `json:"channel"`
+	Chat      string          `json:"chat"`
+	Text      string          `json:"text"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// FileChannel реализует канал архива уведомлений: каждое уведомление дописывается в файл строкой JSON (JSON Lines)
+// Архив хранится отдельно от логов приложения. Строка записывается одной операцией под мьютексом,
+// поэтому записи параллельных отправок не перемешиваются. Файл ротируется по размеру и по времени,
+// ротированные файлы при необходимости сжимаются gzip в фоне
+type FileChannel struct {
+	path             string
+	maxSize          int64
+	rotationInterval time.Duration
+	compress         bool
+	fsync            string
+	fsyncInterval    time.Duration
+	logger           *logrus.Logger
+	now              func() time.Time
+
+	mu          sync.Mutex
+	file        *os.File
+	size        int64
+	lastWriteAt time.Time // Время последней записи в текущий файл, определяет его период ротации
+	syncedAt    time.Time
+	closed      bool
+	compressing sync.WaitGroup
+}
+
+// NewFileChannel открывает файл архива для дозаписи, создавая каталог при необходимости
+// Возвращает ошибку, если файл не удалось открыть. Файл нужно закрыть методом Close после остановки доставки
+func NewFileChannel(cfg config.FileConfig, logger *logrus.Logger) (*FileChannel, error) {
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create file channel directory for %s: %w", cfg.Path, err)
+	}
+
+	c := &FileChannel{
+		path:             cfg.Path,
+		maxSize:          int64(cfg.MaxSize) * 1024 * 1024,
+		rotationInterval: time.Duration(cfg.RotationInterval) * time.Second,
+		compress:         cfg.Compress,
+		fsync:            cfg.Fsync,
+		fsyncInterval:    time.Duration(cfg.FsyncInterval) * time.Second,
+		logger:           logger,
+		now:              time.Now,
+	}
+
+	if err := c.open(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Send дописывает уведомление в архив
+// formattedMessage - запись архива в JSON, текст в другом формате записывается без проекта и payload
+func (c *FileChannel) Send(ctx context.Context, chatID string, formattedMessage string) error {
+	record := fileRecord{
+		EventID: port.EventIDFromContext(ctx),
+		Channel: port.ChannelFile,
+		Chat:    chatID,
+		Text:    formattedMessage,
+	}
+	var message port.FileMessage
+	if json.Unmarshal([]byte(formattedMessage), &message) == nil && message.Payload != nil {
+		record.Project = message.Project
+		record.Issue = message.Issue
+		record.Text = message.Text
+		record.Payload = message.Payload
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return fmt.Errorf("file channel is closed")
+	}
+
+	now := c.now()
+	record.Timestamp = now.UTC()
+	line, err := json.Marshal(record)
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to marshal file archive record")
+		return fmt.Errorf("failed to marshal record: %w", err)
+	}
+	line = append(line, '\n')
+
+	if c.file != nil && c.shouldRotate(now, len(line)) {
+		c.rotate(now)
+	}
+	if c.file == nil {
+		// Файл не удалось открыть после ротации, повторяем попытку при каждой записи
+		if err := c.open(); err != nil {
+			c.logger.WithError(err).WithField("path", c.path).Error("Failed to open file archive")
+			return err
+		}
+	}
+
+	n, err := c.file.Write(line)
+	c.size += int64(n)
+	if err != nil {
+		c.logger.WithError(err).WithField("path", c.path).Error("Failed to write file archive record")
+		return fmt.Errorf("failed to write file archive %s: %w", c.path, err)
+	}
+	c.lastWriteAt = now
+
+	if c.fsync == config.FileFsyncAlways || (c.fsync == config.FileFsyncInterval && now.Sub(c.syncedAt) >= c.fsyncInterval) {
+		if err := c.file.Sync(); err != nil {
+			c.logger.WithError(err).WithField("path", c.path).Error("Failed to sync file archive")
+			return fmt.Errorf("failed to sync file archive %s: %w", c.path, err)
+		}
+		c.syncedAt = now
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"path":     c.path,
+		"event_id": record.EventID,
+	}).Info("Notification sent via file channel")
+
+	return nil
+}
+
+// Channel возвращает название канала
+func (c *FileChannel) Channel() string {
+	return port.ChannelFile
+}
+
+// Close сбрасывает архив на диск и закрывает файл, затем ожидает завершения сжатия ротированных файлов
+// После закрытия Send возвращает ошибку
+func (c *FileChannel) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	err := c.closeFile()
+	c.mu.Unlock()
+
+	c.compressing.Wait()
+
+	return err
+}
+
+// open открывает файл архива для дозаписи
+// Размер и время изменения существующего файла учитываются при ротации, поэтому перезапуск не сбрасывает ее период
+func (c *FileChannel) open() error {
+	file, err := os.OpenFile(c.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to open file archive %s: %w", c.path, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat file archive %s: %w", c.path, err)
+	}
+
+	c.file = file
+	c.size = info.Size()
+	c.lastWriteAt = info.ModTime()
+	c.syncedAt = c.now()
+
+	return nil
+}
+
+// closeFile сбрасывает и закрывает текущий файл архива
+func (c *FileChannel) closeFile() error {
+	if c.file == nil {
+		return nil
+	}
+
+	err := errors.Join(c.file.Sync(), c.file.Close())
+	c.file = nil
+
+	return err
+}
+
+// shouldRotate проверяет, нужно ли начать новый файл перед записью строки размером size
+// Пустой файл не ротируется. Период ротации отсчитывается от нулевого времени, поэтому интервалы,
+// на которые делятся сутки, начинаются на границах суток UTC (например, ежедневная ротация в 00:00 UTC)
+func (c *FileChannel) shouldRotate(now time.Time, size int) bool {
+	if c.size == 0 {
+		return false
+	}
+	if c.maxSize > 0 && c.size+int64(size) > c.maxSize {
+		return true
+	}
+
+	return c.rotationInterval > 0 && !now.Truncate(c.rotationInterval).Equal(c.lastWriteAt.Truncate(c.rotationInterval))
+}
+
+// rotate переименовывает текущий файл архива в файл с временем ротации в имени и открывает новый файл
+// Ошибки ротации не прерывают запись: если файл не удалось переименовать, запись продолжается в текущий файл,
+// если не удалось открыть новый файл, Send повторяет попытку открыть его при следующей записи
+func (c *FileChannel) rotate(now time.Time) {
+	if err := c.closeFile(); err != nil {
+		c.logger.WithError(err).WithField("path", c.path).Warn("Failed to close file archive before rotation")
+	}
+
+	rotatedPath := c.rotatedPath(now)
+	if err := os.Rename(c.path, rotatedPath); err != nil {
+		c.logger.WithError(err).WithField("path", c.path).Error("Failed to rotate file archive")
+		if errOpen := c.open(); errOpen != nil {
+			c.logger.WithError(errOpen).WithField("path", c.path).Error("Failed to reopen file archive")
+		}
+		return
+	}
+
+	// Файл уже переименован, поэтому он сжимается, даже если новый файл не удалось открыть
+	if c.compress {
+		c.compressing.Add(1)
+		go c.compressRotated(rotatedPath)
+	}
+
+	if err := c.open(); err != nil {
+		c.logger.WithError(err).WithField("path", c.path).Error("Failed to open file archive after rotation")
+		return
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"path":    c.path,
+		"rotated": rotatedPath,
+	}).Info("File archive rotated")
+}
+
+// rotatedPath возвращает свободное имя ротированного файла: имя архива с временем ротации перед расширением
+func (c *FileChannel) rotatedPath(now time.Time) string {
+	ext := filepath.Ext(c.path)
+	base := strings.TrimSuffix(c.path, ext) + "-" + now.UTC().Format(fileRotatedTimeLayout)
+
+	path := base + ext
+	for i := 1; fileExists(path) || fileExists(path+".gz"); i++ {
+		path = base + "-" + strconv.Itoa(i) + ext
+	}
+
+	return path
+}
+
+// compressRotated сжимает ротированный файл gzip и удаляет исходный файл
+// Если сжать не удалось, исходный файл остается без изменений
+func (c *FileChannel) compressRotated(path string) {
+	defer c.compressing.Done()
+
+	if err := gzipFile(path); err != nil {
+		c.logger.WithError(err).WithField("path", path).Error("Failed to compress rotated file archive")
+		return
+	}
+
+	c.logger.WithField("path", path+".gz").Debug("Rotated file archive compressed")
+}
+
+// gzipFile сжимает файл в path.gz через временный файл и удаляет исходный файл
+func gzipFile(path string) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	tmpPath := path + ".gz.tmp"
+	dst, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = dst.Close()
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	zw := gzip.NewWriter(dst)
+	zw.Name = filepath.Base(path)
+	if _, err = io.Copy(zw, src); err != nil {
+		return err
+	}
+	if err = zw.Close(); err != nil {
+		return err
+	}
+	if err = dst.Sync(); err != nil {
+		return err
+	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpPath, path+".gz"); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+// fileExists проверяет, существует ли файл
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,410 @@
+package channel
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestFileChannel(t *testing.T, cfg config.FileConfig) *FileChannel {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	if cfg.Path == "" {
+		cfg.Path = filepath.Join(t.TempDir(), "archive", "notifications.jsonl")
+	}
+	if cfg.Fsync == "" {
+		cfg.Fsync = config.FileFsyncAlways
+	}
+
+	channel, err := NewFileChannel(cfg, logger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = channel.Close() })
+
+	return channel
+}
+
+func readFileRecords(t *testing.T, path string) []fileRecord {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open archive: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			t.Fatalf("failed to open gzip archive: %v", err)
+		}
+		reader = gzipReader
+	}
+
+	var records []fileRecord
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		var record fileRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid archive line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("failed to read archive: %v", err)
+	}
+
+	return records
+}
+
+func TestFileChannel_Send(t *testing.T) {
+	type testCase struct {
+		name            string
+		eventID         string
+		chatID          string
+		message         string
+		expectedProject string
+		expectedIssue   string
+		expectedText    string
+		expectedPayload string
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Send_File_Message",
+			eventID:         "event-1",
+			message:         `{"project":"DEMO","issue":"DEMO-1","text":"Проект: Demo","payload":{"issue":{"idReadable":"DEMO-1"}}}`,
+			expectedProject: "DEMO",
+			expectedIssue:   "DEMO-1",
+			expectedText:    "Проект: Demo",
+			expectedPayload: `{"issue":{"idReadable":"DEMO-1"}}`,
+		},
+		{
+			name:            "Send_Plain_Text",
+			chatID:          "archive",
+			message:         "Plain text",
+			expectedText:    "Plain text",
+			expectedPayload: "null",
+		},
+		{
+			name:            "Send_JSON_Without_Payload",
+			message:         `{"text":"not a file message"}`,
+			expectedText:    `{"text":"not a file message"}`,
+			expectedPayload: "null",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			channel := newTestFileChannel(t, config.FileConfig{})
+			channel.now = func() time.Time { return time.Date(2026, 3, 1, 12, 30, 0, 0, time.FixedZone("MSK", 3*60*60)) }
+
+			ctx := context.Background()
+			if tc.eventID != "" {
+				ctx = port.ContextWithEventID(ctx, tc.eventID)
+			}
+
+			if err := channel.Send(ctx, tc.chatID, tc.message); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			records := readFileRecords(t, channel.path)
+			if len(records) != 1 {
+				t.Fatalf("expected 1 record, got: %d", len(records))
+			}
+			record := records[0]
+
+			if expected := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC); !record.Timestamp.Equal(expected) || record.Timestamp.Location() != time.UTC {
+				t.Errorf("expected timestamp %v, got: %v", expected, record.Timestamp)
+			}
+			if record.EventID != tc.eventID {
+				t.Errorf("expected event ID %q, got: %q", tc.eventID, record.EventID)
+			}
+			if record.Channel != port.ChannelFile {
+				t.Errorf("expected channel %q, got: %q", port.ChannelFile, record.Channel)
+			}
+			if record.Chat != tc.chatID {
+				t.Errorf("expected chat %q, got: %q", tc.chatID, record.Chat)
+			}
+			if record.Project != tc.expectedProject {
+				t.Errorf("expected project %q, got: %q", tc.expectedProject, record.Project)
+			}
+			if record.Issue != tc.expectedIssue {
+				t.Errorf("expected issue %q, got: %q", tc.expectedIssue, record.Issue)
+			}
+			if record.Text != tc.expectedText {
+				t.Errorf("expected text %q, got: %q", tc.expectedText, record.Text)
+			}
+			if string(record.Payload) != tc.expectedPayload {
+				t.Errorf("expected payload %s, got: %s", tc.expectedPayload, string(record.Payload))
+			}
+		})
+	}
+}
+
+func TestFileChannel_Send_Appends_To_Existing_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.jsonl")
+	if err := os.WriteFile(path, []byte(`{"text":"previous"}`+"\n"), 0o640); err != nil {
+		t.Fatalf("failed to prepare archive: %v", err)
+	}
+
+	channel := newTestFileChannel(t, config.FileConfig{Path: path})
+	if err := channel.Send(context.Background(), "", "next"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := readFileRecords(t, path)
+	if len(records) != 2 || records[0].Text != "previous" || records[1].Text != "next" {
+		t.Fatalf("expected previous and next records, got: %+v", records)
+	}
+}
+
+func TestFileChannel_Send_Concurrent(t *testing.T) {
+	channel := newTestFileChannel(t, config.FileConfig{Fsync: config.FileFsyncNever})
+
+	const writers = 20
+	const messagesPerWriter = 25
+	longText := strings.Repeat("x", 8*1024)
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < messagesPerWriter; i++ {
+				if err := channel.Send(context.Background(), "", fmt.Sprintf("%d-%d %s", w, i, longText)); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	records := readFileRecords(t, channel.path)
+	if len(records) != writers*messagesPerWriter {
+		t.Fatalf("expected %d records, got: %d", writers*messagesPerWriter, len(records))
+	}
+}
+
+func TestFileChannel_Fsync(t *testing.T) {
+	type testCase struct {
+		name           string
+		fsync          string
+		writeAfter     time.Duration
+		expectedSynced time.Duration
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Fsync_Always_Syncs_Every_Write",
+			fsync:          config.FileFsyncAlways,
+			writeAfter:     100 * time.Millisecond,
+			expectedSynced: 100 * time.Millisecond,
+		},
+		{
+			name:           "Fsync_Interval_Skips_Sync_Within_Interval",
+			fsync:          config.FileFsyncInterval,
+			writeAfter:     500 * time.Millisecond,
+			expectedSynced: 0,
+		},
+		{
+			name:           "Fsync_Interval_Syncs_After_Interval",
+			fsync:          config.FileFsyncInterval,
+			writeAfter:     2 * time.Second,
+			expectedSynced: 2 * time.Second,
+		},
+		{
+			name:           "Fsync_Never_Skips_Sync",
+			fsync:          config.FileFsyncNever,
+			writeAfter:     time.Hour,
+			expectedSynced: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+			channel := newTestFileChannel(t, config.FileConfig{Fsync: tc.fsync, FsyncInterval: 1})
+			channel.syncedAt = start
+			channel.now = func() time.Time { return start.Add(tc.writeAfter) }
+
+			if err := channel.Send(context.Background(), "", "message"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if synced := channel.syncedAt.Sub(start); synced != tc.expectedSynced {
+				t.Errorf("expected sync after %v, got: %v", tc.expectedSynced, synced)
+			}
+		})
+	}
+}
+
+func TestFileChannel_Rotation(t *testing.T) {
+	type testCase struct {
+		name             string
+		cfg              config.FileConfig
+		maxSize          int64
+		writes           []time.Duration
+		expectedRotated  int
+		expectedCurrent  int
+		expectedArchived int
+	}
+
+	testCases := []testCase{
+		{
+			name:             "Rotate_By_Size",
+			maxSize:          250,
+			writes:           []time.Duration{0, time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second},
+			expectedRotated:  2,
+			expectedCurrent:  1,
+			expectedArchived: 4,
+		},
+		{
+			name:             "Rotate_By_Interval",
+			cfg:              config.FileConfig{RotationInterval: 3600},
+			writes:           []time.Duration{0, 30 * time.Minute, 90 * time.Minute, 100 * time.Minute, 5 * time.Hour},
+			expectedRotated:  2,
+			expectedCurrent:  1,
+			expectedArchived: 4,
+		},
+		{
+			name:             "Rotate_By_Interval_With_Compression",
+			cfg:              config.FileConfig{RotationInterval: 3600, Compress: true},
+			writes:           []time.Duration{0, 90 * time.Minute, 5 * time.Hour},
+			expectedRotated:  2,
+			expectedCurrent:  1,
+			expectedArchived: 2,
+		},
+		{
+			name:            "No_Rotation_Without_Limits",
+			writes:          []time.Duration{0, 24 * time.Hour, 48 * time.Hour},
+			expectedCurrent: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			tc.cfg.Path = filepath.Join(dir, "notifications.jsonl")
+
+			channel := newTestFileChannel(t, tc.cfg)
+			if tc.maxSize > 0 {
+				channel.maxSize = tc.maxSize
+			}
+
+			start := time.Date(2026, 3, 1, 10, 15, 0, 0, time.UTC)
+			for i, offset := range tc.writes {
+				now := start.Add(offset)
+				channel.now = func() time.Time { return now }
+				message := fmt.Sprintf(`{"project":"DEMO","text":"message %d","payload":{"n":%d}}`, i, i)
+				if err := channel.Send(context.Background(), "", message); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if err := channel.Close(); err != nil {
+				t.Fatalf("unexpected close error: %v", err)
+			}
+
+			if current := readFileRecords(t, tc.cfg.Path); len(current) != tc.expectedCurrent {
+				t.Errorf("expected %d records in current file, got: %d", tc.expectedCurrent, len(current))
+			}
+
+			rotated, err := filepath.Glob(filepath.Join(dir, "notifications-*"))
+			if err != nil {
+				t.Fatalf("unexpected glob error: %v", err)
+			}
+			sort.Strings(rotated)
+			if len(rotated) != tc.expectedRotated {
+				t.Fatalf("expected %d rotated files, got: %v", tc.expectedRotated, rotated)
+			}
+
+			archived := 0
+			for _, path := range rotated {
+				if tc.cfg.Compress != strings.HasSuffix(path, ".jsonl.gz") {
+					t.Errorf("unexpected rotated file name %s", path)
+				}
+				archived += len(readFileRecords(t, path))
+			}
+			if archived != tc.expectedArchived {
+				t.Errorf("expected %d records in rotated files, got: %d", tc.expectedArchived, archived)
+			}
+		})
+	}
+}
+
+func TestFileChannel_RotatedPath_Avoids_Existing_Files(t *testing.T) {
+	dir := t.TempDir()
+	channel := newTestFileChannel(t, config.FileConfig{Path: filepath.Join(dir, "notifications.jsonl")})
+
+	now := time.Date(2026, 3, 1, 10, 15, 0, 0, time.UTC)
+	first := channel.rotatedPath(now)
+	if expected := filepath.Join(dir, "notifications-20260301T101500.000.jsonl"); first != expected {
+		t.Fatalf("expected rotated path %s, got: %s", expected, first)
+	}
+
+	if err := os.WriteFile(first+".gz", nil, 0o640); err != nil {
+		t.Fatalf("failed to prepare rotated file: %v", err)
+	}
+	if second := channel.rotatedPath(now); second != filepath.Join(dir, "notifications-20260301T101500.000-1.jsonl") {
+		t.Errorf("expected numbered rotated path, got: %s", second)
+	}
+}
+
+func TestFileChannel_Send_After_Close(t *testing.T) {
+	channel := newTestFileChannel(t, config.FileConfig{})
+	if err := channel.Close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+
+	err := channel.Send(context.Background(), "", "message")
+	if err == nil || !strings.Contains(err.Error(), "file channel is closed") {
+		t.Fatalf("expected closed channel error, got: %v", err)
+	}
+}
+
+func TestNewFileChannel_Error(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "blocker")
+	if err := os.WriteFile(blocker, nil, 0o640); err != nil {
+		t.Fatalf("failed to prepare file: %v", err)
+	}
+
+	_, err := NewFileChannel(config.FileConfig{Path: filepath.Join(blocker, "notifications.jsonl")}, logger)
+	if err == nil || !strings.Contains(err.Error(), "failed to create file channel directory") {
+		t.Fatalf("expected directory error, got: %v", err)
+	}
+
+	_, err = NewFileChannel(config.FileConfig{Path: dir}, logger)
+	if err == nil || !strings.Contains(err.Error(), "failed to open file archive") {
+		t.Fatalf("expected open error, got: %v", err)
+	}
+}
+
+func TestFileChannel_Channel(t *testing.T) {
+	channel := newTestFileChannel(t, config.FileConfig{})
+
+	if name := channel.Channel(); name != port.ChannelFile {
+		t.Errorf("expected channel %q, got: %q", port.ChannelFile, name)
+	}
+}
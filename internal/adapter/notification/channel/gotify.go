@@ -0,0 +1,151 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/adapter/ratelimit"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Путь REST API Gotify для отправки сообщения, токен приложения передается в параметре token
+const gotifyMessagePath = "/message"
+
+// Приоритеты сообщения Gotify (0-10) для приоритетов push уведомления
+// Клиент Gotify показывает сообщения с приоритетом от 4 со звуком, от 8 - поверх других приложений
+var gotifyPriorities = map[int]int{
+	port.PushPriorityMin:     0,
+	port.PushPriorityLow:     2,
+	port.PushPriorityDefault: 5,
+	port.PushPriorityHigh:    7,
+	port.PushPriorityMax:     10,
+}
+
+// gotifyMessage описывает тело запроса на отправку сообщения Gotify
+type gotifyMessage struct {
+	Title    string         `json:"title"`
+	Message  string         `json:"message"`
+	Priority int            `json:"priority"`
+	Extras   map[string]any `json:"extras,omitempty"`
+}
+
+// GotifyChannel реализует канал отправки push уведомлений через сервер Gotify
+// Адресат - токен приложения Gotify, поэтому адресат не пишется в лог
+type GotifyChannel struct {
+	serverURL string
+	client    port.HTTPClient
+	limiter   *ratelimit.Limiter
+	logger    *logrus.Logger
+}
+
+// NewGotifyChannel создает новый канал Gotify
+func NewGotifyChannel(cfg config.GotifyConfig, logger *logrus.Logger, httpClient port.HTTPClient) port.NotificationChannel {
+	return &GotifyChannel{
+		serverURL: strings.TrimSuffix(cfg.ServerURL, "/"),
+		client:    httpClient,
+		limiter:   newRateLimiter(cfg.RateLimit, nil),
+		logger:    logger,
+	}
+}
+
+// Send отправляет push уведомление от имени приложения Gotify
+// formattedMessage - JSON уведомления (port.PushMessage), ссылка на задачу передается в extras
+// client::notification, поэтому нажатие на уведомление в клиенте Gotify открывает задачу
+func (c *GotifyChannel) Send(ctx context.Context, chatID string, formattedMessage string) error {
+	if chatID == "" {
+		return fmt.Errorf("gotify application token is not configured")
+	}
+	if c.serverURL == "" {
+		return fmt.Errorf("gotify server URL is not configured")
+	}
+
+	message := pushPayload(formattedMessage)
+
+	jsonData, err := json.Marshal(newGotifyMessage(message))
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to marshal Gotify message")
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	apiURL := c.serverURL + gotifyMessagePath + "?" + url.Values{"token": {chatID}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewReader(jsonData))
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to create Gotify request")
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	waited, err := c.limiter.Wait(ctx, chatID)
+	if err != nil {
+		return fmt.Errorf("gotify rate limit wait interrupted: %w", err)
+	}
+	if waited > 0 {
+		c.logger.WithField("delay", waited.String()).Debug("Gotify rate limit reached, message delayed")
+	}
+
+	resp, errSend := c.client.Do(req)
+	if errSend != nil {
+		errSend = redactURLError(errSend, c.serverURL+gotifyMessagePath)
+		c.logger.WithError(errSend).Error("Failed to send Gotify message")
+		return newTransportError(port.ChannelGotify, errSend)
+	}
+	defer func(Body io.ReadCloser) {
+		if closeErr := Body.Close(); closeErr != nil {
+			c.logger.WithError(closeErr).Error("Failed to close request body")
+		}
+	}(resp.Body)
+
+	body, errRead := io.ReadAll(resp.Body)
+	if errRead != nil {
+		c.logger.WithError(errRead).Warn("Failed to read Gotify response body")
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		c.logger.WithFields(logrus.Fields{
+			"status_code": resp.StatusCode,
+			"response":    string(body),
+		}).Error("Gotify server returned error")
+		deliveryErr := newStatusError(port.ChannelGotify, resp, body)
+		if deliveryErr.RetryAfter > 0 {
+			c.limiter.Block(chatID, deliveryErr.RetryAfter)
+		}
+		return deliveryErr
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"priority": gotifyPriorities[message.Priority],
+		"status":   resp.StatusCode,
+	}).Info("Notification sent via Gotify channel")
+
+	return nil
+}
+
+// Channel возвращает название канала
+func (c *GotifyChannel) Channel() string {
+	return port.ChannelGotify
+}
+
+// newGotifyMessage возвращает тело запроса Gotify для push уведомления
+func newGotifyMessage(message port.PushMessage) gotifyMessage {
+	result := gotifyMessage{
+		Title:    message.Title,
+		Message:  message.Message,
+		Priority: gotifyPriorities[message.Priority],
+	}
+	if message.Click != "" {
+		result.Extras = map[string]any{
+			"client::notification": map[string]any{
+				"click": map[string]string{"url": message.Click},
+			},
+		}
+	}
+	return result
+}
@@ -0,0 +1,174 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGotifyChannel_Send(t *testing.T) {
+	type testCase struct {
+		name              string
+		cfg               config.GotifyConfig
+		chatID            string
+		message           string
+		responseStatus    int
+		responseBody      string
+		httpError         error
+		expectRequest     bool
+		expectedURL       string
+		expectedBody      string
+		expectedError     string
+		expectedRetryable bool
+		expectedRetry     time.Duration
+	}
+
+	cfg := config.GotifyConfig{ServerURL: "https://gotify.example.com/"}
+	pushMessage := `{"title":"PROJ-1 Fix bug","message":"Состояние: Open","priority":5,"tags":["zap"],"click":"https://youtrack.test/issue/PROJ-1"}`
+
+	testCases := []testCase{
+		{
+			name:           "Send_Push_Message_With_Click",
+			cfg:            cfg,
+			chatID:         "AbCdEf123",
+			message:        pushMessage,
+			responseStatus: http.StatusOK,
+			responseBody:   `{"id":1,"appid":2}`,
+			expectRequest:  true,
+			expectedURL:    "https://gotify.example.com/message?token=AbCdEf123",
+			expectedBody:   `{"title":"PROJ-1 Fix bug","message":"Состояние: Open","priority":10,"extras":{"client::notification":{"click":{"url":"https://youtrack.test/issue/PROJ-1"}}}}`,
+		},
+		{
+			name:           "Send_Plain_Text",
+			cfg:            cfg,
+			chatID:         "AbC+Ef/123",
+			message:        "Plain text",
+			responseStatus: http.StatusOK,
+			expectRequest:  true,
+			expectedURL:    "https://gotify.example.com/message?token=AbC%2BEf%2F123",
+			expectedBody:   `{"title":"YouTrack","message":"Plain text","priority":5}`,
+		},
+		{
+			name:          "Send_Empty_Token",
+			cfg:           cfg,
+			message:       pushMessage,
+			expectedError: "gotify application token is not configured",
+		},
+		{
+			name:          "Send_Without_Server_URL",
+			cfg:           config.GotifyConfig{},
+			chatID:        "AbCdEf123",
+			message:       pushMessage,
+			expectedError: "gotify server URL is not configured",
+		},
+		{
+			name:              "Send_HTTP_Client_Error",
+			cfg:               cfg,
+			chatID:            "AbCdEf123",
+			message:           pushMessage,
+			httpError:         errors.New("network error"),
+			expectRequest:     true,
+			expectedError:     "failed to send message",
+			expectedRetryable: true,
+		},
+		{
+			name:           "Send_Unauthorized",
+			cfg:            cfg,
+			chatID:         "AbCdEf123",
+			message:        pushMessage,
+			responseStatus: http.StatusUnauthorized,
+			responseBody:   `{"error":"Unauthorized","errorCode":401,"errorDescription":"you need to provide a valid access token"}`,
+			expectRequest:  true,
+			expectedError:  "gotify API error: status 401",
+		},
+		{
+			name:              "Send_Server_Error",
+			cfg:               cfg,
+			chatID:            "AbCdEf123",
+			message:           pushMessage,
+			responseStatus:    http.StatusServiceUnavailable,
+			expectRequest:     true,
+			expectedError:     "gotify API error: status 503",
+			expectedRetryable: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			if tc.expectRequest {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodPost {
+						t.Errorf("expected method POST, got: %s", req.Method)
+					}
+					if tc.expectedURL != "" && req.URL.String() != tc.expectedURL {
+						t.Errorf("expected URL %s, got: %s", tc.expectedURL, req.URL.String())
+					}
+					body, _ := io.ReadAll(req.Body)
+					if tc.expectedBody != "" && string(body) != tc.expectedBody {
+						t.Errorf("expected body %s, got: %s", tc.expectedBody, string(body))
+					}
+
+					if tc.httpError != nil {
+						return nil, &url.Error{Op: "Post", URL: req.URL.String(), Err: tc.httpError}
+					}
+					return &http.Response{
+						StatusCode: tc.responseStatus,
+						Header:     http.Header{},
+						Body:       io.NopCloser(strings.NewReader(tc.responseBody)),
+					}, nil
+				})
+			}
+
+			channel := NewGotifyChannel(tc.cfg, logger, mockHTTPClient)
+
+			err := channel.Send(context.Background(), tc.chatID, tc.message)
+
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Fatalf("expected error containing %q, got: %v", tc.expectedError, err)
+			}
+			if tc.chatID != "" && strings.Contains(err.Error(), tc.chatID) {
+				t.Errorf("expected error without application token, got: %v", err)
+			}
+			if retryable := port.IsRetryable(err); retryable != tc.expectedRetryable {
+				t.Errorf("expected retryable %v, got: %v", tc.expectedRetryable, retryable)
+			}
+			if retryAfter := port.RetryAfter(err); retryAfter != tc.expectedRetry {
+				t.Errorf("expected retry after %v, got: %v", tc.expectedRetry, retryAfter)
+			}
+		})
+	}
+}
+
+func TestGotifyChannel_Channel(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	channel := NewGotifyChannel(config.GotifyConfig{}, logger, nil)
+
+	if name := channel.Channel(); name != port.ChannelGotify {
+		t.Errorf("expected channel %q, got: %q", port.ChannelGotify, name)
+	}
+}
@@ -1,6 +1,7 @@
 package channel
 
 import (
+	"context"
 	"github.com/beliaev-aa/notifications/internal/domain/port"
 	"github.com/sirupsen/logrus"
 )
@@ -18,7 +19,7 @@ func NewLoggerChannel(logger *logrus.Logger) port.NotificationChannel {
 }
 
 // Send отправляет уведомление в логи
-func (c *LoggerChannel) Send(_ string, formattedMessage string) error {
+func (c *LoggerChannel) Send(_ context.Context, _ string, formattedMessage string) error {
 	c.logger.WithFields(logrus.Fields{
 		"message": formattedMessage,
 	}).Info("Notification sent via logger channel")
@@ -2,6 +2,7 @@ package channel
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"github.com/beliaev-aa/notifications/internal/domain/port"
 	"github.com/sirupsen/logrus"
@@ -119,7 +120,7 @@ func TestLoggerChannel_Send(t *testing.T) {
 
 			channel := NewLoggerChannel(logger).(*LoggerChannel)
 
-			err := channel.Send("", tc.message)
+			err := channel.Send(context.Background(), "", tc.message)
 
 			if tc.expectedError {
 				if err == nil {
@@ -237,7 +238,7 @@ func TestLoggerChannel_Integration(t *testing.T) {
 				t.Errorf("expected channel name %q, got: %q", port.ChannelLogger, channel.Channel())
 			}
 
-			err := channel.Send("", tc.message)
+			err := channel.Send(context.Background(), "", tc.message)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -303,7 +304,7 @@ func TestLoggerChannel_EdgeCases(t *testing.T) {
 
 			channel := NewLoggerChannel(logger).(*LoggerChannel)
 
-			err := channel.Send("", tc.message)
+			err := channel.Send(context.Background(), "", tc.message)
 
 			if tc.expectedError {
 				if err == nil {
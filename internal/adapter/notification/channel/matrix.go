@@ -0,0 +1,185 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/adapter/ratelimit"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Путь client-server API Matrix для отправки события m.room.message в комнату: ID комнаты и ID транзакции
+const matrixSendPath = "/_matrix/client/v3/rooms/%s/send/m.room.message/%s"
+
+// matrixRateLimitResponse описывает тело ответа homeserver со статусом 429
+type matrixRateLimitResponse struct {
+	ErrCode      string `json:"errcode"`
+	RetryAfterMs int64  `json:"retry_after_ms"`
+}
+
+// MatrixChannel реализует канал отправки уведомлений в комнаты Matrix через client-server API
+// Адресат - ID комнаты, пользователь токена доступа должен состоять в комнате
+type MatrixChannel struct {
+	accessToken   string
+	homeserverURL string
+	client        port.HTTPClient
+	limiter       *ratelimit.Limiter
+	logger        *logrus.Logger
+}
+
+// NewMatrixChannel создает новый канал Matrix
+func NewMatrixChannel(cfg config.MatrixConfig, logger *logrus.Logger, httpClient port.HTTPClient) port.NotificationChannel {
+	return &MatrixChannel{
+		accessToken:   cfg.AccessToken,
+		homeserverURL: strings.TrimSuffix(cfg.HomeserverURL, "/"),
+		client:        httpClient,
+		limiter:       newRateLimiter(cfg.RateLimit, nil),
+		logger:        logger,
+	}
+}
+
+// Send отправляет уведомление в комнату Matrix
+// formattedMessage - JSON содержимого события m.room.message, текст в другом формате отправляется как обычное сообщение
+// ID транзакции зависит от события и комнаты, поэтому homeserver не создает дубликат при повторной отправке
+func (c *MatrixChannel) Send(ctx context.Context, chatID string, formattedMessage string) error {
+	if chatID == "" {
+		return fmt.Errorf("matrix room ID is not configured")
+	}
+	if c.accessToken == "" {
+		return fmt.Errorf("matrix access token is not configured")
+	}
+	if c.homeserverURL == "" {
+		return fmt.Errorf("matrix homeserver URL is not configured")
+	}
+
+	jsonData, err := json.Marshal(matrixContent(formattedMessage))
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to marshal Matrix message")
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	txnID := matrixTransactionID(port.EventIDFromContext(ctx), chatID, formattedMessage)
+	apiURL := c.homeserverURL + fmt.Sprintf(matrixSendPath, url.PathEscape(chatID), txnID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, apiURL, bytes.NewReader(jsonData))
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to create Matrix request")
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+
+	waited, err := c.limiter.Wait(ctx, chatID)
+	if err != nil {
+		return fmt.Errorf("matrix rate limit wait interrupted: %w", err)
+	}
+	if waited > 0 {
+		c.logger.WithFields(logrus.Fields{
+			"room_id": chatID,
+			"delay":   waited.String(),
+		}).Debug("Matrix rate limit reached, message delayed")
+	}
+
+	resp, errSend := c.client.Do(req)
+	if errSend != nil {
+		c.logger.WithError(errSend).WithField("room_id", chatID).Error("Failed to send Matrix message")
+		return newTransportError(port.ChannelMatrix, errSend)
+	}
+	defer func(Body io.ReadCloser) {
+		if closeErr := Body.Close(); closeErr != nil {
+			c.logger.WithError(closeErr).Error("Failed to close request body")
+		}
+	}(resp.Body)
+
+	body, errRead := io.ReadAll(resp.Body)
+	if errRead != nil {
+		c.logger.WithError(errRead).Warn("Failed to read Matrix response body")
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		c.logger.WithFields(logrus.Fields{
+			"room_id":     chatID,
+			"status_code": resp.StatusCode,
+			"response":    string(body),
+		}).Error("Matrix API returned error")
+		deliveryErr := newStatusError(port.ChannelMatrix, resp, body)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			if retryAfter := parseMatrixRetryAfter(body); retryAfter > 0 {
+				deliveryErr.RetryAfter = retryAfter
+				c.logger.WithFields(logrus.Fields{
+					"room_id":     chatID,
+					"retry_after": retryAfter.String(),
+				}).Warn("Matrix rate limit exceeded")
+			}
+		}
+		if deliveryErr.RetryAfter > 0 {
+			c.limiter.Block(chatID, deliveryErr.RetryAfter)
+		}
+		return deliveryErr
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"room_id":        chatID,
+		"transaction_id": txnID,
+		"status":         resp.StatusCode,
+	}).Info("Notification sent via Matrix channel")
+
+	return nil
+}
+
+// Channel возвращает название канала
+func (c *MatrixChannel) Channel() string {
+	return port.ChannelMatrix
+}
+
+// matrixContent возвращает содержимое события m.room.message
+// Если сообщение не является JSON объектом, оно отправляется как текстовое сообщение m.text
+func matrixContent(formattedMessage string) map[string]json.RawMessage {
+	var content map[string]json.RawMessage
+	if json.Unmarshal([]byte(formattedMessage), &content) == nil && content != nil {
+		return content
+	}
+
+	body, _ := json.Marshal(formattedMessage)
+	return map[string]json.RawMessage{
+		"msgtype": json.RawMessage(`"m.text"`),
+		"body":    body,
+	}
+}
+
+// matrixTransactionID вычисляет ID транзакции отправки события в комнату
+// Homeserver возвращает уже созданное событие для повторного ID транзакции того же токена доступа.
+// ID зависит от идентификатора доставляемого события и комнаты, а без идентификатора события - от текста сообщения
+func matrixTransactionID(eventID string, roomID string, formattedMessage string) string {
+	hash := sha256.New()
+	if eventID != "" {
+		hash.Write([]byte("event\x00" + eventID))
+	} else {
+		hash.Write([]byte("message\x00" + formattedMessage))
+	}
+	hash.Write([]byte("\x00" + roomID))
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// parseMatrixRetryAfter извлекает задержку перед повтором из тела ответа homeserver со статусом 429
+// Homeserver указывает задержку в миллисекундах в retry_after_ms вместе с кодом ошибки M_LIMIT_EXCEEDED
+func parseMatrixRetryAfter(body []byte) time.Duration {
+	var rateLimit matrixRateLimitResponse
+	if err := json.Unmarshal(body, &rateLimit); err != nil || rateLimit.RetryAfterMs <= 0 {
+		return 0
+	}
+
+	return time.Duration(rateLimit.RetryAfterMs) * time.Millisecond
+}
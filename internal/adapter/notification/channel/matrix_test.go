@@ -0,0 +1,256 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMatrixChannel_Send(t *testing.T) {
+	type testCase struct {
+		name              string
+		cfg               config.MatrixConfig
+		chatID            string
+		message           string
+		eventID           string
+		responseStatus    int
+		responseBody      string
+		httpError         error
+		expectRequest     bool
+		expectedURL       string
+		expectedBody      string
+		expectedError     string
+		expectedRetryable bool
+		expectedRetry     time.Duration
+	}
+
+	cfg := config.MatrixConfig{AccessToken: "syt_token", HomeserverURL: "https://matrix.example.com/"}
+	htmlMessage := `{"msgtype":"m.text","body":"Задача","format":"org.matrix.custom.html","formatted_body":"<b>Задача</b>","m.mentions":{}}`
+	sendURL := "https://matrix.example.com/_matrix/client/v3/rooms/%21abc:example.com/send/m.room.message/"
+
+	testCases := []testCase{
+		{
+			name:           "Send_HTML_Message_With_Event_ID",
+			cfg:            cfg,
+			chatID:         "!abc:example.com",
+			message:        htmlMessage,
+			eventID:        "event-1",
+			responseStatus: http.StatusOK,
+			responseBody:   `{"event_id":"$event"}`,
+			expectRequest:  true,
+			expectedURL:    sendURL + matrixTransactionID("event-1", "!abc:example.com", ""),
+			expectedBody:   `{"body":"Задача","format":"org.matrix.custom.html","formatted_body":"\u003cb\u003eЗадача\u003c/b\u003e","m.mentions":{},"msgtype":"m.text"}`,
+		},
+		{
+			name:           "Send_Plain_Text_Without_Event_ID",
+			cfg:            cfg,
+			chatID:         "!abc:example.com",
+			message:        "Plain text",
+			responseStatus: http.StatusOK,
+			expectRequest:  true,
+			expectedURL:    sendURL + matrixTransactionID("", "!abc:example.com", "Plain text"),
+			expectedBody:   `{"body":"Plain text","msgtype":"m.text"}`,
+		},
+		{
+			name:          "Send_Empty_Room_ID",
+			cfg:           cfg,
+			message:       htmlMessage,
+			expectedError: "matrix room ID is not configured",
+		},
+		{
+			name:          "Send_Without_Access_Token",
+			cfg:           config.MatrixConfig{HomeserverURL: "https://matrix.example.com"},
+			chatID:        "!abc:example.com",
+			message:       htmlMessage,
+			expectedError: "matrix access token is not configured",
+		},
+		{
+			name:          "Send_Without_Homeserver_URL",
+			cfg:           config.MatrixConfig{AccessToken: "syt_token"},
+			chatID:        "!abc:example.com",
+			message:       htmlMessage,
+			expectedError: "matrix homeserver URL is not configured",
+		},
+		{
+			name:              "Send_HTTP_Client_Error",
+			cfg:               cfg,
+			chatID:            "!abc:example.com",
+			message:           htmlMessage,
+			httpError:         errors.New("network error"),
+			expectRequest:     true,
+			expectedError:     "failed to send message",
+			expectedRetryable: true,
+		},
+		{
+			name:           "Send_Forbidden",
+			cfg:            cfg,
+			chatID:         "!abc:example.com",
+			message:        htmlMessage,
+			responseStatus: http.StatusForbidden,
+			responseBody:   `{"errcode":"M_FORBIDDEN","error":"User not in room"}`,
+			expectRequest:  true,
+			expectedError:  "matrix API error: status 403",
+		},
+		{
+			name:              "Send_Server_Error",
+			cfg:               cfg,
+			chatID:            "!abc:example.com",
+			message:           htmlMessage,
+			responseStatus:    http.StatusBadGateway,
+			expectRequest:     true,
+			expectedError:     "matrix API error: status 502",
+			expectedRetryable: true,
+		},
+		{
+			name:              "Send_Too_Many_Requests",
+			cfg:               cfg,
+			chatID:            "!abc:example.com",
+			message:           htmlMessage,
+			responseStatus:    http.StatusTooManyRequests,
+			responseBody:      `{"errcode":"M_LIMIT_EXCEEDED","error":"Too many requests","retry_after_ms":1500}`,
+			expectRequest:     true,
+			expectedError:     "matrix API error: status 429",
+			expectedRetryable: true,
+			expectedRetry:     1500 * time.Millisecond,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			if tc.expectRequest {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodPut {
+						t.Errorf("expected method PUT, got: %s", req.Method)
+					}
+					if tc.expectedURL != "" && req.URL.String() != tc.expectedURL {
+						t.Errorf("expected URL %s, got: %s", tc.expectedURL, req.URL.String())
+					}
+					if auth := req.Header.Get("Authorization"); auth != "Bearer syt_token" {
+						t.Errorf("expected Authorization header %q, got: %q", "Bearer syt_token", auth)
+					}
+					body, _ := io.ReadAll(req.Body)
+					if tc.expectedBody != "" && string(body) != tc.expectedBody {
+						t.Errorf("expected body %s, got: %s", tc.expectedBody, string(body))
+					}
+
+					if tc.httpError != nil {
+						return nil, tc.httpError
+					}
+					return &http.Response{
+						StatusCode: tc.responseStatus,
+						Header:     http.Header{},
+						Body:       io.NopCloser(strings.NewReader(tc.responseBody)),
+					}, nil
+				})
+			}
+
+			channel := NewMatrixChannel(tc.cfg, logger, mockHTTPClient)
+
+			ctx := context.Background()
+			if tc.eventID != "" {
+				ctx = port.ContextWithEventID(ctx, tc.eventID)
+			}
+
+			err := channel.Send(ctx, tc.chatID, tc.message)
+
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Fatalf("expected error containing %q, got: %v", tc.expectedError, err)
+			}
+			if retryable := port.IsRetryable(err); retryable != tc.expectedRetryable {
+				t.Errorf("expected retryable %v, got: %v", tc.expectedRetryable, retryable)
+			}
+			if retryAfter := port.RetryAfter(err); retryAfter != tc.expectedRetry {
+				t.Errorf("expected retry after %v, got: %v", tc.expectedRetry, retryAfter)
+			}
+		})
+	}
+}
+
+func TestMatrixTransactionID(t *testing.T) {
+	type testCase struct {
+		name          string
+		first         [3]string
+		second        [3]string
+		expectedEqual bool
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Same_Event_And_Room_Retry",
+			first:         [3]string{"event-1", "!abc:example.com", "message"},
+			second:        [3]string{"event-1", "!abc:example.com", "message"},
+			expectedEqual: true,
+		},
+		{
+			name:          "Same_Event_Ignores_Message",
+			first:         [3]string{"event-1", "!abc:example.com", "message"},
+			second:        [3]string{"event-1", "!abc:example.com", "other message"},
+			expectedEqual: true,
+		},
+		{
+			name:   "Different_Events",
+			first:  [3]string{"event-1", "!abc:example.com", "message"},
+			second: [3]string{"event-2", "!abc:example.com", "message"},
+		},
+		{
+			name:   "Different_Rooms",
+			first:  [3]string{"event-1", "!abc:example.com", "message"},
+			second: [3]string{"event-1", "!def:example.com", "message"},
+		},
+		{
+			name:          "Same_Message_Without_Event",
+			first:         [3]string{"", "!abc:example.com", "message"},
+			second:        [3]string{"", "!abc:example.com", "message"},
+			expectedEqual: true,
+		},
+		{
+			name:   "Different_Messages_Without_Event",
+			first:  [3]string{"", "!abc:example.com", "message"},
+			second: [3]string{"", "!abc:example.com", "other message"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			first := matrixTransactionID(tc.first[0], tc.first[1], tc.first[2])
+			second := matrixTransactionID(tc.second[0], tc.second[1], tc.second[2])
+
+			if equal := first == second; equal != tc.expectedEqual {
+				t.Errorf("expected equal %v, got: %q and %q", tc.expectedEqual, first, second)
+			}
+		})
+	}
+}
+
+func TestMatrixChannel_Channel(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	channel := NewMatrixChannel(config.MatrixConfig{}, logger, nil)
+
+	if name := channel.Channel(); name != port.ChannelMatrix {
+		t.Errorf("expected channel %q, got: %q", port.ChannelMatrix, name)
+	}
+}
@@ -0,0 +1,160 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/adapter/ratelimit"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Путь REST API Mattermost для создания сообщения
+const mattermostPostsPath = "/api/v4/posts"
+
+// MattermostChannel реализует канал отправки уведомлений через Mattermost
+// Адресат - URL incoming webhook или ID канала, в который сообщение отправляется через REST API с токеном бота
+type MattermostChannel struct {
+	botToken string
+	apiURL   string
+	client   port.HTTPClient
+	limiter  *ratelimit.Limiter
+	logger   *logrus.Logger
+}
+
+// mattermostPost описывает тело запроса создания сообщения через REST API Mattermost
+type mattermostPost struct {
+	ChannelID string                     `json:"channel_id"`
+	Message   string                     `json:"message"`
+	Props     map[string]json.RawMessage `json:"props,omitempty"`
+}
+
+// NewMattermostChannel создает новый канал Mattermost
+func NewMattermostChannel(cfg config.MattermostConfig, logger *logrus.Logger, httpClient port.HTTPClient) port.NotificationChannel {
+	return &MattermostChannel{
+		botToken: cfg.BotToken,
+		apiURL:   strings.TrimSuffix(cfg.ApiUrl, "/"),
+		client:   httpClient,
+		limiter:  newRateLimiter(cfg.RateLimit, nil),
+		logger:   logger,
+	}
+}
+
+// Send отправляет уведомление в Mattermost
+// formattedMessage - JSON сообщения с вложениями, текст в другом формате отправляется как обычное сообщение
+func (c *MattermostChannel) Send(ctx context.Context, chatID string, formattedMessage string) error {
+	if chatID == "" {
+		return fmt.Errorf("mattermost webhook URL or channel ID is not configured")
+	}
+
+	payload := jsonPayload(formattedMessage)
+
+	target := webhookTarget
+	apiURL := chatID
+	viaWebhook := isWebhookURL(chatID)
+
+	var body interface{} = payload
+	if !viaWebhook {
+		if c.botToken == "" {
+			return fmt.Errorf("mattermost bot token is not configured")
+		}
+		if c.apiURL == "" {
+			return fmt.Errorf("mattermost API URL is not configured")
+		}
+		target = chatID
+		apiURL = c.apiURL + mattermostPostsPath
+		body = mattermostRESTPost(chatID, payload)
+	}
+
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to marshal Mattermost payload")
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		err = redactURLError(err, urlOrigin(apiURL))
+		c.logger.WithError(err).Error("Failed to create Mattermost request")
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if !viaWebhook {
+		req.Header.Set("Authorization", "Bearer "+c.botToken)
+	}
+
+	waited, err := c.limiter.Wait(ctx, chatID)
+	if err != nil {
+		return fmt.Errorf("mattermost rate limit wait interrupted: %w", err)
+	}
+	if waited > 0 {
+		c.logger.WithFields(logrus.Fields{
+			"target": target,
+			"delay":  waited.String(),
+		}).Debug("Mattermost rate limit reached, message delayed")
+	}
+
+	resp, errSend := c.client.Do(req)
+	if errSend != nil {
+		// URL incoming webhook содержит секрет, поэтому в ошибке остается только хост
+		errSend = redactURLError(errSend, urlOrigin(apiURL))
+		c.logger.WithError(errSend).Error("Failed to send Mattermost message")
+		return newTransportError(port.ChannelMattermost, errSend)
+	}
+	defer func(Body io.ReadCloser) {
+		if closeErr := Body.Close(); closeErr != nil {
+			c.logger.WithError(closeErr).Error("Failed to close request body")
+		}
+	}(resp.Body)
+
+	respBody, errRead := io.ReadAll(resp.Body)
+	if errRead != nil {
+		c.logger.WithError(errRead).Warn("Failed to read Mattermost response body")
+	}
+
+	// REST API отвечает статусом 201 Created, incoming webhook - 200 OK
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		c.logger.WithFields(logrus.Fields{
+			"status_code": resp.StatusCode,
+			"response":    string(respBody),
+		}).Error("Mattermost API returned error")
+		deliveryErr := newStatusError(port.ChannelMattermost, resp, respBody)
+		if deliveryErr.RetryAfter > 0 {
+			c.limiter.Block(chatID, deliveryErr.RetryAfter)
+		}
+		return deliveryErr
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"target": target,
+		"status": resp.StatusCode,
+	}).Info("Notification sent via Mattermost channel")
+
+	return nil
+}
+
+// Channel возвращает название канала
+func (c *MattermostChannel) Channel() string {
+	return port.ChannelMattermost
+}
+
+// mattermostRESTPost преобразует сообщение incoming webhook в тело запроса REST API:
+// текст передается в message, вложения - в props.attachments
+func mattermostRESTPost(channelID string, payload map[string]json.RawMessage) mattermostPost {
+	post := mattermostPost{ChannelID: channelID}
+
+	if text, exists := payload["text"]; exists {
+		_ = json.Unmarshal(text, &post.Message)
+	}
+	if attachments, exists := payload["attachments"]; exists {
+		post.Props = map[string]json.RawMessage{"attachments": attachments}
+	}
+
+	return post
+}
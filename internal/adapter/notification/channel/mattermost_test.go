@@ -0,0 +1,211 @@
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMattermostChannel_Send(t *testing.T) {
+	type testCase struct {
+		name              string
+		cfg               config.MattermostConfig
+		chatID            string
+		message           string
+		responseStatus    int
+		responseBody      string
+		responseHeader    http.Header
+		httpError         error
+		expectedURL       string
+		expectedAuth      string
+		expectedPayload   map[string]interface{}
+		expectedError     string
+		expectedRetryable bool
+		expectedRetry     time.Duration
+	}
+
+	webhookURL := "https://mattermost.example.com/hooks/xxx"
+	botConfig := config.MattermostConfig{BotToken: "mm-token", ApiUrl: "https://mattermost.example.com/"}
+	attachmentMessage := `{"text":"#### Задача","attachments":[{"color":"#d32f2f","title":"Задача"}]}`
+	attachments := []interface{}{
+		map[string]interface{}{"color": "#d32f2f", "title": "Задача"},
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Send_Via_Incoming_Webhook",
+			chatID:         webhookURL,
+			message:        attachmentMessage,
+			responseStatus: http.StatusOK,
+			responseBody:   "ok",
+			expectedURL:    webhookURL,
+			expectedPayload: map[string]interface{}{
+				"text":        "#### Задача",
+				"attachments": attachments,
+			},
+		},
+		{
+			name:           "Send_Via_REST_API",
+			cfg:            botConfig,
+			chatID:         "channel-id",
+			message:        attachmentMessage,
+			responseStatus: http.StatusCreated,
+			responseBody:   `{"id": "post-id"}`,
+			expectedURL:    "https://mattermost.example.com/api/v4/posts",
+			expectedAuth:   "Bearer mm-token",
+			expectedPayload: map[string]interface{}{
+				"channel_id": "channel-id",
+				"message":    "#### Задача",
+				"props":      map[string]interface{}{"attachments": attachments},
+			},
+		},
+		{
+			name:           "Send_Plain_Text_Via_REST_API",
+			cfg:            botConfig,
+			chatID:         "channel-id",
+			message:        "Plain text",
+			responseStatus: http.StatusCreated,
+			expectedURL:    "https://mattermost.example.com/api/v4/posts",
+			expectedAuth:   "Bearer mm-token",
+			expectedPayload: map[string]interface{}{
+				"channel_id": "channel-id",
+				"message":    "Plain text",
+			},
+		},
+		{
+			name:          "Send_With_Empty_ChatID",
+			cfg:           botConfig,
+			message:       attachmentMessage,
+			expectedError: "mattermost webhook URL or channel ID is not configured",
+		},
+		{
+			name:          "Send_To_Channel_Without_BotToken",
+			cfg:           config.MattermostConfig{ApiUrl: "https://mattermost.example.com"},
+			chatID:        "channel-id",
+			message:       attachmentMessage,
+			expectedError: "mattermost bot token is not configured",
+		},
+		{
+			name:          "Send_To_Channel_Without_ApiUrl",
+			cfg:           config.MattermostConfig{BotToken: "mm-token"},
+			chatID:        "channel-id",
+			message:       attachmentMessage,
+			expectedError: "mattermost API URL is not configured",
+		},
+		{
+			name:              "Send_HTTP_Client_Error",
+			chatID:            webhookURL,
+			message:           attachmentMessage,
+			httpError:         errors.New("network error"),
+			expectedURL:       webhookURL,
+			expectedError:     "failed to send message",
+			expectedRetryable: true,
+		},
+		{
+			name:           "Send_REST_API_Permanent_Error",
+			cfg:            botConfig,
+			chatID:         "channel-id",
+			message:        attachmentMessage,
+			responseStatus: http.StatusForbidden,
+			responseBody:   `{"id": "api.context.permissions.app_error"}`,
+			expectedURL:    "https://mattermost.example.com/api/v4/posts",
+			expectedAuth:   "Bearer mm-token",
+			expectedError:  "mattermost API error: status 403",
+		},
+		{
+			name:              "Send_Rate_Limited",
+			chatID:            webhookURL,
+			message:           attachmentMessage,
+			responseStatus:    http.StatusTooManyRequests,
+			responseHeader:    http.Header{"Retry-After": []string{"5"}},
+			expectedURL:       webhookURL,
+			expectedError:     "mattermost API error: status 429",
+			expectedRetryable: true,
+			expectedRetry:     5 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			if tc.expectedURL != "" {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					if req.URL.String() != tc.expectedURL {
+						t.Errorf("expected URL %q, got: %q", tc.expectedURL, req.URL.String())
+					}
+					if auth := req.Header.Get("Authorization"); auth != tc.expectedAuth {
+						t.Errorf("expected Authorization %q, got: %q", tc.expectedAuth, auth)
+					}
+					if tc.expectedPayload != nil {
+						var payload map[string]interface{}
+						if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+							t.Fatalf("failed to decode request body: %v", err)
+						}
+						if diff := cmp.Diff(tc.expectedPayload, payload); diff != "" {
+							t.Errorf("payload mismatch (-want +got):\n%s", diff)
+						}
+					}
+					if tc.httpError != nil {
+						return nil, &url.Error{Op: "Post", URL: req.URL.String(), Err: tc.httpError}
+					}
+					return &http.Response{
+						StatusCode: tc.responseStatus,
+						Header:     tc.responseHeader,
+						Body:       io.NopCloser(strings.NewReader(tc.responseBody)),
+					}, nil
+				})
+			}
+
+			channel := NewMattermostChannel(tc.cfg, logger, mockHTTPClient)
+			err := channel.Send(context.Background(), tc.chatID, tc.message)
+
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Fatalf("expected error containing %q, got: %v", tc.expectedError, err)
+			}
+			if strings.Contains(err.Error(), webhookURL) {
+				t.Errorf("expected error without webhook URL, got: %v", err)
+			}
+			if retryable := port.IsRetryable(err); retryable != tc.expectedRetryable {
+				t.Errorf("expected retryable %v, got: %v", tc.expectedRetryable, retryable)
+			}
+			if retryAfter := port.RetryAfter(err); retryAfter != tc.expectedRetry {
+				t.Errorf("expected retry after %s, got: %s", tc.expectedRetry, retryAfter)
+			}
+		})
+	}
+}
+
+func TestMattermostChannel_Channel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	channel := NewMattermostChannel(config.MattermostConfig{}, logrus.New(), mocks.NewMockHTTPClient(ctrl))
+
+	if name := channel.Channel(); name != port.ChannelMattermost {
+		t.Errorf("expected channel name %q, got: %q", port.ChannelMattermost, name)
+	}
+}
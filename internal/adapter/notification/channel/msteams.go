@@ -0,0 +1,153 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/adapter/ratelimit"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Префикс ответа incoming webhook Microsoft Teams, который сообщает об ошибке доставки со статусом 200
+const msteamsDeliveryFailedPrefix = "Webhook message delivery failed"
+
+// Статус ответа Microsoft Teams, переданный в тексте ошибки доставки incoming webhook
+var msteamsErrorStatusPattern = regexp.MustCompile(`HTTP error (\d{3})`)
+
+// MSTeamsChannel реализует канал отправки уведомлений через Microsoft Teams
+// Адресат - URL incoming webhook или workflow Microsoft Teams, токен не нужен
+type MSTeamsChannel struct {
+	client  port.HTTPClient
+	limiter *ratelimit.Limiter
+	logger  *logrus.Logger
+}
+
+// NewMSTeamsChannel создает новый канал Microsoft Teams
+func NewMSTeamsChannel(cfg config.MSTeamsConfig, logger *logrus.Logger, httpClient port.HTTPClient) port.NotificationChannel {
+	return &MSTeamsChannel{
+		client:  httpClient,
+		limiter: newRateLimiter(cfg.RateLimit, nil),
+		logger:  logger,
+	}
+}
+
+// Send отправляет уведомление в Microsoft Teams
+// formattedMessage - JSON сообщения с Adaptive Card, текст в другом формате отправляется как обычное сообщение
+func (c *MSTeamsChannel) Send(ctx context.Context, chatID string, formattedMessage string) error {
+	if chatID == "" {
+		return fmt.Errorf("msteams webhook URL is not configured")
+	}
+
+	jsonData, err := json.Marshal(jsonPayload(formattedMessage))
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to marshal Microsoft Teams payload")
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	// Сообщение сверх лимита Microsoft Teams отклонит при любой попытке, поэтому оно не отправляется
+	if len(jsonData) > port.MSTeamsMaxPayloadSize {
+		c.logger.WithFields(logrus.Fields{
+			"size":  len(jsonData),
+			"limit": port.MSTeamsMaxPayloadSize,
+		}).Error("Microsoft Teams message exceeds payload size limit")
+		return port.NewDeliveryError(port.ChannelMSTeams, http.StatusRequestEntityTooLarge,
+			fmt.Errorf("msteams message size %d exceeds limit of %d bytes", len(jsonData), port.MSTeamsMaxPayloadSize))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", chatID, bytes.NewBuffer(jsonData))
+	if err != nil {
+		err = redactURLError(err, urlOrigin(chatID))
+		c.logger.WithError(err).Error("Failed to create Microsoft Teams request")
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	waited, err := c.limiter.Wait(ctx, chatID)
+	if err != nil {
+		return fmt.Errorf("msteams rate limit wait interrupted: %w", err)
+	}
+	if waited > 0 {
+		c.logger.WithFields(logrus.Fields{
+			"target": webhookTarget,
+			"delay":  waited.String(),
+		}).Debug("Microsoft Teams rate limit reached, message delayed")
+	}
+
+	resp, errSend := c.client.Do(req)
+	if errSend != nil {
+		// URL incoming webhook содержит секрет, поэтому в ошибке остается только хост
+		errSend = redactURLError(errSend, urlOrigin(chatID))
+		c.logger.WithError(errSend).Error("Failed to send Microsoft Teams message")
+		return newTransportError(port.ChannelMSTeams, errSend)
+	}
+	defer func(Body io.ReadCloser) {
+		if closeErr := Body.Close(); closeErr != nil {
+			c.logger.WithError(closeErr).Error("Failed to close request body")
+		}
+	}(resp.Body)
+
+	body, errRead := io.ReadAll(resp.Body)
+	if errRead != nil {
+		c.logger.WithError(errRead).Warn("Failed to read Microsoft Teams response body")
+	}
+
+	// Workflow отвечает статусом 202 Accepted, incoming webhook - 200 OK
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		c.logger.WithFields(logrus.Fields{
+			"status_code": resp.StatusCode,
+			"response":    string(body),
+		}).Error("Microsoft Teams API returned error")
+		deliveryErr := newStatusError(port.ChannelMSTeams, resp, body)
+		if deliveryErr.RetryAfter > 0 {
+			c.limiter.Block(chatID, deliveryErr.RetryAfter)
+		}
+		return deliveryErr
+	}
+
+	// Incoming webhook сообщает об ошибке доставки в теле ответа со статусом 200
+	if strings.HasPrefix(string(body), msteamsDeliveryFailedPrefix) {
+		c.logger.WithFields(logrus.Fields{
+			"status_code": resp.StatusCode,
+			"response":    string(body),
+		}).Error("Microsoft Teams API returned error")
+		return port.NewDeliveryError(port.ChannelMSTeams, msteamsErrorStatus(string(body)),
+			fmt.Errorf("msteams API error: %s", string(body)))
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"target": webhookTarget,
+		"status": resp.StatusCode,
+	}).Info("Notification sent via Microsoft Teams channel")
+
+	return nil
+}
+
+// Channel возвращает название канала
+func (c *MSTeamsChannel) Channel() string {
+	return port.ChannelMSTeams
+}
+
+// msteamsErrorStatus извлекает HTTP статус из текста ошибки доставки incoming webhook
+// Если статус не указан, ошибка считается временным сбоем на стороне Microsoft Teams
+func msteamsErrorStatus(response string) int {
+	match := msteamsErrorStatusPattern.FindStringSubmatch(response)
+	if match == nil {
+		return http.StatusBadGateway
+	}
+
+	statusCode, err := strconv.Atoi(match[1])
+	if err != nil {
+		return http.StatusBadGateway
+	}
+
+	return statusCode
+}
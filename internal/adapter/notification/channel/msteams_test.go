@@ -0,0 +1,233 @@
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMSTeamsChannel_Send(t *testing.T) {
+	type testCase struct {
+		name              string
+		chatID            string
+		message           string
+		responseStatus    int
+		responseBody      string
+		responseHeader    http.Header
+		httpError         error
+		expectRequest     bool
+		expectedPayload   map[string]interface{}
+		expectedError     string
+		expectedRetryable bool
+		expectedRetry     time.Duration
+	}
+
+	webhookURL := "https://example.webhook.office.com/webhookb2/xxx"
+	cardMessage := `{"type":"message","attachments":[{"contentType":"application/vnd.microsoft.card.adaptive","content":{"type":"AdaptiveCard"}}]}`
+	cardPayload := map[string]interface{}{
+		"type": "message",
+		"attachments": []interface{}{
+			map[string]interface{}{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content":     map[string]interface{}{"type": "AdaptiveCard"},
+			},
+		},
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Send_Via_Incoming_Webhook",
+			chatID:          webhookURL,
+			message:         cardMessage,
+			responseStatus:  http.StatusOK,
+			responseBody:    "1",
+			expectRequest:   true,
+			expectedPayload: cardPayload,
+		},
+		{
+			name:            "Send_Via_Workflow",
+			chatID:          webhookURL,
+			message:         cardMessage,
+			responseStatus:  http.StatusAccepted,
+			expectRequest:   true,
+			expectedPayload: cardPayload,
+		},
+		{
+			name:            "Send_Plain_Text_Message",
+			chatID:          webhookURL,
+			message:         "Plain text",
+			responseStatus:  http.StatusOK,
+			expectRequest:   true,
+			expectedPayload: map[string]interface{}{"text": "Plain text"},
+		},
+		{
+			name:          "Send_With_Empty_ChatID",
+			message:       cardMessage,
+			expectedError: "msteams webhook URL is not configured",
+		},
+		{
+			name:          "Send_Message_Too_Large",
+			chatID:        webhookURL,
+			message:       strings.Repeat("a", port.MSTeamsMaxPayloadSize),
+			expectedError: "exceeds limit",
+		},
+		{
+			name:              "Send_HTTP_Client_Error",
+			chatID:            webhookURL,
+			message:           cardMessage,
+			httpError:         errors.New("network error"),
+			expectRequest:     true,
+			expectedError:     "failed to send message",
+			expectedRetryable: true,
+		},
+		{
+			name:           "Send_Bad_Request",
+			chatID:         webhookURL,
+			message:        cardMessage,
+			responseStatus: http.StatusBadRequest,
+			responseBody:   "Bad payload received by generic incoming webhook.",
+			expectRequest:  true,
+			expectedError:  "msteams API error: status 400",
+		},
+		{
+			name:              "Send_Rate_Limited",
+			chatID:            webhookURL,
+			message:           cardMessage,
+			responseStatus:    http.StatusTooManyRequests,
+			responseHeader:    http.Header{"Retry-After": []string{"10"}},
+			expectRequest:     true,
+			expectedError:     "msteams API error: status 429",
+			expectedRetryable: true,
+			expectedRetry:     10 * time.Second,
+		},
+		{
+			name:              "Send_Delivery_Failed_Throttled",
+			chatID:            webhookURL,
+			message:           cardMessage,
+			responseStatus:    http.StatusOK,
+			responseBody:      "Webhook message delivery failed with error: Microsoft Teams endpoint returned HTTP error 429 with ContextId abc",
+			expectRequest:     true,
+			expectedError:     "msteams API error: Webhook message delivery failed",
+			expectedRetryable: true,
+		},
+		{
+			name:           "Send_Delivery_Failed_Too_Large",
+			chatID:         webhookURL,
+			message:        cardMessage,
+			responseStatus: http.StatusOK,
+			responseBody:   "Webhook message delivery failed with error: Microsoft Teams endpoint returned HTTP error 413 with ContextId abc",
+			expectRequest:  true,
+			expectedError:  "HTTP error 413",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			if tc.expectRequest {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					if req.URL.String() != tc.chatID {
+						t.Errorf("expected URL %q, got: %q", tc.chatID, req.URL.String())
+					}
+					if tc.expectedPayload != nil {
+						var payload map[string]interface{}
+						if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+							t.Fatalf("failed to decode request body: %v", err)
+						}
+						if diff := cmp.Diff(tc.expectedPayload, payload); diff != "" {
+							t.Errorf("payload mismatch (-want +got):\n%s", diff)
+						}
+					}
+					if tc.httpError != nil {
+						return nil, &url.Error{Op: "Post", URL: req.URL.String(), Err: tc.httpError}
+					}
+					return &http.Response{
+						StatusCode: tc.responseStatus,
+						Header:     tc.responseHeader,
+						Body:       io.NopCloser(strings.NewReader(tc.responseBody)),
+					}, nil
+				})
+			}
+
+			channel := NewMSTeamsChannel(config.MSTeamsConfig{}, logger, mockHTTPClient)
+			err := channel.Send(context.Background(), tc.chatID, tc.message)
+
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Fatalf("expected error containing %q, got: %v", tc.expectedError, err)
+			}
+			if strings.Contains(err.Error(), webhookURL) {
+				t.Errorf("expected error without webhook URL, got: %v", err)
+			}
+			if retryable := port.IsRetryable(err); retryable != tc.expectedRetryable {
+				t.Errorf("expected retryable %v, got: %v", tc.expectedRetryable, retryable)
+			}
+			if retryAfter := port.RetryAfter(err); retryAfter != tc.expectedRetry {
+				t.Errorf("expected retry after %s, got: %s", tc.expectedRetry, retryAfter)
+			}
+		})
+	}
+}
+
+func TestMSTeamsErrorStatus(t *testing.T) {
+	type testCase struct {
+		name           string
+		response       string
+		expectedStatus int
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Status_In_Response",
+			response:       "Webhook message delivery failed with error: Microsoft Teams endpoint returned HTTP error 413 with ContextId abc",
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "Status_Not_In_Response",
+			response:       "Webhook message delivery failed with error: unknown",
+			expectedStatus: http.StatusBadGateway,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if status := msteamsErrorStatus(tc.response); status != tc.expectedStatus {
+				t.Errorf("expected status %d, got: %d", tc.expectedStatus, status)
+			}
+		})
+	}
+}
+
+func TestMSTeamsChannel_Channel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	channel := NewMSTeamsChannel(config.MSTeamsConfig{}, logrus.New(), mocks.NewMockHTTPClient(ctrl))
+
+	if name := channel.Channel(); name != port.ChannelMSTeams {
+		t.Errorf("expected channel name %q, got: %q", port.ChannelMSTeams, name)
+	}
+}
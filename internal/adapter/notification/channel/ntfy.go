@@ -0,0 +1,124 @@
+package channel
+
+import (
+	"context"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/adapter/ratelimit"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// NtfyChannel реализует канал отправки push уведомлений через сервер ntfy
+// Адресат - топик, на который подписаны получатели уведомлений в приложении ntfy
+type NtfyChannel struct {
+	serverURL   string
+	accessToken string
+	client      port.HTTPClient
+	limiter     *ratelimit.Limiter
+	logger      *logrus.Logger
+}
+
+// NewNtfyChannel создает новый канал ntfy
+func NewNtfyChannel(cfg config.NtfyConfig, logger *logrus.Logger, httpClient port.HTTPClient) port.NotificationChannel {
+	return &NtfyChannel{
+		serverURL:   strings.TrimSuffix(cfg.ServerURL, "/"),
+		accessToken: cfg.AccessToken,
+		client:      httpClient,
+		limiter:     newRateLimiter(cfg.RateLimit, nil),
+		logger:      logger,
+	}
+}
+
+// Send публикует push уведомление в топик ntfy
+// formattedMessage - JSON уведомления (port.PushMessage): текст передается в теле запроса,
+// заголовок, приоритет, метки и ссылка - в заголовках Title, Priority, Tags и Click
+func (c *NtfyChannel) Send(ctx context.Context, chatID string, formattedMessage string) error {
+	if chatID == "" {
+		return fmt.Errorf("ntfy topic is not configured")
+	}
+	if c.serverURL == "" {
+		return fmt.Errorf("ntfy server URL is not configured")
+	}
+
+	message := pushPayload(formattedMessage)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.serverURL+"/"+url.PathEscape(chatID), strings.NewReader(message.Message))
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to create ntfy request")
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Значения заголовков вне ASCII (русский текст) кодируются по RFC 2047, ntfy декодирует их на сервере
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	req.Header.Set("Title", mime.BEncoding.Encode("utf-8", message.Title))
+	req.Header.Set("Priority", strconv.Itoa(message.Priority))
+	if len(message.Tags) > 0 {
+		req.Header.Set("Tags", mime.BEncoding.Encode("utf-8", strings.Join(message.Tags, ",")))
+	}
+	if message.Click != "" {
+		req.Header.Set("Click", message.Click)
+	}
+	if c.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	}
+
+	waited, err := c.limiter.Wait(ctx, chatID)
+	if err != nil {
+		return fmt.Errorf("ntfy rate limit wait interrupted: %w", err)
+	}
+	if waited > 0 {
+		c.logger.WithFields(logrus.Fields{
+			"topic": chatID,
+			"delay": waited.String(),
+		}).Debug("Ntfy rate limit reached, message delayed")
+	}
+
+	resp, errSend := c.client.Do(req)
+	if errSend != nil {
+		c.logger.WithError(errSend).WithField("topic", chatID).Error("Failed to send ntfy message")
+		return newTransportError(port.ChannelNtfy, errSend)
+	}
+	defer func(Body io.ReadCloser) {
+		if closeErr := Body.Close(); closeErr != nil {
+			c.logger.WithError(closeErr).Error("Failed to close request body")
+		}
+	}(resp.Body)
+
+	body, errRead := io.ReadAll(resp.Body)
+	if errRead != nil {
+		c.logger.WithError(errRead).Warn("Failed to read ntfy response body")
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		c.logger.WithFields(logrus.Fields{
+			"topic":       chatID,
+			"status_code": resp.StatusCode,
+			"response":    string(body),
+		}).Error("Ntfy server returned error")
+		deliveryErr := newStatusError(port.ChannelNtfy, resp, body)
+		if deliveryErr.RetryAfter > 0 {
+			c.limiter.Block(chatID, deliveryErr.RetryAfter)
+		}
+		return deliveryErr
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"topic":    chatID,
+		"priority": message.Priority,
+		"status":   resp.StatusCode,
+	}).Info("Notification sent via ntfy channel")
+
+	return nil
+}
+
+// Channel возвращает название канала
+func (c *NtfyChannel) Channel() string {
+	return port.ChannelNtfy
+}
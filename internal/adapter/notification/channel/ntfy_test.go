@@ -0,0 +1,213 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNtfyChannel_Send(t *testing.T) {
+	type testCase struct {
+		name              string
+		cfg               config.NtfyConfig
+		chatID            string
+		message           string
+		responseStatus    int
+		responseBody      string
+		responseHeader    http.Header
+		httpError         error
+		expectRequest     bool
+		expectedURL       string
+		expectedBody      string
+		expectedHeaders   map[string]string
+		expectedError     string
+		expectedRetryable bool
+		expectedRetry     time.Duration
+	}
+
+	cfg := config.NtfyConfig{ServerURL: "https://ntfy.example.com/"}
+	pushMessage := `{"title":"PROJ-1 Fix bug","message":"Состояние: Open","priority":4,"tags":["zap","Backend"],"click":"https://youtrack.test/issue/PROJ-1"}`
+
+	testCases := []testCase{
+		{
+			name:           "Send_Push_Message",
+			cfg:            cfg,
+			chatID:         "backend-alerts",
+			message:        pushMessage,
+			responseStatus: http.StatusOK,
+			responseBody:   `{"id":"abc","event":"message","topic":"backend-alerts"}`,
+			expectRequest:  true,
+			expectedURL:    "https://ntfy.example.com/backend-alerts",
+			expectedBody:   "Состояние: Open",
+			expectedHeaders: map[string]string{
+				"Content-Type":  "text/plain; charset=utf-8",
+				"Title":         "PROJ-1 Fix bug",
+				"Priority":      "4",
+				"Tags":          "zap,Backend",
+				"Click":         "https://youtrack.test/issue/PROJ-1",
+				"Authorization": "",
+			},
+		},
+		{
+			name:           "Send_Non_ASCII_Title_With_Access_Token",
+			cfg:            config.NtfyConfig{ServerURL: "https://ntfy.example.com", AccessToken: "tk_token"},
+			chatID:         "backend-alerts",
+			message:        `{"title":"PROJ-1 Исправить вход","message":"Текст","priority":3,"tags":["Проект"]}`,
+			responseStatus: http.StatusOK,
+			expectRequest:  true,
+			expectedURL:    "https://ntfy.example.com/backend-alerts",
+			expectedBody:   "Текст",
+			expectedHeaders: map[string]string{
+				"Title":         "=?utf-8?b?UFJPSi0xINCY0YHQv9GA0LDQstC40YLRjCDQstGF0L7QtA==?=",
+				"Priority":      "3",
+				"Tags":          "=?utf-8?b?0J/RgNC+0LXQutGC?=",
+				"Click":         "",
+				"Authorization": "Bearer tk_token",
+			},
+		},
+		{
+			name:           "Send_Plain_Text",
+			cfg:            cfg,
+			chatID:         "backend-alerts",
+			message:        "Plain text",
+			responseStatus: http.StatusOK,
+			expectRequest:  true,
+			expectedBody:   "Plain text",
+			expectedHeaders: map[string]string{
+				"Title":    "YouTrack",
+				"Priority": "3",
+				"Tags":     "",
+			},
+		},
+		{
+			name:          "Send_Empty_Topic",
+			cfg:           cfg,
+			message:       pushMessage,
+			expectedError: "ntfy topic is not configured",
+		},
+		{
+			name:          "Send_Without_Server_URL",
+			cfg:           config.NtfyConfig{},
+			chatID:        "backend-alerts",
+			message:       pushMessage,
+			expectedError: "ntfy server URL is not configured",
+		},
+		{
+			name:              "Send_HTTP_Client_Error",
+			cfg:               cfg,
+			chatID:            "backend-alerts",
+			message:           pushMessage,
+			httpError:         errors.New("network error"),
+			expectRequest:     true,
+			expectedError:     "failed to send message",
+			expectedRetryable: true,
+		},
+		{
+			name:           "Send_Forbidden",
+			cfg:            cfg,
+			chatID:         "backend-alerts",
+			message:        pushMessage,
+			responseStatus: http.StatusForbidden,
+			responseBody:   `{"code":40301,"http":403,"error":"forbidden"}`,
+			expectRequest:  true,
+			expectedError:  "ntfy API error: status 403",
+		},
+		{
+			name:              "Send_Too_Many_Requests",
+			cfg:               cfg,
+			chatID:            "backend-alerts",
+			message:           pushMessage,
+			responseStatus:    http.StatusTooManyRequests,
+			responseBody:      `{"code":42901,"http":429,"error":"limit reached: too many requests"}`,
+			responseHeader:    http.Header{"Retry-After": []string{"2"}},
+			expectRequest:     true,
+			expectedError:     "ntfy API error: status 429",
+			expectedRetryable: true,
+			expectedRetry:     2 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			if tc.expectRequest {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodPost {
+						t.Errorf("expected method POST, got: %s", req.Method)
+					}
+					if tc.expectedURL != "" && req.URL.String() != tc.expectedURL {
+						t.Errorf("expected URL %s, got: %s", tc.expectedURL, req.URL.String())
+					}
+					body, _ := io.ReadAll(req.Body)
+					if tc.expectedBody != "" && string(body) != tc.expectedBody {
+						t.Errorf("expected body %q, got: %q", tc.expectedBody, string(body))
+					}
+					for name, value := range tc.expectedHeaders {
+						if got := req.Header.Get(name); got != value {
+							t.Errorf("expected header %s %q, got: %q", name, value, got)
+						}
+					}
+
+					if tc.httpError != nil {
+						return nil, tc.httpError
+					}
+					header := tc.responseHeader
+					if header == nil {
+						header = http.Header{}
+					}
+					return &http.Response{
+						StatusCode: tc.responseStatus,
+						Header:     header,
+						Body:       io.NopCloser(strings.NewReader(tc.responseBody)),
+					}, nil
+				})
+			}
+
+			channel := NewNtfyChannel(tc.cfg, logger, mockHTTPClient)
+
+			err := channel.Send(context.Background(), tc.chatID, tc.message)
+
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Fatalf("expected error containing %q, got: %v", tc.expectedError, err)
+			}
+			if retryable := port.IsRetryable(err); retryable != tc.expectedRetryable {
+				t.Errorf("expected retryable %v, got: %v", tc.expectedRetryable, retryable)
+			}
+			if retryAfter := port.RetryAfter(err); retryAfter != tc.expectedRetry {
+				t.Errorf("expected retry after %v, got: %v", tc.expectedRetry, retryAfter)
+			}
+		})
+	}
+}
+
+func TestNtfyChannel_Channel(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	channel := NewNtfyChannel(config.NtfyConfig{}, logger, nil)
+
+	if name := channel.Channel(); name != port.ChannelNtfy {
+		t.Errorf("expected channel %q, got: %q", port.ChannelNtfy, name)
+	}
+}
@@ -0,0 +1,26 @@
+package channel
+
+import (
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+)
+
+// Заголовок push уведомления, если сообщение не содержит заголовка
+const pushDefaultTitle = "YouTrack"
+
+// pushPayload возвращает push уведомление, подготовленное форматированием push каналов
+// Если сообщение не является JSON уведомления с текстом, оно отправляется как текст с обычным приоритетом
+func pushPayload(formattedMessage string) port.PushMessage {
+	var message port.PushMessage
+	if json.Unmarshal([]byte(formattedMessage), &message) == nil && message.Message != "" {
+		if message.Title == "" {
+			message.Title = pushDefaultTitle
+		}
+		if message.Priority < port.PushPriorityMin || message.Priority > port.PushPriorityMax {
+			message.Priority = port.PushPriorityDefault
+		}
+		return message
+	}
+
+	return port.PushMessage{Title: pushDefaultTitle, Message: formattedMessage, Priority: port.PushPriorityDefault}
+}
@@ -0,0 +1,57 @@
+package channel
+
+import (
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestPushPayload(t *testing.T) {
+	type testCase struct {
+		name            string
+		message         string
+		expectedMessage port.PushMessage
+	}
+
+	testCases := []testCase{
+		{
+			name:    "Push_Message_JSON",
+			message: `{"title":"PROJ-1 Fix bug","message":"Text","priority":5,"tags":["zap"],"click":"https://youtrack.test/issue/PROJ-1"}`,
+			expectedMessage: port.PushMessage{
+				Title:    "PROJ-1 Fix bug",
+				Message:  "Text",
+				Priority: port.PushPriorityMax,
+				Tags:     []string{"zap"},
+				Click:    "https://youtrack.test/issue/PROJ-1",
+			},
+		},
+		{
+			name:            "Push_Message_Without_Title_And_Priority",
+			message:         `{"message":"Text"}`,
+			expectedMessage: port.PushMessage{Title: "YouTrack", Message: "Text", Priority: port.PushPriorityDefault},
+		},
+		{
+			name:            "Push_Message_Out_Of_Range_Priority",
+			message:         `{"title":"Title","message":"Text","priority":9}`,
+			expectedMessage: port.PushMessage{Title: "Title", Message: "Text", Priority: port.PushPriorityDefault},
+		},
+		{
+			name:            "Plain_Text",
+			message:         "Plain text",
+			expectedMessage: port.PushMessage{Title: "YouTrack", Message: "Plain text", Priority: port.PushPriorityDefault},
+		},
+		{
+			name:            "JSON_Without_Message",
+			message:         `{"text":"Text"}`,
+			expectedMessage: port.PushMessage{Title: "YouTrack", Message: `{"text":"Text"}`, Priority: port.PushPriorityDefault},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expectedMessage, pushPayload(tc.message)); diff != "" {
+				t.Errorf("unexpected message (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
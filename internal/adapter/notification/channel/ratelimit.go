@@ -0,0 +1,29 @@
+package channel
+
+import (
+	"github.com/beliaev-aa/notifications/internal/adapter/ratelimit"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"strings"
+	"time"
+)
+
+// newRateLimiter создает ограничитель частоты отправки по конфигурации канала
+// Нулевые значения конфигурации отключают соответствующее ограничение
+func newRateLimiter(cfg config.RateLimitConfig, isGroup func(chatID string) bool) *ratelimit.Limiter {
+	return ratelimit.NewLimiter(ratelimit.Limits{
+		PerChat:  ratelimit.Limit{Count: cfg.PerChat, Per: time.Second},
+		PerGroup: ratelimit.Limit{Count: cfg.PerGroup, Per: time.Minute},
+		Global:   ratelimit.Limit{Count: cfg.Global, Per: time.Second},
+	}, isGroup)
+}
+
+// isTelegramGroupChat определяет групповой чат Telegram:
+// идентификаторы групп и каналов отрицательные, публичные каналы задаются через @username
+func isTelegramGroupChat(chatID string) bool {
+	return strings.HasPrefix(chatID, "-") || strings.HasPrefix(chatID, "@")
+}
+
+// isVKTeamsGroupChat определяет групповой чат VK Teams по суффиксу идентификатора
+func isVKTeamsGroupChat(chatID string) bool {
+	return strings.HasSuffix(chatID, "@chat.agent")
+}
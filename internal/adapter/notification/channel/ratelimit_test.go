@@ -0,0 +1,28 @@
+package channel
+
+import "testing"
+
+func TestIsGroupChat(t *testing.T) {
+	type testCase struct {
+		name     string
+		isGroup  func(chatID string) bool
+		chatID   string
+		expected bool
+	}
+
+	testCases := []testCase{
+		{name: "Telegram_Private_Chat", isGroup: isTelegramGroupChat, chatID: "123456789", expected: false},
+		{name: "Telegram_Group_Chat", isGroup: isTelegramGroupChat, chatID: "-1001234567890", expected: true},
+		{name: "Telegram_Public_Channel", isGroup: isTelegramGroupChat, chatID: "@project_news", expected: true},
+		{name: "VKTeams_Private_Chat", isGroup: isVKTeamsGroupChat, chatID: "user@example.com", expected: false},
+		{name: "VKTeams_Group_Chat", isGroup: isVKTeamsGroupChat, chatID: "682345@chat.agent", expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := tc.isGroup(tc.chatID); result != tc.expected {
+				t.Errorf("expected %v for chat %q, got: %v", tc.expected, tc.chatID, result)
+			}
+		})
+	}
+}
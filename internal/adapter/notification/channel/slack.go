@@ -0,0 +1,168 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/adapter/ratelimit"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Метод Slack Web API для отправки сообщения в канал
+const slackPostMessageMethod = "chat.postMessage"
+
+// Ошибки Slack Web API, после которых отправку можно повторить
+var slackRetryableErrors = map[string]bool{
+	"internal_error":      true,
+	"fatal_error":         true,
+	"service_unavailable": true,
+	"request_timeout":     true,
+	"ratelimited":         true,
+}
+
+// SlackChannel реализует канал отправки уведомлений через Slack
+// Адресат - URL incoming webhook или канал, в который сообщение отправляется через chat.postMessage с токеном бота
+type SlackChannel struct {
+	botToken string
+	apiURL   string
+	client   port.HTTPClient
+	limiter  *ratelimit.Limiter
+	logger   *logrus.Logger
+}
+
+// slackAPIResponse описывает ответ Slack Web API
+type slackAPIResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+}
+
+// NewSlackChannel создает новый канал Slack
+func NewSlackChannel(cfg config.SlackConfig, logger *logrus.Logger, httpClient port.HTTPClient) port.NotificationChannel {
+	apiURL := cfg.ApiUrl
+	if apiURL == "" {
+		apiURL = config.DefaultSlackApiUrl
+	}
+
+	return &SlackChannel{
+		botToken: cfg.BotToken,
+		apiURL:   strings.TrimSuffix(apiURL, "/"),
+		client:   httpClient,
+		limiter:  newRateLimiter(cfg.RateLimit, nil),
+		logger:   logger,
+	}
+}
+
+// Send отправляет уведомление в Slack
+// formattedMessage - JSON сообщения Block Kit, текст в другом формате отправляется как обычное сообщение
+func (c *SlackChannel) Send(ctx context.Context, chatID string, formattedMessage string) error {
+	if chatID == "" {
+		return fmt.Errorf("slack webhook URL or channel is not configured")
+	}
+
+	payload := jsonPayload(formattedMessage)
+
+	target := webhookTarget
+	apiURL := chatID
+	viaWebhook := isWebhookURL(chatID)
+	if !viaWebhook {
+		if c.botToken == "" {
+			return fmt.Errorf("slack bot token is not configured")
+		}
+		target = chatID
+		apiURL = fmt.Sprintf("%s/%s", c.apiURL, slackPostMessageMethod)
+		channelID, _ := json.Marshal(chatID)
+		payload["channel"] = channelID
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to marshal Slack payload")
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		err = redactURLError(err, urlOrigin(apiURL))
+		c.logger.WithError(err).Error("Failed to create Slack request")
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if !viaWebhook {
+		req.Header.Set("Authorization", "Bearer "+c.botToken)
+	}
+
+	waited, err := c.limiter.Wait(ctx, chatID)
+	if err != nil {
+		return fmt.Errorf("slack rate limit wait interrupted: %w", err)
+	}
+	if waited > 0 {
+		c.logger.WithFields(logrus.Fields{
+			"target": target,
+			"delay":  waited.String(),
+		}).Debug("Slack rate limit reached, message delayed")
+	}
+
+	resp, errSend := c.client.Do(req)
+	if errSend != nil {
+		// URL incoming webhook содержит секрет, поэтому в ошибке остается только хост
+		errSend = redactURLError(errSend, urlOrigin(apiURL))
+		c.logger.WithError(errSend).Error("Failed to send Slack message")
+		return newTransportError(port.ChannelSlack, errSend)
+	}
+	defer func(Body io.ReadCloser) {
+		if closeErr := Body.Close(); closeErr != nil {
+			c.logger.WithError(closeErr).Error("Failed to close request body")
+		}
+	}(resp.Body)
+
+	body, errRead := io.ReadAll(resp.Body)
+	if errRead != nil {
+		c.logger.WithError(errRead).Warn("Failed to read Slack response body")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		c.logger.WithFields(logrus.Fields{
+			"status_code": resp.StatusCode,
+			"response":    string(body),
+		}).Error("Slack API returned error")
+		deliveryErr := newStatusError(port.ChannelSlack, resp, body)
+		if deliveryErr.RetryAfter > 0 {
+			c.limiter.Block(chatID, deliveryErr.RetryAfter)
+		}
+		return deliveryErr
+	}
+
+	// Web API сообщает об ошибке в теле ответа со статусом 200, incoming webhook отвечает текстом "ok"
+	if !viaWebhook {
+		var apiResp slackAPIResponse
+		if json.Unmarshal(body, &apiResp) == nil && !apiResp.OK {
+			c.logger.WithFields(logrus.Fields{
+				"channel": chatID,
+				"error":   apiResp.Error,
+			}).Error("Slack API returned error")
+			deliveryErr := port.NewDeliveryError(port.ChannelSlack, resp.StatusCode,
+				fmt.Errorf("slack API error: %s", apiResp.Error))
+			deliveryErr.Retryable = slackRetryableErrors[apiResp.Error]
+			return deliveryErr
+		}
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"target": target,
+		"status": resp.StatusCode,
+	}).Info("Notification sent via Slack channel")
+
+	return nil
+}
+
+// Channel возвращает название канала
+func (c *SlackChannel) Channel() string {
+	return port.ChannelSlack
+}
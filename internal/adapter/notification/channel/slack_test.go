@@ -0,0 +1,230 @@
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlackChannel_Send(t *testing.T) {
+	type testCase struct {
+		name              string
+		cfg               config.SlackConfig
+		chatID            string
+		message           string
+		responseStatus    int
+		responseBody      string
+		responseHeader    http.Header
+		httpError         error
+		expectedURL       string
+		expectedAuth      string
+		expectedPayload   map[string]interface{}
+		expectedError     string
+		expectedRetryable bool
+		expectedRetry     time.Duration
+	}
+
+	webhookURL := "https://hooks.slack.com/services/T000/B000/XXX"
+	botConfig := config.SlackConfig{BotToken: "xoxb-token", ApiUrl: "https://slack.example.com/api/"}
+	blockKitMessage := `{"text":"Задача","blocks":[{"type":"section","text":{"type":"mrkdwn","text":"*Задача*"}}]}`
+	blocks := []interface{}{
+		map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": "*Задача*"},
+		},
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Send_Via_Incoming_Webhook",
+			chatID:         webhookURL,
+			message:        blockKitMessage,
+			responseStatus: http.StatusOK,
+			responseBody:   "ok",
+			expectedURL:    webhookURL,
+			expectedPayload: map[string]interface{}{
+				"text":   "Задача",
+				"blocks": blocks,
+			},
+		},
+		{
+			name:           "Send_Via_Chat_PostMessage",
+			cfg:            botConfig,
+			chatID:         "C0123456789",
+			message:        blockKitMessage,
+			responseStatus: http.StatusOK,
+			responseBody:   `{"ok": true}`,
+			expectedURL:    "https://slack.example.com/api/chat.postMessage",
+			expectedAuth:   "Bearer xoxb-token",
+			expectedPayload: map[string]interface{}{
+				"channel": "C0123456789",
+				"text":    "Задача",
+				"blocks":  blocks,
+			},
+		},
+		{
+			name:           "Send_Plain_Text_Message",
+			cfg:            config.SlackConfig{BotToken: "xoxb-token"},
+			chatID:         "#alerts",
+			message:        "Plain text",
+			responseStatus: http.StatusOK,
+			responseBody:   `{"ok": true}`,
+			expectedURL:    "https://slack.com/api/chat.postMessage",
+			expectedAuth:   "Bearer xoxb-token",
+			expectedPayload: map[string]interface{}{
+				"channel": "#alerts",
+				"text":    "Plain text",
+			},
+		},
+		{
+			name:          "Send_With_Empty_ChatID",
+			cfg:           botConfig,
+			message:       blockKitMessage,
+			expectedError: "slack webhook URL or channel is not configured",
+		},
+		{
+			name:          "Send_To_Channel_Without_BotToken",
+			chatID:        "C0123456789",
+			message:       blockKitMessage,
+			expectedError: "slack bot token is not configured",
+		},
+		{
+			name:              "Send_HTTP_Client_Error",
+			chatID:            webhookURL,
+			message:           blockKitMessage,
+			httpError:         errors.New("network error"),
+			expectedURL:       webhookURL,
+			expectedError:     "failed to send message",
+			expectedRetryable: true,
+		},
+		{
+			name:           "Send_Webhook_Error_Response",
+			chatID:         webhookURL,
+			message:        blockKitMessage,
+			responseStatus: http.StatusNotFound,
+			responseBody:   "channel_not_found",
+			expectedURL:    webhookURL,
+			expectedError:  "slack API error: status 404, response: channel_not_found",
+		},
+		{
+			name:              "Send_Rate_Limited",
+			cfg:               botConfig,
+			chatID:            "C0123456789",
+			message:           blockKitMessage,
+			responseStatus:    http.StatusTooManyRequests,
+			responseHeader:    http.Header{"Retry-After": []string{"30"}},
+			expectedURL:       "https://slack.example.com/api/chat.postMessage",
+			expectedAuth:      "Bearer xoxb-token",
+			expectedError:     "slack API error: status 429",
+			expectedRetryable: true,
+			expectedRetry:     30 * time.Second,
+		},
+		{
+			name:           "Send_API_Permanent_Error",
+			cfg:            botConfig,
+			chatID:         "C0123456789",
+			message:        blockKitMessage,
+			responseStatus: http.StatusOK,
+			responseBody:   `{"ok": false, "error": "channel_not_found"}`,
+			expectedURL:    "https://slack.example.com/api/chat.postMessage",
+			expectedAuth:   "Bearer xoxb-token",
+			expectedError:  "slack API error: channel_not_found",
+		},
+		{
+			name:              "Send_API_Temporary_Error",
+			cfg:               botConfig,
+			chatID:            "C0123456789",
+			message:           blockKitMessage,
+			responseStatus:    http.StatusOK,
+			responseBody:      `{"ok": false, "error": "internal_error"}`,
+			expectedURL:       "https://slack.example.com/api/chat.postMessage",
+			expectedAuth:      "Bearer xoxb-token",
+			expectedError:     "slack API error: internal_error",
+			expectedRetryable: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			if tc.expectedURL != "" {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					if req.URL.String() != tc.expectedURL {
+						t.Errorf("expected URL %q, got: %q", tc.expectedURL, req.URL.String())
+					}
+					if auth := req.Header.Get("Authorization"); auth != tc.expectedAuth {
+						t.Errorf("expected Authorization %q, got: %q", tc.expectedAuth, auth)
+					}
+					if tc.expectedPayload != nil {
+						var payload map[string]interface{}
+						if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+							t.Fatalf("failed to decode request body: %v", err)
+						}
+						if diff := cmp.Diff(tc.expectedPayload, payload); diff != "" {
+							t.Errorf("payload mismatch (-want +got):\n%s", diff)
+						}
+					}
+					if tc.httpError != nil {
+						return nil, &url.Error{Op: "Post", URL: req.URL.String(), Err: tc.httpError}
+					}
+					return &http.Response{
+						StatusCode: tc.responseStatus,
+						Header:     tc.responseHeader,
+						Body:       io.NopCloser(strings.NewReader(tc.responseBody)),
+					}, nil
+				})
+			}
+
+			channel := NewSlackChannel(tc.cfg, logger, mockHTTPClient)
+			err := channel.Send(context.Background(), tc.chatID, tc.message)
+
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Fatalf("expected error containing %q, got: %v", tc.expectedError, err)
+			}
+			if strings.Contains(err.Error(), webhookURL) {
+				t.Errorf("expected error without webhook URL, got: %v", err)
+			}
+			if retryable := port.IsRetryable(err); retryable != tc.expectedRetryable {
+				t.Errorf("expected retryable %v, got: %v", tc.expectedRetryable, retryable)
+			}
+			if retryAfter := port.RetryAfter(err); retryAfter != tc.expectedRetry {
+				t.Errorf("expected retry after %s, got: %s", tc.expectedRetry, retryAfter)
+			}
+		})
+	}
+}
+
+func TestSlackChannel_Channel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	channel := NewSlackChannel(config.SlackConfig{}, logrus.New(), mocks.NewMockHTTPClient(ctrl))
+
+	if name := channel.Channel(); name != port.ChannelSlack {
+		t.Errorf("expected channel name %q, got: %q", port.ChannelSlack, name)
+	}
+}
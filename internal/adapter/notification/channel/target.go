@@ -0,0 +1,26 @@
+package channel
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Адресат в логах при отправке через incoming webhook, URL webhook содержит секрет и не логируется
+const webhookTarget = "incoming_webhook"
+
+// isWebhookURL определяет, что адресат канала задан URL incoming webhook, а не идентификатором канала или чата
+func isWebhookURL(chatID string) bool {
+	return strings.HasPrefix(chatID, "https://") || strings.HasPrefix(chatID, "http://")
+}
+
+// jsonPayload возвращает поля сообщения из JSON, подготовленного форматированием канала
+// Если сообщение не является JSON объектом, оно отправляется как текст
+func jsonPayload(formattedMessage string) map[string]json.RawMessage {
+	var payload map[string]json.RawMessage
+	if json.Unmarshal([]byte(formattedMessage), &payload) == nil && payload != nil {
+		return payload
+	}
+
+	text, _ := json.Marshal(formattedMessage)
+	return map[string]json.RawMessage{"text": text}
+}
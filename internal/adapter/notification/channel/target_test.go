@@ -0,0 +1,69 @@
+package channel
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestIsWebhookURL(t *testing.T) {
+	type testCase struct {
+		name     string
+		chatID   string
+		expected bool
+	}
+
+	testCases := []testCase{
+		{name: "HTTPS_URL", chatID: "https://hooks.slack.com/services/T000/B000/XXX", expected: true},
+		{name: "HTTP_URL", chatID: "http://localhost:8065/hooks/abc", expected: true},
+		{name: "Channel_ID", chatID: "C0123456789", expected: false},
+		{name: "Channel_Name", chatID: "#alerts", expected: false},
+		{name: "Empty", chatID: "", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := isWebhookURL(tc.chatID); result != tc.expected {
+				t.Errorf("expected %v, got: %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestJSONPayload(t *testing.T) {
+	type testCase struct {
+		name     string
+		message  string
+		expected map[string]string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "JSON_Object_Message",
+			message:  `{"text":"Задача","attachments":[]}`,
+			expected: map[string]string{"text": `"Задача"`, "attachments": `[]`},
+		},
+		{
+			name:     "Plain_Text_Message",
+			message:  "Plain text",
+			expected: map[string]string{"text": `"Plain text"`},
+		},
+		{
+			name:     "JSON_Null_Message",
+			message:  "null",
+			expected: map[string]string{"text": `"null"`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := make(map[string]string)
+			for key, value := range jsonPayload(tc.message) {
+				result[key] = string(value)
+			}
+
+			if diff := cmp.Diff(tc.expected, result); diff != "" {
+				t.Errorf("payload mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
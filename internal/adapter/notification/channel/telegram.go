@@ -2,8 +2,10 @@ package channel
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/beliaev-aa/notifications/internal/adapter/ratelimit"
 	"github.com/beliaev-aa/notifications/internal/config"
 	"github.com/beliaev-aa/notifications/internal/domain/port"
 	"github.com/sirupsen/logrus"
@@ -24,9 +26,19 @@ type TelegramChannel struct {
 	botToken string
 	timeout  time.Duration
 	client   port.HTTPClient
+	limiter  *ratelimit.Limiter
 	logger   *logrus.Logger
 }
 
+// telegramErrorResponse описывает ответ Telegram Bot API с ошибкой
+type telegramErrorResponse struct {
+	ErrorCode   int    `json:"error_code"`
+	Description string `json:"description"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"` // Через сколько секунд можно повторить запрос после 429
+	} `json:"parameters"`
+}
+
 // NewTelegramChannel создает новый канал Telegram
 func NewTelegramChannel(cfg config.TelegramConfig, logger *logrus.Logger, httpClient port.HTTPClient) port.NotificationChannel {
 	if cfg.BotToken == "" {
@@ -39,12 +51,13 @@ func NewTelegramChannel(cfg config.TelegramConfig, logger *logrus.Logger, httpCl
 		botToken: cfg.BotToken,
 		timeout:  timeout,
 		client:   httpClient,
+		limiter:  newRateLimiter(cfg.RateLimit, isTelegramGroupChat),
 		logger:   logger,
 	}
 }
 
 // Send отправляет уведомление в Telegram
-func (c *TelegramChannel) Send(chatID string, formattedMessage string) error {
+func (c *TelegramChannel) Send(ctx context.Context, chatID string, formattedMessage string) error {
 	if c.botToken == "" {
 		return fmt.Errorf("telegram bot token is not configured")
 	}
@@ -69,18 +82,32 @@ func (c *TelegramChannel) Send(chatID string, formattedMessage string) error {
 	}
 
 	// Отправляем запрос
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonData))
 	if err != nil {
+		err = redactURLError(err, urlOrigin(apiURL))
 		c.logger.WithError(err).Error("Failed to create Telegram request")
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
+	waited, err := c.limiter.Wait(ctx, chatID)
+	if err != nil {
+		return fmt.Errorf("telegram rate limit wait interrupted: %w", err)
+	}
+	if waited > 0 {
+		c.logger.WithFields(logrus.Fields{
+			"chat_id": chatID,
+			"delay":   waited.String(),
+		}).Debug("Telegram rate limit reached, message delayed")
+	}
+
 	resp, errSend := c.client.Do(req)
 	if errSend != nil {
+		// Путь запроса к Bot API содержит токен бота, поэтому в ошибке остается только хост
+		errSend = redactURLError(errSend, urlOrigin(apiURL))
 		c.logger.WithError(errSend).Error("Failed to send Telegram message")
-		return fmt.Errorf("failed to send message: %w", errSend)
+		return newTransportError(port.ChannelTelegram, errSend)
 	}
 	defer func(Body io.ReadCloser) {
 		if closeErr := Body.Close(); closeErr != nil {
@@ -99,7 +126,7 @@ func (c *TelegramChannel) Send(chatID string, formattedMessage string) error {
 			"status_code": resp.StatusCode,
 			"response":    string(body),
 		}).Error("Telegram API returned error")
-		return fmt.Errorf("telegram API error: status %d, response: %s", resp.StatusCode, string(body))
+		return c.newAPIError(chatID, resp, body)
 	}
 
 	c.logger.WithFields(logrus.Fields{
@@ -110,6 +137,26 @@ func (c *TelegramChannel) Send(chatID string, formattedMessage string) error {
 	return nil
 }
 
+// newAPIError создает ошибку доставки по ответу Telegram Bot API
+// При превышении лимита Telegram сообщает в parameters.retry_after, через сколько секунд можно повторить запрос:
+// это время используется как задержка повтора, и до его истечения отправка в чат приостанавливается
+func (c *TelegramChannel) newAPIError(chatID string, resp *http.Response, body []byte) error {
+	deliveryErr := newStatusError(port.ChannelTelegram, resp, body)
+
+	var apiErr telegramErrorResponse
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Parameters.RetryAfter > 0 {
+		deliveryErr.RetryAfter = time.Duration(apiErr.Parameters.RetryAfter) * time.Second
+		c.limiter.Block(chatID, deliveryErr.RetryAfter)
+
+		c.logger.WithFields(logrus.Fields{
+			"chat_id":     chatID,
+			"retry_after": apiErr.Parameters.RetryAfter,
+		}).Warn("Telegram rate limit exceeded")
+	}
+
+	return deliveryErr
+}
+
 // Channel возвращает название канала
 func (c *TelegramChannel) Channel() string {
 	return port.ChannelTelegram
@@ -1,6 +1,7 @@
 package channel
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/beliaev-aa/notifications/internal/config"
@@ -12,6 +13,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 type errorReadCloser struct {
@@ -335,7 +337,7 @@ func TestTelegramChannel_Send(t *testing.T) {
 				}
 			}
 
-			err := channel.Send(tc.chatID, tc.message)
+			err := channel.Send(context.Background(), tc.chatID, tc.message)
 
 			if tc.expectedError {
 				if err == nil {
@@ -431,7 +433,7 @@ func TestTelegramChannel_Integration(t *testing.T) {
 				Body:       io.NopCloser(strings.NewReader(`{"ok": true}`)),
 			}, nil)
 
-			err := channel.Send(tc.chatID, tc.message)
+			err := channel.Send(context.Background(), tc.chatID, tc.message)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -597,7 +599,7 @@ func TestTelegramChannel_EdgeCases(t *testing.T) {
 				}, nil)
 			}
 
-			err := channel.Send(tc.chatID, tc.message)
+			err := channel.Send(context.Background(), tc.chatID, tc.message)
 
 			if tc.expectedError {
 				if err == nil {
@@ -611,3 +613,77 @@ func TestTelegramChannel_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestTelegramChannel_Send_RetryAfter(t *testing.T) {
+	type testCase struct {
+		name               string
+		statusCode         int
+		responseBody       string
+		expectedRetryable  bool
+		expectedRetryAfter time.Duration
+	}
+
+	testCases := []testCase{
+		{
+			name:               "Too_Many_Requests_With_Retry_After",
+			statusCode:         http.StatusTooManyRequests,
+			responseBody:       `{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 35","parameters":{"retry_after":35}}`,
+			expectedRetryable:  true,
+			expectedRetryAfter: 35 * time.Second,
+		},
+		{
+			name:               "Too_Many_Requests_Without_Parameters",
+			statusCode:         http.StatusTooManyRequests,
+			responseBody:       `{"ok":false,"error_code":429,"description":"Too Many Requests"}`,
+			expectedRetryable:  true,
+			expectedRetryAfter: 0,
+		},
+		{
+			name:               "Bad_Request_Chat_Not_Found",
+			statusCode:         http.StatusBadRequest,
+			responseBody:       `{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`,
+			expectedRetryable:  false,
+			expectedRetryAfter: 0,
+		},
+		{
+			name:               "Non_JSON_Response",
+			statusCode:         http.StatusBadGateway,
+			responseBody:       `<html>Bad Gateway</html>`,
+			expectedRetryable:  true,
+			expectedRetryAfter: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			mockHTTPClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
+				StatusCode: tc.statusCode,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(tc.responseBody)),
+			}, nil)
+
+			channel := NewTelegramChannel(config.TelegramConfig{BotToken: "token", Timeout: 10}, logger, mockHTTPClient)
+
+			err := channel.Send(context.Background(), "-100123", "message")
+			if err == nil {
+				t.Fatal("expected error, got: nil")
+			}
+			if !strings.Contains(err.Error(), "telegram API error") {
+				t.Errorf("expected error to contain %q, got: %q", "telegram API error", err.Error())
+			}
+			if port.IsRetryable(err) != tc.expectedRetryable {
+				t.Errorf("expected retryable %v, got: %v", tc.expectedRetryable, port.IsRetryable(err))
+			}
+			if retryAfter := port.RetryAfter(err); retryAfter != tc.expectedRetryAfter {
+				t.Errorf("expected retry after %v, got: %v", tc.expectedRetryAfter, retryAfter)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package channel
 
 import (
+	"context"
 	"fmt"
+	"github.com/beliaev-aa/notifications/internal/adapter/ratelimit"
 	"github.com/beliaev-aa/notifications/internal/config"
 	"github.com/beliaev-aa/notifications/internal/domain/port"
 	"github.com/sirupsen/logrus"
@@ -23,6 +25,7 @@ type VKTeamsChannel struct {
 	timeout  time.Duration
 	apiURL   string // Кастомный URL API
 	client   port.HTTPClient
+	limiter  *ratelimit.Limiter
 	logger   *logrus.Logger
 }
 
@@ -49,12 +52,13 @@ func NewVKTeamsChannel(cfg config.VKTeamsConfig, logger *logrus.Logger, httpClie
 		timeout:  timeout,
 		apiURL:   apiURL,
 		client:   httpClient,
+		limiter:  newRateLimiter(cfg.RateLimit, isVKTeamsGroupChat),
 		logger:   logger,
 	}
 }
 
 // Send отправляет уведомление в VK Teams
-func (c *VKTeamsChannel) Send(chatID string, formattedMessage string) error {
+func (c *VKTeamsChannel) Send(ctx context.Context, chatID string, formattedMessage string) error {
 	if chatID == "" {
 		return fmt.Errorf("vkteams chat ID is not configured")
 	}
@@ -75,16 +79,32 @@ func (c *VKTeamsChannel) Send(chatID string, formattedMessage string) error {
 
 	requestURL.RawQuery = params.Encode()
 
-	req, err := http.NewRequest("GET", requestURL.String(), nil)
+	// Токен бота передается в параметрах запроса, поэтому в ошибках остается только адрес метода API
+	safeURL := c.apiURL + vkTeamsSendTextEndpoint
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL.String(), nil)
 	if err != nil {
+		err = redactURLError(err, safeURL)
 		c.logger.WithError(err).Error("Failed to create VK Teams GET request")
 		return fmt.Errorf("failed to create GET request: %w", err)
 	}
 
+	waited, err := c.limiter.Wait(ctx, chatID)
+	if err != nil {
+		return fmt.Errorf("vkteams rate limit wait interrupted: %w", err)
+	}
+	if waited > 0 {
+		c.logger.WithFields(logrus.Fields{
+			"chat_id": chatID,
+			"delay":   waited.String(),
+		}).Debug("VK Teams rate limit reached, message delayed")
+	}
+
 	resp, errSend := c.client.Do(req)
 	if errSend != nil {
+		errSend = redactURLError(errSend, safeURL)
 		c.logger.WithError(errSend).Error("Failed to send VK Teams message")
-		return fmt.Errorf("failed to send message: %w", errSend)
+		return newTransportError(port.ChannelVKTeams, errSend)
 	}
 	defer func(Body io.ReadCloser) {
 		if closeErr := Body.Close(); closeErr != nil {
@@ -103,7 +123,7 @@ func (c *VKTeamsChannel) Send(chatID string, formattedMessage string) error {
 			"status_code": resp.StatusCode,
 			"response":    string(body),
 		}).Error("VK Teams API returned error")
-		return fmt.Errorf("vkteams API error: status %d, response: %s", resp.StatusCode, string(body))
+		return newStatusError(port.ChannelVKTeams, resp, body)
 	}
 
 	c.logger.WithFields(logrus.Fields{
@@ -2,6 +2,7 @@ package channel
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"github.com/beliaev-aa/notifications/internal/config"
 	"github.com/beliaev-aa/notifications/internal/domain/port"
@@ -310,7 +311,7 @@ func TestVKTeamsChannel_Send(t *testing.T) {
 				}
 			}
 
-			err := channel.Send(tc.chatID, tc.message)
+			err := channel.Send(context.Background(), tc.chatID, tc.message)
 
 			if tc.expectedError {
 				if err == nil {
@@ -408,7 +409,7 @@ func TestVKTeamsChannel_Integration(t *testing.T) {
 				Body:       io.NopCloser(strings.NewReader(`{"ok": true}`)),
 			}, nil)
 
-			err := channel.Send(tc.chatID, tc.message)
+			err := channel.Send(context.Background(), tc.chatID, tc.message)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -582,7 +583,7 @@ func TestVKTeamsChannel_EdgeCases(t *testing.T) {
 				}, nil)
 			}
 
-			err := channel.Send(tc.chatID, tc.message)
+			err := channel.Send(context.Background(), tc.chatID, tc.message)
 
 			if tc.expectedError {
 				if err == nil {
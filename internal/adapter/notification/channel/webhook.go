@@ -0,0 +1,217 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/adapter/ratelimit"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"text/template"
+)
+
+// Префикс значения заголовка подписи, совпадает с форматом подписи входящих webhook запросов
+const webhookSignaturePrefix = "sha256="
+
+// webhookTemplateFuncs функции, доступные в шаблоне тела запроса
+var webhookTemplateFuncs = template.FuncMap{
+	// json возвращает значение в JSON, строки экранируются и заключаются в кавычки
+	"json": func(value any) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+	// field возвращает отображаемое значение поля задачи (состояние, приоритет, проект), пусто - если поле не задано
+	"field": func(field *parser.YoutrackFieldValue) string {
+		if field == nil {
+			return ""
+		}
+		if field.Presentation != nil && *field.Presentation != "" {
+			return *field.Presentation
+		}
+		if field.Name != nil {
+			return *field.Name
+		}
+		return ""
+	},
+	// user возвращает имя пользователя, а при его отсутствии - логин
+	"user": func(user *parser.YoutrackUser) string {
+		if user == nil {
+			return ""
+		}
+		if user.FullName != nil && *user.FullName != "" {
+			return *user.FullName
+		}
+		if user.Login != nil {
+			return *user.Login
+		}
+		return ""
+	},
+}
+
+// outgoingWebhookTarget описывает цель webhook канала с разобранным шаблоном тела запроса
+type outgoingWebhookTarget struct {
+	cfg  config.OutgoingWebhookTargetConfig
+	body *template.Template // nil - тело запроса совпадает с payload в JSON
+}
+
+// WebhookChannel реализует канал отправки событий во внутренние сервисы через HTTP запросы
+// Адресат - имя цели из outgoing_webhook.targets
+type WebhookChannel struct {
+	targets map[string]outgoingWebhookTarget
+	client  port.HTTPClient
+	limiter *ratelimit.Limiter
+	logger  *logrus.Logger
+}
+
+// NewWebhookChannel создает новый webhook канал
+// Возвращает ошибку, если шаблон тела запроса одной из целей не удалось разобрать
+func NewWebhookChannel(cfg config.OutgoingWebhookConfig, logger *logrus.Logger, httpClient port.HTTPClient) (port.NotificationChannel, error) {
+	targets := make(map[string]outgoingWebhookTarget, len(cfg.Targets))
+	for name, targetCfg := range cfg.Targets {
+		target := outgoingWebhookTarget{cfg: targetCfg}
+		if targetCfg.Body != "" {
+			body, err := template.New(name).Funcs(webhookTemplateFuncs).Parse(targetCfg.Body)
+			if err != nil {
+				return nil, fmt.Errorf("outgoing_webhook target %q: invalid body template: %w", name, err)
+			}
+			target.body = body
+		}
+		targets[name] = target
+	}
+
+	return &WebhookChannel{
+		targets: targets,
+		client:  httpClient,
+		limiter: newRateLimiter(cfg.RateLimit, nil),
+		logger:  logger,
+	}, nil
+}
+
+// Send отправляет событие в цель webhook канала
+// formattedMessage - payload YouTrack в JSON, из которого тело запроса формируется шаблоном цели
+func (c *WebhookChannel) Send(ctx context.Context, chatID string, formattedMessage string) error {
+	target, exists := c.targets[chatID]
+	if !exists {
+		return fmt.Errorf("webhook target %q is not configured", chatID)
+	}
+
+	body, err := target.render(formattedMessage)
+	if err != nil {
+		c.logger.WithError(err).WithField("target", chatID).Error("Failed to render webhook body")
+		return fmt.Errorf("failed to render webhook body for target %q: %w", chatID, err)
+	}
+
+	// URL цели может содержать секрет в пути или параметрах, поэтому в логах и ошибках остается только хост
+	host := urlOrigin(target.cfg.URL)
+
+	req, err := http.NewRequestWithContext(ctx, target.cfg.Method, target.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		err = redactURLError(err, host)
+		c.logger.WithError(err).WithFields(logrus.Fields{
+			"target": chatID,
+			"host":   host,
+		}).Error("Failed to create webhook request")
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Заголовки цели переопределяют тип содержимого, подпись вычисляется последней и не переопределяется
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	for name, value := range target.cfg.Headers {
+		req.Header.Set(name, value)
+	}
+	if target.cfg.Secret != "" {
+		req.Header.Set(target.cfg.SignatureHeader, webhookSignaturePrefix+signWebhookBody(target.cfg.Secret, body))
+	}
+
+	waited, err := c.limiter.Wait(ctx, chatID)
+	if err != nil {
+		return fmt.Errorf("webhook rate limit wait interrupted: %w", err)
+	}
+	if waited > 0 {
+		c.logger.WithFields(logrus.Fields{
+			"target": chatID,
+			"delay":  waited.String(),
+		}).Debug("Webhook rate limit reached, message delayed")
+	}
+
+	resp, errSend := c.client.Do(req)
+	if errSend != nil {
+		errSend = redactURLError(errSend, host)
+		c.logger.WithError(errSend).WithFields(logrus.Fields{
+			"target": chatID,
+			"host":   host,
+		}).Error("Failed to send webhook request")
+		return newTransportError(port.ChannelWebhook, errSend)
+	}
+	defer func(Body io.ReadCloser) {
+		if closeErr := Body.Close(); closeErr != nil {
+			c.logger.WithError(closeErr).Error("Failed to close request body")
+		}
+	}(resp.Body)
+
+	respBody, errRead := io.ReadAll(resp.Body)
+	if errRead != nil {
+		c.logger.WithError(errRead).Warn("Failed to read webhook response body")
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		c.logger.WithFields(logrus.Fields{
+			"target":      chatID,
+			"host":        host,
+			"status_code": resp.StatusCode,
+			"response":    string(respBody),
+		}).Error("Webhook target returned error")
+		deliveryErr := newStatusError(port.ChannelWebhook, resp, respBody)
+		if deliveryErr.RetryAfter > 0 {
+			c.limiter.Block(chatID, deliveryErr.RetryAfter)
+		}
+		return deliveryErr
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"target": chatID,
+		"status": resp.StatusCode,
+	}).Info("Notification sent via webhook channel")
+
+	return nil
+}
+
+// Channel возвращает название канала
+func (c *WebhookChannel) Channel() string {
+	return port.ChannelWebhook
+}
+
+// render формирует тело запроса цели
+// Без шаблона отправляется сообщение как есть, с шаблоном - результат шаблона для payload из сообщения
+func (t outgoingWebhookTarget) render(formattedMessage string) ([]byte, error) {
+	if t.body == nil {
+		return []byte(formattedMessage), nil
+	}
+
+	var payload parser.YoutrackWebhookPayload
+	if err := json.Unmarshal([]byte(formattedMessage), &payload); err != nil {
+		return nil, fmt.Errorf("message is not a YouTrack payload: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.body.Execute(&buf, &payload); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// signWebhookBody вычисляет HMAC-SHA256 подпись тела запроса в шестнадцатеричном виде
+func signWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,260 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookChannel_Send(t *testing.T) {
+	type testCase struct {
+		name              string
+		chatID            string
+		message           string
+		responseStatus    int
+		responseHeader    http.Header
+		httpError         error
+		expectRequest     bool
+		expectedMethod    string
+		expectedURL       string
+		expectedBody      string
+		expectedHeaders   map[string]string
+		expectedError     string
+		expectedRetryable bool
+		expectedRetry     time.Duration
+	}
+
+	payloadMessage := `{"project":{"name":"Demo","presentation":null},"issue":{"idReadable":"DEMO-1","isDraft":false,"summary":"Fix \"quotes\"","url":"https://youtrack.test/issue/DEMO-1","state":{"name":"Open","presentation":"Открыта"},"priority":null,"assignee":null},"updater":{"fullName":null,"login":"jane","email":null},"changes":null}`
+
+	cfg := config.OutgoingWebhookConfig{
+		Targets: map[string]config.OutgoingWebhookTargetConfig{
+			"bi_collector": {
+				URL:    "https://bi.example.com/events",
+				Method: "POST",
+			},
+			"deploy_bot": {
+				URL:             "https://deploy.example.com/hooks/youtrack",
+				Method:          "PUT",
+				Headers:         map[string]string{"Authorization": "Bearer token", "Content-Type": "text/plain"},
+				Body:            `{{.Issue.IDReadable}} {{json .Issue.Summary}} {{field .Issue.State}} {{field .Issue.Priority}} {{user .Updater}}`,
+				Secret:          "secret",
+				SignatureHeader: "X-Signature",
+			},
+			"secret_hook": {
+				URL:    "https://hooks.example.com/services/T000/B000?token=secret-token",
+				Method: "POST",
+			},
+			"broken": {
+				URL:    "https://broken.example.com/hooks",
+				Method: "POST",
+				Body:   `{{.Issue.Priority.Name}}`,
+			},
+		},
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Send_Payload_Without_Template",
+			chatID:          "bi_collector",
+			message:         payloadMessage,
+			responseStatus:  http.StatusOK,
+			expectRequest:   true,
+			expectedMethod:  http.MethodPost,
+			expectedURL:     "https://bi.example.com/events",
+			expectedBody:    payloadMessage,
+			expectedHeaders: map[string]string{"Content-Type": "application/json; charset=utf-8", "X-Webhook-Signature": ""},
+		},
+		{
+			name:           "Send_Rendered_Template_With_Headers_And_Signature",
+			chatID:         "deploy_bot",
+			message:        payloadMessage,
+			responseStatus: http.StatusNoContent,
+			expectRequest:  true,
+			expectedMethod: http.MethodPut,
+			expectedURL:    "https://deploy.example.com/hooks/youtrack",
+			expectedBody:   `DEMO-1 "Fix \"quotes\"" Открыта  jane`,
+			expectedHeaders: map[string]string{
+				"Authorization": "Bearer token",
+				"Content-Type":  "text/plain",
+				"X-Signature":   "sha256=" + signWebhookBody("secret", []byte(`DEMO-1 "Fix \"quotes\"" Открыта  jane`)),
+			},
+		},
+		{
+			name:          "Send_Unknown_Target",
+			chatID:        "unknown",
+			message:       payloadMessage,
+			expectedError: "webhook target \"unknown\" is not configured",
+		},
+		{
+			name:          "Send_Template_Execution_Error",
+			chatID:        "broken",
+			message:       payloadMessage,
+			expectedError: "failed to render webhook body for target \"broken\"",
+		},
+		{
+			name:          "Send_Template_Without_Payload",
+			chatID:        "deploy_bot",
+			message:       "Plain text",
+			expectedError: "message is not a YouTrack payload",
+		},
+		{
+			name:              "Send_HTTP_Client_Error",
+			chatID:            "bi_collector",
+			message:           payloadMessage,
+			httpError:         errors.New("network error"),
+			expectRequest:     true,
+			expectedError:     "failed to send message",
+			expectedRetryable: true,
+		},
+		{
+			name:              "Send_HTTP_Client_Error_Redacts_URL",
+			chatID:            "secret_hook",
+			message:           payloadMessage,
+			httpError:         errors.New("network error"),
+			expectRequest:     true,
+			expectedError:     `failed to send message: Post "https://hooks.example.com": network error`,
+			expectedRetryable: true,
+		},
+		{
+			name:              "Send_Server_Error",
+			chatID:            "bi_collector",
+			message:           payloadMessage,
+			responseStatus:    http.StatusBadGateway,
+			expectRequest:     true,
+			expectedError:     "webhook API error: status 502",
+			expectedRetryable: true,
+		},
+		{
+			name:           "Send_Bad_Request",
+			chatID:         "bi_collector",
+			message:        payloadMessage,
+			responseStatus: http.StatusBadRequest,
+			expectRequest:  true,
+			expectedError:  "webhook API error: status 400",
+		},
+		{
+			name:              "Send_Too_Many_Requests",
+			chatID:            "bi_collector",
+			message:           payloadMessage,
+			responseStatus:    http.StatusTooManyRequests,
+			responseHeader:    http.Header{"Retry-After": []string{"3"}},
+			expectRequest:     true,
+			expectedError:     "webhook API error: status 429",
+			expectedRetryable: true,
+			expectedRetry:     3 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var logOutput strings.Builder
+			logger := logrus.New()
+			logger.SetOutput(&logOutput)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			if tc.expectRequest {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					if tc.expectedMethod != "" && req.Method != tc.expectedMethod {
+						t.Errorf("expected method %s, got: %s", tc.expectedMethod, req.Method)
+					}
+					if tc.expectedURL != "" && req.URL.String() != tc.expectedURL {
+						t.Errorf("expected URL %s, got: %s", tc.expectedURL, req.URL.String())
+					}
+					body, _ := io.ReadAll(req.Body)
+					if tc.expectedBody != "" && string(body) != tc.expectedBody {
+						t.Errorf("expected body %q, got: %q", tc.expectedBody, string(body))
+					}
+					for name, value := range tc.expectedHeaders {
+						if got := req.Header.Get(name); got != value {
+							t.Errorf("expected header %s %q, got: %q", name, value, got)
+						}
+					}
+
+					if tc.httpError != nil {
+						return nil, &url.Error{Op: "Post", URL: req.URL.String(), Err: tc.httpError}
+					}
+					header := tc.responseHeader
+					if header == nil {
+						header = http.Header{}
+					}
+					return &http.Response{
+						StatusCode: tc.responseStatus,
+						Header:     header,
+						Body:       io.NopCloser(strings.NewReader("")),
+					}, nil
+				})
+			}
+
+			channel, err := NewWebhookChannel(cfg, logger, mockHTTPClient)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			err = channel.Send(context.Background(), tc.chatID, tc.message)
+			if strings.Contains(logOutput.String(), "secret-token") {
+				t.Errorf("expected log without webhook URL secret, got: %s", logOutput.String())
+			}
+
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Fatalf("expected error containing %q, got: %v", tc.expectedError, err)
+			}
+			if strings.Contains(err.Error(), "secret-token") {
+				t.Errorf("expected error without webhook URL secret, got: %v", err)
+			}
+			if retryable := port.IsRetryable(err); retryable != tc.expectedRetryable {
+				t.Errorf("expected retryable %v, got: %v", tc.expectedRetryable, retryable)
+			}
+			if retryAfter := port.RetryAfter(err); retryAfter != tc.expectedRetry {
+				t.Errorf("expected retry after %v, got: %v", tc.expectedRetry, retryAfter)
+			}
+		})
+	}
+}
+
+func TestNewWebhookChannel_InvalidTemplate(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	_, err := NewWebhookChannel(config.OutgoingWebhookConfig{
+		Targets: map[string]config.OutgoingWebhookTargetConfig{
+			"deploy_bot": {URL: "https://deploy.example.com/hooks/youtrack", Method: "POST", Body: `{{.Issue.IDReadable`},
+		},
+	}, logger, nil)
+
+	if err == nil || !strings.Contains(err.Error(), `outgoing_webhook target "deploy_bot": invalid body template`) {
+		t.Fatalf("expected invalid template error, got: %v", err)
+	}
+}
+
+func TestWebhookChannel_Channel(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	channel, err := NewWebhookChannel(config.OutgoingWebhookConfig{}, logger, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if name := channel.Channel(); name != port.ChannelWebhook {
+		t.Errorf("expected channel %q, got: %q", port.ChannelWebhook, name)
+	}
+}
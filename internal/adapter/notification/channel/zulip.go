@@ -0,0 +1,169 @@
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/adapter/ratelimit"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Путь REST API Zulip для отправки сообщения
+const zulipMessagesPath = "/api/v1/messages"
+
+// Тема сообщения Zulip, если сообщение не содержит темы
+const zulipDefaultTopic = "YouTrack"
+
+// zulipRateLimitResponse описывает тело ответа Zulip со статусом 429
+type zulipRateLimitResponse struct {
+	Code       string  `json:"code"`
+	RetryAfter float64 `json:"retry-after"`
+}
+
+// ZulipChannel реализует канал отправки уведомлений в потоки Zulip через REST API от имени бота
+// Адресат - название потока, тема сообщения формируется по задаче
+type ZulipChannel struct {
+	botEmail string
+	apiKey   string
+	siteURL  string
+	client   port.HTTPClient
+	limiter  *ratelimit.Limiter
+	logger   *logrus.Logger
+}
+
+// NewZulipChannel создает новый канал Zulip
+func NewZulipChannel(cfg config.ZulipConfig, logger *logrus.Logger, httpClient port.HTTPClient) port.NotificationChannel {
+	return &ZulipChannel{
+		botEmail: cfg.BotEmail,
+		apiKey:   cfg.APIKey,
+		siteURL:  strings.TrimSuffix(cfg.SiteURL, "/"),
+		client:   httpClient,
+		limiter:  newRateLimiter(cfg.RateLimit, nil),
+		logger:   logger,
+	}
+}
+
+// Send отправляет уведомление в поток Zulip
+// formattedMessage - JSON сообщения с темой (port.ZulipMessage), текст в другом формате отправляется в тему по умолчанию
+func (c *ZulipChannel) Send(ctx context.Context, chatID string, formattedMessage string) error {
+	if chatID == "" {
+		return fmt.Errorf("zulip stream is not configured")
+	}
+	if c.botEmail == "" || c.apiKey == "" {
+		return fmt.Errorf("zulip bot credentials are not configured")
+	}
+	if c.siteURL == "" {
+		return fmt.Errorf("zulip site URL is not configured")
+	}
+
+	message := zulipPayload(formattedMessage)
+
+	form := url.Values{}
+	form.Set("type", "stream")
+	form.Set("to", chatID)
+	form.Set("topic", message.Topic)
+	form.Set("content", message.Content)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.siteURL+zulipMessagesPath, strings.NewReader(form.Encode()))
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to create Zulip request")
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(c.botEmail, c.apiKey)
+
+	waited, err := c.limiter.Wait(ctx, chatID)
+	if err != nil {
+		return fmt.Errorf("zulip rate limit wait interrupted: %w", err)
+	}
+	if waited > 0 {
+		c.logger.WithFields(logrus.Fields{
+			"stream": chatID,
+			"delay":  waited.String(),
+		}).Debug("Zulip rate limit reached, message delayed")
+	}
+
+	resp, errSend := c.client.Do(req)
+	if errSend != nil {
+		c.logger.WithError(errSend).WithField("stream", chatID).Error("Failed to send Zulip message")
+		return newTransportError(port.ChannelZulip, errSend)
+	}
+	defer func(Body io.ReadCloser) {
+		if closeErr := Body.Close(); closeErr != nil {
+			c.logger.WithError(closeErr).Error("Failed to close request body")
+		}
+	}(resp.Body)
+
+	body, errRead := io.ReadAll(resp.Body)
+	if errRead != nil {
+		c.logger.WithError(errRead).Warn("Failed to read Zulip response body")
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		c.logger.WithFields(logrus.Fields{
+			"stream":      chatID,
+			"status_code": resp.StatusCode,
+			"response":    string(body),
+		}).Error("Zulip API returned error")
+		deliveryErr := newStatusError(port.ChannelZulip, resp, body)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			if retryAfter := parseZulipRetryAfter(body); retryAfter > 0 {
+				deliveryErr.RetryAfter = retryAfter
+				c.logger.WithFields(logrus.Fields{
+					"stream":      chatID,
+					"retry_after": retryAfter.String(),
+				}).Warn("Zulip rate limit exceeded")
+			}
+		}
+		if deliveryErr.RetryAfter > 0 {
+			c.limiter.Block(chatID, deliveryErr.RetryAfter)
+		}
+		return deliveryErr
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"stream": chatID,
+		"topic":  message.Topic,
+		"status": resp.StatusCode,
+	}).Info("Notification sent via Zulip channel")
+
+	return nil
+}
+
+// Channel возвращает название канала
+func (c *ZulipChannel) Channel() string {
+	return port.ChannelZulip
+}
+
+// zulipPayload возвращает тему и текст сообщения Zulip
+// Если сообщение не является JSON сообщения с текстом, оно отправляется как есть в тему по умолчанию
+func zulipPayload(formattedMessage string) port.ZulipMessage {
+	var message port.ZulipMessage
+	if json.Unmarshal([]byte(formattedMessage), &message) == nil && message.Content != "" {
+		if message.Topic == "" {
+			message.Topic = zulipDefaultTopic
+		}
+		return message
+	}
+
+	return port.ZulipMessage{Topic: zulipDefaultTopic, Content: formattedMessage}
+}
+
+// parseZulipRetryAfter извлекает задержку перед повтором из тела ответа Zulip со статусом 429
+// Zulip указывает задержку в секундах с дробной частью в поле retry-after вместе с кодом RATE_LIMIT_HIT
+func parseZulipRetryAfter(body []byte) time.Duration {
+	var rateLimit zulipRateLimitResponse
+	if err := json.Unmarshal(body, &rateLimit); err != nil || rateLimit.RetryAfter <= 0 {
+		return 0
+	}
+
+	return time.Duration(rateLimit.RetryAfter * float64(time.Second))
+}
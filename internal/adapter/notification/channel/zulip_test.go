@@ -0,0 +1,198 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestZulipChannel_Send(t *testing.T) {
+	type testCase struct {
+		name              string
+		cfg               config.ZulipConfig
+		chatID            string
+		message           string
+		responseStatus    int
+		responseBody      string
+		responseHeader    http.Header
+		httpError         error
+		expectRequest     bool
+		expectedBody      string
+		expectedError     string
+		expectedRetryable bool
+		expectedRetry     time.Duration
+	}
+
+	cfg := config.ZulipConfig{BotEmail: "notify-bot@zulip.example.com", APIKey: "api_key", SiteURL: "https://zulip.example.com/"}
+	topicMessage := `{"topic":"PROJ-1 Fix bug","content":"**Fix bug**"}`
+
+	testCases := []testCase{
+		{
+			name:           "Send_Message_With_Topic",
+			cfg:            cfg,
+			chatID:         "backend",
+			message:        topicMessage,
+			responseStatus: http.StatusOK,
+			responseBody:   `{"result":"success","msg":"","id":42}`,
+			expectRequest:  true,
+			expectedBody:   "content=%2A%2AFix+bug%2A%2A&to=backend&topic=PROJ-1+Fix+bug&type=stream",
+		},
+		{
+			name:           "Send_Plain_Text_To_Default_Topic",
+			cfg:            cfg,
+			chatID:         "backend",
+			message:        "Plain text",
+			responseStatus: http.StatusOK,
+			expectRequest:  true,
+			expectedBody:   "content=Plain+text&to=backend&topic=YouTrack&type=stream",
+		},
+		{
+			name:          "Send_Empty_Stream",
+			cfg:           cfg,
+			message:       topicMessage,
+			expectedError: "zulip stream is not configured",
+		},
+		{
+			name:          "Send_Without_API_Key",
+			cfg:           config.ZulipConfig{BotEmail: "notify-bot@zulip.example.com", SiteURL: "https://zulip.example.com"},
+			chatID:        "backend",
+			message:       topicMessage,
+			expectedError: "zulip bot credentials are not configured",
+		},
+		{
+			name:          "Send_Without_Site_URL",
+			cfg:           config.ZulipConfig{BotEmail: "notify-bot@zulip.example.com", APIKey: "api_key"},
+			chatID:        "backend",
+			message:       topicMessage,
+			expectedError: "zulip site URL is not configured",
+		},
+		{
+			name:              "Send_HTTP_Client_Error",
+			cfg:               cfg,
+			chatID:            "backend",
+			message:           topicMessage,
+			httpError:         errors.New("network error"),
+			expectRequest:     true,
+			expectedError:     "failed to send message",
+			expectedRetryable: true,
+		},
+		{
+			name:           "Send_Unknown_Stream",
+			cfg:            cfg,
+			chatID:         "unknown",
+			message:        topicMessage,
+			responseStatus: http.StatusBadRequest,
+			responseBody:   `{"result":"error","msg":"Stream 'unknown' does not exist","code":"STREAM_DOES_NOT_EXIST"}`,
+			expectRequest:  true,
+			expectedError:  "zulip API error: status 400",
+		},
+		{
+			name:              "Send_Server_Error",
+			cfg:               cfg,
+			chatID:            "backend",
+			message:           topicMessage,
+			responseStatus:    http.StatusBadGateway,
+			expectRequest:     true,
+			expectedError:     "zulip API error: status 502",
+			expectedRetryable: true,
+		},
+		{
+			name:              "Send_Too_Many_Requests",
+			cfg:               cfg,
+			chatID:            "backend",
+			message:           topicMessage,
+			responseStatus:    http.StatusTooManyRequests,
+			responseBody:      `{"result":"error","msg":"API usage exceeded rate limit","retry-after":2.5,"code":"RATE_LIMIT_HIT"}`,
+			responseHeader:    http.Header{"Retry-After": []string{"3"}},
+			expectRequest:     true,
+			expectedError:     "zulip API error: status 429",
+			expectedRetryable: true,
+			expectedRetry:     2500 * time.Millisecond,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			if tc.expectRequest {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodPost {
+						t.Errorf("expected method POST, got: %s", req.Method)
+					}
+					if expectedURL := "https://zulip.example.com/api/v1/messages"; req.URL.String() != expectedURL {
+						t.Errorf("expected URL %s, got: %s", expectedURL, req.URL.String())
+					}
+					if contentType := req.Header.Get("Content-Type"); contentType != "application/x-www-form-urlencoded" {
+						t.Errorf("expected Content-Type application/x-www-form-urlencoded, got: %s", contentType)
+					}
+					if user, password, ok := req.BasicAuth(); !ok || user != "notify-bot@zulip.example.com" || password != "api_key" {
+						t.Errorf("expected basic auth with bot email and API key, got: %q %q", user, password)
+					}
+					body, _ := io.ReadAll(req.Body)
+					if tc.expectedBody != "" && string(body) != tc.expectedBody {
+						t.Errorf("expected body %s, got: %s", tc.expectedBody, string(body))
+					}
+
+					if tc.httpError != nil {
+						return nil, tc.httpError
+					}
+					header := tc.responseHeader
+					if header == nil {
+						header = http.Header{}
+					}
+					return &http.Response{
+						StatusCode: tc.responseStatus,
+						Header:     header,
+						Body:       io.NopCloser(strings.NewReader(tc.responseBody)),
+					}, nil
+				})
+			}
+
+			channel := NewZulipChannel(tc.cfg, logger, mockHTTPClient)
+
+			err := channel.Send(context.Background(), tc.chatID, tc.message)
+
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Fatalf("expected error containing %q, got: %v", tc.expectedError, err)
+			}
+			if retryable := port.IsRetryable(err); retryable != tc.expectedRetryable {
+				t.Errorf("expected retryable %v, got: %v", tc.expectedRetryable, retryable)
+			}
+			if retryAfter := port.RetryAfter(err); retryAfter != tc.expectedRetry {
+				t.Errorf("expected retry after %v, got: %v", tc.expectedRetry, retryAfter)
+			}
+		})
+	}
+}
+
+func TestZulipChannel_Channel(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	channel := NewZulipChannel(config.ZulipConfig{}, logger, nil)
+
+	if name := channel.Channel(); name != port.ChannelZulip {
+		t.Errorf("expected channel %q, got: %q", port.ChannelZulip, name)
+	}
+}
@@ -0,0 +1,61 @@
+package notification
+
+import (
+	"github.com/beliaev-aa/notifications/internal/config"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// randFloat используется для тестирования - позволяет подменить источник случайного отклонения задержки
+var randFloat = rand.Float64
+
+// retryPolicy описывает повторную отправку уведомления с экспоненциальной задержкой
+type retryPolicy struct {
+	maxAttempts     int
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	multiplier      float64
+	jitter          float64
+	maxAge          time.Duration
+}
+
+// newRetryPolicy создает политику повторной отправки из конфигурации
+// Пустая конфигурация означает одну попытку без повторов
+func newRetryPolicy(cfg config.RetryPolicyConfig) retryPolicy {
+	policy := retryPolicy{
+		maxAttempts:     cfg.MaxAttempts,
+		initialInterval: time.Duration(cfg.InitialInterval) * time.Second,
+		maxInterval:     time.Duration(cfg.MaxInterval) * time.Second,
+		multiplier:      cfg.Multiplier,
+		maxAge:          time.Duration(cfg.MaxAge) * time.Second,
+	}
+
+	if cfg.Jitter != nil {
+		policy.jitter = *cfg.Jitter
+	}
+	if policy.maxAttempts < 1 {
+		policy.maxAttempts = 1
+	}
+	if policy.multiplier < 1 {
+		policy.multiplier = 1
+	}
+
+	return policy
+}
+
+// backoff возвращает задержку перед повтором после неудачной попытки с номером attempt (начиная с 1)
+// Задержка растет в multiplier раз с каждой попыткой, ограничивается maxInterval
+// и случайно отклоняется на долю jitter в обе стороны, чтобы повторы разных сообщений не совпадали по времени
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.initialInterval) * math.Pow(p.multiplier, float64(attempt-1))
+	if p.maxInterval > 0 && delay > float64(p.maxInterval) {
+		delay = float64(p.maxInterval)
+	}
+
+	if p.jitter > 0 {
+		delay += delay * p.jitter * (2*randFloat() - 1)
+	}
+
+	return time.Duration(delay)
+}
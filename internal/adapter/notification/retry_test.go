@@ -0,0 +1,92 @@
+package notification
+
+import (
+	"github.com/beliaev-aa/notifications/internal/config"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	type testCase struct {
+		name          string
+		config        config.RetryPolicyConfig
+		random        float64
+		attempt       int
+		expectedDelay time.Duration
+	}
+
+	baseConfig := config.RetryPolicyConfig{
+		MaxAttempts:     5,
+		InitialInterval: 1,
+		MaxInterval:     10,
+		Multiplier:      2,
+	}
+	jitterConfig := baseConfig
+	jitter := 0.5
+	jitterConfig.Jitter = &jitter
+
+	testCases := []testCase{
+		{
+			name:          "First_Retry_Uses_Initial_Interval",
+			config:        baseConfig,
+			attempt:       1,
+			expectedDelay: time.Second,
+		},
+		{
+			name:          "Delay_Grows_Exponentially",
+			config:        baseConfig,
+			attempt:       3,
+			expectedDelay: 4 * time.Second,
+		},
+		{
+			name:          "Delay_Capped_By_Max_Interval",
+			config:        baseConfig,
+			attempt:       6,
+			expectedDelay: 10 * time.Second,
+		},
+		{
+			name:          "Jitter_Lower_Bound",
+			config:        jitterConfig,
+			random:        0,
+			attempt:       2,
+			expectedDelay: time.Second,
+		},
+		{
+			name:          "Jitter_Upper_Bound",
+			config:        jitterConfig,
+			random:        1,
+			attempt:       2,
+			expectedDelay: 3 * time.Second,
+		},
+		{
+			name:          "Multiplier_Below_One_Keeps_Interval",
+			config:        config.RetryPolicyConfig{InitialInterval: 2, Multiplier: 0.5},
+			attempt:       4,
+			expectedDelay: 2 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalRand := randFloat
+			randFloat = func() float64 { return tc.random }
+			defer func() {
+				randFloat = originalRand
+			}()
+
+			policy := newRetryPolicy(tc.config)
+
+			if delay := policy.backoff(tc.attempt); delay != tc.expectedDelay {
+				t.Errorf("expected delay %v, got: %v", tc.expectedDelay, delay)
+			}
+		})
+	}
+}
+
+func TestNewRetryPolicy_MinimumOneAttempt(t *testing.T) {
+	policy := newRetryPolicy(config.RetryPolicyConfig{})
+
+	if policy.maxAttempts != 1 {
+		t.Errorf("expected maxAttempts to be 1, got: %d", policy.maxAttempts)
+	}
+}
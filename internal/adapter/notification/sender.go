@@ -1,27 +1,55 @@
 package notification
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"github.com/beliaev-aa/notifications/internal/config"
 	"github.com/beliaev-aa/notifications/internal/domain/port"
 	"github.com/sirupsen/logrus"
+	"sort"
+	"time"
+)
+
+var (
+	// sleepFunc используется для тестирования - позволяет не ждать задержку между попытками
+	sleepFunc = sleepContext
+	// nowFunc используется для тестирования - позволяет подменить текущее время
+	nowFunc = time.Now
 )
 
 // Sender реализует порт NotificationSender для отправки уведомлений через различные каналы
+// Временные ошибки каналов повторяются согласно политике повторной отправки канала
+// При включенном автоматическом выключателе каждый регистрируемый канал оборачивается в него
 type Sender struct {
-	channels map[string]port.NotificationChannel
-	logger   *logrus.Logger
+	channels      map[string]port.NotificationChannel
+	breakers      map[string]*circuitBreaker
+	defaultPolicy retryPolicy
+	policies      map[string]retryPolicy
+	breakerCfg    config.CircuitBreakerConfig
+	logger        *logrus.Logger
 }
 
 // NewSender создает новый экземпляр отправителя уведомлений
-func NewSender(logger *logrus.Logger) port.NotificationSender {
+func NewSender(retryCfg config.RetryConfig, breakerCfg config.CircuitBreakerConfig, logger *logrus.Logger) port.NotificationSender {
+	policies := make(map[string]retryPolicy, len(retryCfg.Channels))
+	for channelName, policyCfg := range retryCfg.Channels {
+		policies[channelName] = newRetryPolicy(policyCfg)
+	}
+
 	return &Sender{
-		channels: make(map[string]port.NotificationChannel),
-		logger:   logger,
+		channels:      make(map[string]port.NotificationChannel),
+		breakers:      make(map[string]*circuitBreaker),
+		defaultPolicy: newRetryPolicy(retryCfg.RetryPolicyConfig),
+		policies:      policies,
+		breakerCfg:    breakerCfg,
+		logger:        logger,
 	}
 }
 
 // Send отправляет уведомление через указанный канал
-func (s *Sender) Send(channel string, chatID string, formattedMessage string) error {
+// Повторы прекращаются при отмене контекста и если задержка перед следующей попыткой выходит за его предельное время
+func (s *Sender) Send(ctx context.Context, channel string, chatID string, formattedMessage string) error {
 	if formattedMessage == "" {
 		return fmt.Errorf("formatted message cannot be empty")
 	}
@@ -31,7 +59,69 @@ func (s *Sender) Send(channel string, chatID string, formattedMessage string) er
 		return fmt.Errorf("channel '%s' is not registered", channel)
 	}
 
-	return ch.Send(chatID, formattedMessage)
+	policy := s.policyFor(channel)
+	startedAt := nowFunc()
+
+	for attempt := 1; ; attempt++ {
+		err := ch.Send(ctx, chatID, formattedMessage)
+		if err == nil {
+			return nil
+		}
+		// Разомкнутый выключатель не ждем: задержка заняла бы обработчик очереди и задержала доставку в другие каналы
+		if !port.IsRetryable(err) || errors.Is(err, port.ErrCircuitOpen) {
+			if attempt > 1 {
+				return &port.AttemptsError{Attempts: attempt, Err: err}
+			}
+			return err
+		}
+		if attempt >= policy.maxAttempts {
+			return &port.AttemptsError{Attempts: attempt, Err: err}
+		}
+
+		delay := policy.backoff(attempt)
+		if retryAfter := port.RetryAfter(err); retryAfter > delay {
+			delay = retryAfter
+		}
+		if policy.maxAge > 0 && nowFunc().Sub(startedAt)+delay > policy.maxAge {
+			return &port.AttemptsError{Attempts: attempt, Err: fmt.Errorf("retry max age exceeded: %w", err)}
+		}
+		if deadline, ok := ctx.Deadline(); ok && nowFunc().Add(delay).After(deadline) {
+			return &port.AttemptsError{Attempts: attempt, Err: fmt.Errorf("delivery deadline exceeded: %w", err)}
+		}
+
+		// Адресат не логируется: для части каналов это секрет (URL webhook, токен приложения)
+		s.logger.WithFields(logrus.Fields{
+			"channel":  channel,
+			"event_id": port.EventIDFromContext(ctx),
+			"attempt":  attempt,
+			"delay":    delay.String(),
+		}).WithError(err).Warn("Retrying notification delivery")
+
+		if sleepErr := sleepFunc(ctx, delay); sleepErr != nil {
+			return &port.AttemptsError{Attempts: attempt, Err: fmt.Errorf("retry interrupted: %w: %w", sleepErr, err)}
+		}
+	}
+}
+
+// sleepContext ожидает время d или отмены контекста
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// policyFor возвращает политику повторной отправки канала или политику по умолчанию
+func (s *Sender) policyFor(channel string) retryPolicy {
+	if policy, exists := s.policies[channel]; exists {
+		return policy
+	}
+	return s.defaultPolicy
 }
 
 // RegisterChannel регистрирует новый канал для отправки уведомлений
@@ -47,6 +137,38 @@ func (s *Sender) RegisterChannel(channel port.NotificationChannel) {
 		return
 	}
 
+	delete(s.breakers, channelName)
+	if s.breakerCfg.Enabled {
+		breaker := newCircuitBreaker(channel, s.breakerPolicyFor(channelName), s.logger)
+		s.breakers[channelName] = breaker
+		channel = breaker
+	}
+
 	s.channels[channelName] = channel
 	s.logger.WithField("channel", channelName).Info("Notification channel registered")
 }
+
+// breakerPolicyFor возвращает настройки автоматического выключателя канала или настройки по умолчанию
+func (s *Sender) breakerPolicyFor(channel string) config.CircuitBreakerPolicyConfig {
+	if policy, exists := s.breakerCfg.Channels[channel]; exists {
+		return policy
+	}
+	return s.breakerCfg.CircuitBreakerPolicyConfig
+}
+
+// ChannelHealth возвращает состояние автоматических выключателей каналов, отсортированное по названию канала
+// Каналы без выключателя в результат не попадают
+func (s *Sender) ChannelHealth() []port.ChannelHealth {
+	names := make([]string, 0, len(s.breakers))
+	for name := range s.breakers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	health := make([]port.ChannelHealth, 0, len(names))
+	for _, name := range names {
+		health = append(health, s.breakers[name].health())
+	}
+
+	return health
+}
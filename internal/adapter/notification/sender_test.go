@@ -1,12 +1,19 @@
 package notification
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/config"
 	"github.com/beliaev-aa/notifications/internal/domain/port"
 	"github.com/beliaev-aa/notifications/tests/mocks"
 	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewSender(t *testing.T) {
@@ -34,7 +41,7 @@ func TestNewSender(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			sender := NewSender(tc.logger)
+			sender := NewSender(config.RetryConfig{}, config.CircuitBreakerConfig{}, tc.logger)
 
 			if tc.checkNil {
 				if sender != nil {
@@ -126,13 +133,13 @@ func TestSender_Send(t *testing.T) {
 
 			logger := logrus.New()
 			logger.SetLevel(logrus.ErrorLevel)
-			sender := NewSender(logger).(*Sender)
+			sender := NewSender(config.RetryConfig{}, config.CircuitBreakerConfig{}, logger).(*Sender)
 
 			if tc.registerChannel {
 				mockChannel := mocks.NewMockNotificationChannel(ctrl)
 				mockChannel.EXPECT().Channel().Return(tc.channelName).AnyTimes()
 				if tc.message != "" || tc.name == "Send_With_Whitespace_Message" {
-					sendCall := mockChannel.EXPECT().Send("", tc.message)
+					sendCall := mockChannel.EXPECT().Send(gomock.Any(), "", tc.message)
 					if tc.channelError != nil {
 						sendCall.Return(tc.channelError)
 					} else {
@@ -142,7 +149,7 @@ func TestSender_Send(t *testing.T) {
 				sender.RegisterChannel(mockChannel)
 			}
 
-			err := sender.Send(tc.channel, "", tc.message)
+			err := sender.Send(context.Background(), tc.channel, "", tc.message)
 
 			if tc.expectedError {
 				if err == nil {
@@ -211,7 +218,7 @@ func TestSender_RegisterChannel(t *testing.T) {
 
 			logger := logrus.New()
 			logger.SetLevel(logrus.ErrorLevel)
-			sender := NewSender(logger).(*Sender)
+			sender := NewSender(config.RetryConfig{}, config.CircuitBreakerConfig{}, logger).(*Sender)
 
 			if tc.name == "RegisterChannel_Multiple_Channels" {
 				mockChannel1 := mocks.NewMockNotificationChannel(ctrl)
@@ -280,13 +287,13 @@ func TestSender_Integration(t *testing.T) {
 
 			logger := logrus.New()
 			logger.SetLevel(logrus.ErrorLevel)
-			sender := NewSender(logger).(*Sender)
+			sender := NewSender(config.RetryConfig{}, config.CircuitBreakerConfig{}, logger).(*Sender)
 
 			for i, channelName := range tc.channels {
 				mockChannel := mocks.NewMockNotificationChannel(ctrl)
 				mockChannel.EXPECT().Channel().Return(channelName).AnyTimes()
 				if tc.checkSend {
-					mockChannel.EXPECT().Send("", tc.messages[i]).Return(nil)
+					mockChannel.EXPECT().Send(gomock.Any(), "", tc.messages[i]).Return(nil)
 				}
 				sender.RegisterChannel(mockChannel)
 			}
@@ -297,7 +304,7 @@ func TestSender_Integration(t *testing.T) {
 
 			if tc.checkSend {
 				for i, channelName := range tc.channels {
-					err := sender.Send(channelName, "", tc.messages[i])
+					err := sender.Send(context.Background(), channelName, "", tc.messages[i])
 					if err != nil {
 						t.Errorf("unexpected error sending to channel %q: %v", channelName, err)
 					}
@@ -355,14 +362,14 @@ func TestSender_EdgeCases(t *testing.T) {
 
 			logger := logrus.New()
 			logger.SetLevel(logrus.ErrorLevel)
-			sender := NewSender(logger).(*Sender)
+			sender := NewSender(config.RetryConfig{}, config.CircuitBreakerConfig{}, logger).(*Sender)
 
 			mockChannel := mocks.NewMockNotificationChannel(ctrl)
 			mockChannel.EXPECT().Channel().Return(tc.channel).AnyTimes()
-			mockChannel.EXPECT().Send("", tc.message).Return(nil)
+			mockChannel.EXPECT().Send(gomock.Any(), "", tc.message).Return(nil)
 			sender.RegisterChannel(mockChannel)
 
-			err := sender.Send(tc.channel, "", tc.message)
+			err := sender.Send(context.Background(), tc.channel, "", tc.message)
 
 			if tc.expectedError {
 				if err == nil {
@@ -376,3 +383,324 @@ func TestSender_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestSender_Send_Retry(t *testing.T) {
+	type testCase struct {
+		name             string
+		retryConfig      config.RetryConfig
+		channelErrors    []error
+		deadline         time.Duration // Предельное время доставки от начала отправки, 0 - без ограничения
+		canceled         bool
+		expectedAttempts int
+		expectedDelays   []time.Duration
+		expectedErrorMsg string
+	}
+
+	transientErr := port.NewDeliveryError("test_channel", http.StatusBadGateway, errors.New("bad gateway"))
+	permanentErr := port.NewDeliveryError("test_channel", http.StatusForbidden, errors.New("bot was kicked"))
+	rateLimitedErr := port.NewDeliveryError("test_channel", http.StatusTooManyRequests, errors.New("too many requests"))
+	rateLimitedErr.RetryAfter = 10 * time.Second
+	circuitOpenErr := port.NewDeliveryError("test_channel", 0, fmt.Errorf("test_channel: %w", port.ErrCircuitOpen))
+	circuitOpenErr.RetryAfter = 20 * time.Second
+
+	policy := config.RetryPolicyConfig{
+		MaxAttempts:     3,
+		InitialInterval: 1,
+		MaxInterval:     30,
+		Multiplier:      2,
+		MaxAge:          300,
+	}
+
+	testCases := []testCase{
+		{
+			name:             "Transient_Error_Retried_Until_Success",
+			retryConfig:      config.RetryConfig{RetryPolicyConfig: policy},
+			channelErrors:    []error{transientErr, transientErr, nil},
+			expectedAttempts: 3,
+			expectedDelays:   []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:             "Permanent_Error_Not_Retried",
+			retryConfig:      config.RetryConfig{RetryPolicyConfig: policy},
+			channelErrors:    []error{permanentErr},
+			expectedAttempts: 1,
+			expectedErrorMsg: "bot was kicked",
+		},
+		{
+			name:             "Permanent_Error_After_Retry",
+			retryConfig:      config.RetryConfig{RetryPolicyConfig: policy},
+			channelErrors:    []error{transientErr, permanentErr},
+			expectedAttempts: 2,
+			expectedDelays:   []time.Duration{time.Second},
+			expectedErrorMsg: "giving up after 2 attempts: bot was kicked",
+		},
+		{
+			name:             "Plain_Error_Not_Retried",
+			retryConfig:      config.RetryConfig{RetryPolicyConfig: policy},
+			channelErrors:    []error{errors.New("chat ID is not configured")},
+			expectedAttempts: 1,
+			expectedErrorMsg: "chat ID is not configured",
+		},
+		{
+			name:             "Max_Attempts_Exhausted",
+			retryConfig:      config.RetryConfig{RetryPolicyConfig: policy},
+			channelErrors:    []error{transientErr, transientErr, transientErr},
+			expectedAttempts: 3,
+			expectedDelays:   []time.Duration{time.Second, 2 * time.Second},
+			expectedErrorMsg: "giving up after 3 attempts: bad gateway",
+		},
+		{
+			name:             "Retry_After_Overrides_Backoff",
+			retryConfig:      config.RetryConfig{RetryPolicyConfig: policy},
+			channelErrors:    []error{rateLimitedErr, nil},
+			expectedAttempts: 2,
+			expectedDelays:   []time.Duration{10 * time.Second},
+		},
+		{
+			name: "Max_Age_Exceeded",
+			retryConfig: config.RetryConfig{RetryPolicyConfig: config.RetryPolicyConfig{
+				MaxAttempts:     5,
+				InitialInterval: 1,
+				MaxInterval:     30,
+				Multiplier:      2,
+				MaxAge:          5,
+			}},
+			channelErrors:    []error{rateLimitedErr},
+			expectedAttempts: 1,
+			expectedErrorMsg: "giving up after 1 attempts: retry max age exceeded: too many requests",
+		},
+		{
+			name: "Channel_Policy_Overrides_Default",
+			retryConfig: config.RetryConfig{
+				RetryPolicyConfig: policy,
+				Channels: map[string]config.RetryPolicyConfig{
+					"test_channel": {MaxAttempts: 1},
+				},
+			},
+			channelErrors:    []error{transientErr},
+			expectedAttempts: 1,
+			expectedErrorMsg: "giving up after 1 attempts: bad gateway",
+		},
+		{
+			name:             "Circuit_Open_Not_Retried",
+			retryConfig:      config.RetryConfig{RetryPolicyConfig: policy},
+			channelErrors:    []error{circuitOpenErr},
+			expectedAttempts: 1,
+			expectedErrorMsg: "test_channel: circuit breaker is open",
+		},
+		{
+			name:             "Circuit_Opened_During_Retry",
+			retryConfig:      config.RetryConfig{RetryPolicyConfig: policy},
+			channelErrors:    []error{transientErr, circuitOpenErr},
+			expectedAttempts: 2,
+			expectedDelays:   []time.Duration{time.Second},
+			expectedErrorMsg: "giving up after 2 attempts: test_channel: circuit breaker is open",
+		},
+		{
+			name:             "Stops_When_Next_Attempt_Exceeds_Delivery_Deadline",
+			retryConfig:      config.RetryConfig{RetryPolicyConfig: policy},
+			channelErrors:    []error{transientErr, transientErr},
+			deadline:         2 * time.Second,
+			expectedAttempts: 2,
+			expectedDelays:   []time.Duration{time.Second},
+			expectedErrorMsg: "giving up after 2 attempts: delivery deadline exceeded: bad gateway",
+		},
+		{
+			name:             "Canceled_Context_Interrupts_Retry",
+			retryConfig:      config.RetryConfig{RetryPolicyConfig: policy},
+			channelErrors:    []error{transientErr},
+			canceled:         true,
+			expectedAttempts: 1,
+			expectedErrorMsg: "giving up after 1 attempts: retry interrupted: context canceled: bad gateway",
+		},
+		{
+			name:             "Empty_Config_Does_Not_Retry",
+			retryConfig:      config.RetryConfig{},
+			channelErrors:    []error{transientErr},
+			expectedAttempts: 1,
+			expectedErrorMsg: "giving up after 1 attempts: bad gateway",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Предельное время контекста сравнивается с реальными часами, поэтому отсчет начинается с текущего момента
+			now := time.Now()
+			var delays []time.Duration
+			originalSleep, originalNow := sleepFunc, nowFunc
+			sleepFunc = func(ctx context.Context, d time.Duration) error {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				delays = append(delays, d)
+				now = now.Add(d)
+				return nil
+			}
+			nowFunc = func() time.Time { return now }
+			defer func() {
+				sleepFunc, nowFunc = originalSleep, originalNow
+			}()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			sender := NewSender(tc.retryConfig, config.CircuitBreakerConfig{}, logger).(*Sender)
+
+			mockChannel := mocks.NewMockNotificationChannel(ctrl)
+			mockChannel.EXPECT().Channel().Return("test_channel").AnyTimes()
+			attempts := 0
+			mockChannel.EXPECT().Send(gomock.Any(), "chat", "Test message").DoAndReturn(func(context.Context, string, string) error {
+				err := tc.channelErrors[attempts]
+				attempts++
+				return err
+			}).Times(tc.expectedAttempts)
+			sender.RegisterChannel(mockChannel)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.deadline > 0 {
+				ctx, cancel = context.WithDeadline(ctx, now.Add(tc.deadline))
+				defer cancel()
+			}
+			if tc.canceled {
+				cancel()
+			}
+
+			err := sender.Send(ctx, "test_channel", "chat", "Test message")
+
+			if tc.expectedErrorMsg != "" {
+				if err == nil {
+					t.Fatalf("expected error %q, got: nil", tc.expectedErrorMsg)
+				}
+				if err.Error() != tc.expectedErrorMsg {
+					t.Errorf("expected error message %q, got: %q", tc.expectedErrorMsg, err.Error())
+				}
+				if attempts := port.Attempts(err); attempts != tc.expectedAttempts {
+					t.Errorf("expected error to report %d attempts, got: %d", tc.expectedAttempts, attempts)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDelays, delays); diff != "" {
+				t.Errorf("delays mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSender_Send_Retry_Does_Not_Log_Chat_ID(t *testing.T) {
+	type testCase struct {
+		name    string
+		channel string
+		chatID  string
+	}
+
+	testCases := []testCase{
+		{
+			name:    "Slack_Webhook_URL",
+			channel: port.ChannelSlack,
+			chatID:  "https://hooks.slack.com/services/T000/B000/SECRET",
+		},
+		{
+			name:    "Gotify_Application_Token",
+			channel: port.ChannelGotify,
+			chatID:  "AbCdEfSecretToken",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			originalSleep := sleepFunc
+			sleepFunc = func(context.Context, time.Duration) error { return nil }
+			defer func() {
+				sleepFunc = originalSleep
+			}()
+
+			var output strings.Builder
+			logger := logrus.New()
+			logger.SetOutput(&output)
+			logger.SetLevel(logrus.DebugLevel)
+
+			retryConfig := config.RetryConfig{RetryPolicyConfig: config.RetryPolicyConfig{MaxAttempts: 2, InitialInterval: 1, MaxInterval: 1}}
+			sender := NewSender(retryConfig, config.CircuitBreakerConfig{}, logger).(*Sender)
+
+			mockChannel := mocks.NewMockNotificationChannel(ctrl)
+			mockChannel.EXPECT().Channel().Return(tc.channel).AnyTimes()
+			gomock.InOrder(
+				mockChannel.EXPECT().Send(gomock.Any(), tc.chatID, "Test message").Return(port.NewDeliveryError(tc.channel, http.StatusServiceUnavailable, errors.New("service unavailable"))),
+				mockChannel.EXPECT().Send(gomock.Any(), tc.chatID, "Test message").Return(nil),
+			)
+			sender.RegisterChannel(mockChannel)
+
+			if err := sender.Send(port.ContextWithEventID(context.Background(), "event-1"), tc.channel, tc.chatID, "Test message"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.Contains(output.String(), "Retrying notification delivery") {
+				t.Fatalf("expected retry to be logged, got: %s", output.String())
+			}
+			if strings.Contains(output.String(), tc.chatID) {
+				t.Errorf("expected log without chat ID, got: %s", output.String())
+			}
+		})
+	}
+}
+
+func TestSender_ChannelHealth(t *testing.T) {
+	type testCase struct {
+		name             string
+		breakerConfig    config.CircuitBreakerConfig
+		expectedChannels []string
+	}
+
+	testCases := []testCase{
+		{
+			name:             "Breaker_Disabled_Reports_No_Channels",
+			breakerConfig:    config.CircuitBreakerConfig{},
+			expectedChannels: []string{},
+		},
+		{
+			name: "Breaker_Enabled_Reports_Channels_Sorted",
+			breakerConfig: config.CircuitBreakerConfig{
+				Enabled:                    true,
+				CircuitBreakerPolicyConfig: config.CircuitBreakerPolicyConfig{FailureThreshold: 5, OpenDuration: 30, HalfOpenProbes: 1},
+			},
+			expectedChannels: []string{"telegram", "vkteams"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			sender := NewSender(config.RetryConfig{}, tc.breakerConfig, logger)
+
+			for _, name := range []string{"vkteams", "telegram"} {
+				mockChannel := mocks.NewMockNotificationChannel(ctrl)
+				mockChannel.EXPECT().Channel().Return(name).AnyTimes()
+				sender.RegisterChannel(mockChannel)
+			}
+
+			channels := make([]string, 0)
+			for _, health := range sender.ChannelHealth() {
+				if health.State != port.CircuitClosed {
+					t.Errorf("expected channel %s to be closed, got: %s", health.Channel, health.State)
+				}
+				channels = append(channels, health.Channel)
+			}
+
+			if diff := cmp.Diff(tc.expectedChannels, channels); diff != "" {
+				t.Errorf("channels mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
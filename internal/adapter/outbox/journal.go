@@ -0,0 +1,350 @@
+package outbox
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// journalFileName имя файла журнала в каталоге outbox
+	journalFileName = "journal.jsonl"
+	// compactFileName имя временного файла при сжатии журнала
+	compactFileName = "journal.jsonl.compact"
+)
+
+// Операции, записываемые в журнал
+const (
+	opAccept = "accept"
+	opTarget = "target"
+	opRemove = "remove"
+)
+
+// nowFunc используется для тестирования - позволяет подменить текущее время
+var nowFunc = time.Now
+
+// record описывает одну строку журнала
+type record struct {
+	Op      string                  `json:"op"`
+	ID      string                  `json:"id"`
+	Event   *port.NotificationEvent `json:"event,omitempty"`
+	Channel string                  `json:"channel,omitempty"`
+	ChatID  string                  `json:"chat_id,omitempty"`
+	State   port.DeliveryState      `json:"state,omitempty"`
+	At      time.Time               `json:"at"`
+}
+
+// entry содержит принятое событие и адресатов, доставка которым еще не завершена
+type entry struct {
+	event     port.NotificationEvent
+	remaining map[port.NotificationTarget]bool
+}
+
+// Journal реализует журнал принятых событий в виде append-only файла JSON Lines
+// Событие записывается с fsync до постановки в очередь, состояние доставки дописывается без fsync:
+// после сбоя событие может быть доставлено повторно, но не будет потеряно
+type Journal struct {
+	dir    string
+	file   *os.File
+	logger *logrus.Logger
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	order   []string
+}
+
+// NewJournal открывает журнал в каталоге dir, создавая каталог при необходимости
+// Записи существующего журнала загружаются в память для повторной доставки
+func NewJournal(dir string, logger *logrus.Logger) (port.Outbox, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create outbox directory %s: %w", dir, err)
+	}
+
+	j := &Journal{
+		dir:     dir,
+		logger:  logger,
+		entries: make(map[string]*entry),
+	}
+
+	if err := j.load(); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(j.path(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open outbox journal: %w", err)
+	}
+	j.file = file
+
+	return j, nil
+}
+
+// Append записывает принятое событие и сбрасывает журнал на диск
+func (j *Journal) Append(event port.NotificationEvent) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.write(record{Op: opAccept, ID: event.ID, Event: &event, At: nowFunc()}); err != nil {
+		return err
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync outbox journal: %w", err)
+	}
+
+	j.apply(record{Op: opAccept, ID: event.ID, Event: &event})
+	return nil
+}
+
+// MarkTarget записывает итоговое состояние доставки события адресату
+func (j *Journal) MarkTarget(eventID string, target port.NotificationTarget, state port.DeliveryState) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	rec := record{Op: opTarget, ID: eventID, Channel: target.Channel, ChatID: target.ChatID, State: state, At: nowFunc()}
+	if err := j.write(rec); err != nil {
+		return err
+	}
+
+	j.apply(rec)
+	return nil
+}
+
+// Remove исключает событие из журнала
+func (j *Journal) Remove(eventID string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	rec := record{Op: opRemove, ID: eventID, At: nowFunc()}
+	if err := j.write(rec); err != nil {
+		return err
+	}
+
+	j.apply(rec)
+	return nil
+}
+
+// Pending возвращает незавершенные события в порядке приема
+// В каждом событии остаются только адресаты, доставка которым не завершена
+func (j *Journal) Pending() []port.NotificationEvent {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.pendingLocked()
+}
+
+// pendingLocked возвращает незавершенные события, вызывается под блокировкой
+func (j *Journal) pendingLocked() []port.NotificationEvent {
+	pending := make([]port.NotificationEvent, 0, len(j.order))
+	for _, id := range j.order {
+		e := j.entries[id]
+		event := e.event
+		event.Targets = make([]port.NotificationTarget, 0, len(e.remaining))
+		for _, target := range e.event.Targets {
+			if e.remaining[target] {
+				event.Targets = append(event.Targets, target)
+			}
+		}
+		pending = append(pending, event)
+	}
+
+	return pending
+}
+
+// Compact перезаписывает журнал, оставляя только незавершенные события и их недоставленных адресатов
+// Новый журнал сначала пишется во временный файл и затем атомарно заменяет старый
+func (j *Journal) Compact() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	tmpPath := filepath.Join(j.dir, compactFileName)
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to create compacted outbox journal: %w", err)
+	}
+
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	for _, event := range j.pendingLocked() {
+		event := event
+		if err = encoder.Encode(record{Op: opAccept, ID: event.ID, Event: &event, At: event.ReceivedAt}); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write compacted outbox journal: %w", err)
+	}
+
+	if err = os.Rename(tmpPath, j.path()); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace outbox journal: %w", err)
+	}
+
+	// Старый дескриптор указывает на замененный файл, дальнейшие записи идут в новый журнал
+	if closeErr := j.file.Close(); closeErr != nil {
+		j.logger.WithError(closeErr).Warn("Failed to close previous outbox journal")
+	}
+	file, err := os.OpenFile(j.path(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to reopen outbox journal: %w", err)
+	}
+	j.file = file
+
+	j.logger.WithFields(logrus.Fields{
+		"pending": len(j.order),
+	}).Debug("Outbox journal compacted")
+
+	return nil
+}
+
+// Close сбрасывает журнал на диск и закрывает файл
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+
+	syncErr := j.file.Sync()
+	closeErr := j.file.Close()
+	j.file = nil
+
+	return errors.Join(syncErr, closeErr)
+}
+
+// load восстанавливает состояние из файла журнала
+// Поврежденные строки (например, недописанные при сбое) пропускаются
+func (j *Journal) load() error {
+	file, err := os.Open(j.path())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open outbox journal: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	reader := bufio.NewReaderSize(file, 64*1024)
+	line := 0
+	for {
+		data, readErr := reader.ReadBytes('\n')
+		if len(data) > 0 {
+			line++
+			var rec record
+			if json.Unmarshal(data, &rec) != nil || rec.ID == "" {
+				j.logger.WithFields(logrus.Fields{
+					"line": line,
+				}).Warn("Skipping corrupted outbox journal record")
+			} else {
+				j.apply(rec)
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("failed to read outbox journal: %w", readErr)
+		}
+	}
+
+	if len(j.order) > 0 {
+		j.logger.WithFields(logrus.Fields{
+			"pending": len(j.order),
+		}).Info("Loaded undelivered events from outbox journal")
+	}
+
+	return nil
+}
+
+// apply применяет запись журнала к состоянию в памяти
+func (j *Journal) apply(rec record) {
+	switch rec.Op {
+	case opAccept:
+		if rec.Event == nil {
+			return
+		}
+		if _, exists := j.entries[rec.ID]; exists {
+			return
+		}
+		remaining := make(map[port.NotificationTarget]bool, len(rec.Event.Targets))
+		for _, target := range rec.Event.Targets {
+			remaining[target] = true
+		}
+		j.entries[rec.ID] = &entry{event: *rec.Event, remaining: remaining}
+		j.order = append(j.order, rec.ID)
+		j.completeIfDone(rec.ID)
+	case opTarget:
+		e, exists := j.entries[rec.ID]
+		if !exists {
+			return
+		}
+		delete(e.remaining, port.NotificationTarget{Channel: rec.Channel, ChatID: rec.ChatID})
+		j.completeIfDone(rec.ID)
+	case opRemove:
+		j.forget(rec.ID)
+	}
+}
+
+// completeIfDone удаляет событие из памяти, если доставка всем адресатам завершена
+func (j *Journal) completeIfDone(id string) {
+	if e, exists := j.entries[id]; exists && len(e.remaining) == 0 {
+		j.forget(id)
+	}
+}
+
+// forget удаляет событие из памяти
+func (j *Journal) forget(id string) {
+	if _, exists := j.entries[id]; !exists {
+		return
+	}
+	delete(j.entries, id)
+	for i, orderedID := range j.order {
+		if orderedID == id {
+			j.order = append(j.order[:i], j.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// write дописывает запись в конец журнала
+func (j *Journal) write(rec record) error {
+	if j.file == nil {
+		return errors.New("outbox journal is closed")
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox record: %w", err)
+	}
+
+	if _, err = j.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write outbox journal: %w", err)
+	}
+
+	return nil
+}
+
+// path возвращает путь к файлу журнала
+func (j *Journal) path() string {
+	return filepath.Join(j.dir, journalFileName)
+}
@@ -0,0 +1,310 @@
+package outbox
+
+import (
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	telegramTarget = port.NotificationTarget{Channel: port.ChannelTelegram, ChatID: "tg_chat"}
+	vkteamsTarget  = port.NotificationTarget{Channel: port.ChannelVKTeams, ChatID: "vk_chat"}
+)
+
+func newTestLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+	return logger
+}
+
+func newTestEvent(id string, targets ...port.NotificationTarget) port.NotificationEvent {
+	summary := "Summary " + id
+	return port.NotificationEvent{
+		ID:         id,
+		Key:        "DEMO-" + id,
+		Project:    "demo",
+		Payload:    &parser.YoutrackWebhookPayload{Issue: parser.YoutrackIssue{IDReadable: "DEMO-" + id, Summary: summary}},
+		Targets:    targets,
+		ReceivedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func openJournal(t *testing.T, dir string) port.Outbox {
+	t.Helper()
+	journal, err := NewJournal(dir, newTestLogger())
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	return journal
+}
+
+func pendingIDs(events []port.NotificationEvent) []string {
+	ids := make([]string, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+	return ids
+}
+
+func TestNewJournal(t *testing.T) {
+	type testCase struct {
+		name          string
+		dir           func(t *testing.T) string
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "Creates_Missing_Directory",
+			dir: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "nested", "outbox")
+			},
+			expectedError: false,
+		},
+		{
+			name: "Directory_Is_File",
+			dir: func(t *testing.T) string {
+				path := filepath.Join(t.TempDir(), "file")
+				if err := os.WriteFile(path, nil, 0o600); err != nil {
+					t.Fatalf("failed to create file: %v", err)
+				}
+				return path
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			journal, err := NewJournal(tc.dir(t), newTestLogger())
+
+			if tc.expectedError {
+				if err == nil {
+					t.Error("expected error, got: nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err = journal.Close(); err != nil {
+				t.Errorf("unexpected close error: %v", err)
+			}
+		})
+	}
+}
+
+func TestJournal_Pending(t *testing.T) {
+	type testCase struct {
+		name            string
+		apply           func(t *testing.T, journal port.Outbox)
+		expectedIDs     []string
+		expectedTargets map[string][]port.NotificationTarget
+	}
+
+	testCases := []testCase{
+		{
+			name: "Accepted_Events_Are_Pending_In_Order",
+			apply: func(t *testing.T, journal port.Outbox) {
+				mustAppend(t, journal, newTestEvent("1", telegramTarget))
+				mustAppend(t, journal, newTestEvent("2", vkteamsTarget))
+			},
+			expectedIDs: []string{"1", "2"},
+			expectedTargets: map[string][]port.NotificationTarget{
+				"1": {telegramTarget},
+				"2": {vkteamsTarget},
+			},
+		},
+		{
+			name: "Delivered_Target_Removed_From_Event",
+			apply: func(t *testing.T, journal port.Outbox) {
+				mustAppend(t, journal, newTestEvent("1", telegramTarget, vkteamsTarget))
+				mustMark(t, journal, "1", telegramTarget, port.DeliveryStateDelivered)
+			},
+			expectedIDs: []string{"1"},
+			expectedTargets: map[string][]port.NotificationTarget{
+				"1": {vkteamsTarget},
+			},
+		},
+		{
+			name: "Event_Completed_When_All_Targets_Finished",
+			apply: func(t *testing.T, journal port.Outbox) {
+				mustAppend(t, journal, newTestEvent("1", telegramTarget, vkteamsTarget))
+				mustMark(t, journal, "1", telegramTarget, port.DeliveryStateDelivered)
+				mustMark(t, journal, "1", vkteamsTarget, port.DeliveryStateFailed)
+			},
+			expectedIDs: []string{},
+		},
+		{
+			name: "Removed_Event_Not_Pending",
+			apply: func(t *testing.T, journal port.Outbox) {
+				mustAppend(t, journal, newTestEvent("1", telegramTarget))
+				mustAppend(t, journal, newTestEvent("2", telegramTarget))
+				if err := journal.Remove("1"); err != nil {
+					t.Fatalf("unexpected remove error: %v", err)
+				}
+			},
+			expectedIDs: []string{"2"},
+		},
+		{
+			name: "Unknown_Event_State_Ignored",
+			apply: func(t *testing.T, journal port.Outbox) {
+				mustAppend(t, journal, newTestEvent("1", telegramTarget))
+				mustMark(t, journal, "unknown", telegramTarget, port.DeliveryStateDelivered)
+			},
+			expectedIDs: []string{"1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			journal := openJournal(t, dir)
+			tc.apply(t, journal)
+
+			assertPending := func(journal port.Outbox) {
+				pending := journal.Pending()
+				if diff := cmp.Diff(tc.expectedIDs, pendingIDs(pending)); diff != "" {
+					t.Errorf("pending mismatch (-want +got):\n%s", diff)
+				}
+				for _, event := range pending {
+					if expected, ok := tc.expectedTargets[event.ID]; ok {
+						if diff := cmp.Diff(expected, event.Targets); diff != "" {
+							t.Errorf("targets of %s mismatch (-want +got):\n%s", event.ID, diff)
+						}
+					}
+				}
+			}
+
+			assertPending(journal)
+			if err := journal.Close(); err != nil {
+				t.Fatalf("unexpected close error: %v", err)
+			}
+
+			// После перезапуска состояние восстанавливается из файла
+			reopened := openJournal(t, dir)
+			defer func() {
+				_ = reopened.Close()
+			}()
+			assertPending(reopened)
+		})
+	}
+}
+
+func TestJournal_Compact(t *testing.T) {
+	dir := t.TempDir()
+	journal := openJournal(t, dir)
+
+	for _, id := range []string{"1", "2", "3"} {
+		mustAppend(t, journal, newTestEvent(id, telegramTarget, vkteamsTarget))
+	}
+	mustMark(t, journal, "1", telegramTarget, port.DeliveryStateDelivered)
+	mustMark(t, journal, "1", vkteamsTarget, port.DeliveryStateDelivered)
+	mustMark(t, journal, "2", telegramTarget, port.DeliveryStateDelivered)
+
+	sizeBefore := fileSize(t, filepath.Join(dir, journalFileName))
+	if err := journal.Compact(); err != nil {
+		t.Fatalf("unexpected compact error: %v", err)
+	}
+	sizeAfter := fileSize(t, filepath.Join(dir, journalFileName))
+	if sizeAfter >= sizeBefore {
+		t.Errorf("expected journal to shrink, before: %d, after: %d", sizeBefore, sizeAfter)
+	}
+	if _, err := os.Stat(filepath.Join(dir, compactFileName)); !os.IsNotExist(err) {
+		t.Error("expected temporary compact file to be removed")
+	}
+
+	// Записи после сжатия попадают в новый файл журнала
+	mustAppend(t, journal, newTestEvent("4", telegramTarget))
+	if err := journal.Close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+
+	reopened := openJournal(t, dir)
+	defer func() {
+		_ = reopened.Close()
+	}()
+
+	pending := reopened.Pending()
+	if diff := cmp.Diff([]string{"2", "3", "4"}, pendingIDs(pending)); diff != "" {
+		t.Errorf("pending mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]port.NotificationTarget{vkteamsTarget}, pending[0].Targets); diff != "" {
+		t.Errorf("targets mismatch (-want +got):\n%s", diff)
+	}
+	if pending[0].Payload == nil || pending[0].Payload.Issue.Summary != "Summary 2" {
+		t.Error("expected payload to survive compaction")
+	}
+}
+
+func TestJournal_SkipsCorruptedRecords(t *testing.T) {
+	dir := t.TempDir()
+	journal := openJournal(t, dir)
+	mustAppend(t, journal, newTestEvent("1", telegramTarget))
+	if err := journal.Close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+
+	// Имитируем недописанную при сбое строку
+	file, err := os.OpenFile(filepath.Join(dir, journalFileName), os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatalf("failed to open journal file: %v", err)
+	}
+	if _, err = file.WriteString(`{"op":"accept","id":"2","event":{"id":"2"`); err != nil {
+		t.Fatalf("failed to write journal file: %v", err)
+	}
+	_ = file.Close()
+
+	reopened := openJournal(t, dir)
+	defer func() {
+		_ = reopened.Close()
+	}()
+
+	if diff := cmp.Diff([]string{"1"}, pendingIDs(reopened.Pending())); diff != "" {
+		t.Errorf("pending mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestJournal_ClosedJournalReturnsError(t *testing.T) {
+	journal := openJournal(t, t.TempDir())
+	if err := journal.Close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+
+	err := journal.Append(newTestEvent("1", telegramTarget))
+	if err == nil || !strings.Contains(err.Error(), "closed") {
+		t.Errorf("expected closed journal error, got: %v", err)
+	}
+	if err = journal.Close(); err != nil {
+		t.Errorf("expected repeated close to succeed, got: %v", err)
+	}
+}
+
+func mustAppend(t *testing.T, journal port.Outbox, event port.NotificationEvent) {
+	t.Helper()
+	if err := journal.Append(event); err != nil {
+		t.Fatalf("unexpected append error: %v", err)
+	}
+}
+
+func mustMark(t *testing.T, journal port.Outbox, id string, target port.NotificationTarget, state port.DeliveryState) {
+	t.Helper()
+	if err := journal.MarkTarget(id, target, state); err != nil {
+		t.Fatalf("unexpected mark error: %v", err)
+	}
+}
+
+func fileSize(t *testing.T, path string) int64 {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat %s: %v", path, err)
+	}
+	return info.Size()
+}
@@ -0,0 +1,200 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxIdleBuckets количество хранимых ограничителей чатов, после которого удаляются неактивные
+const maxIdleBuckets = 1024
+
+var (
+	// sleepFunc используется для тестирования - позволяет не ждать задержку отправки
+	sleepFunc = sleepContext
+	// nowFunc используется для тестирования - позволяет подменить текущее время
+	nowFunc = time.Now
+)
+
+// Limit ограничивает количество сообщений Count за период Per
+// Нулевое значение Count отключает ограничение
+type Limit struct {
+	Count int
+	Per   time.Duration
+}
+
+// enabled проверяет, задано ли ограничение
+func (l Limit) enabled() bool {
+	return l.Count > 0 && l.Per > 0
+}
+
+// Limits описывает ограничения частоты отправки в канал
+type Limits struct {
+	PerChat  Limit // Ограничение для каждого чата
+	PerGroup Limit // Дополнительное ограничение для каждого группового чата
+	Global   Limit // Ограничение для всех чатов вместе
+}
+
+// Limiter ограничивает частоту отправки сообщений алгоритмом token bucket
+// Ограничения применяются к чату, групповому чату и каналу в целом одновременно
+type Limiter struct {
+	limits  Limits
+	isGroup func(chatID string) bool
+
+	mu           sync.Mutex
+	global       *bucket
+	chats        map[string]*bucket
+	groups       map[string]*bucket
+	blockedUntil map[string]time.Time
+}
+
+// NewLimiter создает ограничитель частоты отправки
+// isGroup определяет, является ли чат групповым; nil означает, что групповых чатов нет
+func NewLimiter(limits Limits, isGroup func(chatID string) bool) *Limiter {
+	l := &Limiter{
+		limits:       limits,
+		isGroup:      isGroup,
+		chats:        make(map[string]*bucket),
+		groups:       make(map[string]*bucket),
+		blockedUntil: make(map[string]time.Time),
+	}
+	if limits.Global.enabled() {
+		l.global = newBucket(limits.Global, nowFunc())
+	}
+
+	return l
+}
+
+// Wait ожидает, пока отправка сообщения в чат станет допустимой, и возвращает время ожидания
+// Если контекст отменяется раньше, ожидание прерывается и возвращается ошибка контекста
+func (l *Limiter) Wait(ctx context.Context, chatID string) (time.Duration, error) {
+	delay := l.reserve(chatID)
+	if delay > 0 {
+		if err := sleepFunc(ctx, delay); err != nil {
+			return delay, err
+		}
+	}
+	return delay, nil
+}
+
+// Block запрещает отправку в чат на время d
+// Используется, когда API канала само сообщает о превышении лимита
+func (l *Limiter) Block(chatID string, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	until := nowFunc().Add(d)
+	if until.After(l.blockedUntil[chatID]) {
+		l.blockedUntil[chatID] = until
+	}
+}
+
+// reserve занимает место для сообщения во всех применимых ограничителях и возвращает необходимую задержку
+func (l *Limiter) reserve(chatID string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := nowFunc()
+	var delay time.Duration
+
+	if until, blocked := l.blockedUntil[chatID]; blocked {
+		if until.After(now) {
+			delay = until.Sub(now)
+		} else {
+			delete(l.blockedUntil, chatID)
+		}
+	}
+
+	if l.limits.PerChat.enabled() {
+		delay = max(delay, l.bucketFor(l.chats, chatID, l.limits.PerChat, now).reserve(now))
+	}
+	if l.limits.PerGroup.enabled() && l.isGroup != nil && l.isGroup(chatID) {
+		delay = max(delay, l.bucketFor(l.groups, chatID, l.limits.PerGroup, now).reserve(now))
+	}
+	if l.global != nil {
+		delay = max(delay, l.global.reserve(now))
+	}
+
+	return delay
+}
+
+// bucketFor возвращает ограничитель чата, создавая его при необходимости
+func (l *Limiter) bucketFor(buckets map[string]*bucket, chatID string, limit Limit, now time.Time) *bucket {
+	if b, exists := buckets[chatID]; exists {
+		return b
+	}
+
+	if len(buckets) >= maxIdleBuckets {
+		for id, b := range buckets {
+			if b.idle(now) {
+				delete(buckets, id)
+			}
+		}
+	}
+
+	b := newBucket(limit, now)
+	buckets[chatID] = b
+	return b
+}
+
+// bucket реализует token bucket с возможностью уйти в долг:
+// сообщение резервируется сразу, а задержка вычисляется по недостающим токенам
+type bucket struct {
+	capacity float64
+	rate     float64 // Токенов в секунду
+	tokens   float64
+	updated  time.Time
+}
+
+// newBucket создает заполненный token bucket
+func newBucket(limit Limit, now time.Time) *bucket {
+	return &bucket{
+		capacity: float64(limit.Count),
+		rate:     float64(limit.Count) / limit.Per.Seconds(),
+		tokens:   float64(limit.Count),
+		updated:  now,
+	}
+}
+
+// reserve занимает один токен и возвращает время до момента, когда он будет доступен
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.refill(now)
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// refill пополняет токены за время, прошедшее с последнего обновления
+func (b *bucket) refill(now time.Time) {
+	if !now.After(b.updated) {
+		return
+	}
+	b.tokens = min(b.capacity, b.tokens+now.Sub(b.updated).Seconds()*b.rate)
+	b.updated = now
+}
+
+// idle проверяет, что bucket полностью восстановился и его можно удалить без изменения поведения
+func (b *bucket) idle(now time.Time) bool {
+	b.refill(now)
+	return b.tokens >= b.capacity
+}
+
+// sleepContext ожидает время d или отмены контекста
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,194 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"testing"
+	"time"
+)
+
+func TestLimiter_Wait(t *testing.T) {
+	type send struct {
+		chatID string
+		at     time.Duration // Смещение от начала теста
+	}
+
+	type testCase struct {
+		name           string
+		limits         Limits
+		isGroup        func(chatID string) bool
+		sends          []send
+		expectedDelays []time.Duration
+	}
+
+	isGroup := func(chatID string) bool {
+		return chatID[0] == '-'
+	}
+
+	testCases := []testCase{
+		{
+			name:           "No_Limits_No_Delay",
+			limits:         Limits{},
+			sends:          []send{{"1", 0}, {"1", 0}, {"1", 0}},
+			expectedDelays: []time.Duration{0, 0, 0},
+		},
+		{
+			name:           "Per_Chat_Limit_Delays_Same_Chat",
+			limits:         Limits{PerChat: Limit{Count: 1, Per: time.Second}},
+			sends:          []send{{"1", 0}, {"1", 0}, {"1", 0}},
+			expectedDelays: []time.Duration{0, time.Second, 2 * time.Second},
+		},
+		{
+			name:           "Per_Chat_Limit_Independent_Chats",
+			limits:         Limits{PerChat: Limit{Count: 1, Per: time.Second}},
+			sends:          []send{{"1", 0}, {"2", 0}, {"3", 0}},
+			expectedDelays: []time.Duration{0, 0, 0},
+		},
+		{
+			name:           "Per_Chat_Limit_Refills_Over_Time",
+			limits:         Limits{PerChat: Limit{Count: 1, Per: time.Second}},
+			sends:          []send{{"1", 0}, {"1", 1500 * time.Millisecond}},
+			expectedDelays: []time.Duration{0, 0},
+		},
+		{
+			name:           "Global_Limit_Across_Chats",
+			limits:         Limits{Global: Limit{Count: 2, Per: time.Second}},
+			sends:          []send{{"1", 0}, {"2", 0}, {"3", 0}},
+			expectedDelays: []time.Duration{0, 0, 500 * time.Millisecond},
+		},
+		{
+			name:    "Group_Limit_Applies_Only_To_Groups",
+			limits:  Limits{PerGroup: Limit{Count: 2, Per: time.Minute}},
+			isGroup: isGroup,
+			sends:   []send{{"-100", 0}, {"-100", 0}, {"-100", 0}, {"42", 0}, {"42", 0}, {"42", 0}},
+			expectedDelays: []time.Duration{
+				0, 0, 30 * time.Second,
+				0, 0, 0,
+			},
+		},
+		{
+			name: "Longest_Delay_Wins",
+			limits: Limits{
+				PerChat:  Limit{Count: 1, Per: time.Second},
+				PerGroup: Limit{Count: 1, Per: time.Minute},
+			},
+			isGroup:        isGroup,
+			sends:          []send{{"-100", 0}, {"-100", 0}},
+			expectedDelays: []time.Duration{0, time.Minute},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+			now := start
+			var slept []time.Duration
+			originalSleep, originalNow := sleepFunc, nowFunc
+			sleepFunc = func(_ context.Context, d time.Duration) error {
+				slept = append(slept, d)
+				return nil
+			}
+			nowFunc = func() time.Time { return now }
+			defer func() {
+				sleepFunc, nowFunc = originalSleep, originalNow
+			}()
+
+			limiter := NewLimiter(tc.limits, tc.isGroup)
+
+			delays := make([]time.Duration, 0, len(tc.sends))
+			for _, s := range tc.sends {
+				now = start.Add(s.at)
+				delay, err := limiter.Wait(context.Background(), s.chatID)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				delays = append(delays, delay)
+			}
+
+			if diff := cmp.Diff(tc.expectedDelays, delays); diff != "" {
+				t.Errorf("delays mismatch (-want +got):\n%s", diff)
+			}
+
+			expectedSleeps := 0
+			for _, d := range tc.expectedDelays {
+				if d > 0 {
+					expectedSleeps++
+				}
+			}
+			if len(slept) != expectedSleeps {
+				t.Errorf("expected %d sleeps, got: %d", expectedSleeps, len(slept))
+			}
+		})
+	}
+}
+
+func TestLimiter_Block(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	originalSleep, originalNow := sleepFunc, nowFunc
+	sleepFunc = func(context.Context, time.Duration) error { return nil }
+	nowFunc = func() time.Time { return now }
+	defer func() {
+		sleepFunc, nowFunc = originalSleep, originalNow
+	}()
+
+	limiter := NewLimiter(Limits{}, nil)
+	limiter.Block("1", 10*time.Second)
+	limiter.Block("1", 5*time.Second)
+
+	if delay, _ := limiter.Wait(context.Background(), "1"); delay != 10*time.Second {
+		t.Errorf("expected blocked chat to wait 10s, got: %v", delay)
+	}
+	if delay, _ := limiter.Wait(context.Background(), "2"); delay != 0 {
+		t.Errorf("expected other chat not to wait, got: %v", delay)
+	}
+
+	now = now.Add(11 * time.Second)
+	if delay, _ := limiter.Wait(context.Background(), "1"); delay != 0 {
+		t.Errorf("expected chat to be unblocked, got delay: %v", delay)
+	}
+}
+
+func TestLimiter_EvictsIdleBuckets(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	originalNow := nowFunc
+	nowFunc = func() time.Time { return now }
+	defer func() {
+		nowFunc = originalNow
+	}()
+
+	limiter := NewLimiter(Limits{PerChat: Limit{Count: 1, Per: time.Second}}, nil)
+	for i := 0; i < maxIdleBuckets; i++ {
+		limiter.reserve(time.Duration(i).String())
+	}
+
+	now = now.Add(2 * time.Second)
+	limiter.reserve("new")
+
+	if len(limiter.chats) != 1 {
+		t.Errorf("expected idle buckets to be evicted, got: %d buckets", len(limiter.chats))
+	}
+}
+
+func TestLimiter_Wait_ContextCanceled(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	originalNow := nowFunc
+	nowFunc = func() time.Time { return now }
+	defer func() {
+		nowFunc = originalNow
+	}()
+
+	limiter := NewLimiter(Limits{}, nil)
+	limiter.Block("1", time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	delay, err := limiter.Wait(ctx, "1")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled error, got: %v", err)
+	}
+	if delay != time.Hour {
+		t.Errorf("expected delay 1h, got: %v", delay)
+	}
+}
@@ -12,12 +12,16 @@ import (
 // Parser парсит данные YouTrack webhook
 type Parser struct {
 	projectConfigService port.ProjectConfigService
+	channelFormatters    map[string]func(payload *parser.YoutrackWebhookPayload) string
 }
 
 // NewParser создает новый парсер
-func NewParser(projectConfigService port.ProjectConfigService) parser.YoutrackParser {
+// channelFormatters - форматирование каналов, зависящее от конфигурации (например, ID пользователей Slack для упоминаний),
+// регистрируется в каждом создаваемом форматтере; может быть nil
+func NewParser(projectConfigService port.ProjectConfigService, channelFormatters map[string]func(payload *parser.YoutrackWebhookPayload) string) parser.YoutrackParser {
 	return &Parser{
 		projectConfigService: projectConfigService,
+		channelFormatters:    channelFormatters,
 	}
 }
 
@@ -33,7 +37,11 @@ func (p *Parser) ParseJSON(payload []byte) (*parser.YoutrackWebhookPayload, erro
 
 // NewFormatter создает форматтер для YouTrack payload
 func (p *Parser) NewFormatter() parser.YoutrackFormatter {
-	return formatter.NewYoutrackFormatter()
+	youtrackFormatter := formatter.NewYoutrackFormatter()
+	for channel, channelFormatter := range p.channelFormatters {
+		youtrackFormatter.RegisterChannelFormatter(channel, channelFormatter)
+	}
+	return youtrackFormatter
 }
 
 // GetAllowedChannels возвращает разрешенные каналы для проекта из payload
@@ -61,6 +69,60 @@ func (p *Parser) GetVKTeamsChatID(projectName string) (string, bool) {
 	return p.projectConfigService.GetVKTeamsChatID(strings.ToLower(projectName))
 }
 
+// GetSlackChatID возвращает адресата Slack канала проекта
+func (p *Parser) GetSlackChatID(projectName string) (string, bool) {
+	return p.projectConfigService.GetSlackChatID(strings.ToLower(projectName))
+}
+
+// GetMattermostChatID возвращает адресата Mattermost канала проекта
+func (p *Parser) GetMattermostChatID(projectName string) (string, bool) {
+	return p.projectConfigService.GetMattermostChatID(strings.ToLower(projectName))
+}
+
+// GetMSTeamsChatID возвращает адресата Microsoft Teams канала проекта
+func (p *Parser) GetMSTeamsChatID(projectName string) (string, bool) {
+	return p.projectConfigService.GetMSTeamsChatID(strings.ToLower(projectName))
+}
+
+// GetDiscordChatID возвращает адресата Discord канала проекта
+func (p *Parser) GetDiscordChatID(projectName string) (string, bool) {
+	return p.projectConfigService.GetDiscordChatID(strings.ToLower(projectName))
+}
+
+// GetEmailChatID возвращает адресатов email канала проекта с учетом исполнителя задачи
+func (p *Parser) GetEmailChatID(projectName string, assignee *parser.YoutrackUser) (string, bool) {
+	assigneeEmail := ""
+	if assignee != nil && assignee.Email != nil {
+		assigneeEmail = *assignee.Email
+	}
+	return p.projectConfigService.GetEmailChatID(strings.ToLower(projectName), assigneeEmail)
+}
+
+// GetWebhookTargets возвращает имена целей webhook канала проекта
+func (p *Parser) GetWebhookTargets(projectName string) ([]string, bool) {
+	return p.projectConfigService.GetWebhookTargets(strings.ToLower(projectName))
+}
+
+// GetMatrixChatID возвращает адресата Matrix канала проекта
+func (p *Parser) GetMatrixChatID(projectName string) (string, bool) {
+	return p.projectConfigService.GetMatrixChatID(strings.ToLower(projectName))
+}
+
+// GetZulipChatID возвращает адресата Zulip канала проекта
+func (p *Parser) GetZulipChatID(projectName string) (string, bool) {
+	return p.projectConfigService.GetZulipChatID(strings.ToLower(projectName))
+}
+
+// GetNtfyChatID возвращает адресата ntfy канала проекта
+func (p *Parser) GetNtfyChatID(projectName string) (string, bool) {
+	return p.projectConfigService.GetNtfyChatID(strings.ToLower(projectName))
+}
+
+// GetGotifyChatID возвращает адресата Gotify канала проекта
+func (p *Parser) GetGotifyChatID(projectName string) (string, bool) {
+	return p.projectConfigService.GetGotifyChatID(strings.ToLower(projectName))
+}
+
 // GetSendDraftNotification возвращает настройку отправки уведомлений для черновиков проекта
 func (p *Parser) GetSendDraftNotification(projectName string) bool {
 	return p.projectConfigService.GetSendDraftNotification(strings.ToLower(projectName))
@@ -5,6 +5,7 @@ import (
 	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
 	"github.com/beliaev-aa/notifications/tests/mocks"
 	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
 	"strings"
 	"testing"
 )
@@ -30,7 +31,7 @@ func TestNewParser(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
-			p := NewParser(mockProjectConfig)
+			p := NewParser(mockProjectConfig, nil)
 
 			if tc.checkNil {
 				if p != nil {
@@ -221,7 +222,7 @@ func TestParser_ParseJSON(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
-			p := NewParser(mockProjectConfig)
+			p := NewParser(mockProjectConfig, nil)
 
 			result, err := p.ParseJSON(tc.payload)
 
@@ -277,7 +278,7 @@ func TestParser_NewFormatter(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
-			p := NewParser(mockProjectConfig)
+			p := NewParser(mockProjectConfig, nil)
 
 			formatter := p.NewFormatter()
 
@@ -300,6 +301,28 @@ func TestParser_NewFormatter(t *testing.T) {
 	}
 }
 
+func TestParser_NewFormatter_ChannelFormatters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
+	p := NewParser(mockProjectConfig, map[string]func(payload *parser.YoutrackWebhookPayload) string{
+		port.ChannelSlack: func(payload *parser.YoutrackWebhookPayload) string {
+			return "slack: " + payload.Issue.Summary
+		},
+	})
+
+	payload := &parser.YoutrackWebhookPayload{Issue: parser.YoutrackIssue{Summary: "Test issue"}}
+	formatter := p.NewFormatter()
+
+	if message := formatter.Format(payload, port.ChannelSlack); message != "slack: Test issue" {
+		t.Errorf("expected configured channel formatter to be used, got: %q", message)
+	}
+	if message := formatter.Format(payload, port.ChannelLogger); message == "slack: Test issue" {
+		t.Error("expected configured channel formatter to be used only for its channel")
+	}
+}
+
 func TestParser_Integration(t *testing.T) {
 	type testCase struct {
 		name           string
@@ -331,7 +354,7 @@ func TestParser_Integration(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
-			youtrackParser := NewParser(mockProjectConfig)
+			youtrackParser := NewParser(mockProjectConfig, nil)
 
 			payload, err := youtrackParser.ParseJSON(tc.payload)
 			if err != nil {
@@ -413,7 +436,7 @@ func TestParser_ParseJSON_EdgeCases(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
-			p := NewParser(mockProjectConfig)
+			p := NewParser(mockProjectConfig, nil)
 
 			result, err := p.ParseJSON(tc.payload)
 
@@ -511,7 +534,7 @@ func TestParser_GetAllowedChannels(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
-			p := NewParser(mockProjectConfig)
+			p := NewParser(mockProjectConfig, nil)
 
 			if tc.payload.Project != nil && tc.payload.Project.Name != nil && *tc.payload.Project.Name != "" {
 				normalizedName := strings.ToLower(*tc.payload.Project.Name)
@@ -583,7 +606,7 @@ func TestParser_GetTelegramChatID(t *testing.T) {
 			normalizedName := strings.ToLower(tc.projectName)
 			mockProjectConfig.EXPECT().GetTelegramChatID(normalizedName).Return(tc.chatID, tc.hasChatID)
 
-			p := NewParser(mockProjectConfig)
+			p := NewParser(mockProjectConfig, nil)
 
 			chatID, hasChatID := p.GetTelegramChatID(tc.projectName)
 
@@ -648,7 +671,7 @@ func TestParser_GetAllowedChannels_CaseInsensitive(t *testing.T) {
 			mockProjectConfig.EXPECT().IsProjectAllowed(normalizedName).Return(true)
 			mockProjectConfig.EXPECT().GetAllowedChannels(normalizedName).Return(tc.allowedChannels)
 
-			p := NewParser(mockProjectConfig)
+			p := NewParser(mockProjectConfig, nil)
 
 			payload := &parser.YoutrackWebhookPayload{
 				Project: &parser.YoutrackFieldValue{
@@ -726,7 +749,7 @@ func TestParser_GetTelegramChatID_CaseInsensitive(t *testing.T) {
 			normalizedName := strings.ToLower(tc.projectNameCase)
 			mockProjectConfig.EXPECT().GetTelegramChatID(normalizedName).Return(tc.chatID, tc.hasChatID)
 
-			p := NewParser(mockProjectConfig)
+			p := NewParser(mockProjectConfig, nil)
 
 			chatID, hasChatID := p.GetTelegramChatID(tc.projectNameCase)
 
@@ -787,7 +810,7 @@ func TestParser_GetVKTeamsChatID(t *testing.T) {
 			normalizedName := strings.ToLower(tc.projectName)
 			mockProjectConfig.EXPECT().GetVKTeamsChatID(normalizedName).Return(tc.chatID, tc.hasChatID)
 
-			p := NewParser(mockProjectConfig)
+			p := NewParser(mockProjectConfig, nil)
 
 			chatID, hasChatID := p.GetVKTeamsChatID(tc.projectName)
 
@@ -802,6 +825,67 @@ func TestParser_GetVKTeamsChatID(t *testing.T) {
 	}
 }
 
+func TestParser_GetSlackChatID(t *testing.T) {
+	type testCase struct {
+		name              string
+		projectName       string
+		chatID            string
+		hasChatID         bool
+		expectedChatID    string
+		expectedHasChatID bool
+	}
+
+	testCases := []testCase{
+		{
+			name:              "GetSlackChatID_Project_With_Slack",
+			projectName:       "TestProject",
+			chatID:            "https://hooks.slack.com/services/T000/B000/XXX",
+			hasChatID:         true,
+			expectedChatID:    "https://hooks.slack.com/services/T000/B000/XXX",
+			expectedHasChatID: true,
+		},
+		{
+			name:              "GetSlackChatID_Project_Without_Slack",
+			projectName:       "TestProject",
+			chatID:            "",
+			hasChatID:         false,
+			expectedChatID:    "",
+			expectedHasChatID: false,
+		},
+		{
+			name:              "GetSlackChatID_Non_Existent_Project",
+			projectName:       "NonExistentProject",
+			chatID:            "",
+			hasChatID:         false,
+			expectedChatID:    "",
+			expectedHasChatID: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
+			normalizedName := strings.ToLower(tc.projectName)
+			mockProjectConfig.EXPECT().GetSlackChatID(normalizedName).Return(tc.chatID, tc.hasChatID)
+
+			p := NewParser(mockProjectConfig, nil)
+
+			chatID, hasChatID := p.GetSlackChatID(tc.projectName)
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chatID %q, got: %q", tc.expectedChatID, chatID)
+			}
+
+			if hasChatID != tc.expectedHasChatID {
+				t.Errorf("expected hasChatID %v, got: %v", tc.expectedHasChatID, hasChatID)
+			}
+		})
+	}
+}
+
 func TestParser_GetVKTeamsChatID_CaseInsensitive(t *testing.T) {
 	type testCase struct {
 		name              string
@@ -853,7 +937,7 @@ func TestParser_GetVKTeamsChatID_CaseInsensitive(t *testing.T) {
 			normalizedName := strings.ToLower(tc.projectNameCase)
 			mockProjectConfig.EXPECT().GetVKTeamsChatID(normalizedName).Return(tc.chatID, tc.hasChatID)
 
-			p := NewParser(mockProjectConfig)
+			p := NewParser(mockProjectConfig, nil)
 
 			chatID, hasChatID := p.GetVKTeamsChatID(tc.projectNameCase)
 
@@ -906,7 +990,7 @@ func TestParser_GetSendDraftNotification(t *testing.T) {
 			normalizedName := strings.ToLower(tc.projectName)
 			mockProjectConfig.EXPECT().GetSendDraftNotification(normalizedName).Return(tc.sendDraftNotification)
 
-			p := NewParser(mockProjectConfig)
+			p := NewParser(mockProjectConfig, nil)
 
 			result := p.GetSendDraftNotification(tc.projectName)
 
@@ -960,7 +1044,7 @@ func TestParser_GetSendDraftNotification_CaseInsensitive(t *testing.T) {
 			normalizedName := strings.ToLower(tc.projectNameCase)
 			mockProjectConfig.EXPECT().GetSendDraftNotification(normalizedName).Return(tc.sendDraftNotification)
 
-			p := NewParser(mockProjectConfig)
+			p := NewParser(mockProjectConfig, nil)
 
 			result := p.GetSendDraftNotification(tc.projectNameCase)
 
@@ -970,3 +1054,532 @@ func TestParser_GetSendDraftNotification_CaseInsensitive(t *testing.T) {
 		})
 	}
 }
+
+func TestParser_GetMattermostChatID(t *testing.T) {
+	type testCase struct {
+		name              string
+		projectName       string
+		chatID            string
+		hasChatID         bool
+		expectedChatID    string
+		expectedHasChatID bool
+	}
+
+	testCases := []testCase{
+		{
+			name:              "GetMattermostChatID_Project_With_Mattermost",
+			projectName:       "TestProject",
+			chatID:            "https://mattermost.example.com/hooks/xxx",
+			hasChatID:         true,
+			expectedChatID:    "https://mattermost.example.com/hooks/xxx",
+			expectedHasChatID: true,
+		},
+		{
+			name:              "GetMattermostChatID_Project_Without_Mattermost",
+			projectName:       "TestProject",
+			chatID:            "",
+			hasChatID:         false,
+			expectedChatID:    "",
+			expectedHasChatID: false,
+		},
+		{
+			name:              "GetMattermostChatID_Non_Existent_Project",
+			projectName:       "NonExistentProject",
+			chatID:            "",
+			hasChatID:         false,
+			expectedChatID:    "",
+			expectedHasChatID: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
+			normalizedName := strings.ToLower(tc.projectName)
+			mockProjectConfig.EXPECT().GetMattermostChatID(normalizedName).Return(tc.chatID, tc.hasChatID)
+
+			p := NewParser(mockProjectConfig, nil)
+
+			chatID, hasChatID := p.GetMattermostChatID(tc.projectName)
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chatID %q, got: %q", tc.expectedChatID, chatID)
+			}
+
+			if hasChatID != tc.expectedHasChatID {
+				t.Errorf("expected hasChatID %v, got: %v", tc.expectedHasChatID, hasChatID)
+			}
+		})
+	}
+}
+
+func TestParser_GetMSTeamsChatID(t *testing.T) {
+	type testCase struct {
+		name              string
+		projectName       string
+		chatID            string
+		hasChatID         bool
+		expectedChatID    string
+		expectedHasChatID bool
+	}
+
+	testCases := []testCase{
+		{
+			name:              "GetMSTeamsChatID_Project_With_MSTeams",
+			projectName:       "TestProject",
+			chatID:            "https://example.webhook.office.com/webhookb2/xxx",
+			hasChatID:         true,
+			expectedChatID:    "https://example.webhook.office.com/webhookb2/xxx",
+			expectedHasChatID: true,
+		},
+		{
+			name:              "GetMSTeamsChatID_Project_Without_MSTeams",
+			projectName:       "TestProject",
+			chatID:            "",
+			hasChatID:         false,
+			expectedChatID:    "",
+			expectedHasChatID: false,
+		},
+		{
+			name:              "GetMSTeamsChatID_Non_Existent_Project",
+			projectName:       "NonExistentProject",
+			chatID:            "",
+			hasChatID:         false,
+			expectedChatID:    "",
+			expectedHasChatID: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
+			normalizedName := strings.ToLower(tc.projectName)
+			mockProjectConfig.EXPECT().GetMSTeamsChatID(normalizedName).Return(tc.chatID, tc.hasChatID)
+
+			p := NewParser(mockProjectConfig, nil)
+
+			chatID, hasChatID := p.GetMSTeamsChatID(tc.projectName)
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chatID %q, got: %q", tc.expectedChatID, chatID)
+			}
+
+			if hasChatID != tc.expectedHasChatID {
+				t.Errorf("expected hasChatID %v, got: %v", tc.expectedHasChatID, hasChatID)
+			}
+		})
+	}
+}
+
+func TestParser_GetDiscordChatID(t *testing.T) {
+	type testCase struct {
+		name              string
+		projectName       string
+		chatID            string
+		hasChatID         bool
+		expectedChatID    string
+		expectedHasChatID bool
+	}
+
+	testCases := []testCase{
+		{
+			name:              "GetDiscordChatID_Project_With_Discord",
+			projectName:       "TestProject",
+			chatID:            "https://discord.com/api/webhooks/123/token",
+			hasChatID:         true,
+			expectedChatID:    "https://discord.com/api/webhooks/123/token",
+			expectedHasChatID: true,
+		},
+		{
+			name:              "GetDiscordChatID_Project_Without_Discord",
+			projectName:       "TestProject",
+			chatID:            "",
+			hasChatID:         false,
+			expectedChatID:    "",
+			expectedHasChatID: false,
+		},
+		{
+			name:              "GetDiscordChatID_Non_Existent_Project",
+			projectName:       "NonExistentProject",
+			chatID:            "",
+			hasChatID:         false,
+			expectedChatID:    "",
+			expectedHasChatID: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
+			normalizedName := strings.ToLower(tc.projectName)
+			mockProjectConfig.EXPECT().GetDiscordChatID(normalizedName).Return(tc.chatID, tc.hasChatID)
+
+			p := NewParser(mockProjectConfig, nil)
+
+			chatID, hasChatID := p.GetDiscordChatID(tc.projectName)
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chatID %q, got: %q", tc.expectedChatID, chatID)
+			}
+
+			if hasChatID != tc.expectedHasChatID {
+				t.Errorf("expected hasChatID %v, got: %v", tc.expectedHasChatID, hasChatID)
+			}
+		})
+	}
+}
+
+func TestParser_GetEmailChatID(t *testing.T) {
+	type testCase struct {
+		name                  string
+		projectName           string
+		assignee              *parser.YoutrackUser
+		expectedAssigneeEmail string
+		chatID                string
+		hasChatID             bool
+	}
+
+	assigneeEmail := "john@example.com"
+
+	testCases := []testCase{
+		{
+			name:                  "GetEmailChatID_With_Assignee_Email",
+			projectName:           "TestProject",
+			assignee:              &parser.YoutrackUser{Email: &assigneeEmail},
+			expectedAssigneeEmail: assigneeEmail,
+			chatID:                "manager@example.com, john@example.com",
+			hasChatID:             true,
+		},
+		{
+			name:        "GetEmailChatID_Assignee_Without_Email",
+			projectName: "TestProject",
+			assignee:    &parser.YoutrackUser{},
+			chatID:      "manager@example.com",
+			hasChatID:   true,
+		},
+		{
+			name:        "GetEmailChatID_Without_Assignee",
+			projectName: "NonExistentProject",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
+			normalizedName := strings.ToLower(tc.projectName)
+			mockProjectConfig.EXPECT().GetEmailChatID(normalizedName, tc.expectedAssigneeEmail).Return(tc.chatID, tc.hasChatID)
+
+			p := NewParser(mockProjectConfig, nil)
+
+			chatID, hasChatID := p.GetEmailChatID(tc.projectName, tc.assignee)
+
+			if chatID != tc.chatID {
+				t.Errorf("expected chatID %q, got: %q", tc.chatID, chatID)
+			}
+
+			if hasChatID != tc.hasChatID {
+				t.Errorf("expected hasChatID %v, got: %v", tc.hasChatID, hasChatID)
+			}
+		})
+	}
+}
+
+func TestParser_GetWebhookTargets(t *testing.T) {
+	type testCase struct {
+		name        string
+		projectName string
+		targets     []string
+		hasTargets  bool
+	}
+
+	testCases := []testCase{
+		{
+			name:        "GetWebhookTargets_Project_With_Targets",
+			projectName: "TestProject",
+			targets:     []string{"deploy_bot", "bi_collector"},
+			hasTargets:  true,
+		},
+		{
+			name:        "GetWebhookTargets_Project_Not_Exists",
+			projectName: "NonExistentProject",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
+			mockProjectConfig.EXPECT().GetWebhookTargets(strings.ToLower(tc.projectName)).Return(tc.targets, tc.hasTargets)
+
+			p := NewParser(mockProjectConfig, nil)
+
+			targets, hasTargets := p.GetWebhookTargets(tc.projectName)
+
+			if diff := cmp.Diff(tc.targets, targets); diff != "" {
+				t.Errorf("targets mismatch (-want +got):\n%s", diff)
+			}
+
+			if hasTargets != tc.hasTargets {
+				t.Errorf("expected hasTargets %v, got: %v", tc.hasTargets, hasTargets)
+			}
+		})
+	}
+}
+
+func TestParser_GetMatrixChatID(t *testing.T) {
+	type testCase struct {
+		name              string
+		projectName       string
+		chatID            string
+		hasChatID         bool
+		expectedChatID    string
+		expectedHasChatID bool
+	}
+
+	testCases := []testCase{
+		{
+			name:              "GetMatrixChatID_Project_With_Matrix",
+			projectName:       "TestProject",
+			chatID:            "!abcdef:example.com",
+			hasChatID:         true,
+			expectedChatID:    "!abcdef:example.com",
+			expectedHasChatID: true,
+		},
+		{
+			name:              "GetMatrixChatID_Project_Without_Matrix",
+			projectName:       "TestProject",
+			chatID:            "",
+			hasChatID:         false,
+			expectedChatID:    "",
+			expectedHasChatID: false,
+		},
+		{
+			name:              "GetMatrixChatID_Non_Existent_Project",
+			projectName:       "NonExistentProject",
+			chatID:            "",
+			hasChatID:         false,
+			expectedChatID:    "",
+			expectedHasChatID: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
+			normalizedName := strings.ToLower(tc.projectName)
+			mockProjectConfig.EXPECT().GetMatrixChatID(normalizedName).Return(tc.chatID, tc.hasChatID)
+
+			p := NewParser(mockProjectConfig, nil)
+
+			chatID, hasChatID := p.GetMatrixChatID(tc.projectName)
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chatID %q, got: %q", tc.expectedChatID, chatID)
+			}
+
+			if hasChatID != tc.expectedHasChatID {
+				t.Errorf("expected hasChatID %v, got: %v", tc.expectedHasChatID, hasChatID)
+			}
+		})
+	}
+}
+
+func TestParser_GetZulipChatID(t *testing.T) {
+	type testCase struct {
+		name              string
+		projectName       string
+		chatID            string
+		hasChatID         bool
+		expectedChatID    string
+		expectedHasChatID bool
+	}
+
+	testCases := []testCase{
+		{
+			name:              "GetZulipChatID_Project_With_Zulip",
+			projectName:       "TestProject",
+			chatID:            "backend",
+			hasChatID:         true,
+			expectedChatID:    "backend",
+			expectedHasChatID: true,
+		},
+		{
+			name:              "GetZulipChatID_Project_Without_Zulip",
+			projectName:       "TestProject",
+			chatID:            "",
+			hasChatID:         false,
+			expectedChatID:    "",
+			expectedHasChatID: false,
+		},
+		{
+			name:              "GetZulipChatID_Non_Existent_Project",
+			projectName:       "NonExistentProject",
+			chatID:            "",
+			hasChatID:         false,
+			expectedChatID:    "",
+			expectedHasChatID: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
+			normalizedName := strings.ToLower(tc.projectName)
+			mockProjectConfig.EXPECT().GetZulipChatID(normalizedName).Return(tc.chatID, tc.hasChatID)
+
+			p := NewParser(mockProjectConfig, nil)
+
+			chatID, hasChatID := p.GetZulipChatID(tc.projectName)
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chatID %q, got: %q", tc.expectedChatID, chatID)
+			}
+
+			if hasChatID != tc.expectedHasChatID {
+				t.Errorf("expected hasChatID %v, got: %v", tc.expectedHasChatID, hasChatID)
+			}
+		})
+	}
+}
+
+func TestParser_GetNtfyChatID(t *testing.T) {
+	type testCase struct {
+		name              string
+		projectName       string
+		chatID            string
+		hasChatID         bool
+		expectedChatID    string
+		expectedHasChatID bool
+	}
+
+	testCases := []testCase{
+		{
+			name:              "GetNtfyChatID_Project_With_Ntfy",
+			projectName:       "TestProject",
+			chatID:            "backend-alerts",
+			hasChatID:         true,
+			expectedChatID:    "backend-alerts",
+			expectedHasChatID: true,
+		},
+		{
+			name:              "GetNtfyChatID_Project_Without_Ntfy",
+			projectName:       "TestProject",
+			chatID:            "",
+			hasChatID:         false,
+			expectedChatID:    "",
+			expectedHasChatID: false,
+		},
+		{
+			name:              "GetNtfyChatID_Non_Existent_Project",
+			projectName:       "NonExistentProject",
+			chatID:            "",
+			hasChatID:         false,
+			expectedChatID:    "",
+			expectedHasChatID: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
+			normalizedName := strings.ToLower(tc.projectName)
+			mockProjectConfig.EXPECT().GetNtfyChatID(normalizedName).Return(tc.chatID, tc.hasChatID)
+
+			p := NewParser(mockProjectConfig, nil)
+
+			chatID, hasChatID := p.GetNtfyChatID(tc.projectName)
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chatID %q, got: %q", tc.expectedChatID, chatID)
+			}
+
+			if hasChatID != tc.expectedHasChatID {
+				t.Errorf("expected hasChatID %v, got: %v", tc.expectedHasChatID, hasChatID)
+			}
+		})
+	}
+}
+
+func TestParser_GetGotifyChatID(t *testing.T) {
+	type testCase struct {
+		name              string
+		projectName       string
+		chatID            string
+		hasChatID         bool
+		expectedChatID    string
+		expectedHasChatID bool
+	}
+
+	testCases := []testCase{
+		{
+			name:              "GetGotifyChatID_Project_With_Gotify",
+			projectName:       "TestProject",
+			chatID:            "AbCdEf123",
+			hasChatID:         true,
+			expectedChatID:    "AbCdEf123",
+			expectedHasChatID: true,
+		},
+		{
+			name:              "GetGotifyChatID_Project_Without_Gotify",
+			projectName:       "TestProject",
+			chatID:            "",
+			hasChatID:         false,
+			expectedChatID:    "",
+			expectedHasChatID: false,
+		},
+		{
+			name:              "GetGotifyChatID_Non_Existent_Project",
+			projectName:       "NonExistentProject",
+			chatID:            "",
+			hasChatID:         false,
+			expectedChatID:    "",
+			expectedHasChatID: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
+			normalizedName := strings.ToLower(tc.projectName)
+			mockProjectConfig.EXPECT().GetGotifyChatID(normalizedName).Return(tc.chatID, tc.hasChatID)
+
+			p := NewParser(mockProjectConfig, nil)
+
+			chatID, hasChatID := p.GetGotifyChatID(tc.projectName)
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chatID %q, got: %q", tc.expectedChatID, chatID)
+			}
+
+			if hasChatID != tc.expectedHasChatID {
+				t.Errorf("expected hasChatID %v, got: %v", tc.expectedHasChatID, hasChatID)
+			}
+		})
+	}
+}
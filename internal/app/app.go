@@ -1,45 +1,190 @@
 package app
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/adapter/deadletter"
+	"github.com/beliaev-aa/notifications/internal/adapter/formatter"
 	"github.com/beliaev-aa/notifications/internal/adapter/http"
 	"github.com/beliaev-aa/notifications/internal/adapter/httpclient"
+	"github.com/beliaev-aa/notifications/internal/adapter/metrics"
 	"github.com/beliaev-aa/notifications/internal/adapter/notification"
 	"github.com/beliaev-aa/notifications/internal/adapter/notification/channel"
+	"github.com/beliaev-aa/notifications/internal/adapter/outbox"
 	"github.com/beliaev-aa/notifications/internal/adapter/youtrack"
 	"github.com/beliaev-aa/notifications/internal/config"
 	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
 	"github.com/beliaev-aa/notifications/internal/service"
 	"github.com/sirupsen/logrus"
+	"io"
+	"time"
 )
 
 // App представляет основное приложение с композицией всех зависимостей
 type App struct {
-	httpServer port.HTTPServer
+	httpServer    port.HTTPServer
+	deliveryQueue port.DeliveryQueue
+	lifecycle     *Lifecycle
+	logger        *logrus.Logger
 }
 
+const (
+	// Названия компонентов жизненного цикла приложения
+	componentChannels      = "notification_channels"
+	componentDeliveryQueue = "delivery_queue"
+	componentHTTPServer    = "http_server"
+)
+
 // NewApp создает новый экземпляр приложения с инициализированными зависимостями
-func NewApp(cfg *config.Config, logger *logrus.Logger) *App {
+// Возвращает ошибку, если не удалось создать канал отправки или открыть журнал принятых событий
+func NewApp(cfg *config.Config, logger *logrus.Logger) (*App, error) {
 	// Создаем и настраиваем отправитель уведомлений
-	notificationSender := setupNotificationSender(cfg, logger)
+	notificationSender, channelClosers, err := setupNotificationSender(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
 
 	// Создаем сервис конфигурации проектов
 	projectConfigService := service.NewProjectConfigService(cfg, logger)
 
-	youtrackParser := youtrack.NewParser(projectConfigService)
-	webhookService := service.NewWebhookService(notificationSender, youtrackParser, logger)
+	// Форматирование каналов, зависящее от конфигурации
+	youtrackParser := youtrack.NewParser(projectConfigService, map[string]func(payload *parser.YoutrackWebhookPayload) string{
+		port.ChannelSlack:      formatter.NewSlackFormatter(cfg.Slack.UserIDs),
+		port.ChannelMattermost: formatter.FormatMattermost,
+		port.ChannelMSTeams:    formatter.FormatMSTeams,
+		port.ChannelDiscord:    formatter.FormatDiscord,
+		port.ChannelEmail:      formatter.FormatEmail,
+		port.ChannelWebhook:    formatter.FormatWebhook,
+		port.ChannelMatrix:     formatter.NewMatrixFormatter(cfg.Matrix.UserIDs),
+		port.ChannelZulip:      formatter.FormatZulip,
+		port.ChannelNtfy:       formatter.FormatPush,
+		port.ChannelGotify:     formatter.FormatPush,
+		port.ChannelFile:       formatter.FormatFile,
+	})
+	// Проверки подлинности webhook запросов: токен источника, подпись тела, затем защита от повторов
+	// Защита от повторов выполняется последней, чтобы запоминать идентификаторы только подлинных запросов
+	webhookVerifier := service.NewVerifierChain(
+		service.NewTokenVerifier(cfg.Webhook.Tokens, projectConfigService, logger),
+		service.NewSignatureVerifier(cfg.Webhook, projectConfigService, logger),
+		service.NewReplayVerifier(cfg.Webhook, logger),
+	)
+	// Журнал принятых событий для повторной доставки после перезапуска (опционально)
+	var deliveryOutbox port.Outbox
+	if cfg.Outbox.Enabled {
+		journal, err := outbox.NewJournal(cfg.Outbox.Dir, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open outbox: %w", err)
+		}
+		deliveryOutbox = journal
+	}
+
+	// Хранилище уведомлений, доставка которых окончательно не удалась (опционально)
+	var deadLetterStore port.DeadLetterStore
+	if cfg.DeadLetter.Enabled {
+		store, err := deadletter.NewFileStore(cfg.DeadLetter.Dir, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open dead letter store: %w", err)
+		}
+		deadLetterStore = store
+	}
+
+	// Очередь доставки: форматирование и отправка уведомлений выполняются вне HTTP запроса
+	dispatcher := service.NewDispatcher(notificationSender, youtrackParser, deliveryOutbox, deadLetterStore, logger)
+	deliveryQueue := service.NewDeliveryQueue(cfg.Delivery, dispatcher, logger)
+	// Изменения одной задачи, полученные в течение coalesceWindow проекта, объединяются в одно уведомление
+	// Объединение выполняется после записи в журнал, поэтому отложенные события не теряются при сбое
+	deliveryQueue = service.NewCoalescingQueue(deliveryQueue, projectConfigService, logger)
+	if deliveryOutbox != nil {
+		deliveryQueue = service.NewOutboxQueue(deliveryQueue, deliveryOutbox, time.Duration(cfg.Outbox.CompactInterval)*time.Second, logger)
+	}
+	// Повторно полученные события отбрасываются, счетчики публикуются через expvar (/admin/debug/vars)
+	idempotencyGuard := service.NewIdempotencyGuard(cfg.Webhook.Idempotency)
+	webhookService := service.NewWebhookService(notificationSender, youtrackParser, webhookVerifier, deliveryQueue, idempotencyGuard, metrics.NewCounters(), logger)
+
+	var deadLetterService port.DeadLetterService
+	if deadLetterStore != nil {
+		deadLetterService = service.NewDeadLetterService(deadLetterStore, youtrackParser, deliveryQueue, logger)
+		if cfg.Admin.Token == "" {
+			logger.Warn("Admin token is not configured, dead letter admin API is disabled")
+		}
+	}
 
 	// Создаем HTTP адаптер с зависимостью
-	httpServer := http.NewServer(&cfg.HTTP, webhookService, logger)
+	httpServer := http.NewServer(&cfg.HTTP, cfg.Admin, cfg.Webhook.Response, webhookService, deadLetterService, notificationSender, logger)
 
 	return &App{
-		httpServer: httpServer,
+		httpServer:    httpServer,
+		deliveryQueue: deliveryQueue,
+		lifecycle:     newAppLifecycle(httpServer, deliveryQueue, channelClosers, time.Duration(cfg.HTTP.ShutdownTimeout)*time.Second, logger),
+		logger:        logger,
+	}, nil
+}
+
+// newAppLifecycle регистрирует компоненты приложения в менеджере жизненного цикла
+// HTTP сервер зависит от очереди доставки: он запускается после нее и останавливается раньше,
+// поэтому события, принятые до остановки сервера, успевают попасть в очередь и доставиться.
+// Каналы, которые нужно закрыть (channelClosers), закрываются после остановки очереди доставки
+func newAppLifecycle(httpServer port.HTTPServer, deliveryQueue port.DeliveryQueue, channelClosers []io.Closer, shutdownTimeout time.Duration, logger *logrus.Logger) *Lifecycle {
+	lifecycle := NewLifecycle(shutdownTimeout, logger)
+
+	var queueDependencies []string
+	if len(channelClosers) > 0 {
+		lifecycle.Register(componentChannels, channelsComponent{closers: channelClosers})
+		queueDependencies = append(queueDependencies, componentChannels)
+	}
+
+	var serverDependencies []string
+	if deliveryQueue != nil {
+		lifecycle.Register(componentDeliveryQueue, deliveryQueueComponent{queue: deliveryQueue}, queueDependencies...)
+		serverDependencies = append(serverDependencies, componentDeliveryQueue)
+	}
+	lifecycle.Register(componentHTTPServer, httpServer, serverDependencies...)
+
+	return lifecycle
+}
+
+// deliveryQueueComponent представляет очередь доставки как компонент жизненного цикла
+type deliveryQueueComponent struct {
+	queue port.DeliveryQueue
+}
+
+// Start запускает обработчики очереди доставки
+func (c deliveryQueueComponent) Start(_ context.Context) error {
+	c.queue.Start()
+	return nil
+}
+
+// Stop прекращает прием событий и ожидает доставки уже принятых до истечения контекста
+func (c deliveryQueueComponent) Stop(ctx context.Context) error {
+	return c.queue.Stop(ctx)
+}
+
+// channelsComponent представляет каналы, владеющие ресурсами (например, открытым файлом), как компонент жизненного цикла
+type channelsComponent struct {
+	closers []io.Closer
+}
+
+// Start ничего не выполняет: ресурсы каналов открываются при создании приложения
+func (c channelsComponent) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop закрывает каналы после остановки очереди доставки
+func (c channelsComponent) Stop(_ context.Context) error {
+	var errs []error
+	for _, closer := range c.closers {
+		errs = append(errs, closer.Close())
 	}
+	return errors.Join(errs...)
 }
 
 // setupNotificationSender создает и настраивает отправитель уведомлений с зарегистрированными каналами
-func setupNotificationSender(cfg *config.Config, logger *logrus.Logger) port.NotificationSender {
+// Возвращает каналы, которые нужно закрыть при остановке приложения, и ошибку, если канал не удалось создать по конфигурации
+func setupNotificationSender(cfg *config.Config, logger *logrus.Logger) (port.NotificationSender, []io.Closer, error) {
 	// Создаем отправитель уведомлений
-	notificationSender := notification.NewSender(logger)
+	notificationSender := notification.NewSender(cfg.Delivery.Retry, cfg.Delivery.CircuitBreaker, logger)
 
 	// Регистрируем каналы отправки уведомлений
 	notificationSender.RegisterChannel(channel.NewLoggerChannel(logger))
@@ -56,10 +201,99 @@ func setupNotificationSender(cfg *config.Config, logger *logrus.Logger) port.Not
 		notificationSender.RegisterChannel(channel.NewVKTeamsChannel(cfg.VKTeams, logger, httpclient.NewVKTeamsClient(cfg.VKTeams)))
 	}
 
-	return notificationSender
+	// Регистрируем Slack канал (используется для проектов со slack в allowedChannels)
+	// Slack канал создается, если указан bot_token или проекты отправляют уведомления через incoming webhook
+	if cfg.Slack.BotToken != "" || projectsUseChannel(cfg, port.ChannelSlack) {
+		notificationSender.RegisterChannel(channel.NewSlackChannel(cfg.Slack, logger, httpclient.NewSlackClient(cfg.Slack)))
+	}
+
+	// Регистрируем Mattermost канал (используется для проектов с mattermost в allowedChannels)
+	// Mattermost канал создается, если указан bot_token или проекты отправляют уведомления через incoming webhook
+	if cfg.Mattermost.BotToken != "" || projectsUseChannel(cfg, port.ChannelMattermost) {
+		notificationSender.RegisterChannel(channel.NewMattermostChannel(cfg.Mattermost, logger, httpclient.NewMattermostClient(cfg.Mattermost)))
+	}
+
+	// Регистрируем Microsoft Teams канал (используется для проектов с msteams в allowedChannels)
+	// Microsoft Teams канал создается, если проекты отправляют уведомления через webhook
+	if projectsUseChannel(cfg, port.ChannelMSTeams) {
+		notificationSender.RegisterChannel(channel.NewMSTeamsChannel(cfg.MSTeams, logger, httpclient.NewMSTeamsClient(cfg.MSTeams)))
+	}
+
+	// Регистрируем Discord канал (используется для проектов с discord в allowedChannels)
+	// Discord канал создается, если проекты отправляют уведомления через webhook
+	if projectsUseChannel(cfg, port.ChannelDiscord) {
+		notificationSender.RegisterChannel(channel.NewDiscordChannel(cfg.Discord, logger, httpclient.NewDiscordClient(cfg.Discord)))
+	}
+
+	// Регистрируем email канал (используется для проектов с email в allowedChannels)
+	// Email канал создается, если проекты отправляют уведомления по электронной почте
+	if projectsUseChannel(cfg, port.ChannelEmail) {
+		notificationSender.RegisterChannel(channel.NewEmailChannel(cfg.Email, logger))
+	}
+
+	// Регистрируем webhook канал (используется для проектов с webhook в allowedChannels)
+	// Шаблоны тела запроса разбираются при запуске, поэтому ошибка в шаблоне не позволяет запустить сервис
+	if projectsUseChannel(cfg, port.ChannelWebhook) {
+		webhookChannel, err := channel.NewWebhookChannel(cfg.OutgoingWebhook, logger, httpclient.NewOutgoingWebhookClient(cfg.OutgoingWebhook))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create webhook channel: %w", err)
+		}
+		notificationSender.RegisterChannel(webhookChannel)
+	}
+
+	// Регистрируем Matrix канал (используется для проектов с matrix в allowedChannels)
+	// Matrix канал создается только если указан access_token
+	if cfg.Matrix.AccessToken != "" {
+		notificationSender.RegisterChannel(channel.NewMatrixChannel(cfg.Matrix, logger, httpclient.NewMatrixClient(cfg.Matrix)))
+	}
+
+	// Регистрируем Zulip канал (используется для проектов с zulip в allowedChannels)
+	// Zulip канал создается только если указан API ключ бота
+	if cfg.Zulip.APIKey != "" {
+		notificationSender.RegisterChannel(channel.NewZulipChannel(cfg.Zulip, logger, httpclient.NewZulipClient(cfg.Zulip)))
+	}
+
+	// Регистрируем ntfy канал (используется для проектов с ntfy в allowedChannels)
+	// Сервер ntfy задан по умолчанию, поэтому канал создается, если он используется хотя бы в одном проекте
+	if projectsUseChannel(cfg, port.ChannelNtfy) {
+		notificationSender.RegisterChannel(channel.NewNtfyChannel(cfg.Ntfy, logger, httpclient.NewNtfyClient(cfg.Ntfy)))
+	}
+
+	// Регистрируем Gotify канал (используется для проектов с gotify в allowedChannels)
+	// Gotify канал создается только если указан URL сервера
+	if cfg.Gotify.ServerURL != "" {
+		notificationSender.RegisterChannel(channel.NewGotifyChannel(cfg.Gotify, logger, httpclient.NewGotifyClient(cfg.Gotify)))
+	}
+
+	// Регистрируем file канал (используется для проектов с file в allowedChannels)
+	// Файл архива открывается при запуске, поэтому ошибка открытия не позволяет запустить сервис
+	var closers []io.Closer
+	if projectsUseChannel(cfg, port.ChannelFile) {
+		fileChannel, err := channel.NewFileChannel(cfg.File, logger)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create file channel: %w", err)
+		}
+		notificationSender.RegisterChannel(fileChannel)
+		closers = append(closers, fileChannel)
+	}
+
+	return notificationSender, closers, nil
+}
+
+// projectsUseChannel проверяет, указан ли канал в allowedChannels хотя бы одного проекта
+func projectsUseChannel(cfg *config.Config, channelName string) bool {
+	for _, projectConfig := range cfg.Notifications.Youtrack.Projects {
+		for _, allowed := range projectConfig.AllowedChannels {
+			if allowed == channelName {
+				return true
+			}
+		}
+	}
+	return false
 }
 
-// Run запускает приложение
-func (a *App) Run() error {
-	return a.httpServer.Start()
+// Run запускает компоненты приложения и блокируется до сигнала SIGTERM/SIGINT или отмены контекста
+// При остановке ожидает доставки уже принятых уведомлений не дольше shutdown_timeout
+func (a *App) Run(ctx context.Context) error {
+	return a.lifecycle.Run(ctx)
 }
@@ -1,12 +1,20 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/service"
 	"github.com/beliaev-aa/notifications/tests/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/sirupsen/logrus"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewApp(t *testing.T) {
@@ -106,7 +114,10 @@ func TestNewApp(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			app := NewApp(tc.cfg, tc.logger)
+			app, err := NewApp(tc.cfg, tc.logger)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
 
 			if app == nil {
 				t.Error("expected app to be created, got: nil")
@@ -147,14 +158,27 @@ func TestApp_Run(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
 			mockHTTPServer := mocks.NewMockHTTPServer(ctrl)
 			app := &App{
 				httpServer: mockHTTPServer,
+				lifecycle:  newAppLifecycle(mockHTTPServer, nil, nil, time.Second, logger),
+				logger:     logger,
+			}
+
+			mockHTTPServer.EXPECT().Start(gomock.Any()).Return(tc.startError)
+			if tc.startError == nil {
+				mockHTTPServer.EXPECT().Failed().Return(nil)
+				mockHTTPServer.EXPECT().Stop(gomock.Any()).Return(nil)
 			}
 
-			mockHTTPServer.EXPECT().Start().Return(tc.startError)
+			// Отмененный контекст сразу запускает остановку приложения
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
 
-			err := app.Run()
+			err := app.Run(ctx)
 
 			if tc.expectError {
 				if err == nil {
@@ -218,7 +242,10 @@ func TestNewApp_Structure(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			app := NewApp(tc.cfg, tc.logger)
+			app, err := NewApp(tc.cfg, tc.logger)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
 
 			if tc.expectedNil {
 				if app != nil {
@@ -340,7 +367,10 @@ func TestApp_Integration(t *testing.T) {
 			tc.cfg.Telegram = tc.telegramConfig
 			tc.cfg.VKTeams = tc.vkteamsConfig
 
-			app := NewApp(tc.cfg, tc.logger)
+			app, err := NewApp(tc.cfg, tc.logger)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
 
 			if app == nil {
 				t.Fatal("expected app to be created, got: nil")
@@ -352,3 +382,458 @@ func TestApp_Integration(t *testing.T) {
 		})
 	}
 }
+
+func TestApp_Run_DeliveryQueue(t *testing.T) {
+	type testCase struct {
+		name        string
+		startError  error
+		stopError   error
+		expectError bool
+	}
+
+	testCases := []testCase{
+		{
+			name:        "Queue_Started_Before_Server_And_Drained_After_It",
+			startError:  nil,
+			stopError:   nil,
+			expectError: false,
+		},
+		{
+			name:        "Queue_Drained_After_Server_Error",
+			startError:  errors.New("server start error"),
+			stopError:   nil,
+			expectError: true,
+		},
+		{
+			name:        "Queue_Drain_Timeout_Does_Not_Fail_Run",
+			startError:  nil,
+			stopError:   context.DeadlineExceeded,
+			expectError: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockHTTPServer := mocks.NewMockHTTPServer(ctrl)
+			mockQueue := mocks.NewMockDeliveryQueue(ctrl)
+			app := &App{
+				httpServer:    mockHTTPServer,
+				deliveryQueue: mockQueue,
+				lifecycle:     newAppLifecycle(mockHTTPServer, mockQueue, nil, time.Second, logger),
+				logger:        logger,
+			}
+
+			calls := []*gomock.Call{
+				mockQueue.EXPECT().Start(),
+				mockHTTPServer.EXPECT().Start(gomock.Any()).Return(tc.startError),
+			}
+			if tc.startError == nil {
+				calls = append(calls,
+					mockHTTPServer.EXPECT().Failed().Return(nil),
+					mockHTTPServer.EXPECT().Stop(gomock.Any()).Return(nil),
+				)
+			}
+			calls = append(calls, mockQueue.EXPECT().Stop(gomock.Any()).Return(tc.stopError))
+			gomock.InOrder(calls...)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := app.Run(ctx)
+
+			if tc.expectError {
+				if !errors.Is(err, tc.startError) {
+					t.Errorf("expected error %v, got: %v", tc.startError, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewApp_Outbox(t *testing.T) {
+	type testCase struct {
+		name          string
+		dir           func(t *testing.T) string
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "Outbox_Journal_Created_In_Directory",
+			dir: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "outbox")
+			},
+			expectedError: false,
+		},
+		{
+			name: "Outbox_Directory_Is_File_Returns_Error",
+			dir: func(t *testing.T) string {
+				path := filepath.Join(t.TempDir(), "file")
+				if err := os.WriteFile(path, nil, 0o600); err != nil {
+					t.Fatalf("failed to create file: %v", err)
+				}
+				return path
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			cfg := &config.Config{
+				HTTP: config.HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 10,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Outbox: config.OutboxConfig{
+					Enabled:         true,
+					Dir:             tc.dir(t),
+					CompactInterval: 60,
+				},
+			}
+
+			app, err := NewApp(cfg, logger)
+
+			if tc.expectedError {
+				if err == nil {
+					t.Error("expected error, got: nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := app.deliveryQueue.(*service.OutboxQueue); !ok {
+				t.Errorf("expected delivery queue to be wrapped with outbox, got: %T", app.deliveryQueue)
+			}
+			if err = app.deliveryQueue.Stop(context.Background()); err != nil {
+				t.Errorf("unexpected error on stop: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewApp_WebhookChannel(t *testing.T) {
+	type testCase struct {
+		name          string
+		body          string
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Webhook_Channel_With_Valid_Template",
+			body:          `{"issue": {{json .Issue.IDReadable}}}`,
+			expectedError: false,
+		},
+		{
+			name:          "Webhook_Channel_With_Invalid_Template_Returns_Error",
+			body:          `{"issue": {{json .Issue.IDReadable}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			cfg := &config.Config{
+				HTTP: config.HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 10,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				OutgoingWebhook: config.OutgoingWebhookConfig{
+					Timeout: 10,
+					Targets: map[string]config.OutgoingWebhookTargetConfig{
+						"deploy_bot": {URL: "https://deploy.example.com/hooks/youtrack", Method: "POST", Body: tc.body},
+					},
+				},
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"demo": {
+								AllowedChannels: []string{"webhook"},
+								Webhook:         &config.ProjectWebhookConfig{Targets: []string{"deploy_bot"}},
+							},
+						},
+					},
+				},
+			}
+
+			app, err := NewApp(cfg, logger)
+
+			if tc.expectedError {
+				if err == nil {
+					t.Error("expected error, got: nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err = app.deliveryQueue.Stop(context.Background()); err != nil {
+				t.Errorf("unexpected error on stop: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewApp_FileChannel(t *testing.T) {
+	type testCase struct {
+		name          string
+		path          func(t *testing.T) string
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "File_Channel_Archive_Created",
+			path: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "archive", "notifications.jsonl")
+			},
+			expectedError: false,
+		},
+		{
+			name: "File_Channel_Path_Is_Directory_Returns_Error",
+			path: func(t *testing.T) string {
+				return t.TempDir()
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			path := tc.path(t)
+			cfg := &config.Config{
+				HTTP: config.HTTPConfig{
+					Addr:            "127.0.0.1:0",
+					ShutdownTimeout: 10,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				File: config.FileConfig{Path: path, Fsync: config.FileFsyncAlways, FsyncInterval: 1},
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"demo": {AllowedChannels: []string{"file"}},
+						},
+					},
+				},
+			}
+
+			app, err := NewApp(cfg, logger)
+
+			if tc.expectedError {
+				if err == nil {
+					t.Error("expected error, got: nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err = os.Stat(path); err != nil {
+				t.Errorf("expected archive file to be created: %v", err)
+			}
+
+			// Отмененный контекст сразу запускает остановку, file канал закрывается после очереди доставки
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			if err = app.Run(ctx); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// closerFunc реализует io.Closer функцией
+type closerFunc func() error
+
+func (f closerFunc) Close() error {
+	return f()
+}
+
+func TestApp_Run_Closes_Channels_After_DeliveryQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	var order []string
+	mockHTTPServer := mocks.NewMockHTTPServer(ctrl)
+	mockQueue := mocks.NewMockDeliveryQueue(ctrl)
+	closer := closerFunc(func() error {
+		order = append(order, "channels")
+		return errors.New("close error")
+	})
+
+	app := &App{
+		httpServer:    mockHTTPServer,
+		deliveryQueue: mockQueue,
+		lifecycle:     newAppLifecycle(mockHTTPServer, mockQueue, []io.Closer{closer}, time.Second, logger),
+		logger:        logger,
+	}
+
+	gomock.InOrder(
+		mockQueue.EXPECT().Start(),
+		mockHTTPServer.EXPECT().Start(gomock.Any()).Return(nil),
+		mockHTTPServer.EXPECT().Failed().Return(nil),
+		mockHTTPServer.EXPECT().Stop(gomock.Any()).DoAndReturn(func(_ context.Context) error {
+			order = append(order, "http_server")
+			return nil
+		}),
+		mockQueue.EXPECT().Stop(gomock.Any()).DoAndReturn(func(_ context.Context) error {
+			order = append(order, "delivery_queue")
+			return nil
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Ошибка закрытия канала только логируется и не влияет на результат
+	if err := app.Run(ctx); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	expected := []string{"http_server", "delivery_queue", "channels"}
+	if strings.Join(order, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected stop order %v, got: %v", expected, order)
+	}
+}
+
+func TestNewApp_DeadLetter(t *testing.T) {
+	type testCase struct {
+		name          string
+		dir           func(t *testing.T) string
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "Dead_Letter_Store_Created_In_Directory",
+			dir: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "dead-letters")
+			},
+			expectedError: false,
+		},
+		{
+			name: "Dead_Letter_Directory_Is_File_Returns_Error",
+			dir: func(t *testing.T) string {
+				path := filepath.Join(t.TempDir(), "file")
+				if err := os.WriteFile(path, nil, 0o600); err != nil {
+					t.Fatalf("failed to create file: %v", err)
+				}
+				return path
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			dir := tc.dir(t)
+			cfg := &config.Config{
+				HTTP: config.HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 10,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				DeadLetter: config.DeadLetterConfig{
+					Enabled: true,
+					Dir:     dir,
+				},
+				Admin: config.AdminConfig{
+					Token: "admin-secret",
+				},
+			}
+
+			app, err := NewApp(cfg, logger)
+
+			if tc.expectedError {
+				if err == nil {
+					t.Error("expected error, got: nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if app == nil {
+				t.Fatal("expected app to be created, got: nil")
+			}
+			if info, statErr := os.Stat(dir); statErr != nil || !info.IsDir() {
+				t.Errorf("expected dead letter directory to be created, got: %v", statErr)
+			}
+		})
+	}
+}
+
+func TestProjectsUseChannel(t *testing.T) {
+	type testCase struct {
+		name     string
+		projects map[string]config.ProjectConfig
+		channel  string
+		expected bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "Channel_Used_By_Project",
+			projects: map[string]config.ProjectConfig{
+				"demo":  {AllowedChannels: []string{"logger"}},
+				"slack": {AllowedChannels: []string{"logger", "slack"}},
+			},
+			channel:  port.ChannelSlack,
+			expected: true,
+		},
+		{
+			name: "Channel_Not_Used",
+			projects: map[string]config.ProjectConfig{
+				"demo": {AllowedChannels: []string{"telegram"}},
+			},
+			channel:  port.ChannelSlack,
+			expected: false,
+		},
+		{
+			name:     "No_Projects",
+			channel:  port.ChannelSlack,
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{Notifications: config.NotificationsConfig{Youtrack: config.YoutrackConfig{Projects: tc.projects}}}
+
+			if result := projectsUseChannel(cfg, tc.channel); result != tc.expected {
+				t.Errorf("expected %v, got: %v", tc.expected, result)
+			}
+		})
+	}
+}
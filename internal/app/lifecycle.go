@@ -0,0 +1,180 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// shutdownSignals сигналы, по которым приложение завершает работу
+var shutdownSignals = []os.Signal{syscall.SIGTERM, syscall.SIGINT}
+
+// managedComponent описывает зарегистрированный компонент и компоненты, от которых он зависит
+type managedComponent struct {
+	name      string
+	component port.Component
+	dependsOn []string
+}
+
+// componentFailure описывает аварийное завершение компонента после запуска
+type componentFailure struct {
+	name string
+	err  error
+}
+
+// Lifecycle управляет запуском и остановкой компонентов приложения
+// Компоненты запускаются так, чтобы зависимости запускались раньше зависящих от них компонентов,
+// и останавливаются в обратном порядке: например, HTTP сервер перестает принимать события до остановки очереди доставки
+type Lifecycle struct {
+	components      []managedComponent
+	shutdownTimeout time.Duration
+	logger          *logrus.Logger
+}
+
+// NewLifecycle создает менеджер жизненного цикла
+// shutdownTimeout ограничивает остановку всех компонентов вместе, включая доставку уже принятых уведомлений
+func NewLifecycle(shutdownTimeout time.Duration, logger *logrus.Logger) *Lifecycle {
+	return &Lifecycle{
+		shutdownTimeout: shutdownTimeout,
+		logger:          logger,
+	}
+}
+
+// Register добавляет компонент с уникальным названием и названиями компонентов, которые должны быть запущены раньше него
+// Компоненты без зависимостей между собой запускаются в порядке регистрации
+func (l *Lifecycle) Register(name string, component port.Component, dependsOn ...string) {
+	l.components = append(l.components, managedComponent{
+		name:      name,
+		component: component,
+		dependsOn: dependsOn,
+	})
+}
+
+// Run запускает компоненты и ожидает сигнала остановки, отмены контекста или аварийного завершения компонента,
+// после чего останавливает запущенные компоненты в обратном порядке не дольше shutdownTimeout
+// Возвращает ошибку запуска или аварийного завершения компонента; ошибки остановки только логируются
+func (l *Lifecycle) Run(ctx context.Context) error {
+	ordered, err := l.startOrder()
+	if err != nil {
+		return err
+	}
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, shutdownSignals...)
+	defer signal.Stop(quit)
+
+	started := make([]managedComponent, 0, len(ordered))
+	for _, c := range ordered {
+		if startErr := c.component.Start(ctx); startErr != nil {
+			l.logger.WithError(startErr).WithField("component", c.name).Error("Failed to start component")
+			l.stop(started)
+			return fmt.Errorf("failed to start %s: %w", c.name, startErr)
+		}
+		started = append(started, c)
+		l.logger.WithField("component", c.name).Debug("Component started")
+	}
+
+	failures := make(chan componentFailure, len(started))
+	done := make(chan struct{})
+	defer close(done)
+	for _, c := range started {
+		if reporter, ok := c.component.(port.FailureReporter); ok {
+			go watchFailure(c.name, reporter.Failed(), failures, done)
+		}
+	}
+
+	var runErr error
+	select {
+	case sig := <-quit:
+		l.logger.WithField("signal", sig.String()).Info("Shutdown signal received")
+	case <-ctx.Done():
+		l.logger.Info("Shutdown requested")
+	case failure := <-failures:
+		l.logger.WithError(failure.err).WithField("component", failure.name).Error("Component failed, shutting down")
+		runErr = fmt.Errorf("%s failed: %w", failure.name, failure.err)
+	}
+
+	l.stop(started)
+
+	return runErr
+}
+
+// stop останавливает компоненты в обратном порядке запуска с общим ограничением времени shutdownTimeout
+func (l *Lifecycle) stop(started []managedComponent) {
+	ctx, cancel := context.WithTimeout(context.Background(), l.shutdownTimeout)
+	defer cancel()
+
+	for i := len(started) - 1; i >= 0; i-- {
+		c := started[i]
+		if err := c.component.Stop(ctx); err != nil {
+			l.logger.WithError(err).WithField("component", c.name).Warn("Component was not stopped cleanly")
+			continue
+		}
+		l.logger.WithField("component", c.name).Debug("Component stopped")
+	}
+}
+
+// startOrder возвращает компоненты в порядке запуска: каждый компонент следует за своими зависимостями
+// Возвращает ошибку, если название повторяется, зависимость не зарегистрирована или зависимости образуют цикл
+func (l *Lifecycle) startOrder() ([]managedComponent, error) {
+	index := make(map[string]int, len(l.components))
+	for i, c := range l.components {
+		if _, exists := index[c.name]; exists {
+			return nil, fmt.Errorf("lifecycle: component %q is registered twice", c.name)
+		}
+		index[c.name] = i
+	}
+	for _, c := range l.components {
+		for _, dependency := range c.dependsOn {
+			if _, exists := index[dependency]; !exists {
+				return nil, fmt.Errorf("lifecycle: component %q depends on unknown component %q", c.name, dependency)
+			}
+		}
+	}
+
+	ordered := make([]managedComponent, 0, len(l.components))
+	placed := make(map[string]bool, len(l.components))
+	for len(ordered) < len(l.components) {
+		progressed := false
+		for _, c := range l.components {
+			if placed[c.name] || !dependenciesPlaced(c, placed) {
+				continue
+			}
+			ordered = append(ordered, c)
+			placed[c.name] = true
+			progressed = true
+			// Начинаем сначала, чтобы компоненты без взаимных зависимостей сохраняли порядок регистрации
+			break
+		}
+		if !progressed {
+			return nil, errors.New("lifecycle: components have circular dependencies")
+		}
+	}
+
+	return ordered, nil
+}
+
+// dependenciesPlaced проверяет, что все зависимости компонента уже включены в порядок запуска
+func dependenciesPlaced(c managedComponent, placed map[string]bool) bool {
+	for _, dependency := range c.dependsOn {
+		if !placed[dependency] {
+			return false
+		}
+	}
+	return true
+}
+
+// watchFailure передает ошибку аварийного завершения компонента, пока приложение не начало остановку
+func watchFailure(name string, failed <-chan error, failures chan<- componentFailure, done <-chan struct{}) {
+	select {
+	case err := <-failed:
+		failures <- componentFailure{name: name, err: err}
+	case <-done:
+	}
+}
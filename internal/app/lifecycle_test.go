@@ -0,0 +1,247 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordingComponent записывает вызовы запуска и остановки в общий журнал
+type recordingComponent struct {
+	name     string
+	journal  *[]string
+	startErr error
+	stopErr  error
+	failed   chan error
+	stopCtx  context.Context
+}
+
+func (c *recordingComponent) Start(_ context.Context) error {
+	*c.journal = append(*c.journal, "start "+c.name)
+	return c.startErr
+}
+
+func (c *recordingComponent) Stop(ctx context.Context) error {
+	*c.journal = append(*c.journal, "stop "+c.name)
+	c.stopCtx = ctx
+	return c.stopErr
+}
+
+// failingComponent дополнительно сообщает об аварийном завершении
+type failingComponent struct {
+	*recordingComponent
+}
+
+func (c failingComponent) Failed() <-chan error {
+	return c.failed
+}
+
+func TestLifecycle_Run(t *testing.T) {
+	type registration struct {
+		name      string
+		startErr  error
+		stopErr   error
+		fails     error
+		dependsOn []string
+	}
+
+	type testCase struct {
+		name            string
+		components      []registration
+		cancel          bool
+		expectedJournal []string
+		expectedError   string
+	}
+
+	testCases := []testCase{
+		{
+			name: "Starts_In_Dependency_Order_And_Stops_In_Reverse",
+			components: []registration{
+				{name: "http_server", dependsOn: []string{"delivery_queue"}},
+				{name: "delivery_queue", dependsOn: []string{"storage"}},
+				{name: "storage"},
+			},
+			cancel: true,
+			expectedJournal: []string{
+				"start storage", "start delivery_queue", "start http_server",
+				"stop http_server", "stop delivery_queue", "stop storage",
+			},
+		},
+		{
+			name: "Independent_Components_Keep_Registration_Order",
+			components: []registration{
+				{name: "first"},
+				{name: "second"},
+				{name: "third", dependsOn: []string{"first"}},
+			},
+			cancel: true,
+			expectedJournal: []string{
+				"start first", "start second", "start third",
+				"stop third", "stop second", "stop first",
+			},
+		},
+		{
+			name: "Start_Error_Stops_Started_Components",
+			components: []registration{
+				{name: "delivery_queue"},
+				{name: "http_server", startErr: errors.New("address in use"), dependsOn: []string{"delivery_queue"}},
+			},
+			expectedJournal: []string{"start delivery_queue", "start http_server", "stop delivery_queue"},
+			expectedError:   "failed to start http_server: address in use",
+		},
+		{
+			name: "Component_Failure_Shuts_Down",
+			components: []registration{
+				{name: "delivery_queue"},
+				{name: "http_server", fails: errors.New("listener closed"), dependsOn: []string{"delivery_queue"}},
+			},
+			expectedJournal: []string{
+				"start delivery_queue", "start http_server",
+				"stop http_server", "stop delivery_queue",
+			},
+			expectedError: "http_server failed: listener closed",
+		},
+		{
+			name: "Stop_Errors_Are_Not_Returned",
+			components: []registration{
+				{name: "delivery_queue", stopErr: context.DeadlineExceeded},
+				{name: "http_server", stopErr: errors.New("shutdown failed"), dependsOn: []string{"delivery_queue"}},
+			},
+			cancel: true,
+			expectedJournal: []string{
+				"start delivery_queue", "start http_server",
+				"stop http_server", "stop delivery_queue",
+			},
+		},
+		{
+			name: "Unknown_Dependency_Returns_Error",
+			components: []registration{
+				{name: "http_server", dependsOn: []string{"delivery_queue"}},
+			},
+			expectedError: `component "http_server" depends on unknown component "delivery_queue"`,
+		},
+		{
+			name: "Duplicate_Name_Returns_Error",
+			components: []registration{
+				{name: "http_server"},
+				{name: "http_server"},
+			},
+			expectedError: `component "http_server" is registered twice`,
+		},
+		{
+			name: "Circular_Dependencies_Return_Error",
+			components: []registration{
+				{name: "first", dependsOn: []string{"second"}},
+				{name: "second", dependsOn: []string{"first"}},
+			},
+			expectedError: "components have circular dependencies",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			var journal []string
+			lifecycle := NewLifecycle(time.Second, logger)
+			for _, r := range tc.components {
+				component := &recordingComponent{name: r.name, journal: &journal, startErr: r.startErr, stopErr: r.stopErr}
+				if r.fails != nil {
+					component.failed = make(chan error, 1)
+					component.failed <- r.fails
+					lifecycle.Register(r.name, failingComponent{component}, r.dependsOn...)
+					continue
+				}
+				lifecycle.Register(r.name, component, r.dependsOn...)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.cancel {
+				cancel()
+			}
+
+			err := lifecycle.Run(ctx)
+
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Errorf("expected error containing %q, got: %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.expectedJournal, journal); diff != "" {
+				t.Errorf("journal mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLifecycle_Run_StopDeadline(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	var journal []string
+	first := &recordingComponent{name: "delivery_queue", journal: &journal}
+	second := &recordingComponent{name: "http_server", journal: &journal}
+
+	lifecycle := NewLifecycle(5*time.Second, logger)
+	lifecycle.Register("delivery_queue", first)
+	lifecycle.Register("http_server", second, "delivery_queue")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	started := time.Now()
+	if err := lifecycle.Run(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Компоненты останавливаются с общим ограничением shutdown_timeout, а не с отмененным контекстом запуска
+	for _, c := range []*recordingComponent{first, second} {
+		deadline, ok := c.stopCtx.Deadline()
+		if !ok {
+			t.Fatalf("expected %s stop context to have deadline", c.name)
+		}
+		if remaining := deadline.Sub(started); remaining <= 4*time.Second || remaining > 5*time.Second+time.Second {
+			t.Errorf("expected %s stop deadline about 5s after start, got: %s", c.name, remaining)
+		}
+	}
+	if first.stopCtx != second.stopCtx {
+		t.Error("expected components to share one stop context")
+	}
+}
+
+func TestLifecycle_Run_HTTPServerFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	serveErr := errors.New("web server failed: accept error")
+	failed := make(chan error, 1)
+	failed <- serveErr
+
+	mockHTTPServer := mocks.NewMockHTTPServer(ctrl)
+	gomock.InOrder(
+		mockHTTPServer.EXPECT().Start(gomock.Any()).Return(nil),
+		mockHTTPServer.EXPECT().Failed().Return(failed),
+		mockHTTPServer.EXPECT().Stop(gomock.Any()).Return(nil),
+	)
+
+	lifecycle := NewLifecycle(time.Second, logger)
+	lifecycle.Register("http_server", mockHTTPServer)
+
+	if err := lifecycle.Run(context.Background()); !errors.Is(err, serveErr) {
+		t.Errorf("expected error %v, got: %v", serveErr, err)
+	}
+}
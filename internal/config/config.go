@@ -3,10 +3,14 @@ package config
 import (
 	"fmt"
 	"gopkg.in/yaml.v3"
+	"net/mail"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // filepathAbsFunc используется для тестирования - позволяет подменить проверку абсолютного пути файла
@@ -17,15 +21,88 @@ const (
 	DefaultConfigPath = "./config/config.yml"
 	// EnvConfigPath переменная окружения для пути к конфигурации
 	EnvConfigPath = "CONFIG_PATH"
+	// DefaultMaxBodySize максимальный размер тела HTTP запроса по умолчанию (1 МиБ)
+	DefaultMaxBodySize = 1 << 20
+	// DefaultOutboxDir каталог журнала принятых событий по умолчанию
+	DefaultOutboxDir = "./data/outbox"
+	// DefaultDeadLetterDir каталог недоставленных уведомлений по умолчанию
+	DefaultDeadLetterDir = "./data/dead-letters"
+	// DefaultSignatureHeader заголовок с HMAC подписью webhook запроса по умолчанию
+	DefaultSignatureHeader = "X-Webhook-Signature"
+	// DefaultTimestampHeader заголовок с меткой времени webhook запроса по умолчанию
+	DefaultTimestampHeader = "X-Webhook-Timestamp"
+	// DefaultDeliveryHeader заголовок с идентификатором доставки webhook запроса по умолчанию
+	DefaultDeliveryHeader = "X-Webhook-Delivery"
+	// DefaultIdempotencyHeader заголовок с ключом идемпотентности события по умолчанию
+	DefaultIdempotencyHeader = "Idempotency-Key"
+	// DefaultWebhookSuccessStatus код ответа webhook, если ни одна доставка не завершилась ошибкой
+	DefaultWebhookSuccessStatus = 202
+	// DefaultWebhookPartialStatus код ответа webhook, если доставка части адресатов завершилась ошибкой
+	DefaultWebhookPartialStatus = 207
+	// DefaultWebhookFailedStatus код ответа webhook, если доставка всем адресатам завершилась ошибкой
+	DefaultWebhookFailedStatus = 502
+	// DefaultSlackApiUrl базовый URL Slack Web API по умолчанию
+	DefaultSlackApiUrl = "https://slack.com/api"
+	// DefaultNtfyServerURL URL публичного сервера ntfy по умолчанию
+	DefaultNtfyServerURL = "https://ntfy.sh"
+	// DefaultFilePath путь файла архива уведомлений по умолчанию
+	DefaultFilePath = "./data/notifications.jsonl"
+)
+
+// ntfyTopicPattern допустимое имя топика ntfy
+var ntfyTopicPattern = regexp.MustCompile(`^[-_A-Za-z0-9]{1,64}$`)
+
+// Режимы шифрования соединения с SMTP сервером
+const (
+	// EmailTLSStartTLS - соединение без шифрования, которое переключается на TLS командой STARTTLS
+	EmailTLSStartTLS = "starttls"
+	// EmailTLSImplicit - соединение сразу устанавливается по TLS (SMTPS)
+	EmailTLSImplicit = "tls"
+	// EmailTLSNone - соединение без шифрования (только для локальных серверов)
+	EmailTLSNone = "none"
+)
+
+// Политики сброса записей архива уведомлений на диск (fsync)
+const (
+	// FileFsyncAlways - сброс после каждой записи
+	FileFsyncAlways = "always"
+	// FileFsyncInterval - сброс не чаще одного раза в fsync_interval
+	FileFsyncInterval = "interval"
+	// FileFsyncNever - сброс выполняет операционная система, файл сбрасывается только при ротации и остановке
+	FileFsyncNever = "never"
+)
+
+// Способы авторизации на SMTP сервере
+const (
+	// EmailAuthPlain - авторизация AUTH PLAIN
+	EmailAuthPlain = "plain"
+	// EmailAuthLogin - авторизация AUTH LOGIN
+	EmailAuthLogin = "login"
 )
 
 // Config содержит конфигурацию приложения
 type Config struct {
-	HTTP          HTTPConfig          `yaml:"http"`
-	Telegram      TelegramConfig      `yaml:"telegram"`
-	VKTeams       VKTeamsConfig       `yaml:"vkteams"`
-	Logger        LoggerConfig        `yaml:"logger"`
-	Notifications NotificationsConfig `yaml:"notifications"`
+	HTTP            HTTPConfig            `yaml:"http"`
+	Telegram        TelegramConfig        `yaml:"telegram"`
+	VKTeams         VKTeamsConfig         `yaml:"vkteams"`
+	Slack           SlackConfig           `yaml:"slack"`
+	Mattermost      MattermostConfig      `yaml:"mattermost"`
+	MSTeams         MSTeamsConfig         `yaml:"msteams"`
+	Discord         DiscordConfig         `yaml:"discord"`
+	Email           EmailConfig           `yaml:"email"`
+	OutgoingWebhook OutgoingWebhookConfig `yaml:"outgoing_webhook"`
+	Matrix          MatrixConfig          `yaml:"matrix"`
+	Zulip           ZulipConfig           `yaml:"zulip"`
+	Ntfy            NtfyConfig            `yaml:"ntfy"`
+	Gotify          GotifyConfig          `yaml:"gotify"`
+	File            FileConfig            `yaml:"file"`
+	Logger          LoggerConfig          `yaml:"logger"`
+	Webhook         WebhookConfig         `yaml:"webhook"`
+	Delivery        DeliveryConfig        `yaml:"delivery"`
+	Outbox          OutboxConfig          `yaml:"outbox"`
+	DeadLetter      DeadLetterConfig      `yaml:"dead_letter"`
+	Admin           AdminConfig           `yaml:"admin"`
+	Notifications   NotificationsConfig   `yaml:"notifications"`
 }
 
 // HTTPConfig содержит конфигурацию HTTP сервера
@@ -34,22 +111,154 @@ type HTTPConfig struct {
 	ShutdownTimeout int    `yaml:"shutdown_timeout"` // Таймаут в секундах
 	ReadTimeout     int    `yaml:"read_timeout"`     // Таймаут в секундах
 	WriteTimeout    int    `yaml:"write_timeout"`    // Таймаут в секундах
+	MaxBodySize     int64  `yaml:"max_body_size"`    // Максимальный размер тела запроса в байтах
 }
 
 // TelegramConfig содержит глобальную конфигурацию для Telegram канала
 // BotToken и Timeout используются для всех проектов
 type TelegramConfig struct {
-	BotToken string `yaml:"bot_token"` // Глобальный токен бота
-	Timeout  int    `yaml:"timeout"`   // Таймаут для HTTP запросов к Telegram API (секунды)
+	BotToken  string          `yaml:"bot_token"`  // Глобальный токен бота
+	Timeout   int             `yaml:"timeout"`    // Таймаут для HTTP запросов к Telegram API (секунды)
+	RateLimit RateLimitConfig `yaml:"rate_limit"` // Ограничения частоты отправки сообщений
 }
 
 // VKTeamsConfig содержит глобальную конфигурацию для VK Teams канала
 // BotToken, Timeout и ApiUrl используются для всех проектов
 type VKTeamsConfig struct {
-	BotToken           string `yaml:"bot_token"`            // Глобальный токен бота
-	Timeout            int    `yaml:"timeout"`              // Таймаут для HTTP запросов к VK Teams API (секунды)
-	ApiUrl             string `yaml:"api_url"`              // URL API (обязателен)
-	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"` // Игнорировать проверку SSL сертификата (не рекомендуется для production)
+	BotToken           string          `yaml:"bot_token"`            // Глобальный токен бота
+	Timeout            int             `yaml:"timeout"`              // Таймаут для HTTP запросов к VK Teams API (секунды)
+	ApiUrl             string          `yaml:"api_url"`              // URL API (обязателен)
+	InsecureSkipVerify bool            `yaml:"insecure_skip_verify"` // Игнорировать проверку SSL сертификата (не рекомендуется для production)
+	RateLimit          RateLimitConfig `yaml:"rate_limit"`           // Ограничения частоты отправки сообщений
+}
+
+// SlackConfig содержит глобальную конфигурацию для Slack канала
+// BotToken нужен только проектам, которые отправляют уведомления через chat.postMessage, а не через incoming webhook
+type SlackConfig struct {
+	BotToken  string            `yaml:"bot_token"`  // Глобальный токен бота (xoxb-...)
+	Timeout   int               `yaml:"timeout"`    // Таймаут для HTTP запросов к Slack (секунды)
+	ApiUrl    string            `yaml:"api_url"`    // URL Slack Web API (по умолчанию https://slack.com/api)
+	UserIDs   map[string]string `yaml:"user_ids"`   // ID пользователей Slack для упоминаний, ключ - логин или email в YouTrack
+	RateLimit RateLimitConfig   `yaml:"rate_limit"` // Ограничения частоты отправки сообщений
+}
+
+// MattermostConfig содержит глобальную конфигурацию для Mattermost канала
+// BotToken и ApiUrl нужны только проектам, которые отправляют уведомления через REST API, а не через incoming webhook
+type MattermostConfig struct {
+	BotToken           string          `yaml:"bot_token"`            // Глобальный токен бота
+	Timeout            int             `yaml:"timeout"`              // Таймаут для HTTP запросов к Mattermost (секунды)
+	ApiUrl             string          `yaml:"api_url"`              // URL сервера Mattermost (например, https://mattermost.example.com)
+	InsecureSkipVerify bool            `yaml:"insecure_skip_verify"` // Игнорировать проверку SSL сертификата (не рекомендуется для production)
+	RateLimit          RateLimitConfig `yaml:"rate_limit"`           // Ограничения частоты отправки сообщений
+}
+
+// MSTeamsConfig содержит глобальную конфигурацию для Microsoft Teams канала
+// Токен не нужен: сообщения отправляются на URL webhook, который указывается в настройках проекта
+type MSTeamsConfig struct {
+	Timeout   int             `yaml:"timeout"`    // Таймаут для HTTP запросов к Microsoft Teams (секунды)
+	RateLimit RateLimitConfig `yaml:"rate_limit"` // Ограничения частоты отправки сообщений
+}
+
+// DiscordConfig содержит глобальную конфигурацию для Discord канала
+// Токен не нужен: сообщения отправляются на URL webhook, который указывается в настройках проекта
+type DiscordConfig struct {
+	Timeout   int             `yaml:"timeout"`    // Таймаут для HTTP запросов к Discord (секунды)
+	RateLimit RateLimitConfig `yaml:"rate_limit"` // Ограничения частоты отправки сообщений
+}
+
+// EmailConfig содержит глобальную конфигурацию для email канала (SMTP)
+// Адреса получателей указываются в настройках проекта
+type EmailConfig struct {
+	Host               string          `yaml:"host"`                 // Адрес SMTP сервера
+	Port               int             `yaml:"port"`                 // Порт SMTP сервера (по умолчанию 587 для starttls, 465 для tls, 25 для none)
+	Username           string          `yaml:"username"`             // Имя пользователя SMTP, если не указано - отправка без авторизации
+	Password           string          `yaml:"password"`             // Пароль пользователя SMTP
+	Auth               string          `yaml:"auth"`                 // Способ авторизации: plain или login (по умолчанию plain)
+	TLS                string          `yaml:"tls"`                  // Шифрование: starttls, tls или none (по умолчанию starttls)
+	From               string          `yaml:"from"`                 // Адрес отправителя, например "YouTrack <youtrack@example.com>"
+	Timeout            int             `yaml:"timeout"`              // Таймаут для SMTP соединения (секунды)
+	InsecureSkipVerify bool            `yaml:"insecure_skip_verify"` // Игнорировать проверку SSL сертификата (не рекомендуется для production)
+	RateLimit          RateLimitConfig `yaml:"rate_limit"`           // Ограничения частоты отправки сообщений
+}
+
+// MatrixConfig содержит глобальную конфигурацию для Matrix канала
+// AccessToken и HomeserverURL используются для всех проектов, комнаты указываются в настройках проекта
+type MatrixConfig struct {
+	AccessToken        string            `yaml:"access_token"`         // Токен доступа пользователя (бота), от имени которого отправляются сообщения
+	HomeserverURL      string            `yaml:"homeserver_url"`       // URL homeserver (например, https://matrix.example.com)
+	Timeout            int               `yaml:"timeout"`              // Таймаут для HTTP запросов к homeserver (секунды)
+	InsecureSkipVerify bool              `yaml:"insecure_skip_verify"` // Игнорировать проверку SSL сертификата (не рекомендуется для production)
+	UserIDs            map[string]string `yaml:"user_ids"`             // Matrix ID пользователей для упоминаний (@user:server), ключ - логин или email в YouTrack
+	RateLimit          RateLimitConfig   `yaml:"rate_limit"`           // Ограничения частоты отправки сообщений
+}
+
+// ZulipConfig содержит глобальную конфигурацию для Zulip канала
+// BotEmail, APIKey и SiteURL используются для всех проектов, потоки указываются в настройках проекта
+type ZulipConfig struct {
+	BotEmail           string          `yaml:"bot_email"`            // Email бота Zulip
+	APIKey             string          `yaml:"api_key"`              // API ключ бота Zulip
+	SiteURL            string          `yaml:"site_url"`             // URL сервера Zulip (например, https://example.zulipchat.com)
+	Timeout            int             `yaml:"timeout"`              // Таймаут для HTTP запросов к Zulip (секунды)
+	InsecureSkipVerify bool            `yaml:"insecure_skip_verify"` // Игнорировать проверку SSL сертификата (не рекомендуется для production)
+	RateLimit          RateLimitConfig `yaml:"rate_limit"`           // Ограничения частоты отправки сообщений
+}
+
+// NtfyConfig содержит глобальную конфигурацию для ntfy канала
+// Сервер и токен доступа используются для всех проектов, топики указываются в настройках проекта
+type NtfyConfig struct {
+	ServerURL          string          `yaml:"server_url"`           // URL сервера ntfy (по умолчанию https://ntfy.sh)
+	AccessToken        string          `yaml:"access_token"`         // Токен доступа к топикам (опционально, для серверов с авторизацией)
+	Timeout            int             `yaml:"timeout"`              // Таймаут для HTTP запросов к ntfy (секунды)
+	InsecureSkipVerify bool            `yaml:"insecure_skip_verify"` // Игнорировать проверку SSL сертификата (не рекомендуется для production)
+	RateLimit          RateLimitConfig `yaml:"rate_limit"`           // Ограничения частоты отправки сообщений
+}
+
+// GotifyConfig содержит глобальную конфигурацию для Gotify канала
+// Сервер используется для всех проектов, токены приложений указываются в настройках проекта
+type GotifyConfig struct {
+	ServerURL          string          `yaml:"server_url"`           // URL сервера Gotify
+	Timeout            int             `yaml:"timeout"`              // Таймаут для HTTP запросов к Gotify (секунды)
+	InsecureSkipVerify bool            `yaml:"insecure_skip_verify"` // Игнорировать проверку SSL сертификата (не рекомендуется для production)
+	RateLimit          RateLimitConfig `yaml:"rate_limit"`           // Ограничения частоты отправки сообщений
+}
+
+// FileConfig содержит конфигурацию file канала - архива уведомлений в формате JSON Lines
+// Архив общий для всех проектов и хранится отдельно от логов приложения
+type FileConfig struct {
+	Path             string `yaml:"path"`              // Путь файла архива
+	MaxSize          int    `yaml:"max_size"`          // Размер файла, после которого он ротируется (МиБ, 0 - без ограничения)
+	RotationInterval int    `yaml:"rotation_interval"` // Интервал ротации файла (секунды, 0 - без ротации по времени)
+	Compress         bool   `yaml:"compress"`          // Сжимать ротированные файлы gzip
+	Fsync            string `yaml:"fsync"`             // Политика сброса записей на диск: always, interval, never
+	FsyncInterval    int    `yaml:"fsync_interval"`    // Интервал сброса записей на диск для политики interval (секунды)
+}
+
+// OutgoingWebhookConfig содержит глобальную конфигурацию для webhook канала
+// Канал отправляет события во внутренние сервисы без отдельной интеграции, адресаты описываются именованными целями
+type OutgoingWebhookConfig struct {
+	Timeout            int                                    `yaml:"timeout"`              // Таймаут для HTTP запросов к целям (секунды)
+	InsecureSkipVerify bool                                   `yaml:"insecure_skip_verify"` // Игнорировать проверку SSL сертификата (не рекомендуется для production)
+	RateLimit          RateLimitConfig                        `yaml:"rate_limit"`           // Ограничения частоты отправки сообщений
+	Targets            map[string]OutgoingWebhookTargetConfig `yaml:"targets"`              // Ключ - имя цели, на которое ссылаются проекты
+}
+
+// OutgoingWebhookTargetConfig описывает цель webhook канала
+// Тело запроса формируется шаблоном text/template из payload YouTrack, если шаблон не указан - отправляется сам payload в JSON
+type OutgoingWebhookTargetConfig struct {
+	URL             string            `yaml:"url"`              // URL цели
+	Method          string            `yaml:"method"`           // HTTP метод: POST, PUT или PATCH (по умолчанию POST)
+	Headers         map[string]string `yaml:"headers"`          // Дополнительные заголовки запроса
+	Body            string            `yaml:"body"`             // Шаблон тела запроса (text/template)
+	Secret          string            `yaml:"secret"`           // Секрет HMAC-SHA256 подписи тела запроса (опционально)
+	SignatureHeader string            `yaml:"signature_header"` // Заголовок с подписью (по умолчанию X-Webhook-Signature)
+}
+
+// RateLimitConfig содержит ограничения частоты отправки сообщений в канал
+// Сообщения сверх лимита не отбрасываются, а ожидают своей очереди
+type RateLimitConfig struct {
+	PerChat  int `yaml:"per_chat"`  // Сообщений в секунду в один чат
+	PerGroup int `yaml:"per_group"` // Сообщений в минуту в один групповой чат
+	Global   int `yaml:"global"`    // Сообщений в секунду во все чаты
 }
 
 // LoggerConfig содержит конфигурацию для логгера
@@ -57,6 +266,111 @@ type LoggerConfig struct {
 	Level string `yaml:"level"` // Уровень логирования (debug, info, warn, error)
 }
 
+// DeliveryConfig содержит конфигурацию асинхронной доставки уведомлений
+type DeliveryConfig struct {
+	Workers        int                  `yaml:"workers"`         // Количество обработчиков очереди доставки
+	QueueSize      int                  `yaml:"queue_size"`      // Максимальное количество событий в очереди
+	RetryAfter     int                  `yaml:"retry_after"`     // Значение Retry-After при заполненной очереди (секунды)
+	Timeout        int                  `yaml:"timeout"`         // Предельное время доставки одного события во все каналы, включая повторы (секунды)
+	Retry          RetryConfig          `yaml:"retry"`           // Политика повторной отправки при временных ошибках каналов
+	CircuitBreaker CircuitBreakerConfig `yaml:"circuit_breaker"` // Автоматическое отключение недоступных каналов
+}
+
+// RetryConfig содержит политику повторной отправки по умолчанию и ее переопределения для отдельных каналов
+// Незаданные поля политики канала наследуются из политики по умолчанию
+type RetryConfig struct {
+	RetryPolicyConfig `yaml:",inline"`
+	Channels          map[string]RetryPolicyConfig `yaml:"channels"` // Ключ - имя канала
+}
+
+// RetryPolicyConfig описывает экспоненциальную задержку между повторными попытками отправки
+type RetryPolicyConfig struct {
+	MaxAttempts     int      `yaml:"max_attempts"`     // Максимальное количество попыток, включая первую
+	InitialInterval int      `yaml:"initial_interval"` // Задержка перед первым повтором (секунды)
+	MaxInterval     int      `yaml:"max_interval"`     // Максимальная задержка между попытками (секунды)
+	Multiplier      float64  `yaml:"multiplier"`       // Множитель задержки для каждой следующей попытки
+	Jitter          *float64 `yaml:"jitter"`           // Случайное отклонение задержки, доля от 0 до 1 (nil - не задано, 0 отключает отклонение)
+	MaxAge          int      `yaml:"max_age"`          // Максимальное время повторов одного сообщения (секунды)
+}
+
+// CircuitBreakerConfig содержит настройки автоматического выключателя по умолчанию и их переопределения для отдельных каналов
+// Незаданные поля настроек канала наследуются из настроек по умолчанию
+type CircuitBreakerConfig struct {
+	CircuitBreakerPolicyConfig `yaml:",inline"`
+	Enabled                    bool                                  `yaml:"enabled"`  // Оборачивать каналы отправки в автоматический выключатель
+	Channels                   map[string]CircuitBreakerPolicyConfig `yaml:"channels"` // Ключ - имя канала
+}
+
+// CircuitBreakerPolicyConfig описывает условия размыкания и восстановления автоматического выключателя канала
+type CircuitBreakerPolicyConfig struct {
+	FailureThreshold int `yaml:"failure_threshold"` // Количество временных ошибок подряд, после которого выключатель размыкается
+	OpenDuration     int `yaml:"open_duration"`     // Время, в течение которого обращения к API канала не выполняются (секунды)
+	HalfOpenProbes   int `yaml:"half_open_probes"`  // Количество успешных пробных отправок для замыкания выключателя
+}
+
+// OutboxConfig содержит конфигурацию журнала принятых событий на диске
+type OutboxConfig struct {
+	Enabled         bool   `yaml:"enabled"`          // Сохранять принятые события на диск для повторной доставки после перезапуска
+	Dir             string `yaml:"dir"`              // Каталог журнала
+	CompactInterval int    `yaml:"compact_interval"` // Интервал сжатия журнала (секунды)
+}
+
+// DeadLetterConfig содержит конфигурацию хранилища недоставленных уведомлений
+type DeadLetterConfig struct {
+	Enabled bool   `yaml:"enabled"` // Сохранять уведомления, доставка которых окончательно не удалась
+	Dir     string `yaml:"dir"`     // Каталог хранилища
+}
+
+// AdminConfig содержит конфигурацию административного API
+type AdminConfig struct {
+	Token string `yaml:"token"` // Bearer токен доступа, пусто - административный API отключен
+}
+
+// WebhookConfig содержит конфигурацию приема входящих webhook запросов
+type WebhookConfig struct {
+	Secret          string                `yaml:"secret"`           // Глобальный секрет для проверки HMAC-SHA256 подписи (опционально)
+	SignatureHeader string                `yaml:"signature_header"` // Заголовок с подписью запроса (по умолчанию X-Webhook-Signature)
+	Tokens          []WebhookTokenConfig  `yaml:"tokens"`           // Токены источников, если список не пуст - токен обязателен
+	Replay          ReplayConfig          `yaml:"replay"`           // Защита от повторной отправки перехваченных запросов
+	Idempotency     IdempotencyConfig     `yaml:"idempotency"`      // Отбрасывание повторно полученных событий
+	Response        WebhookResponseConfig `yaml:"response"`         // Коды ответа в зависимости от результата доставки
+}
+
+// ReplayConfig содержит конфигурацию защиты от повторного воспроизведения webhook запросов
+type ReplayConfig struct {
+	Enabled         bool   `yaml:"enabled"`          // Включить проверку метки времени и одноразового идентификатора
+	MaxSkew         int    `yaml:"max_skew"`         // Допустимое расхождение метки времени (секунды)
+	CacheSize       int    `yaml:"cache_size"`       // Максимальное количество запоминаемых идентификаторов доставки
+	TimestampHeader string `yaml:"timestamp_header"` // Заголовок с меткой времени запроса (Unix, секунды)
+	DeliveryHeader  string `yaml:"delivery_header"`  // Заголовок с уникальным идентификатором доставки
+}
+
+// IdempotencyConfig содержит конфигурацию отбрасывания повторно полученных событий
+// Ключ события - значение заголовка с ключом идемпотентности, при его отсутствии - хэш полей payload
+type IdempotencyConfig struct {
+	Enabled   bool   `yaml:"enabled"`    // Отбрасывать события, полученные повторно в пределах ttl
+	TTL       int    `yaml:"ttl"`        // Время, в течение которого ключ события запоминается (секунды)
+	CacheSize int    `yaml:"cache_size"` // Максимальное количество запоминаемых ключей
+	Header    string `yaml:"header"`     // Заголовок с ключом идемпотентности, одинаковым для повторов одного события
+}
+
+// WebhookResponseConfig содержит коды HTTP ответа webhook в зависимости от результата доставки по адресатам
+// Пропущенные адресаты (черновик, не настроен chat_id, повтор события) не учитываются
+type WebhookResponseConfig struct {
+	SuccessStatus int `yaml:"success_status"` // Все адресаты получили уведомление или оно поставлено в очередь, а также событие проигнорировано
+	PartialStatus int `yaml:"partial_status"` // Доставка части адресатов завершилась ошибкой
+	FailedStatus  int `yaml:"failed_status"`  // Доставка всем адресатам завершилась ошибкой
+}
+
+// WebhookTokenConfig описывает токен отдельного источника webhook запросов (инстанса YouTrack или команды)
+// Для ротации без простоя новый токен добавляется рядом со старым, а старому указывается expires_at
+type WebhookTokenConfig struct {
+	Name      string     `yaml:"name"`                 // Имя источника (используется в логах)
+	Token     string     `yaml:"token"`                // Значение токена
+	Projects  []string   `yaml:"projects,omitempty"`   // Проекты, для которых источник может отправлять события, пусто - все проекты
+	ExpiresAt *time.Time `yaml:"expires_at,omitempty"` // Момент окончания действия токена (RFC3339), пусто - бессрочно
+}
+
 // NotificationsConfig содержит конфигурацию уведомлений
 type NotificationsConfig struct {
 	Youtrack YoutrackConfig `yaml:"youtrack"`
@@ -73,9 +387,24 @@ type ProjectConfig struct {
 	AllowedChannels []string `yaml:"allowedChannels"`
 	// SendDraftNotification определяет, отправлять ли уведомления для черновиков (IsDraft = true)
 	// По умолчанию true (если не указано)
-	SendDraftNotification *bool                  `yaml:"sendDraftNotification,omitempty"`
-	Telegram              *ProjectTelegramConfig `yaml:"telegram,omitempty"` // Обязательно, если telegram в allowedChannels
-	VKTeams               *ProjectVKTeamsConfig  `yaml:"vkteams,omitempty"`  // Обязательно, если vkteams в allowedChannels
+	SendDraftNotification *bool `yaml:"sendDraftNotification,omitempty"`
+	// WebhookSecret секрет для проверки подписи webhook запросов проекта, переопределяет глобальный webhook.secret
+	WebhookSecret string `yaml:"webhookSecret,omitempty"`
+	// CoalesceWindow время в секундах, в течение которого изменения одной задачи объединяются в одно уведомление
+	// По умолчанию 0 - каждое изменение отправляется отдельным уведомлением
+	CoalesceWindow int                      `yaml:"coalesceWindow,omitempty"`
+	Telegram       *ProjectTelegramConfig   `yaml:"telegram,omitempty"`   // Обязательно, если telegram в allowedChannels
+	VKTeams        *ProjectVKTeamsConfig    `yaml:"vkteams,omitempty"`    // Обязательно, если vkteams в allowedChannels
+	Slack          *ProjectSlackConfig      `yaml:"slack,omitempty"`      // Обязательно, если slack в allowedChannels
+	Mattermost     *ProjectMattermostConfig `yaml:"mattermost,omitempty"` // Обязательно, если mattermost в allowedChannels
+	MSTeams        *ProjectMSTeamsConfig    `yaml:"msteams,omitempty"`    // Обязательно, если msteams в allowedChannels
+	Discord        *ProjectDiscordConfig    `yaml:"discord,omitempty"`    // Обязательно, если discord в allowedChannels
+	Email          *ProjectEmailConfig      `yaml:"email,omitempty"`      // Обязательно, если email в allowedChannels
+	Webhook        *ProjectWebhookConfig    `yaml:"webhook,omitempty"`    // Обязательно, если webhook в allowedChannels
+	Matrix         *ProjectMatrixConfig     `yaml:"matrix,omitempty"`     // Обязательно, если matrix в allowedChannels
+	Zulip          *ProjectZulipConfig      `yaml:"zulip,omitempty"`      // Обязательно, если zulip в allowedChannels
+	Ntfy           *ProjectNtfyConfig       `yaml:"ntfy,omitempty"`       // Обязательно, если ntfy в allowedChannels
+	Gotify         *ProjectGotifyConfig     `yaml:"gotify,omitempty"`     // Обязательно, если gotify в allowedChannels
 }
 
 // ProjectTelegramConfig настройки для Telegram
@@ -88,6 +417,63 @@ type ProjectVKTeamsConfig struct {
 	ChatID string `yaml:"chat_id"` // Обязательное поле для каждого проекта
 }
 
+// ProjectSlackConfig настройки для Slack
+// Если указан webhook_url, сообщения отправляются через incoming webhook, иначе через chat.postMessage в channel
+type ProjectSlackConfig struct {
+	WebhookURL string `yaml:"webhook_url,omitempty"` // URL incoming webhook Slack
+	Channel    string `yaml:"channel,omitempty"`     // ID или имя канала для chat.postMessage
+}
+
+// ProjectMattermostConfig настройки для Mattermost
+// Если указан webhook_url, сообщения отправляются через incoming webhook, иначе через REST API в channel_id
+type ProjectMattermostConfig struct {
+	WebhookURL string `yaml:"webhook_url,omitempty"` // URL incoming webhook Mattermost
+	ChannelID  string `yaml:"channel_id,omitempty"`  // ID канала для отправки через REST API
+}
+
+// ProjectMSTeamsConfig настройки для Microsoft Teams
+type ProjectMSTeamsConfig struct {
+	WebhookURL string `yaml:"webhook_url"` // URL incoming webhook или workflow Microsoft Teams
+}
+
+// ProjectDiscordConfig настройки для Discord
+type ProjectDiscordConfig struct {
+	WebhookURL string `yaml:"webhook_url"` // URL webhook канала Discord
+}
+
+// ProjectEmailConfig настройки для email
+// Письмо отправляется получателям из recipients и, если включено notify_assignee, исполнителю задачи
+type ProjectEmailConfig struct {
+	Recipients     []string `yaml:"recipients,omitempty"`      // Адреса получателей
+	NotifyAssignee bool     `yaml:"notify_assignee,omitempty"` // Отправлять письмо на email исполнителя задачи из YouTrack
+}
+
+// ProjectWebhookConfig настройки для webhook канала
+type ProjectWebhookConfig struct {
+	Targets []string `yaml:"targets"` // Имена целей из outgoing_webhook.targets
+}
+
+// ProjectMatrixConfig настройки для Matrix
+type ProjectMatrixConfig struct {
+	RoomID string `yaml:"room_id"` // ID комнаты (!room:server), пользователь токена должен состоять в комнате
+}
+
+// ProjectZulipConfig настройки для Zulip
+// Тема сообщения формируется по задаче, поэтому уведомления одной задачи собираются в одной теме потока
+type ProjectZulipConfig struct {
+	Stream string `yaml:"stream"` // Имя потока (stream), бот должен иметь право писать в него
+}
+
+// ProjectNtfyConfig настройки для ntfy
+type ProjectNtfyConfig struct {
+	Topic string `yaml:"topic"` // Топик ntfy, на который подписаны получатели уведомлений проекта
+}
+
+// ProjectGotifyConfig настройки для Gotify
+type ProjectGotifyConfig struct {
+	Token string `yaml:"token"` // Токен приложения Gotify, сообщения проекта отправляются от имени этого приложения
+}
+
 // LoadConfig загружает конфигурацию из YAML файла и ENV переменных
 // Приоритет: ENV > YAML
 func LoadConfig() (*Config, error) {
@@ -109,6 +495,7 @@ func LoadConfig() (*Config, error) {
 
 	// Нормализуем ключи проектов к нижнему регистру
 	normalizeProjectNames(cfg)
+	normalizeWebhookTokens(cfg)
 
 	// Перезаписываем значения из ENV переменных (приоритет ENV)
 	if err := loadFromEnv(cfg); err != nil {
@@ -188,22 +575,39 @@ func loadFromEnv(cfg *Config) error {
 		cfg.HTTP.WriteTimeout = seconds
 	}
 
+	// MaxBodySize (значение в байтах, целое число)
+	if val := os.Getenv("HTTP_MAX_BODY_SIZE"); val != "" {
+		size, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid HTTP_MAX_BODY_SIZE format: must be integer (bytes), got: %s", val)
+		}
+		if size <= 0 {
+			return fmt.Errorf("HTTP_MAX_BODY_SIZE must be positive, got: %d", size)
+		}
+		cfg.HTTP.MaxBodySize = size
+	}
+
 	// Telegram
 	// BotToken
 	if val := os.Getenv("TELEGRAM_BOT_TOKEN"); val != "" {
 		cfg.Telegram.BotToken = val
 	}
 
-	// Timeout (значение в секундах, целое число)
-	if val := os.Getenv("TELEGRAM_TIMEOUT"); val != "" {
-		seconds, err := strconv.Atoi(val)
+	// Timeout и RateLimit (целые числа)
+	if err := loadChannelCommonEnv("TELEGRAM", &cfg.Telegram.Timeout, &cfg.Telegram.RateLimit); err != nil {
+		return err
+	}
+
+	// RateLimit.PerGroup (целое число)
+	if val := os.Getenv("TELEGRAM_RATE_LIMIT_PER_GROUP"); val != "" {
+		limit, err := strconv.Atoi(val)
 		if err != nil {
-			return fmt.Errorf("invalid TELEGRAM_TIMEOUT format: must be integer (seconds), got: %s", val)
+			return fmt.Errorf("invalid TELEGRAM_RATE_LIMIT_PER_GROUP format: must be integer, got: %s", val)
 		}
-		if seconds <= 0 {
-			return fmt.Errorf("TELEGRAM_TIMEOUT must be positive, got: %d", seconds)
+		if limit <= 0 {
+			return fmt.Errorf("TELEGRAM_RATE_LIMIT_PER_GROUP must be positive, got: %d", limit)
 		}
-		cfg.Telegram.Timeout = seconds
+		cfg.Telegram.RateLimit.PerGroup = limit
 	}
 
 	// VK Teams
@@ -212,16 +616,9 @@ func loadFromEnv(cfg *Config) error {
 		cfg.VKTeams.BotToken = val
 	}
 
-	// Timeout (значение в секундах, целое число)
-	if val := os.Getenv("VKTEAMS_TIMEOUT"); val != "" {
-		seconds, err := strconv.Atoi(val)
-		if err != nil {
-			return fmt.Errorf("invalid VKTEAMS_TIMEOUT format: must be integer (seconds), got: %s", val)
-		}
-		if seconds <= 0 {
-			return fmt.Errorf("VKTEAMS_TIMEOUT must be positive, got: %d", seconds)
-		}
-		cfg.VKTeams.Timeout = seconds
+	// Timeout и RateLimit (целые числа)
+	if err := loadChannelCommonEnv("VKTEAMS", &cfg.VKTeams.Timeout, &cfg.VKTeams.RateLimit); err != nil {
+		return err
 	}
 
 	// ApiUrl
@@ -234,144 +631,1344 @@ func loadFromEnv(cfg *Config) error {
 		cfg.VKTeams.InsecureSkipVerify = val == "true"
 	}
 
-	// Logger.Level
-	if val := os.Getenv("LOG_LEVEL"); val != "" {
-		cfg.Logger.Level = val
+	// RateLimit.PerGroup (целое число)
+	if val := os.Getenv("VKTEAMS_RATE_LIMIT_PER_GROUP"); val != "" {
+		limit, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid VKTEAMS_RATE_LIMIT_PER_GROUP format: must be integer, got: %s", val)
+		}
+		if limit <= 0 {
+			return fmt.Errorf("VKTEAMS_RATE_LIMIT_PER_GROUP must be positive, got: %d", limit)
+		}
+		cfg.VKTeams.RateLimit.PerGroup = limit
 	}
 
-	return nil
-}
+	// Slack
+	// BotToken
+	if val := os.Getenv("SLACK_BOT_TOKEN"); val != "" {
+		cfg.Slack.BotToken = val
+	}
 
-// normalizeProjectNames нормализует ключи проектов к нижнему регистру
-func normalizeProjectNames(cfg *Config) {
-	if cfg.Notifications.Youtrack.Projects == nil {
-		// Инициализируем пустую map, если она nil
-		cfg.Notifications.Youtrack.Projects = make(map[string]ProjectConfig)
-		return
+	// Timeout и RateLimit (целые числа)
+	if err := loadChannelCommonEnv("SLACK", &cfg.Slack.Timeout, &cfg.Slack.RateLimit); err != nil {
+		return err
 	}
 
-	if len(cfg.Notifications.Youtrack.Projects) == 0 {
-		// Если map пустая, ничего не делаем
-		return
+	// ApiUrl
+	if val := os.Getenv("SLACK_API_URL"); val != "" {
+		cfg.Slack.ApiUrl = val
 	}
 
-	// Собираем оригинальные ключи для логирования
-	originalKeys := make([]string, 0, len(cfg.Notifications.Youtrack.Projects))
-	for k := range cfg.Notifications.Youtrack.Projects {
-		originalKeys = append(originalKeys, k)
+	// Mattermost
+	// BotToken
+	if val := os.Getenv("MATTERMOST_BOT_TOKEN"); val != "" {
+		cfg.Mattermost.BotToken = val
 	}
 
-	normalizedProjects := make(map[string]ProjectConfig)
-	for projectName, projectConfig := range cfg.Notifications.Youtrack.Projects {
-		normalizedName := strings.ToLower(projectName)
-		normalizedProjects[normalizedName] = projectConfig
+	// Timeout и RateLimit (целые числа)
+	if err := loadChannelCommonEnv("MATTERMOST", &cfg.Mattermost.Timeout, &cfg.Mattermost.RateLimit); err != nil {
+		return err
 	}
 
-	cfg.Notifications.Youtrack.Projects = normalizedProjects
-}
+	// ApiUrl
+	if val := os.Getenv("MATTERMOST_API_URL"); val != "" {
+		cfg.Mattermost.ApiUrl = val
+	}
 
-// validateConfig проверяет корректность конфигурации
-func validateConfig(cfg *Config) error {
-	if cfg.HTTP.Addr == "" {
-		return fmt.Errorf("HTTP_ADDR is required")
+	// InsecureSkipVerify
+	if val := os.Getenv("MATTERMOST_INSECURE_SKIP_VERIFY"); val != "" {
+		cfg.Mattermost.InsecureSkipVerify = val == "true"
 	}
 
-	if cfg.HTTP.ShutdownTimeout <= 0 {
-		return fmt.Errorf("HTTP_SHUTDOWN_TIMEOUT must be positive")
+	// MSTeams
+	// Timeout и RateLimit (целые числа)
+	if err := loadChannelCommonEnv("MSTEAMS", &cfg.MSTeams.Timeout, &cfg.MSTeams.RateLimit); err != nil {
+		return err
 	}
 
-	if cfg.HTTP.ReadTimeout <= 0 {
-		return fmt.Errorf("HTTP_READ_TIMEOUT must be positive")
+	// Discord
+	// Timeout и RateLimit (целые числа)
+	if err := loadChannelCommonEnv("DISCORD", &cfg.Discord.Timeout, &cfg.Discord.RateLimit); err != nil {
+		return err
 	}
 
-	if cfg.HTTP.WriteTimeout <= 0 {
-		return fmt.Errorf("HTTP_WRITE_TIMEOUT must be positive")
+	// Email
+	// Host
+	if val := os.Getenv("EMAIL_HOST"); val != "" {
+		cfg.Email.Host = val
 	}
 
-	// Устанавливаем значения по умолчанию для Telegram, если не заданы
-	if cfg.Telegram.Timeout <= 0 {
-		cfg.Telegram.Timeout = 10
+	// Port (целое число)
+	if val := os.Getenv("EMAIL_PORT"); val != "" {
+		port, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid EMAIL_PORT format: must be integer, got: %s", val)
+		}
+		if port <= 0 || port > 65535 {
+			return fmt.Errorf("EMAIL_PORT must be between 1 and 65535, got: %d", port)
+		}
+		cfg.Email.Port = port
 	}
 
-	// Устанавливаем значения по умолчанию для VK Teams, если не заданы
-	if cfg.VKTeams.Timeout <= 0 {
-		cfg.VKTeams.Timeout = 10
+	// Username
+	if val := os.Getenv("EMAIL_USERNAME"); val != "" {
+		cfg.Email.Username = val
 	}
 
-	// Валидация конфигурации проектов
-	if err := validateNotificationsConfig(cfg); err != nil {
+	// Password
+	if val := os.Getenv("EMAIL_PASSWORD"); val != "" {
+		cfg.Email.Password = val
+	}
+
+	// Auth
+	if val := os.Getenv("EMAIL_AUTH"); val != "" {
+		cfg.Email.Auth = val
+	}
+
+	// TLS
+	if val := os.Getenv("EMAIL_TLS"); val != "" {
+		cfg.Email.TLS = val
+	}
+
+	// From
+	if val := os.Getenv("EMAIL_FROM"); val != "" {
+		cfg.Email.From = val
+	}
+
+	// Timeout и RateLimit (целые числа)
+	if err := loadChannelCommonEnv("EMAIL", &cfg.Email.Timeout, &cfg.Email.RateLimit); err != nil {
 		return err
 	}
 
-	return nil
-}
+	// InsecureSkipVerify
+	if val := os.Getenv("EMAIL_INSECURE_SKIP_VERIFY"); val != "" {
+		cfg.Email.InsecureSkipVerify = val == "true"
+	}
 
-// validateNotificationsConfig проверяет корректность конфигурации уведомлений
-func validateNotificationsConfig(cfg *Config) error {
-	if cfg.Notifications.Youtrack.Projects == nil {
-		return nil // Проекты не настроены - это допустимо (уведомления будут игнорироваться)
+	// Outgoing webhook
+	// Timeout и RateLimit (целые числа)
+	if err := loadChannelCommonEnv("OUTGOING_WEBHOOK", &cfg.OutgoingWebhook.Timeout, &cfg.OutgoingWebhook.RateLimit); err != nil {
+		return err
 	}
 
-	// Проверяем каждый проект
-	for projectName, projectConfig := range cfg.Notifications.Youtrack.Projects {
-		// Проверяем, что allowedChannels не пустой
-		if len(projectConfig.AllowedChannels) == 0 {
-			return fmt.Errorf("project %q: allowedChannels cannot be empty", projectName)
-		}
+	// InsecureSkipVerify
+	if val := os.Getenv("OUTGOING_WEBHOOK_INSECURE_SKIP_VERIFY"); val != "" {
+		cfg.OutgoingWebhook.InsecureSkipVerify = val == "true"
+	}
 
-		// Проверяем валидность каналов
-		validChannels := map[string]bool{
-			"telegram": true,
-			"vkteams":  true,
-			"logger":   true,
-		}
+	// Matrix
+	// AccessToken
+	if val := os.Getenv("MATRIX_ACCESS_TOKEN"); val != "" {
+		cfg.Matrix.AccessToken = val
+	}
 
-		hasTelegram := false
-		hasVKTeams := false
-		for _, channel := range projectConfig.AllowedChannels {
-			if !validChannels[channel] {
-				return fmt.Errorf("project %q: invalid channel %q, allowed channels: telegram, vkteams, logger", projectName, channel)
-			}
-			if channel == "telegram" {
-				hasTelegram = true
-			}
-			if channel == "vkteams" {
-				hasVKTeams = true
-			}
+	// HomeserverURL
+	if val := os.Getenv("MATRIX_HOMESERVER_URL"); val != "" {
+		cfg.Matrix.HomeserverURL = val
+	}
+
+	// Timeout и RateLimit (целые числа)
+	if err := loadChannelCommonEnv("MATRIX", &cfg.Matrix.Timeout, &cfg.Matrix.RateLimit); err != nil {
+		return err
+	}
+
+	// InsecureSkipVerify
+	if val := os.Getenv("MATRIX_INSECURE_SKIP_VERIFY"); val != "" {
+		cfg.Matrix.InsecureSkipVerify = val == "true"
+	}
+
+	// Zulip
+	// BotEmail
+	if val := os.Getenv("ZULIP_BOT_EMAIL"); val != "" {
+		cfg.Zulip.BotEmail = val
+	}
+
+	// APIKey
+	if val := os.Getenv("ZULIP_API_KEY"); val != "" {
+		cfg.Zulip.APIKey = val
+	}
+
+	// SiteURL
+	if val := os.Getenv("ZULIP_SITE_URL"); val != "" {
+		cfg.Zulip.SiteURL = val
+	}
+
+	// Timeout и RateLimit (целые числа)
+	if err := loadChannelCommonEnv("ZULIP", &cfg.Zulip.Timeout, &cfg.Zulip.RateLimit); err != nil {
+		return err
+	}
+
+	// InsecureSkipVerify
+	if val := os.Getenv("ZULIP_INSECURE_SKIP_VERIFY"); val != "" {
+		cfg.Zulip.InsecureSkipVerify = val == "true"
+	}
+
+	// Ntfy
+	// ServerURL
+	if val := os.Getenv("NTFY_SERVER_URL"); val != "" {
+		cfg.Ntfy.ServerURL = val
+	}
+
+	// AccessToken
+	if val := os.Getenv("NTFY_ACCESS_TOKEN"); val != "" {
+		cfg.Ntfy.AccessToken = val
+	}
+
+	// Timeout и RateLimit (целые числа)
+	if err := loadChannelCommonEnv("NTFY", &cfg.Ntfy.Timeout, &cfg.Ntfy.RateLimit); err != nil {
+		return err
+	}
+
+	// InsecureSkipVerify
+	if val := os.Getenv("NTFY_INSECURE_SKIP_VERIFY"); val != "" {
+		cfg.Ntfy.InsecureSkipVerify = val == "true"
+	}
+
+	// Gotify
+	// ServerURL
+	if val := os.Getenv("GOTIFY_SERVER_URL"); val != "" {
+		cfg.Gotify.ServerURL = val
+	}
+
+	// Timeout и RateLimit (целые числа)
+	if err := loadChannelCommonEnv("GOTIFY", &cfg.Gotify.Timeout, &cfg.Gotify.RateLimit); err != nil {
+		return err
+	}
+
+	// InsecureSkipVerify
+	if val := os.Getenv("GOTIFY_INSECURE_SKIP_VERIFY"); val != "" {
+		cfg.Gotify.InsecureSkipVerify = val == "true"
+	}
+
+	// File
+	// Path
+	if val := os.Getenv("FILE_PATH"); val != "" {
+		cfg.File.Path = val
+	}
+
+	// MaxSize (целое число МиБ)
+	if val := os.Getenv("FILE_MAX_SIZE"); val != "" {
+		size, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid FILE_MAX_SIZE format: must be integer (MiB), got: %s", val)
 		}
+		cfg.File.MaxSize = size
+	}
 
-		// Если telegram в allowedChannels, проверяем наличие telegram.chat_id
-		if hasTelegram {
-			if projectConfig.Telegram == nil {
-				return fmt.Errorf("project %q: telegram.chat_id is required when telegram is in allowedChannels", projectName)
-			}
-			if projectConfig.Telegram.ChatID == "" {
-				return fmt.Errorf("project %q: telegram.chat_id cannot be empty when telegram is in allowedChannels", projectName)
-			}
-			// Проверяем, что глобальный bot_token указан
-			if cfg.Telegram.BotToken == "" {
-				return fmt.Errorf("TELEGRAM_BOT_TOKEN is required when telegram is used in project configurations")
-			}
+	// RotationInterval (целое число секунд)
+	if val := os.Getenv("FILE_ROTATION_INTERVAL"); val != "" {
+		seconds, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid FILE_ROTATION_INTERVAL format: must be integer (seconds), got: %s", val)
 		}
+		cfg.File.RotationInterval = seconds
+	}
 
-		// Если vkteams в allowedChannels, проверяем наличие vkteams.chat_id
-		if hasVKTeams {
-			if projectConfig.VKTeams == nil {
-				return fmt.Errorf("project %q: vkteams.chat_id is required when vkteams is in allowedChannels", projectName)
-			}
-			if projectConfig.VKTeams.ChatID == "" {
-				return fmt.Errorf("project %q: vkteams.chat_id cannot be empty when vkteams is in allowedChannels", projectName)
-			}
-			// Проверяем, что глобальный bot_token указан
-			if cfg.VKTeams.BotToken == "" {
-				return fmt.Errorf("VKTEAMS_BOT_TOKEN is required when vkteams is used in project configurations")
-			}
-			// Проверяем, что глобальный api_url указан
-			if cfg.VKTeams.ApiUrl == "" {
-				return fmt.Errorf("VKTEAMS_API_URL is required when vkteams is used in project configurations")
-			}
+	// Compress
+	if val := os.Getenv("FILE_COMPRESS"); val != "" {
+		cfg.File.Compress = val == "true"
+	}
+
+	// Fsync
+	if val := os.Getenv("FILE_FSYNC"); val != "" {
+		cfg.File.Fsync = val
+	}
+
+	// FsyncInterval (целое число секунд)
+	if val := os.Getenv("FILE_FSYNC_INTERVAL"); val != "" {
+		seconds, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid FILE_FSYNC_INTERVAL format: must be integer (seconds), got: %s", val)
+		}
+		if seconds <= 0 {
+			return fmt.Errorf("FILE_FSYNC_INTERVAL must be positive, got: %d", seconds)
 		}
+		cfg.File.FsyncInterval = seconds
 	}
 
-	return nil
+	// Logger.Level
+	if val := os.Getenv("LOG_LEVEL"); val != "" {
+		cfg.Logger.Level = val
+	}
+
+	// Webhook
+	// Secret
+	if val := os.Getenv("WEBHOOK_SECRET"); val != "" {
+		cfg.Webhook.Secret = val
+	}
+
+	// SignatureHeader
+	if val := os.Getenv("WEBHOOK_SIGNATURE_HEADER"); val != "" {
+		cfg.Webhook.SignatureHeader = val
+	}
+
+	// Replay.Enabled
+	if val := os.Getenv("WEBHOOK_REPLAY_ENABLED"); val != "" {
+		cfg.Webhook.Replay.Enabled = val == "true"
+	}
+
+	// Replay.MaxSkew (значение в секундах, целое число)
+	if val := os.Getenv("WEBHOOK_REPLAY_MAX_SKEW"); val != "" {
+		seconds, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid WEBHOOK_REPLAY_MAX_SKEW format: must be integer (seconds), got: %s", val)
+		}
+		if seconds <= 0 {
+			return fmt.Errorf("WEBHOOK_REPLAY_MAX_SKEW must be positive, got: %d", seconds)
+		}
+		cfg.Webhook.Replay.MaxSkew = seconds
+	}
+
+	// Idempotency.Enabled
+	if val := os.Getenv("WEBHOOK_IDEMPOTENCY_ENABLED"); val != "" {
+		cfg.Webhook.Idempotency.Enabled = val == "true"
+	}
+
+	// Idempotency.TTL (значение в секундах, целое число)
+	if val := os.Getenv("WEBHOOK_IDEMPOTENCY_TTL"); val != "" {
+		seconds, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid WEBHOOK_IDEMPOTENCY_TTL format: must be integer (seconds), got: %s", val)
+		}
+		if seconds <= 0 {
+			return fmt.Errorf("WEBHOOK_IDEMPOTENCY_TTL must be positive, got: %d", seconds)
+		}
+		cfg.Webhook.Idempotency.TTL = seconds
+	}
+
+	// Response.SuccessStatus (HTTP статус)
+	if val := os.Getenv("WEBHOOK_RESPONSE_SUCCESS_STATUS"); val != "" {
+		status, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid WEBHOOK_RESPONSE_SUCCESS_STATUS format: must be integer, got: %s", val)
+		}
+		cfg.Webhook.Response.SuccessStatus = status
+	}
+
+	// Response.PartialStatus (HTTP статус)
+	if val := os.Getenv("WEBHOOK_RESPONSE_PARTIAL_STATUS"); val != "" {
+		status, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid WEBHOOK_RESPONSE_PARTIAL_STATUS format: must be integer, got: %s", val)
+		}
+		cfg.Webhook.Response.PartialStatus = status
+	}
+
+	// Response.FailedStatus (HTTP статус)
+	if val := os.Getenv("WEBHOOK_RESPONSE_FAILED_STATUS"); val != "" {
+		status, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid WEBHOOK_RESPONSE_FAILED_STATUS format: must be integer, got: %s", val)
+		}
+		cfg.Webhook.Response.FailedStatus = status
+	}
+
+	// Delivery
+	// Workers (целое число)
+	if val := os.Getenv("DELIVERY_WORKERS"); val != "" {
+		workers, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid DELIVERY_WORKERS format: must be integer, got: %s", val)
+		}
+		if workers <= 0 {
+			return fmt.Errorf("DELIVERY_WORKERS must be positive, got: %d", workers)
+		}
+		cfg.Delivery.Workers = workers
+	}
+
+	// QueueSize (целое число)
+	if val := os.Getenv("DELIVERY_QUEUE_SIZE"); val != "" {
+		size, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid DELIVERY_QUEUE_SIZE format: must be integer, got: %s", val)
+		}
+		if size <= 0 {
+			return fmt.Errorf("DELIVERY_QUEUE_SIZE must be positive, got: %d", size)
+		}
+		cfg.Delivery.QueueSize = size
+	}
+
+	// Timeout (секунды)
+	if val := os.Getenv("DELIVERY_TIMEOUT"); val != "" {
+		seconds, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid DELIVERY_TIMEOUT format: must be integer (seconds), got: %s", val)
+		}
+		if seconds <= 0 {
+			return fmt.Errorf("DELIVERY_TIMEOUT must be positive, got: %d", seconds)
+		}
+		cfg.Delivery.Timeout = seconds
+	}
+
+	// Retry
+	// MaxAttempts (целое число)
+	if val := os.Getenv("DELIVERY_RETRY_MAX_ATTEMPTS"); val != "" {
+		attempts, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid DELIVERY_RETRY_MAX_ATTEMPTS format: must be integer, got: %s", val)
+		}
+		if attempts <= 0 {
+			return fmt.Errorf("DELIVERY_RETRY_MAX_ATTEMPTS must be positive, got: %d", attempts)
+		}
+		cfg.Delivery.Retry.MaxAttempts = attempts
+	}
+
+	// InitialInterval (значение в секундах, целое число)
+	if val := os.Getenv("DELIVERY_RETRY_INITIAL_INTERVAL"); val != "" {
+		seconds, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid DELIVERY_RETRY_INITIAL_INTERVAL format: must be integer (seconds), got: %s", val)
+		}
+		if seconds <= 0 {
+			return fmt.Errorf("DELIVERY_RETRY_INITIAL_INTERVAL must be positive, got: %d", seconds)
+		}
+		cfg.Delivery.Retry.InitialInterval = seconds
+	}
+
+	// MaxInterval (значение в секундах, целое число)
+	if val := os.Getenv("DELIVERY_RETRY_MAX_INTERVAL"); val != "" {
+		seconds, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid DELIVERY_RETRY_MAX_INTERVAL format: must be integer (seconds), got: %s", val)
+		}
+		if seconds <= 0 {
+			return fmt.Errorf("DELIVERY_RETRY_MAX_INTERVAL must be positive, got: %d", seconds)
+		}
+		cfg.Delivery.Retry.MaxInterval = seconds
+	}
+
+	// MaxAge (значение в секундах, целое число)
+	if val := os.Getenv("DELIVERY_RETRY_MAX_AGE"); val != "" {
+		seconds, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid DELIVERY_RETRY_MAX_AGE format: must be integer (seconds), got: %s", val)
+		}
+		if seconds <= 0 {
+			return fmt.Errorf("DELIVERY_RETRY_MAX_AGE must be positive, got: %d", seconds)
+		}
+		cfg.Delivery.Retry.MaxAge = seconds
+	}
+
+	// CircuitBreaker
+	if val := os.Getenv("DELIVERY_CIRCUIT_BREAKER_ENABLED"); val != "" {
+		cfg.Delivery.CircuitBreaker.Enabled = val == "true"
+	}
+
+	// FailureThreshold (целое число)
+	if val := os.Getenv("DELIVERY_CIRCUIT_BREAKER_FAILURE_THRESHOLD"); val != "" {
+		threshold, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid DELIVERY_CIRCUIT_BREAKER_FAILURE_THRESHOLD format: must be integer, got: %s", val)
+		}
+		if threshold <= 0 {
+			return fmt.Errorf("DELIVERY_CIRCUIT_BREAKER_FAILURE_THRESHOLD must be positive, got: %d", threshold)
+		}
+		cfg.Delivery.CircuitBreaker.FailureThreshold = threshold
+	}
+
+	// OpenDuration (значение в секундах, целое число)
+	if val := os.Getenv("DELIVERY_CIRCUIT_BREAKER_OPEN_DURATION"); val != "" {
+		seconds, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid DELIVERY_CIRCUIT_BREAKER_OPEN_DURATION format: must be integer (seconds), got: %s", val)
+		}
+		if seconds <= 0 {
+			return fmt.Errorf("DELIVERY_CIRCUIT_BREAKER_OPEN_DURATION must be positive, got: %d", seconds)
+		}
+		cfg.Delivery.CircuitBreaker.OpenDuration = seconds
+	}
+
+	// HalfOpenProbes (целое число)
+	if val := os.Getenv("DELIVERY_CIRCUIT_BREAKER_HALF_OPEN_PROBES"); val != "" {
+		probes, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid DELIVERY_CIRCUIT_BREAKER_HALF_OPEN_PROBES format: must be integer, got: %s", val)
+		}
+		if probes <= 0 {
+			return fmt.Errorf("DELIVERY_CIRCUIT_BREAKER_HALF_OPEN_PROBES must be positive, got: %d", probes)
+		}
+		cfg.Delivery.CircuitBreaker.HalfOpenProbes = probes
+	}
+
+	// Outbox
+	if val := os.Getenv("OUTBOX_ENABLED"); val != "" {
+		cfg.Outbox.Enabled = val == "true"
+	}
+
+	if val := os.Getenv("OUTBOX_DIR"); val != "" {
+		cfg.Outbox.Dir = val
+	}
+
+	// CompactInterval (значение в секундах, целое число)
+	if val := os.Getenv("OUTBOX_COMPACT_INTERVAL"); val != "" {
+		seconds, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid OUTBOX_COMPACT_INTERVAL format: must be integer (seconds), got: %s", val)
+		}
+		if seconds <= 0 {
+			return fmt.Errorf("OUTBOX_COMPACT_INTERVAL must be positive, got: %d", seconds)
+		}
+		cfg.Outbox.CompactInterval = seconds
+	}
+
+	// Dead letter
+	if val := os.Getenv("DEAD_LETTER_ENABLED"); val != "" {
+		cfg.DeadLetter.Enabled = val == "true"
+	}
+
+	if val := os.Getenv("DEAD_LETTER_DIR"); val != "" {
+		cfg.DeadLetter.Dir = val
+	}
+
+	// Admin
+	if val := os.Getenv("ADMIN_TOKEN"); val != "" {
+		cfg.Admin.Token = val
+	}
+
+	return nil
+}
+
+// loadChannelCommonEnv загружает общие настройки канала из переменных окружения с префиксом prefix:
+// <prefix>_TIMEOUT (секунды), <prefix>_RATE_LIMIT_PER_CHAT и <prefix>_RATE_LIMIT_GLOBAL
+func loadChannelCommonEnv(prefix string, timeout *int, rateLimit *RateLimitConfig) error {
+	if val := os.Getenv(prefix + "_TIMEOUT"); val != "" {
+		seconds, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid %s_TIMEOUT format: must be integer (seconds), got: %s", prefix, val)
+		}
+		if seconds <= 0 {
+			return fmt.Errorf("%s_TIMEOUT must be positive, got: %d", prefix, seconds)
+		}
+		*timeout = seconds
+	}
+
+	limits := []struct {
+		name  string
+		value *int
+	}{
+		{name: prefix + "_RATE_LIMIT_PER_CHAT", value: &rateLimit.PerChat},
+		{name: prefix + "_RATE_LIMIT_GLOBAL", value: &rateLimit.Global},
+	}
+	for _, limit := range limits {
+		val := os.Getenv(limit.name)
+		if val == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid %s format: must be integer, got: %s", limit.name, val)
+		}
+		if parsed <= 0 {
+			return fmt.Errorf("%s must be positive, got: %d", limit.name, parsed)
+		}
+		*limit.value = parsed
+	}
+
+	return nil
+}
+
+// normalizeProjectNames нормализует ключи проектов к нижнему регистру
+func normalizeProjectNames(cfg *Config) {
+	if cfg.Notifications.Youtrack.Projects == nil {
+		// Инициализируем пустую map, если она nil
+		cfg.Notifications.Youtrack.Projects = make(map[string]ProjectConfig)
+		return
+	}
+
+	if len(cfg.Notifications.Youtrack.Projects) == 0 {
+		// Если map пустая, ничего не делаем
+		return
+	}
+
+	// Собираем оригинальные ключи для логирования
+	originalKeys := make([]string, 0, len(cfg.Notifications.Youtrack.Projects))
+	for k := range cfg.Notifications.Youtrack.Projects {
+		originalKeys = append(originalKeys, k)
+	}
+
+	normalizedProjects := make(map[string]ProjectConfig)
+	for projectName, projectConfig := range cfg.Notifications.Youtrack.Projects {
+		normalizedName := strings.ToLower(projectName)
+		normalizedProjects[normalizedName] = projectConfig
+	}
+
+	cfg.Notifications.Youtrack.Projects = normalizedProjects
+}
+
+// normalizeWebhookTokens нормализует имена проектов в токенах источников к нижнему регистру
+func normalizeWebhookTokens(cfg *Config) {
+	for i := range cfg.Webhook.Tokens {
+		for j, projectName := range cfg.Webhook.Tokens[i].Projects {
+			cfg.Webhook.Tokens[i].Projects[j] = strings.ToLower(projectName)
+		}
+	}
+}
+
+// validateConfig проверяет корректность конфигурации
+func validateConfig(cfg *Config) error {
+	if cfg.HTTP.Addr == "" {
+		return fmt.Errorf("HTTP_ADDR is required")
+	}
+
+	if cfg.HTTP.ShutdownTimeout <= 0 {
+		return fmt.Errorf("HTTP_SHUTDOWN_TIMEOUT must be positive")
+	}
+
+	if cfg.HTTP.ReadTimeout <= 0 {
+		return fmt.Errorf("HTTP_READ_TIMEOUT must be positive")
+	}
+
+	if cfg.HTTP.WriteTimeout <= 0 {
+		return fmt.Errorf("HTTP_WRITE_TIMEOUT must be positive")
+	}
+
+	// Устанавливаем значение по умолчанию для максимального размера тела запроса
+	if cfg.HTTP.MaxBodySize <= 0 {
+		cfg.HTTP.MaxBodySize = DefaultMaxBodySize
+	}
+
+	// Устанавливаем значения по умолчанию для Telegram, если не заданы
+	if cfg.Telegram.Timeout <= 0 {
+		cfg.Telegram.Timeout = 10
+	}
+
+	// Лимиты Telegram Bot API: около 1 сообщения в секунду в чат, 20 в минуту в группу и 30 в секунду всего
+	setRateLimitDefaults(&cfg.Telegram.RateLimit)
+
+	// Устанавливаем значения по умолчанию для VK Teams, если не заданы
+	if cfg.VKTeams.Timeout <= 0 {
+		cfg.VKTeams.Timeout = 10
+	}
+	setRateLimitDefaults(&cfg.VKTeams.RateLimit)
+
+	// Устанавливаем значения по умолчанию для Slack, если не заданы
+	if cfg.Slack.Timeout <= 0 {
+		cfg.Slack.Timeout = 10
+	}
+	if cfg.Slack.ApiUrl == "" {
+		cfg.Slack.ApiUrl = DefaultSlackApiUrl
+	}
+	// Slack ограничивает отправку около 1 сообщения в секунду в канал, групповых чатов в понимании лимитов нет,
+	// поэтому per_group для Slack не применяется
+	setRateLimitDefaults(&cfg.Slack.RateLimit)
+
+	// Устанавливаем значения по умолчанию для Mattermost, если не заданы
+	if cfg.Mattermost.Timeout <= 0 {
+		cfg.Mattermost.Timeout = 10
+	}
+	setRateLimitDefaults(&cfg.Mattermost.RateLimit)
+
+	// Устанавливаем значения по умолчанию для Microsoft Teams, если не заданы
+	if cfg.MSTeams.Timeout <= 0 {
+		cfg.MSTeams.Timeout = 10
+	}
+	setRateLimitDefaults(&cfg.MSTeams.RateLimit)
+
+	// Устанавливаем значения по умолчанию для Discord, если не заданы
+	if cfg.Discord.Timeout <= 0 {
+		cfg.Discord.Timeout = 10
+	}
+	setRateLimitDefaults(&cfg.Discord.RateLimit)
+
+	// Устанавливаем значения по умолчанию для email, если не заданы
+	if cfg.Email.TLS == "" {
+		cfg.Email.TLS = EmailTLSStartTLS
+	}
+	if cfg.Email.TLS != EmailTLSStartTLS && cfg.Email.TLS != EmailTLSImplicit && cfg.Email.TLS != EmailTLSNone {
+		return fmt.Errorf("EMAIL_TLS must be one of: starttls, tls, none, got: %s", cfg.Email.TLS)
+	}
+	if cfg.Email.Auth == "" {
+		cfg.Email.Auth = EmailAuthPlain
+	}
+	if cfg.Email.Auth != EmailAuthPlain && cfg.Email.Auth != EmailAuthLogin {
+		return fmt.Errorf("EMAIL_AUTH must be one of: plain, login, got: %s", cfg.Email.Auth)
+	}
+	if cfg.Email.Port <= 0 {
+		cfg.Email.Port = defaultEmailPort(cfg.Email.TLS)
+	}
+	if cfg.Email.Timeout <= 0 {
+		cfg.Email.Timeout = 10
+	}
+	setRateLimitDefaults(&cfg.Email.RateLimit)
+
+	// Устанавливаем значения по умолчанию для webhook канала и проверяем цели
+	if cfg.OutgoingWebhook.Timeout <= 0 {
+		cfg.OutgoingWebhook.Timeout = 10
+	}
+	setRateLimitDefaults(&cfg.OutgoingWebhook.RateLimit)
+	if err := normalizeOutgoingWebhookTargets(cfg.OutgoingWebhook.Targets); err != nil {
+		return err
+	}
+
+	// Устанавливаем значения по умолчанию для Matrix, если не заданы
+	if cfg.Matrix.Timeout <= 0 {
+		cfg.Matrix.Timeout = 10
+	}
+	setRateLimitDefaults(&cfg.Matrix.RateLimit)
+
+	// Устанавливаем значения по умолчанию для Zulip, если не заданы
+	if cfg.Zulip.Timeout <= 0 {
+		cfg.Zulip.Timeout = 10
+	}
+	setRateLimitDefaults(&cfg.Zulip.RateLimit)
+
+	// Устанавливаем значения по умолчанию для ntfy, если не заданы
+	if cfg.Ntfy.ServerURL == "" {
+		cfg.Ntfy.ServerURL = DefaultNtfyServerURL
+	}
+	if cfg.Ntfy.Timeout <= 0 {
+		cfg.Ntfy.Timeout = 10
+	}
+	setRateLimitDefaults(&cfg.Ntfy.RateLimit)
+
+	// Устанавливаем значения по умолчанию для Gotify, если не заданы
+	if cfg.Gotify.Timeout <= 0 {
+		cfg.Gotify.Timeout = 10
+	}
+	setRateLimitDefaults(&cfg.Gotify.RateLimit)
+
+	// Архив уведомлений file канала
+	if err := validateFileConfig(&cfg.File); err != nil {
+		return err
+	}
+
+	// Устанавливаем заголовок подписи webhook по умолчанию, если не задан
+	if cfg.Webhook.SignatureHeader == "" {
+		cfg.Webhook.SignatureHeader = DefaultSignatureHeader
+	}
+
+	// Устанавливаем значения по умолчанию для защиты от повторных запросов, если не заданы
+	if cfg.Webhook.Replay.MaxSkew <= 0 {
+		cfg.Webhook.Replay.MaxSkew = 300
+	}
+	if cfg.Webhook.Replay.CacheSize <= 0 {
+		cfg.Webhook.Replay.CacheSize = 10000
+	}
+	if cfg.Webhook.Replay.TimestampHeader == "" {
+		cfg.Webhook.Replay.TimestampHeader = DefaultTimestampHeader
+	}
+	if cfg.Webhook.Replay.DeliveryHeader == "" {
+		cfg.Webhook.Replay.DeliveryHeader = DefaultDeliveryHeader
+	}
+
+	// Устанавливаем значения по умолчанию для отбрасывания повторных событий, если не заданы
+	if cfg.Webhook.Idempotency.TTL <= 0 {
+		cfg.Webhook.Idempotency.TTL = 600
+	}
+	if cfg.Webhook.Idempotency.CacheSize <= 0 {
+		cfg.Webhook.Idempotency.CacheSize = 10000
+	}
+	if cfg.Webhook.Idempotency.Header == "" {
+		cfg.Webhook.Idempotency.Header = DefaultIdempotencyHeader
+	}
+
+	// Коды ответа webhook
+	if err := validateWebhookResponseConfig(&cfg.Webhook.Response); err != nil {
+		return err
+	}
+
+	// Устанавливаем значения по умолчанию для очереди доставки, если не заданы
+	if cfg.Delivery.Workers <= 0 {
+		cfg.Delivery.Workers = 4
+	}
+	if cfg.Delivery.QueueSize <= 0 {
+		cfg.Delivery.QueueSize = 1000
+	}
+	if cfg.Delivery.RetryAfter <= 0 {
+		cfg.Delivery.RetryAfter = 5
+	}
+	if cfg.Delivery.Timeout <= 0 {
+		cfg.Delivery.Timeout = 600
+	}
+
+	// Политика повторной отправки
+	if err := validateRetryConfig(&cfg.Delivery.Retry); err != nil {
+		return err
+	}
+
+	// Автоматический выключатель каналов
+	setCircuitBreakerDefaults(&cfg.Delivery.CircuitBreaker)
+
+	// Устанавливаем значения по умолчанию для журнала событий, если не заданы
+	if cfg.Outbox.Dir == "" {
+		cfg.Outbox.Dir = DefaultOutboxDir
+	}
+	if cfg.Outbox.CompactInterval <= 0 {
+		cfg.Outbox.CompactInterval = 300
+	}
+
+	// Устанавливаем каталог недоставленных уведомлений по умолчанию, если не задан
+	if cfg.DeadLetter.Dir == "" {
+		cfg.DeadLetter.Dir = DefaultDeadLetterDir
+	}
+
+	// Валидация конфигурации проектов
+	if err := validateNotificationsConfig(cfg); err != nil {
+		return err
+	}
+
+	// Валидация токенов источников webhook
+	if err := validateWebhookTokens(cfg); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// setRateLimitDefaults устанавливает незаданные ограничения частоты отправки
+// PerGroup применяется только каналами, которые отличают групповые чаты от личных (Telegram, VK Teams)
+func setRateLimitDefaults(cfg *RateLimitConfig) {
+	if cfg.PerChat <= 0 {
+		cfg.PerChat = 1
+	}
+	if cfg.PerGroup <= 0 {
+		cfg.PerGroup = 20
+	}
+	if cfg.Global <= 0 {
+		cfg.Global = 30
+	}
+}
+
+// validateRetryConfig устанавливает значения по умолчанию для политики повторной отправки и проверяет ее
+// Политики каналов дополняются незаданными значениями из политики по умолчанию
+func validateRetryConfig(cfg *RetryConfig) error {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.InitialInterval <= 0 {
+		cfg.InitialInterval = 1
+	}
+	if cfg.MaxInterval <= 0 {
+		cfg.MaxInterval = 30
+	}
+	if cfg.Multiplier <= 0 {
+		cfg.Multiplier = 2
+	}
+	if cfg.Jitter == nil {
+		jitter := 0.2
+		cfg.Jitter = &jitter
+	}
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = 300
+	}
+	if err := validateRetryPolicy("delivery.retry", cfg.RetryPolicyConfig); err != nil {
+		return err
+	}
+
+	if len(cfg.Channels) == 0 {
+		return nil
+	}
+
+	normalized := make(map[string]RetryPolicyConfig, len(cfg.Channels))
+	for channelName, policy := range cfg.Channels {
+		if policy.MaxAttempts <= 0 {
+			policy.MaxAttempts = cfg.MaxAttempts
+		}
+		if policy.InitialInterval <= 0 {
+			policy.InitialInterval = cfg.InitialInterval
+		}
+		if policy.MaxInterval <= 0 {
+			policy.MaxInterval = cfg.MaxInterval
+		}
+		if policy.Multiplier <= 0 {
+			policy.Multiplier = cfg.Multiplier
+		}
+		if policy.Jitter == nil {
+			jitter := *cfg.Jitter
+			policy.Jitter = &jitter
+		}
+		if policy.MaxAge <= 0 {
+			policy.MaxAge = cfg.MaxAge
+		}
+
+		normalizedName := strings.ToLower(channelName)
+		if err := validateRetryPolicy(fmt.Sprintf("delivery.retry.channels.%s", normalizedName), policy); err != nil {
+			return err
+		}
+		normalized[normalizedName] = policy
+	}
+	cfg.Channels = normalized
+
+	return nil
+}
+
+// setCircuitBreakerDefaults устанавливает значения по умолчанию для автоматического выключателя
+// Настройки каналов дополняются незаданными значениями из настроек по умолчанию
+func setCircuitBreakerDefaults(cfg *CircuitBreakerConfig) {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenDuration <= 0 {
+		cfg.OpenDuration = 30
+	}
+	if cfg.HalfOpenProbes <= 0 {
+		cfg.HalfOpenProbes = 1
+	}
+
+	if len(cfg.Channels) == 0 {
+		return
+	}
+
+	normalized := make(map[string]CircuitBreakerPolicyConfig, len(cfg.Channels))
+	for channelName, policy := range cfg.Channels {
+		if policy.FailureThreshold <= 0 {
+			policy.FailureThreshold = cfg.FailureThreshold
+		}
+		if policy.OpenDuration <= 0 {
+			policy.OpenDuration = cfg.OpenDuration
+		}
+		if policy.HalfOpenProbes <= 0 {
+			policy.HalfOpenProbes = cfg.HalfOpenProbes
+		}
+		normalized[strings.ToLower(channelName)] = policy
+	}
+	cfg.Channels = normalized
+}
+
+// validateWebhookResponseConfig устанавливает коды ответа webhook по умолчанию и проверяет их
+func validateWebhookResponseConfig(cfg *WebhookResponseConfig) error {
+	if cfg.SuccessStatus == 0 {
+		cfg.SuccessStatus = DefaultWebhookSuccessStatus
+	}
+	if cfg.PartialStatus == 0 {
+		cfg.PartialStatus = DefaultWebhookPartialStatus
+	}
+	if cfg.FailedStatus == 0 {
+		cfg.FailedStatus = DefaultWebhookFailedStatus
+	}
+
+	statuses := []struct {
+		name   string
+		status int
+	}{
+		{name: "success_status", status: cfg.SuccessStatus},
+		{name: "partial_status", status: cfg.PartialStatus},
+		{name: "failed_status", status: cfg.FailedStatus},
+	}
+	for _, s := range statuses {
+		if s.status < 200 || s.status > 599 {
+			return fmt.Errorf("webhook.response.%s must be between 200 and 599, got: %d", s.name, s.status)
+		}
+	}
+
+	return nil
+}
+
+// validateRetryPolicy проверяет допустимость значений политики повторной отправки
+func validateRetryPolicy(path string, policy RetryPolicyConfig) error {
+	if policy.Multiplier < 1 {
+		return fmt.Errorf("%s: multiplier must be at least 1, got: %v", path, policy.Multiplier)
+	}
+	if policy.Jitter != nil && (*policy.Jitter < 0 || *policy.Jitter > 1) {
+		return fmt.Errorf("%s: jitter must be between 0 and 1, got: %v", path, *policy.Jitter)
+	}
+	if policy.MaxInterval < policy.InitialInterval {
+		return fmt.Errorf("%s: max_interval must not be less than initial_interval", path)
+	}
+	return nil
+}
+
+// validateWebhookTokens проверяет корректность токенов источников webhook запросов
+func validateWebhookTokens(cfg *Config) error {
+	seenTokens := make(map[string]bool, len(cfg.Webhook.Tokens))
+
+	for i, token := range cfg.Webhook.Tokens {
+		if token.Name == "" {
+			return fmt.Errorf("webhook.tokens[%d]: name cannot be empty", i)
+		}
+		if token.Token == "" {
+			return fmt.Errorf("webhook token %q: token cannot be empty", token.Name)
+		}
+		if seenTokens[token.Token] {
+			return fmt.Errorf("webhook token %q: token value is duplicated", token.Name)
+		}
+		seenTokens[token.Token] = true
+
+		for _, projectName := range token.Projects {
+			if _, exists := cfg.Notifications.Youtrack.Projects[strings.ToLower(projectName)]; !exists {
+				return fmt.Errorf("webhook token %q: project %q is not configured in notifications.youtrack.projects", token.Name, projectName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateNotificationsConfig проверяет корректность конфигурации уведомлений
+func validateNotificationsConfig(cfg *Config) error {
+	if cfg.Notifications.Youtrack.Projects == nil {
+		return nil // Проекты не настроены - это допустимо (уведомления будут игнорироваться)
+	}
+
+	// Проверяем каждый проект
+	for projectName, projectConfig := range cfg.Notifications.Youtrack.Projects {
+		// Проверяем, что allowedChannels не пустой
+		if len(projectConfig.AllowedChannels) == 0 {
+			return fmt.Errorf("project %q: allowedChannels cannot be empty", projectName)
+		}
+
+		if projectConfig.CoalesceWindow < 0 {
+			return fmt.Errorf("project %q: coalesceWindow cannot be negative, got: %d", projectName, projectConfig.CoalesceWindow)
+		}
+
+		// Проверяем валидность каналов
+		channelNames := projectChannelNames()
+		for _, channel := range projectConfig.AllowedChannels {
+			if !slices.Contains(channelNames, channel) {
+				return fmt.Errorf("project %q: invalid channel %q, allowed channels: %s", projectName, channel, strings.Join(channelNames, ", "))
+			}
+		}
+
+		// Проверяем настройки проекта и глобальные настройки каналов из allowedChannels
+		for _, validator := range projectChannelValidators {
+			if validator.validate == nil || !slices.Contains(projectConfig.AllowedChannels, validator.channel) {
+				continue
+			}
+			if err := validator.validate(projectName, projectConfig, cfg); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// projectChannelValidator описывает проверку настроек канала, указанного в allowedChannels проекта
+type projectChannelValidator struct {
+	channel  string
+	validate func(projectName string, projectConfig ProjectConfig, cfg *Config) error // nil - канал не требует настроек
+}
+
+// projectChannelValidators содержит допустимые каналы проекта в порядке проверки их настроек
+var projectChannelValidators = []projectChannelValidator{
+	{channel: "telegram", validate: func(projectName string, projectConfig ProjectConfig, cfg *Config) error {
+		return validateProjectTelegramConfig(projectName, projectConfig.Telegram, cfg.Telegram)
+	}},
+	{channel: "vkteams", validate: func(projectName string, projectConfig ProjectConfig, cfg *Config) error {
+		return validateProjectVKTeamsConfig(projectName, projectConfig.VKTeams, cfg.VKTeams)
+	}},
+	{channel: "slack", validate: func(projectName string, projectConfig ProjectConfig, cfg *Config) error {
+		return validateProjectSlackConfig(projectName, projectConfig.Slack, cfg.Slack)
+	}},
+	{channel: "mattermost", validate: func(projectName string, projectConfig ProjectConfig, cfg *Config) error {
+		return validateProjectMattermostConfig(projectName, projectConfig.Mattermost, cfg.Mattermost)
+	}},
+	{channel: "msteams", validate: func(projectName string, projectConfig ProjectConfig, _ *Config) error {
+		return validateProjectMSTeamsConfig(projectName, projectConfig.MSTeams)
+	}},
+	{channel: "discord", validate: func(projectName string, projectConfig ProjectConfig, _ *Config) error {
+		return validateProjectDiscordConfig(projectName, projectConfig.Discord)
+	}},
+	{channel: "email", validate: func(projectName string, projectConfig ProjectConfig, cfg *Config) error {
+		return validateProjectEmailConfig(projectName, projectConfig.Email, cfg.Email)
+	}},
+	{channel: "webhook", validate: func(projectName string, projectConfig ProjectConfig, cfg *Config) error {
+		return validateProjectWebhookConfig(projectName, projectConfig.Webhook, cfg.OutgoingWebhook)
+	}},
+	{channel: "matrix", validate: func(projectName string, projectConfig ProjectConfig, cfg *Config) error {
+		return validateProjectMatrixConfig(projectName, projectConfig.Matrix, cfg.Matrix)
+	}},
+	{channel: "zulip", validate: func(projectName string, projectConfig ProjectConfig, cfg *Config) error {
+		return validateProjectZulipConfig(projectName, projectConfig.Zulip, cfg.Zulip)
+	}},
+	{channel: "ntfy", validate: func(projectName string, projectConfig ProjectConfig, cfg *Config) error {
+		return validateProjectNtfyConfig(projectName, projectConfig.Ntfy, cfg.Ntfy)
+	}},
+	{channel: "gotify", validate: func(projectName string, projectConfig ProjectConfig, cfg *Config) error {
+		return validateProjectGotifyConfig(projectName, projectConfig.Gotify, cfg.Gotify)
+	}},
+	{channel: "file"},
+	{channel: "logger"},
+}
+
+// projectChannelNames возвращает имена допустимых каналов проекта
+func projectChannelNames() []string {
+	names := make([]string, 0, len(projectChannelValidators))
+	for _, validator := range projectChannelValidators {
+		names = append(names, validator.channel)
+	}
+	return names
+}
+
+// validateProjectTelegramConfig проверяет настройки Telegram проекта
+// Для отправки нужен глобальный bot_token
+func validateProjectTelegramConfig(projectName string, projectTelegram *ProjectTelegramConfig, cfg TelegramConfig) error {
+	if projectTelegram == nil {
+		return fmt.Errorf("project %q: telegram.chat_id is required when telegram is in allowedChannels", projectName)
+	}
+	if projectTelegram.ChatID == "" {
+		return fmt.Errorf("project %q: telegram.chat_id cannot be empty when telegram is in allowedChannels", projectName)
+	}
+
+	if cfg.BotToken == "" {
+		return fmt.Errorf("TELEGRAM_BOT_TOKEN is required when telegram is used in project configurations")
+	}
+
+	return nil
+}
+
+// validateProjectVKTeamsConfig проверяет настройки VK Teams проекта
+// Для отправки нужны глобальные bot_token и api_url
+func validateProjectVKTeamsConfig(projectName string, projectVKTeams *ProjectVKTeamsConfig, cfg VKTeamsConfig) error {
+	if projectVKTeams == nil {
+		return fmt.Errorf("project %q: vkteams.chat_id is required when vkteams is in allowedChannels", projectName)
+	}
+	if projectVKTeams.ChatID == "" {
+		return fmt.Errorf("project %q: vkteams.chat_id cannot be empty when vkteams is in allowedChannels", projectName)
+	}
+
+	if cfg.BotToken == "" {
+		return fmt.Errorf("VKTEAMS_BOT_TOKEN is required when vkteams is used in project configurations")
+	}
+	if cfg.ApiUrl == "" {
+		return fmt.Errorf("VKTEAMS_API_URL is required when vkteams is used in project configurations")
+	}
+
+	return nil
+}
+
+// validateProjectSlackConfig проверяет настройки Slack проекта
+// Для incoming webhook достаточно webhook_url, для отправки в channel нужен глобальный bot_token
+func validateProjectSlackConfig(projectName string, projectSlack *ProjectSlackConfig, cfg SlackConfig) error {
+	if projectSlack == nil || (projectSlack.WebhookURL == "" && projectSlack.Channel == "") {
+		return fmt.Errorf("project %q: slack.webhook_url or slack.channel is required when slack is in allowedChannels", projectName)
+	}
+
+	if projectSlack.WebhookURL != "" {
+		if !strings.HasPrefix(projectSlack.WebhookURL, "https://") {
+			return fmt.Errorf("project %q: slack.webhook_url must be an https URL", projectName)
+		}
+		return nil
+	}
+
+	if cfg.BotToken == "" {
+		return fmt.Errorf("SLACK_BOT_TOKEN is required when slack.channel is used in project configurations")
+	}
+
+	return nil
+}
+
+// validateProjectMattermostConfig проверяет настройки Mattermost проекта
+// Для incoming webhook достаточно webhook_url, для отправки в channel_id нужны глобальные bot_token и api_url
+func validateProjectMattermostConfig(projectName string, projectMattermost *ProjectMattermostConfig, cfg MattermostConfig) error {
+	if projectMattermost == nil || (projectMattermost.WebhookURL == "" && projectMattermost.ChannelID == "") {
+		return fmt.Errorf("project %q: mattermost.webhook_url or mattermost.channel_id is required when mattermost is in allowedChannels", projectName)
+	}
+
+	if projectMattermost.WebhookURL != "" {
+		if !strings.HasPrefix(projectMattermost.WebhookURL, "https://") && !strings.HasPrefix(projectMattermost.WebhookURL, "http://") {
+			return fmt.Errorf("project %q: mattermost.webhook_url must be an http or https URL", projectName)
+		}
+		return nil
+	}
+
+	if cfg.BotToken == "" {
+		return fmt.Errorf("MATTERMOST_BOT_TOKEN is required when mattermost.channel_id is used in project configurations")
+	}
+	if cfg.ApiUrl == "" {
+		return fmt.Errorf("MATTERMOST_API_URL is required when mattermost.channel_id is used in project configurations")
+	}
+
+	return nil
+}
+
+// validateProjectMSTeamsConfig проверяет настройки Microsoft Teams проекта
+// Адрес incoming webhook или workflow должен использовать https
+func validateProjectMSTeamsConfig(projectName string, projectMSTeams *ProjectMSTeamsConfig) error {
+	if projectMSTeams == nil || projectMSTeams.WebhookURL == "" {
+		return fmt.Errorf("project %q: msteams.webhook_url is required when msteams is in allowedChannels", projectName)
+	}
+	if !strings.HasPrefix(projectMSTeams.WebhookURL, "https://") {
+		return fmt.Errorf("project %q: msteams.webhook_url must be an https URL", projectName)
+	}
+
+	return nil
+}
+
+// validateProjectDiscordConfig проверяет настройки Discord проекта
+// Адрес webhook канала должен использовать https
+func validateProjectDiscordConfig(projectName string, projectDiscord *ProjectDiscordConfig) error {
+	if projectDiscord == nil || projectDiscord.WebhookURL == "" {
+		return fmt.Errorf("project %q: discord.webhook_url is required when discord is in allowedChannels", projectName)
+	}
+	if !strings.HasPrefix(projectDiscord.WebhookURL, "https://") {
+		return fmt.Errorf("project %q: discord.webhook_url must be an https URL", projectName)
+	}
+
+	return nil
+}
+
+// validateProjectEmailConfig проверяет настройки email проекта
+// Нужен хотя бы один получатель или отправка исполнителю, а также глобальные адрес SMTP сервера и отправителя
+func validateProjectEmailConfig(projectName string, projectEmail *ProjectEmailConfig, cfg EmailConfig) error {
+	if projectEmail == nil || (len(projectEmail.Recipients) == 0 && !projectEmail.NotifyAssignee) {
+		return fmt.Errorf("project %q: email.recipients or email.notify_assignee is required when email is in allowedChannels", projectName)
+	}
+
+	for _, recipient := range projectEmail.Recipients {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			return fmt.Errorf("project %q: invalid email.recipients address %q: %w", projectName, recipient, err)
+		}
+	}
+
+	if cfg.Host == "" {
+		return fmt.Errorf("EMAIL_HOST is required when email is used in project configurations")
+	}
+	if cfg.From == "" {
+		return fmt.Errorf("EMAIL_FROM is required when email is used in project configurations")
+	}
+	if _, err := mail.ParseAddress(cfg.From); err != nil {
+		return fmt.Errorf("EMAIL_FROM must be a valid email address: %w", err)
+	}
+
+	return nil
+}
+
+// validateProjectWebhookConfig проверяет настройки webhook канала проекта
+// Каждая цель проекта должна быть описана в глобальной секции outgoing_webhook.targets
+func validateProjectWebhookConfig(projectName string, projectWebhook *ProjectWebhookConfig, cfg OutgoingWebhookConfig) error {
+	if projectWebhook == nil || len(projectWebhook.Targets) == 0 {
+		return fmt.Errorf("project %q: webhook.targets is required when webhook is in allowedChannels", projectName)
+	}
+
+	for _, target := range projectWebhook.Targets {
+		if _, exists := cfg.Targets[target]; !exists {
+			return fmt.Errorf("project %q: webhook target %q is not defined in outgoing_webhook.targets", projectName, target)
+		}
+	}
+
+	return nil
+}
+
+// validateProjectMatrixConfig проверяет настройки Matrix проекта
+// ID комнаты должен начинаться с "!", для отправки нужны глобальные access_token и homeserver_url
+func validateProjectMatrixConfig(projectName string, projectMatrix *ProjectMatrixConfig, cfg MatrixConfig) error {
+	if projectMatrix == nil || projectMatrix.RoomID == "" {
+		return fmt.Errorf("project %q: matrix.room_id is required when matrix is in allowedChannels", projectName)
+	}
+	if !strings.HasPrefix(projectMatrix.RoomID, "!") || !strings.Contains(projectMatrix.RoomID, ":") {
+		return fmt.Errorf("project %q: matrix.room_id must be a room ID like !room:server, got: %s", projectName, projectMatrix.RoomID)
+	}
+
+	if cfg.AccessToken == "" {
+		return fmt.Errorf("MATRIX_ACCESS_TOKEN is required when matrix is used in project configurations")
+	}
+	if !strings.HasPrefix(cfg.HomeserverURL, "https://") && !strings.HasPrefix(cfg.HomeserverURL, "http://") {
+		return fmt.Errorf("MATRIX_HOMESERVER_URL must be an http or https URL when matrix is used in project configurations")
+	}
+
+	return nil
+}
+
+// validateProjectZulipConfig проверяет настройки Zulip проекта
+// Для отправки нужны глобальные bot_email, api_key и site_url
+func validateProjectZulipConfig(projectName string, projectZulip *ProjectZulipConfig, cfg ZulipConfig) error {
+	if projectZulip == nil || strings.TrimSpace(projectZulip.Stream) == "" {
+		return fmt.Errorf("project %q: zulip.stream is required when zulip is in allowedChannels", projectName)
+	}
+
+	if cfg.BotEmail == "" {
+		return fmt.Errorf("ZULIP_BOT_EMAIL is required when zulip is used in project configurations")
+	}
+	if cfg.APIKey == "" {
+		return fmt.Errorf("ZULIP_API_KEY is required when zulip is used in project configurations")
+	}
+	if !strings.HasPrefix(cfg.SiteURL, "https://") && !strings.HasPrefix(cfg.SiteURL, "http://") {
+		return fmt.Errorf("ZULIP_SITE_URL must be an http or https URL when zulip is used in project configurations")
+	}
+
+	return nil
+}
+
+// validateProjectNtfyConfig проверяет настройки ntfy проекта
+// Топик указывается в пути запроса, поэтому допускаются только символы, которые ntfy разрешает в имени топика
+func validateProjectNtfyConfig(projectName string, projectNtfy *ProjectNtfyConfig, cfg NtfyConfig) error {
+	if projectNtfy == nil || strings.TrimSpace(projectNtfy.Topic) == "" {
+		return fmt.Errorf("project %q: ntfy.topic is required when ntfy is in allowedChannels", projectName)
+	}
+	if !ntfyTopicPattern.MatchString(projectNtfy.Topic) {
+		return fmt.Errorf("project %q: ntfy.topic must contain only letters, digits, '-' and '_' (up to 64 characters), got: %s", projectName, projectNtfy.Topic)
+	}
+
+	if !strings.HasPrefix(cfg.ServerURL, "https://") && !strings.HasPrefix(cfg.ServerURL, "http://") {
+		return fmt.Errorf("NTFY_SERVER_URL must be an http or https URL when ntfy is used in project configurations")
+	}
+
+	return nil
+}
+
+// validateProjectGotifyConfig проверяет настройки Gotify проекта
+// Для отправки нужен глобальный server_url
+func validateProjectGotifyConfig(projectName string, projectGotify *ProjectGotifyConfig, cfg GotifyConfig) error {
+	if projectGotify == nil || strings.TrimSpace(projectGotify.Token) == "" {
+		return fmt.Errorf("project %q: gotify.token is required when gotify is in allowedChannels", projectName)
+	}
+
+	if !strings.HasPrefix(cfg.ServerURL, "https://") && !strings.HasPrefix(cfg.ServerURL, "http://") {
+		return fmt.Errorf("GOTIFY_SERVER_URL must be an http or https URL when gotify is used in project configurations")
+	}
+
+	return nil
+}
+
+// validateFileConfig устанавливает значения по умолчанию и проверяет настройки file канала
+func validateFileConfig(cfg *FileConfig) error {
+	if cfg.Path == "" {
+		cfg.Path = DefaultFilePath
+	}
+	if cfg.MaxSize < 0 {
+		return fmt.Errorf("FILE_MAX_SIZE cannot be negative, got: %d", cfg.MaxSize)
+	}
+	if cfg.RotationInterval < 0 {
+		return fmt.Errorf("FILE_ROTATION_INTERVAL cannot be negative, got: %d", cfg.RotationInterval)
+	}
+	if cfg.Fsync == "" {
+		cfg.Fsync = FileFsyncAlways
+	}
+	if cfg.Fsync != FileFsyncAlways && cfg.Fsync != FileFsyncInterval && cfg.Fsync != FileFsyncNever {
+		return fmt.Errorf("FILE_FSYNC must be one of: always, interval, never, got: %s", cfg.Fsync)
+	}
+	if cfg.FsyncInterval <= 0 {
+		cfg.FsyncInterval = 1
+	}
+
+	return nil
+}
+
+// normalizeOutgoingWebhookTargets проверяет цели webhook канала и устанавливает значения по умолчанию
+// Метод приводится к верхнему регистру, заголовок подписи по умолчанию совпадает с заголовком входящих запросов
+func normalizeOutgoingWebhookTargets(targets map[string]OutgoingWebhookTargetConfig) error {
+	for name, target := range targets {
+		if !strings.HasPrefix(target.URL, "https://") && !strings.HasPrefix(target.URL, "http://") {
+			return fmt.Errorf("outgoing_webhook target %q: url must be an http or https URL", name)
+		}
+
+		target.Method = strings.ToUpper(target.Method)
+		if target.Method == "" {
+			target.Method = "POST"
+		}
+		if target.Method != "POST" && target.Method != "PUT" && target.Method != "PATCH" {
+			return fmt.Errorf("outgoing_webhook target %q: method must be one of: POST, PUT, PATCH, got: %s", name, target.Method)
+		}
+
+		if target.SignatureHeader == "" {
+			target.SignatureHeader = DefaultSignatureHeader
+		}
+
+		targets[name] = target
+	}
+
+	return nil
+}
+
+// defaultEmailPort возвращает стандартный порт SMTP сервера для режима шифрования
+func defaultEmailPort(tlsMode string) int {
+	switch tlsMode {
+	case EmailTLSImplicit:
+		return 465
+	case EmailTLSNone:
+		return 25
+	default:
+		return 587
+	}
 }
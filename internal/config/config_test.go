@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -19,21 +20,207 @@ func TestLoadConfig(t *testing.T) {
 		expectedErr    error
 	}
 
+	tokenExpiresAt := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	defaultReplayConfig := ReplayConfig{
+		MaxSkew:         300,
+		CacheSize:       10000,
+		TimestampHeader: DefaultTimestampHeader,
+		DeliveryHeader:  DefaultDeliveryHeader,
+	}
+	defaultIdempotencyConfig := IdempotencyConfig{
+		TTL:       600,
+		CacheSize: 10000,
+		Header:    DefaultIdempotencyHeader,
+	}
+	defaultResponseConfig := WebhookResponseConfig{
+		SuccessStatus: DefaultWebhookSuccessStatus,
+		PartialStatus: DefaultWebhookPartialStatus,
+		FailedStatus:  DefaultWebhookFailedStatus,
+	}
+	defaultOutboxConfig := OutboxConfig{
+		Dir:             DefaultOutboxDir,
+		CompactInterval: 300,
+	}
+	defaultDeadLetterConfig := DeadLetterConfig{
+		Dir: DefaultDeadLetterDir,
+	}
+	defaultRateLimitConfig := RateLimitConfig{
+		PerChat:  1,
+		PerGroup: 20,
+		Global:   30,
+	}
+	defaultSlackConfig := SlackConfig{
+		Timeout:   10,
+		ApiUrl:    DefaultSlackApiUrl,
+		RateLimit: defaultRateLimitConfig,
+	}
+	defaultMattermostConfig := MattermostConfig{
+		Timeout:   10,
+		RateLimit: defaultRateLimitConfig,
+	}
+	defaultMSTeamsConfig := MSTeamsConfig{
+		Timeout:   10,
+		RateLimit: defaultRateLimitConfig,
+	}
+	defaultDiscordConfig := DiscordConfig{
+		Timeout:   10,
+		RateLimit: defaultRateLimitConfig,
+	}
+	defaultEmailConfig := EmailConfig{
+		Port:      587,
+		Auth:      EmailAuthPlain,
+		TLS:       EmailTLSStartTLS,
+		Timeout:   10,
+		RateLimit: defaultRateLimitConfig,
+	}
+	defaultOutgoingWebhookConfig := OutgoingWebhookConfig{
+		Timeout:   10,
+		RateLimit: defaultRateLimitConfig,
+	}
+	defaultMatrixConfig := MatrixConfig{
+		Timeout:   10,
+		RateLimit: defaultRateLimitConfig,
+	}
+	defaultZulipConfig := ZulipConfig{
+		Timeout:   10,
+		RateLimit: defaultRateLimitConfig,
+	}
+	defaultNtfyConfig := NtfyConfig{
+		ServerURL: DefaultNtfyServerURL,
+		Timeout:   10,
+		RateLimit: defaultRateLimitConfig,
+	}
+	defaultGotifyConfig := GotifyConfig{
+		Timeout:   10,
+		RateLimit: defaultRateLimitConfig,
+	}
+	defaultFileConfig := FileConfig{
+		Path:          DefaultFilePath,
+		Fsync:         FileFsyncAlways,
+		FsyncInterval: 1,
+	}
+	defaultRetryConfig := RetryConfig{
+		RetryPolicyConfig: RetryPolicyConfig{
+			MaxAttempts:     5,
+			InitialInterval: 1,
+			MaxInterval:     30,
+			Multiplier:      2,
+			Jitter:          float64Ptr(0.2),
+			MaxAge:          300,
+		},
+	}
+	defaultCircuitBreakerConfig := CircuitBreakerConfig{
+		CircuitBreakerPolicyConfig: CircuitBreakerPolicyConfig{
+			FailureThreshold: 5,
+			OpenDuration:     30,
+			HalfOpenProbes:   1,
+		},
+	}
+	defaultDeliveryConfig := DeliveryConfig{
+		Workers:        4,
+		QueueSize:      1000,
+		RetryAfter:     5,
+		Timeout:        600,
+		Retry:          defaultRetryConfig,
+		CircuitBreaker: defaultCircuitBreakerConfig,
+	}
+
 	testCases := []testCase{
 		{
 			name: "ENV_Variables_Have_Priority_Over_YAML",
 			envVariables: map[string]string{
-				"HTTP_ADDR":                    "env_addr:8080",
-				"HTTP_SHUTDOWN_TIMEOUT":        "10",
-				"HTTP_READ_TIMEOUT":            "15",
-				"HTTP_WRITE_TIMEOUT":           "20",
-				"TELEGRAM_BOT_TOKEN":           "env_token",
-				"TELEGRAM_TIMEOUT":             "30",
-				"VKTEAMS_BOT_TOKEN":            "env_vkteams_token",
-				"VKTEAMS_TIMEOUT":              "25",
-				"VKTEAMS_API_URL":              "https://api.env.example.com/bot/v1",
-				"VKTEAMS_INSECURE_SKIP_VERIFY": "true",
-				"LOG_LEVEL":                    "info",
+				"HTTP_ADDR":                                  "env_addr:8080",
+				"HTTP_SHUTDOWN_TIMEOUT":                      "10",
+				"HTTP_READ_TIMEOUT":                          "15",
+				"HTTP_WRITE_TIMEOUT":                         "20",
+				"HTTP_MAX_BODY_SIZE":                         "2048",
+				"DELIVERY_WORKERS":                           "8",
+				"DELIVERY_QUEUE_SIZE":                        "50",
+				"DELIVERY_TIMEOUT":                           "90",
+				"DELIVERY_RETRY_MAX_ATTEMPTS":                "7",
+				"DELIVERY_RETRY_INITIAL_INTERVAL":            "2",
+				"DELIVERY_RETRY_MAX_INTERVAL":                "60",
+				"DELIVERY_RETRY_MAX_AGE":                     "120",
+				"DELIVERY_CIRCUIT_BREAKER_ENABLED":           "true",
+				"DELIVERY_CIRCUIT_BREAKER_FAILURE_THRESHOLD": "3",
+				"OUTBOX_ENABLED":                             "true",
+				"OUTBOX_DIR":                                 "/var/lib/notifications",
+				"DEAD_LETTER_ENABLED":                        "true",
+				"DEAD_LETTER_DIR":                            "/var/lib/notifications/dead-letters",
+				"ADMIN_TOKEN":                                "env_admin_token",
+				"OUTBOX_COMPACT_INTERVAL":                    "60",
+				"TELEGRAM_BOT_TOKEN":                         "env_token",
+				"TELEGRAM_TIMEOUT":                           "30",
+				"TELEGRAM_RATE_LIMIT_PER_CHAT":               "2",
+				"TELEGRAM_RATE_LIMIT_GLOBAL":                 "10",
+				"VKTEAMS_RATE_LIMIT_PER_GROUP":               "5",
+				"VKTEAMS_BOT_TOKEN":                          "env_vkteams_token",
+				"VKTEAMS_TIMEOUT":                            "25",
+				"VKTEAMS_API_URL":                            "https://api.env.example.com/bot/v1",
+				"VKTEAMS_INSECURE_SKIP_VERIFY":               "true",
+				"SLACK_BOT_TOKEN":                            "env_slack_token",
+				"SLACK_TIMEOUT":                              "15",
+				"SLACK_API_URL":                              "https://slack.env.example.com/api",
+				"SLACK_RATE_LIMIT_PER_CHAT":                  "3",
+				"SLACK_RATE_LIMIT_GLOBAL":                    "40",
+				"MATTERMOST_BOT_TOKEN":                       "env_mattermost_token",
+				"MATTERMOST_TIMEOUT":                         "12",
+				"MATTERMOST_API_URL":                         "https://mattermost.env.example.com",
+				"MATTERMOST_INSECURE_SKIP_VERIFY":            "true",
+				"MATTERMOST_RATE_LIMIT_PER_CHAT":             "4",
+				"MATTERMOST_RATE_LIMIT_GLOBAL":               "50",
+				"MSTEAMS_TIMEOUT":                            "20",
+				"MSTEAMS_RATE_LIMIT_PER_CHAT":                "2",
+				"MSTEAMS_RATE_LIMIT_GLOBAL":                  "15",
+				"DISCORD_TIMEOUT":                            "25",
+				"DISCORD_RATE_LIMIT_PER_CHAT":                "3",
+				"DISCORD_RATE_LIMIT_GLOBAL":                  "30",
+				"EMAIL_HOST":                                 "smtp.env.example.com",
+				"EMAIL_PORT":                                 "2525",
+				"EMAIL_USERNAME":                             "env_user",
+				"EMAIL_PASSWORD":                             "env_password",
+				"EMAIL_AUTH":                                 "login",
+				"EMAIL_TLS":                                  "none",
+				"EMAIL_FROM":                                 "env@example.com",
+				"EMAIL_TIMEOUT":                              "15",
+				"EMAIL_INSECURE_SKIP_VERIFY":                 "true",
+				"EMAIL_RATE_LIMIT_PER_CHAT":                  "2",
+				"EMAIL_RATE_LIMIT_GLOBAL":                    "10",
+				"OUTGOING_WEBHOOK_TIMEOUT":                   "20",
+				"OUTGOING_WEBHOOK_INSECURE_SKIP_VERIFY":      "true",
+				"OUTGOING_WEBHOOK_RATE_LIMIT_PER_CHAT":       "5",
+				"OUTGOING_WEBHOOK_RATE_LIMIT_GLOBAL":         "25",
+				"MATRIX_ACCESS_TOKEN":                        "env_matrix_token",
+				"MATRIX_HOMESERVER_URL":                      "https://matrix.env.example.com",
+				"MATRIX_TIMEOUT":                             "25",
+				"MATRIX_INSECURE_SKIP_VERIFY":                "true",
+				"MATRIX_RATE_LIMIT_PER_CHAT":                 "3",
+				"MATRIX_RATE_LIMIT_GLOBAL":                   "15",
+				"ZULIP_BOT_EMAIL":                            "env-bot@zulip.example.com",
+				"ZULIP_API_KEY":                              "env_zulip_key",
+				"ZULIP_SITE_URL":                             "https://zulip.env.example.com",
+				"ZULIP_TIMEOUT":                              "30",
+				"ZULIP_INSECURE_SKIP_VERIFY":                 "true",
+				"ZULIP_RATE_LIMIT_PER_CHAT":                  "4",
+				"ZULIP_RATE_LIMIT_GLOBAL":                    "12",
+				"NTFY_SERVER_URL":                            "https://ntfy.env.example.com",
+				"NTFY_ACCESS_TOKEN":                          "tk_env_token",
+				"NTFY_TIMEOUT":                               "35",
+				"NTFY_INSECURE_SKIP_VERIFY":                  "true",
+				"NTFY_RATE_LIMIT_PER_CHAT":                   "5",
+				"NTFY_RATE_LIMIT_GLOBAL":                     "14",
+				"GOTIFY_SERVER_URL":                          "https://gotify.env.example.com",
+				"GOTIFY_TIMEOUT":                             "40",
+				"GOTIFY_INSECURE_SKIP_VERIFY":                "true",
+				"GOTIFY_RATE_LIMIT_PER_CHAT":                 "6",
+				"GOTIFY_RATE_LIMIT_GLOBAL":                   "16",
+				"FILE_PATH":                                  "/var/lib/notifications/archive.jsonl",
+				"FILE_MAX_SIZE":                              "100",
+				"FILE_ROTATION_INTERVAL":                     "86400",
+				"FILE_COMPRESS":                              "true",
+				"FILE_FSYNC":                                 "interval",
+				"FILE_FSYNC_INTERVAL":                        "5",
+				"LOG_LEVEL":                                  "info",
 			},
 			yamlContent: `
 http:
@@ -53,20 +240,139 @@ logger:
 					ShutdownTimeout: 10,
 					ReadTimeout:     15,
 					WriteTimeout:    20,
+					MaxBodySize:     2048,
 				},
 				Telegram: TelegramConfig{
-					BotToken: "env_token",
-					Timeout:  30,
+					BotToken:  "env_token",
+					Timeout:   30,
+					RateLimit: RateLimitConfig{PerChat: 2, PerGroup: 20, Global: 10},
 				},
 				VKTeams: VKTeamsConfig{
 					BotToken:           "env_vkteams_token",
 					Timeout:            25,
 					ApiUrl:             "https://api.env.example.com/bot/v1",
 					InsecureSkipVerify: true,
+					RateLimit:          RateLimitConfig{PerChat: 1, PerGroup: 5, Global: 30},
+				},
+				Slack: SlackConfig{
+					BotToken:  "env_slack_token",
+					Timeout:   15,
+					ApiUrl:    "https://slack.env.example.com/api",
+					RateLimit: RateLimitConfig{PerChat: 3, PerGroup: 20, Global: 40},
+				},
+				Mattermost: MattermostConfig{
+					BotToken:           "env_mattermost_token",
+					Timeout:            12,
+					ApiUrl:             "https://mattermost.env.example.com",
+					InsecureSkipVerify: true,
+					RateLimit:          RateLimitConfig{PerChat: 4, PerGroup: 20, Global: 50},
+				},
+				MSTeams: MSTeamsConfig{
+					Timeout:   20,
+					RateLimit: RateLimitConfig{PerChat: 2, PerGroup: 20, Global: 15},
+				},
+				Discord: DiscordConfig{
+					Timeout:   25,
+					RateLimit: RateLimitConfig{PerChat: 3, PerGroup: 20, Global: 30},
+				},
+				Email: EmailConfig{
+					Host:               "smtp.env.example.com",
+					Port:               2525,
+					Username:           "env_user",
+					Password:           "env_password",
+					Auth:               EmailAuthLogin,
+					TLS:                EmailTLSNone,
+					From:               "env@example.com",
+					Timeout:            15,
+					InsecureSkipVerify: true,
+					RateLimit:          RateLimitConfig{PerChat: 2, PerGroup: 20, Global: 10},
+				},
+				OutgoingWebhook: OutgoingWebhookConfig{
+					Timeout:            20,
+					InsecureSkipVerify: true,
+					RateLimit:          RateLimitConfig{PerChat: 5, PerGroup: 20, Global: 25},
+				},
+				Matrix: MatrixConfig{
+					AccessToken:        "env_matrix_token",
+					HomeserverURL:      "https://matrix.env.example.com",
+					Timeout:            25,
+					InsecureSkipVerify: true,
+					RateLimit:          RateLimitConfig{PerChat: 3, PerGroup: 20, Global: 15},
+				},
+				Zulip: ZulipConfig{
+					BotEmail:           "env-bot@zulip.example.com",
+					APIKey:             "env_zulip_key",
+					SiteURL:            "https://zulip.env.example.com",
+					Timeout:            30,
+					InsecureSkipVerify: true,
+					RateLimit:          RateLimitConfig{PerChat: 4, PerGroup: 20, Global: 12},
+				},
+				Ntfy: NtfyConfig{
+					ServerURL:          "https://ntfy.env.example.com",
+					AccessToken:        "tk_env_token",
+					Timeout:            35,
+					InsecureSkipVerify: true,
+					RateLimit:          RateLimitConfig{PerChat: 5, PerGroup: 20, Global: 14},
+				},
+				Gotify: GotifyConfig{
+					ServerURL:          "https://gotify.env.example.com",
+					Timeout:            40,
+					InsecureSkipVerify: true,
+					RateLimit:          RateLimitConfig{PerChat: 6, PerGroup: 20, Global: 16},
+				},
+				File: FileConfig{
+					Path:             "/var/lib/notifications/archive.jsonl",
+					MaxSize:          100,
+					RotationInterval: 86400,
+					Compress:         true,
+					Fsync:            FileFsyncInterval,
+					FsyncInterval:    5,
 				},
 				Logger: LoggerConfig{
 					Level: "info",
 				},
+				Webhook: WebhookConfig{
+					SignatureHeader: DefaultSignatureHeader,
+					Replay:          defaultReplayConfig,
+					Idempotency:     defaultIdempotencyConfig,
+					Response:        defaultResponseConfig,
+				},
+				Delivery: DeliveryConfig{
+					Workers:    8,
+					QueueSize:  50,
+					RetryAfter: 5,
+					Timeout:    90,
+					Retry: RetryConfig{
+						RetryPolicyConfig: RetryPolicyConfig{
+							MaxAttempts:     7,
+							InitialInterval: 2,
+							MaxInterval:     60,
+							Multiplier:      2,
+							Jitter:          float64Ptr(0.2),
+							MaxAge:          120,
+						},
+					},
+					CircuitBreaker: CircuitBreakerConfig{
+						CircuitBreakerPolicyConfig: CircuitBreakerPolicyConfig{
+							FailureThreshold: 3,
+							OpenDuration:     30,
+							HalfOpenProbes:   1,
+						},
+						Enabled: true,
+					},
+				},
+				Outbox: OutboxConfig{
+					Enabled:         true,
+					Dir:             "/var/lib/notifications",
+					CompactInterval: 60,
+				},
+				DeadLetter: DeadLetterConfig{
+					Enabled: true,
+					Dir:     "/var/lib/notifications/dead-letters",
+				},
+				Admin: AdminConfig{
+					Token: "env_admin_token",
+				},
 				Notifications: NotificationsConfig{
 					Youtrack: YoutrackConfig{
 						Projects: make(map[string]ProjectConfig),
@@ -95,18 +401,41 @@ logger:
 					ShutdownTimeout: 5,
 					ReadTimeout:     5,
 					WriteTimeout:    5,
+					MaxBodySize:     DefaultMaxBodySize,
 				},
 				Telegram: TelegramConfig{
-					BotToken: "",
-					Timeout:  10,
+					BotToken:  "",
+					Timeout:   10,
+					RateLimit: defaultRateLimitConfig,
 				},
 				VKTeams: VKTeamsConfig{
-					Timeout: 10,
-					ApiUrl:  "",
+					Timeout:   10,
+					ApiUrl:    "",
+					RateLimit: defaultRateLimitConfig,
 				},
+				Slack:           defaultSlackConfig,
+				Mattermost:      defaultMattermostConfig,
+				MSTeams:         defaultMSTeamsConfig,
+				Discord:         defaultDiscordConfig,
+				Email:           defaultEmailConfig,
+				OutgoingWebhook: defaultOutgoingWebhookConfig,
+				Matrix:          defaultMatrixConfig,
+				Zulip:           defaultZulipConfig,
+				Ntfy:            defaultNtfyConfig,
+				Gotify:          defaultGotifyConfig,
+				File:            defaultFileConfig,
 				Logger: LoggerConfig{
 					Level: "debug",
 				},
+				Webhook: WebhookConfig{
+					SignatureHeader: DefaultSignatureHeader,
+					Replay:          defaultReplayConfig,
+					Idempotency:     defaultIdempotencyConfig,
+					Response:        defaultResponseConfig,
+				},
+				Delivery:   defaultDeliveryConfig,
+				Outbox:     defaultOutboxConfig,
+				DeadLetter: defaultDeadLetterConfig,
 				Notifications: NotificationsConfig{
 					Youtrack: YoutrackConfig{
 						Projects: make(map[string]ProjectConfig),
@@ -128,14 +457,37 @@ logger:
 					ShutdownTimeout: 5,
 					ReadTimeout:     5,
 					WriteTimeout:    5,
+					MaxBodySize:     DefaultMaxBodySize,
 				},
 				Telegram: TelegramConfig{
-					Timeout: 10,
+					Timeout:   10,
+					RateLimit: defaultRateLimitConfig,
 				},
 				VKTeams: VKTeamsConfig{
-					Timeout: 10,
-					ApiUrl:  "",
+					Timeout:   10,
+					ApiUrl:    "",
+					RateLimit: defaultRateLimitConfig,
 				},
+				Slack:           defaultSlackConfig,
+				Mattermost:      defaultMattermostConfig,
+				MSTeams:         defaultMSTeamsConfig,
+				Discord:         defaultDiscordConfig,
+				Email:           defaultEmailConfig,
+				OutgoingWebhook: defaultOutgoingWebhookConfig,
+				Matrix:          defaultMatrixConfig,
+				Zulip:           defaultZulipConfig,
+				Ntfy:            defaultNtfyConfig,
+				Gotify:          defaultGotifyConfig,
+				File:            defaultFileConfig,
+				Webhook: WebhookConfig{
+					SignatureHeader: DefaultSignatureHeader,
+					Replay:          defaultReplayConfig,
+					Idempotency:     defaultIdempotencyConfig,
+					Response:        defaultResponseConfig,
+				},
+				Delivery:   defaultDeliveryConfig,
+				Outbox:     defaultOutboxConfig,
+				DeadLetter: defaultDeadLetterConfig,
 				Notifications: NotificationsConfig{
 					Youtrack: YoutrackConfig{
 						Projects: make(map[string]ProjectConfig),
@@ -143,6 +495,171 @@ logger:
 				},
 			},
 		},
+		{
+			name: "Webhook_Config_From_ENV",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                       ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":           "5",
+				"HTTP_READ_TIMEOUT":               "5",
+				"HTTP_WRITE_TIMEOUT":              "5",
+				"WEBHOOK_SECRET":                  "env_secret",
+				"WEBHOOK_SIGNATURE_HEADER":        "X-Custom-Signature",
+				"WEBHOOK_REPLAY_ENABLED":          "true",
+				"WEBHOOK_REPLAY_MAX_SKEW":         "60",
+				"WEBHOOK_IDEMPOTENCY_ENABLED":     "true",
+				"WEBHOOK_IDEMPOTENCY_TTL":         "120",
+				"WEBHOOK_RESPONSE_SUCCESS_STATUS": "200",
+				"WEBHOOK_RESPONSE_FAILED_STATUS":  "503",
+			},
+			yamlContent: `
+webhook:
+  secret: "yaml_secret"
+notifications:
+  youtrack:
+    projects:
+      Demo:
+        allowedChannels: [logger]
+        webhookSecret: "project_secret"
+`,
+			expectedConfig: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+					MaxBodySize:     DefaultMaxBodySize,
+				},
+				Telegram: TelegramConfig{
+					Timeout:   10,
+					RateLimit: defaultRateLimitConfig,
+				},
+				VKTeams: VKTeamsConfig{
+					Timeout:   10,
+					RateLimit: defaultRateLimitConfig,
+				},
+				Slack:           defaultSlackConfig,
+				Mattermost:      defaultMattermostConfig,
+				MSTeams:         defaultMSTeamsConfig,
+				Discord:         defaultDiscordConfig,
+				Email:           defaultEmailConfig,
+				OutgoingWebhook: defaultOutgoingWebhookConfig,
+				Matrix:          defaultMatrixConfig,
+				Zulip:           defaultZulipConfig,
+				Ntfy:            defaultNtfyConfig,
+				Gotify:          defaultGotifyConfig,
+				File:            defaultFileConfig,
+				Webhook: WebhookConfig{
+					Secret:          "env_secret",
+					SignatureHeader: "X-Custom-Signature",
+					Replay: ReplayConfig{
+						Enabled:         true,
+						MaxSkew:         60,
+						CacheSize:       10000,
+						TimestampHeader: DefaultTimestampHeader,
+						DeliveryHeader:  DefaultDeliveryHeader,
+					},
+					Idempotency: IdempotencyConfig{
+						Enabled:   true,
+						TTL:       120,
+						CacheSize: 10000,
+						Header:    DefaultIdempotencyHeader,
+					},
+					Response: WebhookResponseConfig{
+						SuccessStatus: 200,
+						PartialStatus: DefaultWebhookPartialStatus,
+						FailedStatus:  503,
+					},
+				},
+				Delivery:   defaultDeliveryConfig,
+				Outbox:     defaultOutboxConfig,
+				DeadLetter: defaultDeadLetterConfig,
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"demo": {
+								AllowedChannels: []string{"logger"},
+								WebhookSecret:   "project_secret",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Webhook_Tokens_From_YAML",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+			},
+			yamlContent: `
+webhook:
+  tokens:
+    - name: "team"
+      token: "old_token"
+      projects: [Demo]
+      expires_at: 2025-01-31T00:00:00Z
+    - name: "team"
+      token: "new_token"
+      projects: [Demo]
+notifications:
+  youtrack:
+    projects:
+      Demo:
+        allowedChannels: [logger]
+`,
+			expectedConfig: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+					MaxBodySize:     DefaultMaxBodySize,
+				},
+				Telegram: TelegramConfig{
+					Timeout:   10,
+					RateLimit: defaultRateLimitConfig,
+				},
+				VKTeams: VKTeamsConfig{
+					Timeout:   10,
+					RateLimit: defaultRateLimitConfig,
+				},
+				Slack:           defaultSlackConfig,
+				Mattermost:      defaultMattermostConfig,
+				MSTeams:         defaultMSTeamsConfig,
+				Discord:         defaultDiscordConfig,
+				Email:           defaultEmailConfig,
+				OutgoingWebhook: defaultOutgoingWebhookConfig,
+				Matrix:          defaultMatrixConfig,
+				Zulip:           defaultZulipConfig,
+				Ntfy:            defaultNtfyConfig,
+				Gotify:          defaultGotifyConfig,
+				File:            defaultFileConfig,
+				Webhook: WebhookConfig{
+					SignatureHeader: DefaultSignatureHeader,
+					Replay:          defaultReplayConfig,
+					Idempotency:     defaultIdempotencyConfig,
+					Response:        defaultResponseConfig,
+					Tokens: []WebhookTokenConfig{
+						{Name: "team", Token: "old_token", Projects: []string{"demo"}, ExpiresAt: &tokenExpiresAt},
+						{Name: "team", Token: "new_token", Projects: []string{"demo"}},
+					},
+				},
+				Delivery:   defaultDeliveryConfig,
+				Outbox:     defaultOutboxConfig,
+				DeadLetter: defaultDeadLetterConfig,
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"demo": {
+								AllowedChannels: []string{"logger"},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "ENV_Only_Configuration",
 			envVariables: map[string]string{
@@ -158,17 +675,40 @@ logger:
 					ShutdownTimeout: 5,
 					ReadTimeout:     5,
 					WriteTimeout:    5,
+					MaxBodySize:     DefaultMaxBodySize,
 				},
 				Telegram: TelegramConfig{
-					Timeout: 10,
+					Timeout:   10,
+					RateLimit: defaultRateLimitConfig,
 				},
 				VKTeams: VKTeamsConfig{
-					Timeout: 10,
-					ApiUrl:  "",
+					Timeout:   10,
+					ApiUrl:    "",
+					RateLimit: defaultRateLimitConfig,
 				},
+				Slack:           defaultSlackConfig,
+				Mattermost:      defaultMattermostConfig,
+				MSTeams:         defaultMSTeamsConfig,
+				Discord:         defaultDiscordConfig,
+				Email:           defaultEmailConfig,
+				OutgoingWebhook: defaultOutgoingWebhookConfig,
+				Matrix:          defaultMatrixConfig,
+				Zulip:           defaultZulipConfig,
+				Ntfy:            defaultNtfyConfig,
+				Gotify:          defaultGotifyConfig,
+				File:            defaultFileConfig,
 				Logger: LoggerConfig{
 					Level: "warn",
 				},
+				Webhook: WebhookConfig{
+					SignatureHeader: DefaultSignatureHeader,
+					Replay:          defaultReplayConfig,
+					Idempotency:     defaultIdempotencyConfig,
+					Response:        defaultResponseConfig,
+				},
+				Delivery:   defaultDeliveryConfig,
+				Outbox:     defaultOutboxConfig,
+				DeadLetter: defaultDeadLetterConfig,
 				Notifications: NotificationsConfig{
 					Youtrack: YoutrackConfig{
 						Projects: make(map[string]ProjectConfig),
@@ -210,15 +750,38 @@ notifications:
 					ShutdownTimeout: 5,
 					ReadTimeout:     5,
 					WriteTimeout:    5,
+					MaxBodySize:     DefaultMaxBodySize,
 				},
 				Telegram: TelegramConfig{
-					BotToken: "token123",
-					Timeout:  10,
+					BotToken:  "token123",
+					Timeout:   10,
+					RateLimit: defaultRateLimitConfig,
 				},
 				VKTeams: VKTeamsConfig{
-					Timeout: 10,
-					ApiUrl:  "",
+					Timeout:   10,
+					ApiUrl:    "",
+					RateLimit: defaultRateLimitConfig,
 				},
+				Slack:           defaultSlackConfig,
+				Mattermost:      defaultMattermostConfig,
+				MSTeams:         defaultMSTeamsConfig,
+				Discord:         defaultDiscordConfig,
+				Email:           defaultEmailConfig,
+				OutgoingWebhook: defaultOutgoingWebhookConfig,
+				Matrix:          defaultMatrixConfig,
+				Zulip:           defaultZulipConfig,
+				Ntfy:            defaultNtfyConfig,
+				Gotify:          defaultGotifyConfig,
+				File:            defaultFileConfig,
+				Webhook: WebhookConfig{
+					SignatureHeader: DefaultSignatureHeader,
+					Replay:          defaultReplayConfig,
+					Idempotency:     defaultIdempotencyConfig,
+					Response:        defaultResponseConfig,
+				},
+				Delivery:   defaultDeliveryConfig,
+				Outbox:     defaultOutboxConfig,
+				DeadLetter: defaultDeadLetterConfig,
 				Notifications: NotificationsConfig{
 					Youtrack: YoutrackConfig{
 						Projects: map[string]ProjectConfig{
@@ -311,6 +874,51 @@ notifications:
 			expectedConfig: nil,
 			expectedErr:    errors.New("telegram.chat_id cannot be empty"),
 		},
+		{
+			name: "Config_Validation_Error_If_Project_Has_Negative_CoalesceWindow",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+			},
+			yamlContent: `
+http:
+  addr: ":3000"
+  shutdown_timeout: 5
+  read_timeout: 5
+  write_timeout: 5
+notifications:
+  youtrack:
+    projects:
+      project1:
+        allowedChannels: [logger]
+        coalesceWindow: -5
+`,
+			expectedConfig: nil,
+			expectedErr:    errors.New("coalesceWindow cannot be negative"),
+		},
+		{
+			name: "Config_Validation_Error_If_Webhook_Failed_Status_Out_Of_Range",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+			},
+			yamlContent: `
+http:
+  addr: ":3000"
+  shutdown_timeout: 5
+  read_timeout: 5
+  write_timeout: 5
+webhook:
+  response:
+    failed_status: 700
+`,
+			expectedConfig: nil,
+			expectedErr:    errors.New("webhook.response.failed_status must be between 200 and 599, got: 700"),
+		},
 		{
 			name: "Config_Validation_Error_If_Project_Has_Telegram_But_No_BotToken",
 			envVariables: map[string]string{
@@ -528,355 +1136,2884 @@ notifications:
 			expectedErr:    errors.New("HTTP_WRITE_TIMEOUT must be positive"),
 		},
 		{
-			name: "Invalid_TelegramTimeout_Format_Returns_Error",
+			name: "Invalid_WebhookReplayMaxSkew_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":               ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":   "5",
+				"HTTP_READ_TIMEOUT":       "5",
+				"HTTP_WRITE_TIMEOUT":      "5",
+				"WEBHOOK_REPLAY_MAX_SKEW": "invalid",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid WEBHOOK_REPLAY_MAX_SKEW format"),
+		},
+		{
+			name: "Negative_WebhookReplayMaxSkew_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":               ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":   "5",
+				"HTTP_READ_TIMEOUT":       "5",
+				"HTTP_WRITE_TIMEOUT":      "5",
+				"WEBHOOK_REPLAY_MAX_SKEW": "-5",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("WEBHOOK_REPLAY_MAX_SKEW must be positive"),
+		},
+		{
+			name: "Invalid_HTTPMaxBodySize_Format_Returns_Error",
 			envVariables: map[string]string{
 				"HTTP_ADDR":             ":8080",
 				"HTTP_SHUTDOWN_TIMEOUT": "5",
 				"HTTP_READ_TIMEOUT":     "5",
 				"HTTP_WRITE_TIMEOUT":    "5",
-				"TELEGRAM_TIMEOUT":      "invalid",
+				"HTTP_MAX_BODY_SIZE":    "1mb",
 			},
 			expectedConfig: nil,
-			expectedErr:    errors.New("invalid TELEGRAM_TIMEOUT format"),
+			expectedErr:    errors.New("invalid HTTP_MAX_BODY_SIZE format: must be integer (bytes), got: 1mb"),
 		},
 		{
-			name: "Negative_TelegramTimeout_Returns_Error",
+			name: "Negative_HTTPMaxBodySize_Returns_Error",
 			envVariables: map[string]string{
 				"HTTP_ADDR":             ":8080",
 				"HTTP_SHUTDOWN_TIMEOUT": "5",
 				"HTTP_READ_TIMEOUT":     "5",
 				"HTTP_WRITE_TIMEOUT":    "5",
-				"TELEGRAM_TIMEOUT":      "-5",
+				"HTTP_MAX_BODY_SIZE":    "-1",
 			},
 			expectedConfig: nil,
-			expectedErr:    errors.New("TELEGRAM_TIMEOUT must be positive"),
+			expectedErr:    errors.New("HTTP_MAX_BODY_SIZE must be positive, got: -1"),
 		},
 		{
-			name: "Invalid_VKTeamsTimeout_Format_Returns_Error",
+			name: "Invalid_DeliveryWorkers_Format_Returns_Error",
 			envVariables: map[string]string{
 				"HTTP_ADDR":             ":8080",
 				"HTTP_SHUTDOWN_TIMEOUT": "5",
 				"HTTP_READ_TIMEOUT":     "5",
 				"HTTP_WRITE_TIMEOUT":    "5",
-				"VKTEAMS_TIMEOUT":       "invalid",
+				"DELIVERY_WORKERS":      "many",
 			},
 			expectedConfig: nil,
-			expectedErr:    errors.New("invalid VKTEAMS_TIMEOUT format"),
+			expectedErr:    errors.New("invalid DELIVERY_WORKERS format: must be integer, got: many"),
 		},
 		{
-			name: "Negative_VKTeamsTimeout_Returns_Error",
+			name: "Zero_DeliveryWorkers_Returns_Error",
 			envVariables: map[string]string{
 				"HTTP_ADDR":             ":8080",
 				"HTTP_SHUTDOWN_TIMEOUT": "5",
 				"HTTP_READ_TIMEOUT":     "5",
 				"HTTP_WRITE_TIMEOUT":    "5",
-				"VKTEAMS_TIMEOUT":       "-5",
+				"DELIVERY_WORKERS":      "0",
 			},
 			expectedConfig: nil,
-			expectedErr:    errors.New("VKTEAMS_TIMEOUT must be positive"),
+			expectedErr:    errors.New("DELIVERY_WORKERS must be positive, got: 0"),
 		},
 		{
-			name: "VKTEAMS_INSECURE_SKIP_VERIFY_True_Value",
+			name: "Invalid_DeliveryQueueSize_Format_Returns_Error",
 			envVariables: map[string]string{
-				"HTTP_ADDR":                    ":8080",
-				"HTTP_SHUTDOWN_TIMEOUT":        "5",
-				"HTTP_READ_TIMEOUT":            "5",
-				"HTTP_WRITE_TIMEOUT":           "5",
-				"VKTEAMS_INSECURE_SKIP_VERIFY": "true",
-			},
-			expectedConfig: &Config{
-				HTTP: HTTPConfig{
-					Addr:            ":8080",
-					ShutdownTimeout: 5,
-					ReadTimeout:     5,
-					WriteTimeout:    5,
-				},
-				Telegram: TelegramConfig{
-					Timeout: 10,
-				},
-				VKTeams: VKTeamsConfig{
-					Timeout:            10,
-					ApiUrl:             "",
-					InsecureSkipVerify: true,
-				},
-				Notifications: NotificationsConfig{
-					Youtrack: YoutrackConfig{
-						Projects: make(map[string]ProjectConfig),
-					},
-				},
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"DELIVERY_QUEUE_SIZE":   "big",
 			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid DELIVERY_QUEUE_SIZE format: must be integer, got: big"),
 		},
 		{
-			name: "VKTEAMS_INSECURE_SKIP_VERIFY_Invalid_Value",
+			name: "Negative_DeliveryQueueSize_Returns_Error",
 			envVariables: map[string]string{
-				"HTTP_ADDR":                    ":8080",
-				"HTTP_SHUTDOWN_TIMEOUT":        "5",
-				"HTTP_READ_TIMEOUT":            "5",
-				"HTTP_WRITE_TIMEOUT":           "5",
-				"VKTEAMS_INSECURE_SKIP_VERIFY": "1",
-			},
-			expectedConfig: &Config{
-				HTTP: HTTPConfig{
-					Addr:            ":8080",
-					ShutdownTimeout: 5,
-					ReadTimeout:     5,
-					WriteTimeout:    5,
-				},
-				Telegram: TelegramConfig{
-					Timeout: 10,
-				},
-				VKTeams: VKTeamsConfig{
-					Timeout:            10,
-					ApiUrl:             "",
-					InsecureSkipVerify: false,
-				},
-				Notifications: NotificationsConfig{
-					Youtrack: YoutrackConfig{
-						Projects: make(map[string]ProjectConfig),
-					},
-				},
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"DELIVERY_QUEUE_SIZE":   "-5",
 			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("DELIVERY_QUEUE_SIZE must be positive, got: -5"),
 		},
 		{
-			name: "VKTEAMS_INSECURE_SKIP_VERIFY_Invalid_Value_Yes",
+			name: "Invalid_OutboxCompactInterval_Format_Returns_Error",
 			envVariables: map[string]string{
-				"HTTP_ADDR":                    ":8080",
-				"HTTP_SHUTDOWN_TIMEOUT":        "5",
-				"HTTP_READ_TIMEOUT":            "5",
-				"HTTP_WRITE_TIMEOUT":           "5",
-				"VKTEAMS_INSECURE_SKIP_VERIFY": "yes",
+				"HTTP_ADDR":               ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":   "5",
+				"HTTP_READ_TIMEOUT":       "5",
+				"HTTP_WRITE_TIMEOUT":      "5",
+				"OUTBOX_COMPACT_INTERVAL": "5m",
 			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid OUTBOX_COMPACT_INTERVAL format: must be integer (seconds), got: 5m"),
+		},
+		{
+			name: "Zero_OutboxCompactInterval_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":               ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":   "5",
+				"HTTP_READ_TIMEOUT":       "5",
+				"HTTP_WRITE_TIMEOUT":      "5",
+				"OUTBOX_COMPACT_INTERVAL": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("OUTBOX_COMPACT_INTERVAL must be positive, got: 0"),
+		},
+		{
+			name: "Invalid_DeliveryRetryMaxAttempts_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                   ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":       "5",
+				"HTTP_READ_TIMEOUT":           "5",
+				"HTTP_WRITE_TIMEOUT":          "5",
+				"DELIVERY_RETRY_MAX_ATTEMPTS": "many",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid DELIVERY_RETRY_MAX_ATTEMPTS format: must be integer, got: many"),
+		},
+		{
+			name: "Zero_DeliveryRetryMaxAttempts_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                   ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":       "5",
+				"HTTP_READ_TIMEOUT":           "5",
+				"HTTP_WRITE_TIMEOUT":          "5",
+				"DELIVERY_RETRY_MAX_ATTEMPTS": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("DELIVERY_RETRY_MAX_ATTEMPTS must be positive, got: 0"),
+		},
+		{
+			name: "Invalid_DeliveryRetryInitialInterval_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                       ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":           "5",
+				"HTTP_READ_TIMEOUT":               "5",
+				"HTTP_WRITE_TIMEOUT":              "5",
+				"DELIVERY_RETRY_INITIAL_INTERVAL": "1s",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid DELIVERY_RETRY_INITIAL_INTERVAL format: must be integer (seconds), got: 1s"),
+		},
+		{
+			name: "Negative_DeliveryRetryInitialInterval_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                       ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":           "5",
+				"HTTP_READ_TIMEOUT":               "5",
+				"HTTP_WRITE_TIMEOUT":              "5",
+				"DELIVERY_RETRY_INITIAL_INTERVAL": "-1",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("DELIVERY_RETRY_INITIAL_INTERVAL must be positive, got: -1"),
+		},
+		{
+			name: "Invalid_DeliveryRetryMaxInterval_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                   ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":       "5",
+				"HTTP_READ_TIMEOUT":           "5",
+				"HTTP_WRITE_TIMEOUT":          "5",
+				"DELIVERY_RETRY_MAX_INTERVAL": "long",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid DELIVERY_RETRY_MAX_INTERVAL format: must be integer (seconds), got: long"),
+		},
+		{
+			name: "Zero_DeliveryRetryMaxInterval_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                   ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":       "5",
+				"HTTP_READ_TIMEOUT":           "5",
+				"HTTP_WRITE_TIMEOUT":          "5",
+				"DELIVERY_RETRY_MAX_INTERVAL": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("DELIVERY_RETRY_MAX_INTERVAL must be positive, got: 0"),
+		},
+		{
+			name: "Invalid_DeliveryRetryMaxAge_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":              ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":  "5",
+				"HTTP_READ_TIMEOUT":      "5",
+				"HTTP_WRITE_TIMEOUT":     "5",
+				"DELIVERY_RETRY_MAX_AGE": "old",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid DELIVERY_RETRY_MAX_AGE format: must be integer (seconds), got: old"),
+		},
+		{
+			name: "Zero_DeliveryRetryMaxAge_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":              ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":  "5",
+				"HTTP_READ_TIMEOUT":      "5",
+				"HTTP_WRITE_TIMEOUT":     "5",
+				"DELIVERY_RETRY_MAX_AGE": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("DELIVERY_RETRY_MAX_AGE must be positive, got: 0"),
+		},
+		{
+			name: "Invalid_TelegramRateLimitPerChat_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                    ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":        "5",
+				"HTTP_READ_TIMEOUT":            "5",
+				"HTTP_WRITE_TIMEOUT":           "5",
+				"TELEGRAM_RATE_LIMIT_PER_CHAT": "fast",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid TELEGRAM_RATE_LIMIT_PER_CHAT format: must be integer, got: fast"),
+		},
+		{
+			name: "Zero_TelegramRateLimitGlobal_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                  ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":      "5",
+				"HTTP_READ_TIMEOUT":          "5",
+				"HTTP_WRITE_TIMEOUT":         "5",
+				"TELEGRAM_RATE_LIMIT_GLOBAL": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("TELEGRAM_RATE_LIMIT_GLOBAL must be positive, got: 0"),
+		},
+		{
+			name: "Invalid_VKTeamsRateLimitPerGroup_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                    ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":        "5",
+				"HTTP_READ_TIMEOUT":            "5",
+				"HTTP_WRITE_TIMEOUT":           "5",
+				"VKTEAMS_RATE_LIMIT_PER_GROUP": "1.5",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid VKTEAMS_RATE_LIMIT_PER_GROUP format: must be integer, got: 1.5"),
+		},
+		{
+			name: "Negative_VKTeamsRateLimitPerChat_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                   ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":       "5",
+				"HTTP_READ_TIMEOUT":           "5",
+				"HTTP_WRITE_TIMEOUT":          "5",
+				"VKTEAMS_RATE_LIMIT_PER_CHAT": "-1",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("VKTEAMS_RATE_LIMIT_PER_CHAT must be positive, got: -1"),
+		},
+		{
+			name: "Invalid_CircuitBreakerFailureThreshold_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"DELIVERY_CIRCUIT_BREAKER_FAILURE_THRESHOLD": "five",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid DELIVERY_CIRCUIT_BREAKER_FAILURE_THRESHOLD format: must be integer, got: five"),
+		},
+		{
+			name: "NonPositive_CircuitBreakerFailureThreshold_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"DELIVERY_CIRCUIT_BREAKER_FAILURE_THRESHOLD": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("DELIVERY_CIRCUIT_BREAKER_FAILURE_THRESHOLD must be positive, got: 0"),
+		},
+		{
+			name: "Invalid_CircuitBreakerOpenDuration_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                              ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":                  "5",
+				"HTTP_READ_TIMEOUT":                      "5",
+				"HTTP_WRITE_TIMEOUT":                     "5",
+				"DELIVERY_CIRCUIT_BREAKER_OPEN_DURATION": "30s",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid DELIVERY_CIRCUIT_BREAKER_OPEN_DURATION format: must be integer (seconds), got: 30s"),
+		},
+		{
+			name: "NonPositive_CircuitBreakerOpenDuration_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                              ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":                  "5",
+				"HTTP_READ_TIMEOUT":                      "5",
+				"HTTP_WRITE_TIMEOUT":                     "5",
+				"DELIVERY_CIRCUIT_BREAKER_OPEN_DURATION": "-5",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("DELIVERY_CIRCUIT_BREAKER_OPEN_DURATION must be positive, got: -5"),
+		},
+		{
+			name: "Invalid_CircuitBreakerHalfOpenProbes_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"DELIVERY_CIRCUIT_BREAKER_HALF_OPEN_PROBES": "one",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid DELIVERY_CIRCUIT_BREAKER_HALF_OPEN_PROBES format: must be integer, got: one"),
+		},
+		{
+			name: "NonPositive_CircuitBreakerHalfOpenProbes_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"DELIVERY_CIRCUIT_BREAKER_HALF_OPEN_PROBES": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("DELIVERY_CIRCUIT_BREAKER_HALF_OPEN_PROBES must be positive, got: 0"),
+		},
+		{
+			name: "Invalid_IdempotencyTTL_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":               ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":   "5",
+				"HTTP_READ_TIMEOUT":       "5",
+				"HTTP_WRITE_TIMEOUT":      "5",
+				"WEBHOOK_IDEMPOTENCY_TTL": "10m",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid WEBHOOK_IDEMPOTENCY_TTL format: must be integer (seconds), got: 10m"),
+		},
+		{
+			name: "NonPositive_IdempotencyTTL_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":               ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":   "5",
+				"HTTP_READ_TIMEOUT":       "5",
+				"HTTP_WRITE_TIMEOUT":      "5",
+				"WEBHOOK_IDEMPOTENCY_TTL": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("WEBHOOK_IDEMPOTENCY_TTL must be positive, got: 0"),
+		},
+		{
+			name: "Invalid_DeliveryTimeout_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"DELIVERY_TIMEOUT":      "soon",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid DELIVERY_TIMEOUT format: must be integer (seconds), got: soon"),
+		},
+		{
+			name: "Negative_DeliveryTimeout_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"DELIVERY_TIMEOUT":      "-1",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("DELIVERY_TIMEOUT must be positive, got: -1"),
+		},
+		{
+			name: "Invalid_WebhookResponseSuccessStatus_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                       ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":           "5",
+				"HTTP_READ_TIMEOUT":               "5",
+				"HTTP_WRITE_TIMEOUT":              "5",
+				"WEBHOOK_RESPONSE_SUCCESS_STATUS": "ok",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid WEBHOOK_RESPONSE_SUCCESS_STATUS format"),
+		},
+		{
+			name: "Invalid_WebhookResponseFailedStatus_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                      ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":          "5",
+				"HTTP_READ_TIMEOUT":              "5",
+				"HTTP_WRITE_TIMEOUT":             "5",
+				"WEBHOOK_RESPONSE_FAILED_STATUS": "bad",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid WEBHOOK_RESPONSE_FAILED_STATUS format"),
+		},
+		{
+			name: "WebhookResponsePartialStatus_Out_Of_Range_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                       ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":           "5",
+				"HTTP_READ_TIMEOUT":               "5",
+				"HTTP_WRITE_TIMEOUT":              "5",
+				"WEBHOOK_RESPONSE_PARTIAL_STATUS": "99",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("webhook.response.partial_status must be between 200 and 599"),
+		},
+		{
+			name: "Invalid_SlackTimeout_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"SLACK_TIMEOUT":         "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid SLACK_TIMEOUT format"),
+		},
+		{
+			name: "Negative_SlackTimeout_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"SLACK_TIMEOUT":         "-1",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("SLACK_TIMEOUT must be positive"),
+		},
+		{
+			name: "Invalid_SlackRateLimitPerChat_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                 ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":     "5",
+				"HTTP_READ_TIMEOUT":         "5",
+				"HTTP_WRITE_TIMEOUT":        "5",
+				"SLACK_RATE_LIMIT_PER_CHAT": "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid SLACK_RATE_LIMIT_PER_CHAT format"),
+		},
+		{
+			name: "Invalid_SlackRateLimitGlobal_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":               ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":   "5",
+				"HTTP_READ_TIMEOUT":       "5",
+				"HTTP_WRITE_TIMEOUT":      "5",
+				"SLACK_RATE_LIMIT_GLOBAL": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("SLACK_RATE_LIMIT_GLOBAL must be positive"),
+		},
+		{
+			name: "Invalid_MattermostTimeout_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"MATTERMOST_TIMEOUT":    "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid MATTERMOST_TIMEOUT format"),
+		},
+		{
+			name: "Negative_MattermostTimeout_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"MATTERMOST_TIMEOUT":    "-1",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("MATTERMOST_TIMEOUT must be positive"),
+		},
+		{
+			name: "Invalid_MattermostRateLimitPerChat_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                      ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":          "5",
+				"HTTP_READ_TIMEOUT":              "5",
+				"HTTP_WRITE_TIMEOUT":             "5",
+				"MATTERMOST_RATE_LIMIT_PER_CHAT": "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid MATTERMOST_RATE_LIMIT_PER_CHAT format"),
+		},
+		{
+			name: "Invalid_MattermostRateLimitGlobal_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                    ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":        "5",
+				"HTTP_READ_TIMEOUT":            "5",
+				"HTTP_WRITE_TIMEOUT":           "5",
+				"MATTERMOST_RATE_LIMIT_GLOBAL": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("MATTERMOST_RATE_LIMIT_GLOBAL must be positive"),
+		},
+		{
+			name: "Invalid_MSTeamsTimeout_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"MSTEAMS_TIMEOUT":       "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid MSTEAMS_TIMEOUT format"),
+		},
+		{
+			name: "Negative_MSTeamsTimeout_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"MSTEAMS_TIMEOUT":       "-1",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("MSTEAMS_TIMEOUT must be positive"),
+		},
+		{
+			name: "Invalid_MSTeamsRateLimitPerChat_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                   ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":       "5",
+				"HTTP_READ_TIMEOUT":           "5",
+				"HTTP_WRITE_TIMEOUT":          "5",
+				"MSTEAMS_RATE_LIMIT_PER_CHAT": "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid MSTEAMS_RATE_LIMIT_PER_CHAT format"),
+		},
+		{
+			name: "Invalid_MSTeamsRateLimitGlobal_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                 ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":     "5",
+				"HTTP_READ_TIMEOUT":         "5",
+				"HTTP_WRITE_TIMEOUT":        "5",
+				"MSTEAMS_RATE_LIMIT_GLOBAL": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("MSTEAMS_RATE_LIMIT_GLOBAL must be positive"),
+		},
+		{
+			name: "Invalid_DiscordTimeout_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"DISCORD_TIMEOUT":       "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid DISCORD_TIMEOUT format"),
+		},
+		{
+			name: "Negative_DiscordTimeout_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"DISCORD_TIMEOUT":       "-1",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("DISCORD_TIMEOUT must be positive"),
+		},
+		{
+			name: "Invalid_DiscordRateLimitPerChat_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                   ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":       "5",
+				"HTTP_READ_TIMEOUT":           "5",
+				"HTTP_WRITE_TIMEOUT":          "5",
+				"DISCORD_RATE_LIMIT_PER_CHAT": "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid DISCORD_RATE_LIMIT_PER_CHAT format"),
+		},
+		{
+			name: "Invalid_DiscordRateLimitGlobal_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                 ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":     "5",
+				"HTTP_READ_TIMEOUT":         "5",
+				"HTTP_WRITE_TIMEOUT":        "5",
+				"DISCORD_RATE_LIMIT_GLOBAL": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("DISCORD_RATE_LIMIT_GLOBAL must be positive"),
+		},
+		{
+			name: "Invalid_EmailPort_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"EMAIL_PORT":            "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid EMAIL_PORT format"),
+		},
+		{
+			name: "EmailPort_Out_Of_Range_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"EMAIL_PORT":            "70000",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("EMAIL_PORT must be between 1 and 65535"),
+		},
+		{
+			name: "Invalid_EmailTimeout_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"EMAIL_TIMEOUT":         "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid EMAIL_TIMEOUT format"),
+		},
+		{
+			name: "Negative_EmailTimeout_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"EMAIL_TIMEOUT":         "-1",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("EMAIL_TIMEOUT must be positive"),
+		},
+		{
+			name: "Invalid_EmailRateLimitPerChat_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                 ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":     "5",
+				"HTTP_READ_TIMEOUT":         "5",
+				"HTTP_WRITE_TIMEOUT":        "5",
+				"EMAIL_RATE_LIMIT_PER_CHAT": "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid EMAIL_RATE_LIMIT_PER_CHAT format"),
+		},
+		{
+			name: "Invalid_EmailRateLimitGlobal_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":               ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":   "5",
+				"HTTP_READ_TIMEOUT":       "5",
+				"HTTP_WRITE_TIMEOUT":      "5",
+				"EMAIL_RATE_LIMIT_GLOBAL": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("EMAIL_RATE_LIMIT_GLOBAL must be positive"),
+		},
+		{
+			name: "Invalid_EmailTLS_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"EMAIL_TLS":             "ssl",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("EMAIL_TLS must be one of: starttls, tls, none"),
+		},
+		{
+			name: "Invalid_EmailAuth_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"EMAIL_AUTH":            "cram-md5",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("EMAIL_AUTH must be one of: plain, login"),
+		},
+		{
+			name: "Invalid_Outgoing_Webhook_Timeout_Format",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":    "5",
+				"HTTP_READ_TIMEOUT":        "5",
+				"HTTP_WRITE_TIMEOUT":       "5",
+				"OUTGOING_WEBHOOK_TIMEOUT": "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid OUTGOING_WEBHOOK_TIMEOUT format"),
+		},
+		{
+			name: "Negative_Outgoing_Webhook_Timeout",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":    "5",
+				"HTTP_READ_TIMEOUT":        "5",
+				"HTTP_WRITE_TIMEOUT":       "5",
+				"OUTGOING_WEBHOOK_TIMEOUT": "-1",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("OUTGOING_WEBHOOK_TIMEOUT must be positive"),
+		},
+		{
+			name: "Invalid_Outgoing_Webhook_Rate_Limit_Per_Chat_Format",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                            ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":                "5",
+				"HTTP_READ_TIMEOUT":                    "5",
+				"HTTP_WRITE_TIMEOUT":                   "5",
+				"OUTGOING_WEBHOOK_RATE_LIMIT_PER_CHAT": "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid OUTGOING_WEBHOOK_RATE_LIMIT_PER_CHAT format"),
+		},
+		{
+			name: "Zero_Outgoing_Webhook_Rate_Limit_Global",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                          ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":              "5",
+				"HTTP_READ_TIMEOUT":                  "5",
+				"HTTP_WRITE_TIMEOUT":                 "5",
+				"OUTGOING_WEBHOOK_RATE_LIMIT_GLOBAL": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("OUTGOING_WEBHOOK_RATE_LIMIT_GLOBAL must be positive"),
+		},
+		{
+			name: "Invalid_MatrixTimeout_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"MATRIX_TIMEOUT":        "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid MATRIX_TIMEOUT format"),
+		},
+		{
+			name: "Negative_MatrixTimeout_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"MATRIX_TIMEOUT":        "-1",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("MATRIX_TIMEOUT must be positive"),
+		},
+		{
+			name: "Invalid_MatrixRateLimitPerChat_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                  ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":      "5",
+				"HTTP_READ_TIMEOUT":          "5",
+				"HTTP_WRITE_TIMEOUT":         "5",
+				"MATRIX_RATE_LIMIT_PER_CHAT": "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid MATRIX_RATE_LIMIT_PER_CHAT format"),
+		},
+		{
+			name: "Zero_MatrixRateLimitGlobal_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":    "5",
+				"HTTP_READ_TIMEOUT":        "5",
+				"HTTP_WRITE_TIMEOUT":       "5",
+				"MATRIX_RATE_LIMIT_GLOBAL": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("MATRIX_RATE_LIMIT_GLOBAL must be positive"),
+		},
+		{
+			name: "Invalid_ZulipTimeout_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"ZULIP_TIMEOUT":         "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid ZULIP_TIMEOUT format"),
+		},
+		{
+			name: "Negative_ZulipTimeout_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"ZULIP_TIMEOUT":         "-1",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("ZULIP_TIMEOUT must be positive"),
+		},
+		{
+			name: "Invalid_ZulipRateLimitPerChat_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                 ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":     "5",
+				"HTTP_READ_TIMEOUT":         "5",
+				"HTTP_WRITE_TIMEOUT":        "5",
+				"ZULIP_RATE_LIMIT_PER_CHAT": "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid ZULIP_RATE_LIMIT_PER_CHAT format"),
+		},
+		{
+			name: "Zero_ZulipRateLimitGlobal_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":               ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":   "5",
+				"HTTP_READ_TIMEOUT":       "5",
+				"HTTP_WRITE_TIMEOUT":      "5",
+				"ZULIP_RATE_LIMIT_GLOBAL": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("ZULIP_RATE_LIMIT_GLOBAL must be positive"),
+		},
+		{
+			name: "Invalid_NtfyTimeout_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"NTFY_TIMEOUT":          "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid NTFY_TIMEOUT format"),
+		},
+		{
+			name: "Zero_NtfyRateLimitPerChat_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":    "5",
+				"HTTP_READ_TIMEOUT":        "5",
+				"HTTP_WRITE_TIMEOUT":       "5",
+				"NTFY_RATE_LIMIT_PER_CHAT": "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("NTFY_RATE_LIMIT_PER_CHAT must be positive"),
+		},
+		{
+			name: "Negative_GotifyTimeout_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"GOTIFY_TIMEOUT":        "-1",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("GOTIFY_TIMEOUT must be positive"),
+		},
+		{
+			name: "Invalid_GotifyRateLimitGlobal_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":    "5",
+				"HTTP_READ_TIMEOUT":        "5",
+				"HTTP_WRITE_TIMEOUT":       "5",
+				"GOTIFY_RATE_LIMIT_GLOBAL": "abc",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid GOTIFY_RATE_LIMIT_GLOBAL format"),
+		},
+		{
+			name: "Invalid_FileMaxSize_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"FILE_MAX_SIZE":         "10MB",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid FILE_MAX_SIZE format"),
+		},
+		{
+			name: "Invalid_FileRotationInterval_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":              ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":  "5",
+				"HTTP_READ_TIMEOUT":      "5",
+				"HTTP_WRITE_TIMEOUT":     "5",
+				"FILE_ROTATION_INTERVAL": "1d",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid FILE_ROTATION_INTERVAL format"),
+		},
+		{
+			name: "Negative_FileFsyncInterval_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"FILE_FSYNC_INTERVAL":   "0",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("FILE_FSYNC_INTERVAL must be positive"),
+		},
+		{
+			name: "Invalid_FileFsync_Value_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"FILE_FSYNC":            "sometimes",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("FILE_FSYNC must be one of: always, interval, never"),
+		},
+		{
+			name: "Invalid_TelegramTimeout_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"TELEGRAM_TIMEOUT":      "invalid",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid TELEGRAM_TIMEOUT format"),
+		},
+		{
+			name: "Negative_TelegramTimeout_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"TELEGRAM_TIMEOUT":      "-5",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("TELEGRAM_TIMEOUT must be positive"),
+		},
+		{
+			name: "Invalid_VKTeamsTimeout_Format_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"VKTEAMS_TIMEOUT":       "invalid",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("invalid VKTEAMS_TIMEOUT format"),
+		},
+		{
+			name: "Negative_VKTeamsTimeout_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+				"VKTEAMS_TIMEOUT":       "-5",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("VKTEAMS_TIMEOUT must be positive"),
+		},
+		{
+			name: "VKTEAMS_INSECURE_SKIP_VERIFY_True_Value",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                    ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":        "5",
+				"HTTP_READ_TIMEOUT":            "5",
+				"HTTP_WRITE_TIMEOUT":           "5",
+				"VKTEAMS_INSECURE_SKIP_VERIFY": "true",
+			},
+			expectedConfig: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+					MaxBodySize:     DefaultMaxBodySize,
+				},
+				Telegram: TelegramConfig{
+					Timeout:   10,
+					RateLimit: defaultRateLimitConfig,
+				},
+				VKTeams: VKTeamsConfig{
+					Timeout:            10,
+					ApiUrl:             "",
+					InsecureSkipVerify: true,
+					RateLimit:          defaultRateLimitConfig,
+				},
+				Slack:           defaultSlackConfig,
+				Mattermost:      defaultMattermostConfig,
+				MSTeams:         defaultMSTeamsConfig,
+				Discord:         defaultDiscordConfig,
+				Email:           defaultEmailConfig,
+				OutgoingWebhook: defaultOutgoingWebhookConfig,
+				Matrix:          defaultMatrixConfig,
+				Zulip:           defaultZulipConfig,
+				Ntfy:            defaultNtfyConfig,
+				Gotify:          defaultGotifyConfig,
+				File:            defaultFileConfig,
+				Webhook: WebhookConfig{
+					SignatureHeader: DefaultSignatureHeader,
+					Replay:          defaultReplayConfig,
+					Idempotency:     defaultIdempotencyConfig,
+					Response:        defaultResponseConfig,
+				},
+				Delivery:   defaultDeliveryConfig,
+				Outbox:     defaultOutboxConfig,
+				DeadLetter: defaultDeadLetterConfig,
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: make(map[string]ProjectConfig),
+					},
+				},
+			},
+		},
+		{
+			name: "VKTEAMS_INSECURE_SKIP_VERIFY_Invalid_Value",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                    ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":        "5",
+				"HTTP_READ_TIMEOUT":            "5",
+				"HTTP_WRITE_TIMEOUT":           "5",
+				"VKTEAMS_INSECURE_SKIP_VERIFY": "1",
+			},
+			expectedConfig: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+					MaxBodySize:     DefaultMaxBodySize,
+				},
+				Telegram: TelegramConfig{
+					Timeout:   10,
+					RateLimit: defaultRateLimitConfig,
+				},
+				VKTeams: VKTeamsConfig{
+					Timeout:            10,
+					ApiUrl:             "",
+					InsecureSkipVerify: false,
+					RateLimit:          defaultRateLimitConfig,
+				},
+				Slack:           defaultSlackConfig,
+				Mattermost:      defaultMattermostConfig,
+				MSTeams:         defaultMSTeamsConfig,
+				Discord:         defaultDiscordConfig,
+				Email:           defaultEmailConfig,
+				OutgoingWebhook: defaultOutgoingWebhookConfig,
+				Matrix:          defaultMatrixConfig,
+				Zulip:           defaultZulipConfig,
+				Ntfy:            defaultNtfyConfig,
+				Gotify:          defaultGotifyConfig,
+				File:            defaultFileConfig,
+				Webhook: WebhookConfig{
+					SignatureHeader: DefaultSignatureHeader,
+					Replay:          defaultReplayConfig,
+					Idempotency:     defaultIdempotencyConfig,
+					Response:        defaultResponseConfig,
+				},
+				Delivery:   defaultDeliveryConfig,
+				Outbox:     defaultOutboxConfig,
+				DeadLetter: defaultDeadLetterConfig,
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: make(map[string]ProjectConfig),
+					},
+				},
+			},
+		},
+		{
+			name: "VKTEAMS_INSECURE_SKIP_VERIFY_Invalid_Value_Yes",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                    ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":        "5",
+				"HTTP_READ_TIMEOUT":            "5",
+				"HTTP_WRITE_TIMEOUT":           "5",
+				"VKTEAMS_INSECURE_SKIP_VERIFY": "yes",
+			},
+			expectedConfig: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+					MaxBodySize:     DefaultMaxBodySize,
+				},
+				Telegram: TelegramConfig{
+					Timeout:   10,
+					RateLimit: defaultRateLimitConfig,
+				},
+				VKTeams: VKTeamsConfig{
+					Timeout:            10,
+					ApiUrl:             "",
+					InsecureSkipVerify: false,
+					RateLimit:          defaultRateLimitConfig,
+				},
+				Slack:           defaultSlackConfig,
+				Mattermost:      defaultMattermostConfig,
+				MSTeams:         defaultMSTeamsConfig,
+				Discord:         defaultDiscordConfig,
+				Email:           defaultEmailConfig,
+				OutgoingWebhook: defaultOutgoingWebhookConfig,
+				Matrix:          defaultMatrixConfig,
+				Zulip:           defaultZulipConfig,
+				Ntfy:            defaultNtfyConfig,
+				Gotify:          defaultGotifyConfig,
+				File:            defaultFileConfig,
+				Webhook: WebhookConfig{
+					SignatureHeader: DefaultSignatureHeader,
+					Replay:          defaultReplayConfig,
+					Idempotency:     defaultIdempotencyConfig,
+					Response:        defaultResponseConfig,
+				},
+				Delivery:   defaultDeliveryConfig,
+				Outbox:     defaultOutboxConfig,
+				DeadLetter: defaultDeadLetterConfig,
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: make(map[string]ProjectConfig),
+					},
+				},
+			},
+		},
+		{
+			name: "VKTEAMS_INSECURE_SKIP_VERIFY_False_Value",
+			envVariables: map[string]string{
+				"HTTP_ADDR":                    ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT":        "5",
+				"HTTP_READ_TIMEOUT":            "5",
+				"HTTP_WRITE_TIMEOUT":           "5",
+				"VKTEAMS_INSECURE_SKIP_VERIFY": "false",
+			},
+			expectedConfig: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+					MaxBodySize:     DefaultMaxBodySize,
+				},
+				Telegram: TelegramConfig{
+					Timeout:   10,
+					RateLimit: defaultRateLimitConfig,
+				},
+				VKTeams: VKTeamsConfig{
+					Timeout:            10,
+					ApiUrl:             "",
+					InsecureSkipVerify: false,
+					RateLimit:          defaultRateLimitConfig,
+				},
+				Slack:           defaultSlackConfig,
+				Mattermost:      defaultMattermostConfig,
+				MSTeams:         defaultMSTeamsConfig,
+				Discord:         defaultDiscordConfig,
+				Email:           defaultEmailConfig,
+				OutgoingWebhook: defaultOutgoingWebhookConfig,
+				Matrix:          defaultMatrixConfig,
+				Zulip:           defaultZulipConfig,
+				Ntfy:            defaultNtfyConfig,
+				Gotify:          defaultGotifyConfig,
+				File:            defaultFileConfig,
+				Webhook: WebhookConfig{
+					SignatureHeader: DefaultSignatureHeader,
+					Replay:          defaultReplayConfig,
+					Idempotency:     defaultIdempotencyConfig,
+					Response:        defaultResponseConfig,
+				},
+				Delivery:   defaultDeliveryConfig,
+				Outbox:     defaultOutboxConfig,
+				DeadLetter: defaultDeadLetterConfig,
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: make(map[string]ProjectConfig),
+					},
+				},
+			},
+		},
+		{
+			name: "Invalid_YAML_Content_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+			},
+			yamlContent: `
+http:
+  addr: ":3000"
+  shutdown_timeout: invalid_yaml
+`,
+			expectedConfig: nil,
+			expectedErr:    errors.New("failed to load config from YAML"),
+		},
+		{
+			name: "CONFIG_PATH_From_Env_Is_Used",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+			},
+			yamlContent: `
+http:
+  addr: ":3000"
+  shutdown_timeout: 10
+  read_timeout: 10
+  write_timeout: 10
+telegram:
+  bot_token: ""
+logger:
+  level: "error"
+`,
 			expectedConfig: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+					MaxBodySize:     DefaultMaxBodySize,
+				},
+				Telegram: TelegramConfig{
+					Timeout:   10,
+					RateLimit: defaultRateLimitConfig,
+				},
+				VKTeams: VKTeamsConfig{
+					Timeout:   10,
+					ApiUrl:    "",
+					RateLimit: defaultRateLimitConfig,
+				},
+				Slack:           defaultSlackConfig,
+				Mattermost:      defaultMattermostConfig,
+				MSTeams:         defaultMSTeamsConfig,
+				Discord:         defaultDiscordConfig,
+				Email:           defaultEmailConfig,
+				OutgoingWebhook: defaultOutgoingWebhookConfig,
+				Matrix:          defaultMatrixConfig,
+				Zulip:           defaultZulipConfig,
+				Ntfy:            defaultNtfyConfig,
+				Gotify:          defaultGotifyConfig,
+				File:            defaultFileConfig,
+				Logger: LoggerConfig{
+					Level: "error",
+				},
+				Webhook: WebhookConfig{
+					SignatureHeader: DefaultSignatureHeader,
+					Replay:          defaultReplayConfig,
+					Idempotency:     defaultIdempotencyConfig,
+					Response:        defaultResponseConfig,
+				},
+				Delivery:   defaultDeliveryConfig,
+				Outbox:     defaultOutboxConfig,
+				DeadLetter: defaultDeadLetterConfig,
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: make(map[string]ProjectConfig),
+					},
+				},
+			},
+		},
+		{
+			name: "YAML_Parse_Error_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+			},
+			yamlContent: `
+http:
+  addr: ":3000"
+  shutdown_timeout: [invalid_yaml_structure
+`,
+			expectedConfig: nil,
+			expectedErr:    errors.New("failed to load config from YAML"),
+		},
+		{
+			name: "YAML_Unmarshal_Error_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+			},
+			yamlContent: `
+http:
+  addr: ":3000"
+  shutdown_timeout: invalid_yaml_value
+  read_timeout: "not_a_number"
+`,
+			expectedConfig: nil,
+			expectedErr:    errors.New("failed to load config from YAML"),
+		},
+		{
+			name: "YAML_File_Read_Error_Other_Than_NotExist_Returns_Error",
+			envVariables: map[string]string{
+				"HTTP_ADDR":             ":8080",
+				"HTTP_SHUTDOWN_TIMEOUT": "5",
+				"HTTP_READ_TIMEOUT":     "5",
+				"HTTP_WRITE_TIMEOUT":    "5",
+			},
+			expectedConfig: nil,
+			expectedErr:    errors.New("failed to load config from YAML"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Clearenv()
+
+			for key, value := range tc.envVariables {
+				if err := os.Setenv(key, value); err != nil {
+					t.Fatalf("failed to set env variable %s: %v", key, err)
+				}
+			}
+
+			if tc.yamlContent != "" {
+				tempDir := t.TempDir()
+				tempYAMLPath := filepath.Join(tempDir, "config.yml")
+				if err := os.WriteFile(tempYAMLPath, []byte(tc.yamlContent), 0644); err != nil {
+					t.Fatalf("failed to create temp YAML file: %v", err)
+				}
+
+				if err := os.Setenv(EnvConfigPath, tempYAMLPath); err != nil {
+					t.Fatalf("failed to set CONFIG_PATH: %v", err)
+				}
+			} else if tc.name == "YAML_File_Not_Found_Is_Not_Critical" {
+				tempDir := t.TempDir()
+				nonExistentPath := filepath.Join(tempDir, "nonexistent.yml")
+				if err := os.Setenv(EnvConfigPath, nonExistentPath); err != nil {
+					t.Fatalf("failed to set CONFIG_PATH: %v", err)
+				}
+			} else if tc.name == "YAML_File_Read_Error_Other_Than_NotExist_Returns_Error" {
+				tempDir := t.TempDir()
+				if err := os.Setenv(EnvConfigPath, tempDir); err != nil {
+					t.Fatalf("failed to set CONFIG_PATH: %v", err)
+				}
+			} else {
+				err := os.Unsetenv(EnvConfigPath)
+				if err != nil {
+					return
+				}
+			}
+
+			cfg, err := LoadConfig()
+
+			if tc.expectedErr != nil {
+				if err == nil {
+					t.Errorf("expected error: %v, got: nil", tc.expectedErr)
+				} else if !strings.Contains(err.Error(), tc.expectedErr.Error()) {
+					t.Errorf("expected error to contain: %v, got: %v", tc.expectedErr, err)
+				}
+				if cfg != nil {
+					t.Errorf("expected config to be nil on error, got: %+v", cfg)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if diff := cmp.Diff(tc.expectedConfig, cfg); diff != "" {
+					t.Errorf("Unexpected config (-want +got):\n%s", diff)
+				}
+			}
+
+			os.Clearenv()
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	type testCase struct {
+		name        string
+		config      *Config
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Valid_Config",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Valid_Config_With_Telegram",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Telegram: TelegramConfig{
+					BotToken: "token123",
+					Timeout:  10,
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Valid_Config_With_VKTeams",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				VKTeams: VKTeamsConfig{
+					BotToken: "token123",
+					Timeout:  10,
+					ApiUrl:   "https://api.example.com/bot/v1",
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Valid_Config_With_VKTeams_Project",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				VKTeams: VKTeamsConfig{
+					BotToken: "token123",
+					Timeout:  10,
+					ApiUrl:   "https://api.example.com/bot/v1",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"vkteams", "logger"},
+								VKTeams: &ProjectVKTeamsConfig{
+									ChatID: "chat123",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Valid_Config_With_Projects",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Telegram: TelegramConfig{
+					BotToken: "token123",
+					Timeout:  10,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"telegram", "logger"},
+								Telegram: &ProjectTelegramConfig{
+									ChatID: "123456789",
+								},
+							},
+							"project2": {
+								AllowedChannels: []string{"logger"},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Missing_HTTP_Addr",
+			config: &Config{
+				HTTP: HTTPConfig{
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+			},
+			expectedErr: errors.New("HTTP_ADDR is required"),
+		},
+		{
+			name: "Invalid_ShutdownTimeout",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 0,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+			},
+			expectedErr: errors.New("HTTP_SHUTDOWN_TIMEOUT must be positive"),
+		},
+		{
+			name: "Invalid_ReadTimeout",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     0,
+					WriteTimeout:    5,
+				},
+			},
+			expectedErr: errors.New("HTTP_READ_TIMEOUT must be positive"),
+		},
+		{
+			name: "Invalid_WriteTimeout",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    0,
+				},
+			},
+			expectedErr: errors.New("HTTP_WRITE_TIMEOUT must be positive"),
+		},
+		{
+			name: "Project_With_Telegram_But_No_ChatID",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Telegram: TelegramConfig{
+					BotToken: "token123",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"telegram"},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("telegram.chat_id is required"),
+		},
+		{
+			name: "Project_With_Telegram_But_Empty_ChatID",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Telegram: TelegramConfig{
+					BotToken: "token123",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"telegram"},
+								Telegram: &ProjectTelegramConfig{
+									ChatID: "",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("telegram.chat_id cannot be empty"),
+		},
+		{
+			name: "Project_With_Telegram_But_No_BotToken",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"telegram"},
+								Telegram: &ProjectTelegramConfig{
+									ChatID: "123456789",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("TELEGRAM_BOT_TOKEN is required"),
+		},
+		{
+			name: "Project_With_Invalid_Channel",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"invalid_channel"},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("invalid channel"),
+		},
+		{
+			name: "Project_With_Empty_AllowedChannels",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("allowedChannels cannot be empty"),
+		},
+		{
+			name: "Telegram_Timeout_Default_Value_Set",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Telegram: TelegramConfig{
+					BotToken: "token123",
+					Timeout:  0,
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "VKTeams_Timeout_Default_Value_Set",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				VKTeams: VKTeamsConfig{
+					BotToken: "token123",
+					Timeout:  0,
+					ApiUrl:   "https://api.example.com/bot/v1",
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Project_With_VKTeams_But_No_ChatID",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				VKTeams: VKTeamsConfig{
+					BotToken: "token123",
+					ApiUrl:   "https://api.example.com/bot/v1",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"vkteams"},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("vkteams.chat_id is required"),
+		},
+		{
+			name: "Project_With_VKTeams_But_Empty_ChatID",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				VKTeams: VKTeamsConfig{
+					BotToken: "token123",
+					ApiUrl:   "https://api.example.com/bot/v1",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"vkteams"},
+								VKTeams: &ProjectVKTeamsConfig{
+									ChatID: "",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("vkteams.chat_id cannot be empty"),
+		},
+		{
+			name: "Project_With_Slack_Webhook_Without_BotToken",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"slack"},
+								Slack: &ProjectSlackConfig{
+									WebhookURL: "https://hooks.slack.com/services/T000/B000/XXX",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Project_With_Slack_Channel_And_BotToken",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Slack: SlackConfig{
+					BotToken: "xoxb-token",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"slack"},
+								Slack: &ProjectSlackConfig{
+									Channel: "C0123456789",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Project_With_Slack_But_No_Config",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Slack: SlackConfig{
+					BotToken: "xoxb-token",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"slack"},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("slack.webhook_url or slack.channel is required"),
+		},
+		{
+			name: "Project_With_Slack_But_Empty_Config",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Slack: SlackConfig{
+					BotToken: "xoxb-token",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"slack"},
+								Slack:           &ProjectSlackConfig{},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("slack.webhook_url or slack.channel is required"),
+		},
+		{
+			name: "Project_With_Slack_Webhook_Not_HTTPS",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"slack"},
+								Slack: &ProjectSlackConfig{
+									WebhookURL: "http://hooks.slack.com/services/T000/B000/XXX",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("slack.webhook_url must be an https URL"),
+		},
+		{
+			name: "Project_With_Slack_Channel_But_No_BotToken",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"slack"},
+								Slack: &ProjectSlackConfig{
+									Channel: "#alerts",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("SLACK_BOT_TOKEN is required"),
+		},
+		{
+			name: "Project_With_Mattermost_Webhook_Without_BotToken",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"mattermost"},
+								Mattermost: &ProjectMattermostConfig{
+									WebhookURL: "https://mattermost.example.com/hooks/xxx",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Project_With_Mattermost_Channel_And_BotToken",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Mattermost: MattermostConfig{
+					BotToken: "mm-token",
+					ApiUrl:   "https://mattermost.example.com",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"mattermost"},
+								Mattermost: &ProjectMattermostConfig{
+									ChannelID: "channel-id",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Project_With_Mattermost_But_No_Config",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"mattermost"},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("mattermost.webhook_url or mattermost.channel_id is required"),
+		},
+		{
+			name: "Project_With_Mattermost_Webhook_Not_URL",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"mattermost"},
+								Mattermost: &ProjectMattermostConfig{
+									WebhookURL: "mattermost.example.com/hooks/xxx",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("mattermost.webhook_url must be an http or https URL"),
+		},
+		{
+			name: "Project_With_Mattermost_Channel_But_No_BotToken",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Mattermost: MattermostConfig{
+					ApiUrl: "https://mattermost.example.com",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"mattermost"},
+								Mattermost: &ProjectMattermostConfig{
+									ChannelID: "channel-id",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("MATTERMOST_BOT_TOKEN is required"),
+		},
+		{
+			name: "Project_With_Mattermost_Channel_But_No_ApiUrl",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Mattermost: MattermostConfig{
+					BotToken: "mm-token",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"mattermost"},
+								Mattermost: &ProjectMattermostConfig{
+									ChannelID: "channel-id",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("MATTERMOST_API_URL is required"),
+		},
+		{
+			name: "Project_With_MSTeams_Webhook",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"msteams"},
+								MSTeams: &ProjectMSTeamsConfig{
+									WebhookURL: "https://example.webhook.office.com/webhookb2/xxx",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Project_With_MSTeams_But_No_Config",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"msteams"},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("msteams.webhook_url is required when msteams is in allowedChannels"),
+		},
+		{
+			name: "Project_With_MSTeams_But_Empty_Webhook",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"msteams"},
+								MSTeams:         &ProjectMSTeamsConfig{},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("msteams.webhook_url is required when msteams is in allowedChannels"),
+		},
+		{
+			name: "Project_With_MSTeams_Webhook_Not_HTTPS",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"msteams"},
+								MSTeams: &ProjectMSTeamsConfig{
+									WebhookURL: "http://example.webhook.office.com/webhookb2/xxx",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("msteams.webhook_url must be an https URL"),
+		},
+		{
+			name: "Project_With_Discord_Webhook",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"discord"},
+								Discord: &ProjectDiscordConfig{
+									WebhookURL: "https://discord.com/api/webhooks/123/token",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Project_With_Discord_But_No_Config",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"discord"},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("discord.webhook_url is required when discord is in allowedChannels"),
+		},
+		{
+			name: "Project_With_Discord_But_Empty_Webhook",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"discord"},
+								Discord:         &ProjectDiscordConfig{},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("discord.webhook_url is required when discord is in allowedChannels"),
+		},
+		{
+			name: "Project_With_Discord_Webhook_Not_HTTPS",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"discord"},
+								Discord: &ProjectDiscordConfig{
+									WebhookURL: "http://discord.com/api/webhooks/123/token",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("discord.webhook_url must be an https URL"),
+		},
+		{
+			name: "Project_With_Email_Recipients",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Email: EmailConfig{
+					Host: "smtp.example.com",
+					From: "YouTrack <youtrack@example.com>",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"email"},
+								Email: &ProjectEmailConfig{
+									Recipients: []string{"manager@example.com", "Team Lead <lead@example.com>"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Project_With_Email_Notify_Assignee_Only",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Email: EmailConfig{
+					Host: "smtp.example.com",
+					From: "YouTrack <youtrack@example.com>",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"email"},
+								Email: &ProjectEmailConfig{
+									NotifyAssignee: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Project_With_Email_But_No_Config",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Email: EmailConfig{
+					Host: "smtp.example.com",
+					From: "YouTrack <youtrack@example.com>",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"email"},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("email.recipients or email.notify_assignee is required when email is in allowedChannels"),
+		},
+		{
+			name: "Project_With_Email_Invalid_Recipient",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Email: EmailConfig{
+					Host: "smtp.example.com",
+					From: "YouTrack <youtrack@example.com>",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"email"},
+								Email: &ProjectEmailConfig{
+									Recipients: []string{"not an address"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("invalid email.recipients address"),
+		},
+		{
+			name: "Project_With_Email_But_No_Host",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Email: EmailConfig{
+					From: "youtrack@example.com",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"email"},
+								Email: &ProjectEmailConfig{
+									Recipients: []string{"manager@example.com", "Team Lead <lead@example.com>"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("EMAIL_HOST is required when email is used in project configurations"),
+		},
+		{
+			name: "Project_With_Email_But_No_From",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Email: EmailConfig{
+					Host: "smtp.example.com",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"email"},
+								Email: &ProjectEmailConfig{
+									Recipients: []string{"manager@example.com", "Team Lead <lead@example.com>"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("EMAIL_FROM is required when email is used in project configurations"),
+		},
+		{
+			name: "Project_With_Email_Invalid_From",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Email: EmailConfig{
+					Host: "smtp.example.com",
+					From: "youtrack",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"email"},
+								Email: &ProjectEmailConfig{
+									Recipients: []string{"manager@example.com", "Team Lead <lead@example.com>"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("EMAIL_FROM must be a valid email address"),
+		},
+		{
+			name: "Valid_Config_With_Webhook_Targets",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				OutgoingWebhook: OutgoingWebhookConfig{
+					Targets: map[string]OutgoingWebhookTargetConfig{
+						"deploy_bot":   {URL: "https://deploy.example.com/hooks/youtrack", Method: "put", Secret: "secret"},
+						"bi_collector": {URL: "http://bi.internal/events", Body: `{"issue": {{json .Issue.IDReadable}}}`},
+					},
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"webhook"},
+								Webhook:         &ProjectWebhookConfig{Targets: []string{"deploy_bot", "bi_collector"}},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Project_With_Webhook_But_No_Targets",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				OutgoingWebhook: OutgoingWebhookConfig{
+					Targets: map[string]OutgoingWebhookTargetConfig{
+						"deploy_bot": {URL: "https://deploy.example.com/hooks/youtrack"},
+					},
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"webhook"},
+								Webhook:         &ProjectWebhookConfig{},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("webhook.targets is required when webhook is in allowedChannels"),
+		},
+		{
+			name: "Project_With_Webhook_Undefined_Target",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				OutgoingWebhook: OutgoingWebhookConfig{
+					Targets: map[string]OutgoingWebhookTargetConfig{
+						"deploy_bot": {URL: "https://deploy.example.com/hooks/youtrack"},
+					},
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"webhook"},
+								Webhook:         &ProjectWebhookConfig{Targets: []string{"unknown"}},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("webhook target \"unknown\" is not defined in outgoing_webhook.targets"),
+		},
+		{
+			name: "Webhook_Target_With_Invalid_URL",
+			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
 					ShutdownTimeout: 5,
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
-				Telegram: TelegramConfig{
-					Timeout: 10,
+				OutgoingWebhook: OutgoingWebhookConfig{
+					Targets: map[string]OutgoingWebhookTargetConfig{
+						"deploy_bot": {URL: "deploy.example.com/hooks/youtrack"},
+					},
 				},
-				VKTeams: VKTeamsConfig{
-					Timeout:            10,
-					ApiUrl:             "",
-					InsecureSkipVerify: false,
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"webhook"},
+								Webhook:         &ProjectWebhookConfig{Targets: []string{"deploy_bot"}},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("url must be an http or https URL"),
+		},
+		{
+			name: "Webhook_Target_With_Invalid_Method",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				OutgoingWebhook: OutgoingWebhookConfig{
+					Targets: map[string]OutgoingWebhookTargetConfig{
+						"deploy_bot": {URL: "https://deploy.example.com/hooks/youtrack", Method: "DELETE"},
+					},
 				},
 				Notifications: NotificationsConfig{
 					Youtrack: YoutrackConfig{
-						Projects: make(map[string]ProjectConfig),
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"webhook"},
+								Webhook:         &ProjectWebhookConfig{Targets: []string{"deploy_bot"}},
+							},
+						},
 					},
 				},
 			},
+			expectedErr: errors.New("method must be one of: POST, PUT, PATCH, got: DELETE"),
 		},
 		{
-			name: "VKTEAMS_INSECURE_SKIP_VERIFY_False_Value",
-			envVariables: map[string]string{
-				"HTTP_ADDR":                    ":8080",
-				"HTTP_SHUTDOWN_TIMEOUT":        "5",
-				"HTTP_READ_TIMEOUT":            "5",
-				"HTTP_WRITE_TIMEOUT":           "5",
-				"VKTEAMS_INSECURE_SKIP_VERIFY": "false",
+			name: "Valid_Config_With_Matrix",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Matrix: MatrixConfig{
+					AccessToken:   "syt_token",
+					HomeserverURL: "https://matrix.example.com",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"matrix"},
+								Matrix:          &ProjectMatrixConfig{RoomID: "!abcdef:example.com"},
+							},
+						},
+					},
+				},
 			},
-			expectedConfig: &Config{
+			expectedErr: nil,
+		},
+		{
+			name: "Project_With_Matrix_But_No_RoomID",
+			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
 					ShutdownTimeout: 5,
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
-				Telegram: TelegramConfig{
-					Timeout: 10,
+				Matrix: MatrixConfig{
+					AccessToken:   "syt_token",
+					HomeserverURL: "https://matrix.example.com",
 				},
-				VKTeams: VKTeamsConfig{
-					Timeout:            10,
-					ApiUrl:             "",
-					InsecureSkipVerify: false,
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"matrix"},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("matrix.room_id is required when matrix is in allowedChannels"),
+		},
+		{
+			name: "Project_With_Matrix_Room_Alias",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Matrix: MatrixConfig{
+					AccessToken:   "syt_token",
+					HomeserverURL: "https://matrix.example.com",
 				},
 				Notifications: NotificationsConfig{
 					Youtrack: YoutrackConfig{
-						Projects: make(map[string]ProjectConfig),
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"matrix"},
+								Matrix:          &ProjectMatrixConfig{RoomID: "#youtrack:example.com"},
+							},
+						},
 					},
 				},
 			},
+			expectedErr: errors.New("matrix.room_id must be a room ID like !room:server, got: #youtrack:example.com"),
 		},
 		{
-			name: "Invalid_YAML_Content_Returns_Error",
-			envVariables: map[string]string{
-				"HTTP_ADDR":             ":8080",
-				"HTTP_SHUTDOWN_TIMEOUT": "5",
-				"HTTP_READ_TIMEOUT":     "5",
-				"HTTP_WRITE_TIMEOUT":    "5",
+			name: "Project_With_Matrix_But_No_AccessToken",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Matrix: MatrixConfig{
+					HomeserverURL: "https://matrix.example.com",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"matrix"},
+								Matrix:          &ProjectMatrixConfig{RoomID: "!abcdef:example.com"},
+							},
+						},
+					},
+				},
 			},
-			yamlContent: `
-http:
-  addr: ":3000"
-  shutdown_timeout: invalid_yaml
-`,
-			expectedConfig: nil,
-			expectedErr:    errors.New("failed to load config from YAML"),
+			expectedErr: errors.New("MATRIX_ACCESS_TOKEN is required when matrix is used in project configurations"),
 		},
 		{
-			name: "CONFIG_PATH_From_Env_Is_Used",
-			envVariables: map[string]string{
-				"HTTP_ADDR":             ":8080",
-				"HTTP_SHUTDOWN_TIMEOUT": "5",
-				"HTTP_READ_TIMEOUT":     "5",
-				"HTTP_WRITE_TIMEOUT":    "5",
+			name: "Project_With_Matrix_But_No_HomeserverURL",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Matrix: MatrixConfig{
+					AccessToken: "syt_token",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"matrix"},
+								Matrix:          &ProjectMatrixConfig{RoomID: "!abcdef:example.com"},
+							},
+						},
+					},
+				},
 			},
-			yamlContent: `
-http:
-  addr: ":3000"
-  shutdown_timeout: 10
-  read_timeout: 10
-  write_timeout: 10
-telegram:
-  bot_token: ""
-logger:
-  level: "error"
-`,
-			expectedConfig: &Config{
+			expectedErr: errors.New("MATRIX_HOMESERVER_URL must be an http or https URL when matrix is used in project configurations"),
+		},
+		{
+			name: "Valid_Config_With_Zulip",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Zulip: ZulipConfig{
+					BotEmail: "youtrack-bot@zulip.example.com",
+					APIKey:   "zulip_key",
+					SiteURL:  "https://zulip.example.com",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"zulip"},
+								Zulip:           &ProjectZulipConfig{Stream: "backend"},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Project_With_Zulip_But_No_Stream",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Zulip: ZulipConfig{
+					BotEmail: "youtrack-bot@zulip.example.com",
+					APIKey:   "zulip_key",
+					SiteURL:  "https://zulip.example.com",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"zulip"},
+								Zulip:           &ProjectZulipConfig{Stream: " "},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("zulip.stream is required when zulip is in allowedChannels"),
+		},
+		{
+			name: "Project_With_Zulip_But_No_BotEmail",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Zulip: ZulipConfig{
+					APIKey:  "zulip_key",
+					SiteURL: "https://zulip.example.com",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"zulip"},
+								Zulip:           &ProjectZulipConfig{Stream: "backend"},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("ZULIP_BOT_EMAIL is required when zulip is used in project configurations"),
+		},
+		{
+			name: "Project_With_Zulip_But_No_APIKey",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Zulip: ZulipConfig{
+					BotEmail: "youtrack-bot@zulip.example.com",
+					SiteURL:  "https://zulip.example.com",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"zulip"},
+								Zulip:           &ProjectZulipConfig{Stream: "backend"},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("ZULIP_API_KEY is required when zulip is used in project configurations"),
+		},
+		{
+			name: "Project_With_Zulip_But_No_SiteURL",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Zulip: ZulipConfig{
+					BotEmail: "youtrack-bot@zulip.example.com",
+					APIKey:   "zulip_key",
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"zulip"},
+								Zulip:           &ProjectZulipConfig{Stream: "backend"},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errors.New("ZULIP_SITE_URL must be an http or https URL when zulip is used in project configurations"),
+		},
+		{
+			name: "Valid_Config_With_Ntfy_Default_Server",
+			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
 					ShutdownTimeout: 5,
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
-				Telegram: TelegramConfig{
-					Timeout: 10,
-				},
-				VKTeams: VKTeamsConfig{
-					Timeout: 10,
-					ApiUrl:  "",
-				},
-				Logger: LoggerConfig{
-					Level: "error",
-				},
 				Notifications: NotificationsConfig{
 					Youtrack: YoutrackConfig{
-						Projects: make(map[string]ProjectConfig),
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"ntfy"},
+								Ntfy:            &ProjectNtfyConfig{Topic: "backend-alerts_1"},
+							},
+						},
 					},
 				},
 			},
+			expectedErr: nil,
 		},
 		{
-			name: "YAML_Parse_Error_Returns_Error",
-			envVariables: map[string]string{
-				"HTTP_ADDR":             ":8080",
-				"HTTP_SHUTDOWN_TIMEOUT": "5",
-				"HTTP_READ_TIMEOUT":     "5",
-				"HTTP_WRITE_TIMEOUT":    "5",
-			},
-			yamlContent: `
-http:
-  addr: ":3000"
-  shutdown_timeout: [invalid_yaml_structure
-`,
-			expectedConfig: nil,
-			expectedErr:    errors.New("failed to load config from YAML"),
-		},
-		{
-			name: "YAML_Unmarshal_Error_Returns_Error",
-			envVariables: map[string]string{
-				"HTTP_ADDR":             ":8080",
-				"HTTP_SHUTDOWN_TIMEOUT": "5",
-				"HTTP_READ_TIMEOUT":     "5",
-				"HTTP_WRITE_TIMEOUT":    "5",
-			},
-			yamlContent: `
-http:
-  addr: ":3000"
-  shutdown_timeout: invalid_yaml_value
-  read_timeout: "not_a_number"
-`,
-			expectedConfig: nil,
-			expectedErr:    errors.New("failed to load config from YAML"),
-		},
-		{
-			name: "YAML_File_Read_Error_Other_Than_NotExist_Returns_Error",
-			envVariables: map[string]string{
-				"HTTP_ADDR":             ":8080",
-				"HTTP_SHUTDOWN_TIMEOUT": "5",
-				"HTTP_READ_TIMEOUT":     "5",
-				"HTTP_WRITE_TIMEOUT":    "5",
+			name: "Project_With_Ntfy_But_No_Topic",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"ntfy"},
+								Ntfy:            &ProjectNtfyConfig{Topic: " "},
+							},
+						},
+					},
+				},
 			},
-			expectedConfig: nil,
-			expectedErr:    errors.New("failed to load config from YAML"),
+			expectedErr: errors.New("ntfy.topic is required when ntfy is in allowedChannels"),
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			os.Clearenv()
-
-			for key, value := range tc.envVariables {
-				if err := os.Setenv(key, value); err != nil {
-					t.Fatalf("failed to set env variable %s: %v", key, err)
-				}
-			}
-
-			if tc.yamlContent != "" {
-				tempDir := t.TempDir()
-				tempYAMLPath := filepath.Join(tempDir, "config.yml")
-				if err := os.WriteFile(tempYAMLPath, []byte(tc.yamlContent), 0644); err != nil {
-					t.Fatalf("failed to create temp YAML file: %v", err)
-				}
-
-				if err := os.Setenv(EnvConfigPath, tempYAMLPath); err != nil {
-					t.Fatalf("failed to set CONFIG_PATH: %v", err)
-				}
-			} else if tc.name == "YAML_File_Not_Found_Is_Not_Critical" {
-				tempDir := t.TempDir()
-				nonExistentPath := filepath.Join(tempDir, "nonexistent.yml")
-				if err := os.Setenv(EnvConfigPath, nonExistentPath); err != nil {
-					t.Fatalf("failed to set CONFIG_PATH: %v", err)
-				}
-			} else if tc.name == "YAML_File_Read_Error_Other_Than_NotExist_Returns_Error" {
-				tempDir := t.TempDir()
-				if err := os.Setenv(EnvConfigPath, tempDir); err != nil {
-					t.Fatalf("failed to set CONFIG_PATH: %v", err)
-				}
-			} else {
-				err := os.Unsetenv(EnvConfigPath)
-				if err != nil {
-					return
-				}
-			}
-
-			cfg, err := LoadConfig()
-
-			if tc.expectedErr != nil {
-				if err == nil {
-					t.Errorf("expected error: %v, got: nil", tc.expectedErr)
-				} else if !strings.Contains(err.Error(), tc.expectedErr.Error()) {
-					t.Errorf("expected error to contain: %v, got: %v", tc.expectedErr, err)
-				}
-				if cfg != nil {
-					t.Errorf("expected config to be nil on error, got: %+v", cfg)
-				}
-			} else {
-				if err != nil {
-					t.Errorf("unexpected error: %v", err)
-				}
-				if diff := cmp.Diff(tc.expectedConfig, cfg); diff != "" {
-					t.Errorf("Unexpected config (-want +got):\n%s", diff)
-				}
-			}
-
-			os.Clearenv()
-		})
-	}
-}
-
-func TestValidateConfig(t *testing.T) {
-	type testCase struct {
-		name        string
-		config      *Config
-		expectedErr error
-	}
-
-	testCases := []testCase{
 		{
-			name: "Valid_Config",
+			name: "Project_With_Ntfy_Invalid_Topic",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
@@ -884,11 +4021,21 @@ func TestValidateConfig(t *testing.T) {
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"ntfy"},
+								Ntfy:            &ProjectNtfyConfig{Topic: "backend/alerts"},
+							},
+						},
+					},
+				},
 			},
-			expectedErr: nil,
+			expectedErr: errors.New("ntfy.topic must contain only letters, digits, '-' and '_' (up to 64 characters), got: backend/alerts"),
 		},
 		{
-			name: "Valid_Config_With_Telegram",
+			name: "Project_With_Ntfy_Invalid_ServerURL",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
@@ -896,15 +4043,22 @@ func TestValidateConfig(t *testing.T) {
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
-				Telegram: TelegramConfig{
-					BotToken: "token123",
-					Timeout:  10,
+				Ntfy: NtfyConfig{ServerURL: "ntfy.example.com"},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"ntfy"},
+								Ntfy:            &ProjectNtfyConfig{Topic: "backend"},
+							},
+						},
+					},
 				},
 			},
-			expectedErr: nil,
+			expectedErr: errors.New("NTFY_SERVER_URL must be an http or https URL when ntfy is used in project configurations"),
 		},
 		{
-			name: "Valid_Config_With_VKTeams",
+			name: "Valid_Config_With_Gotify",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
@@ -912,16 +4066,22 @@ func TestValidateConfig(t *testing.T) {
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
-				VKTeams: VKTeamsConfig{
-					BotToken: "token123",
-					Timeout:  10,
-					ApiUrl:   "https://api.example.com/bot/v1",
+				Gotify: GotifyConfig{ServerURL: "https://gotify.example.com"},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"gotify"},
+								Gotify:          &ProjectGotifyConfig{Token: "AbCdEf123"},
+							},
+						},
+					},
 				},
 			},
 			expectedErr: nil,
 		},
 		{
-			name: "Valid_Config_With_VKTeams_Project",
+			name: "Project_With_Gotify_But_No_Token",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
@@ -929,28 +4089,22 @@ func TestValidateConfig(t *testing.T) {
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
-				VKTeams: VKTeamsConfig{
-					BotToken: "token123",
-					Timeout:  10,
-					ApiUrl:   "https://api.example.com/bot/v1",
-				},
+				Gotify: GotifyConfig{ServerURL: "https://gotify.example.com"},
 				Notifications: NotificationsConfig{
 					Youtrack: YoutrackConfig{
 						Projects: map[string]ProjectConfig{
 							"project1": {
-								AllowedChannels: []string{"vkteams", "logger"},
-								VKTeams: &ProjectVKTeamsConfig{
-									ChatID: "chat123",
-								},
+								AllowedChannels: []string{"gotify"},
+								Gotify:          &ProjectGotifyConfig{},
 							},
 						},
 					},
 				},
 			},
-			expectedErr: nil,
+			expectedErr: errors.New("gotify.token is required when gotify is in allowedChannels"),
 		},
 		{
-			name: "Valid_Config_With_Projects",
+			name: "Project_With_Gotify_But_No_ServerURL",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
@@ -958,77 +4112,114 @@ func TestValidateConfig(t *testing.T) {
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
-				Telegram: TelegramConfig{
-					BotToken: "token123",
-					Timeout:  10,
-				},
 				Notifications: NotificationsConfig{
 					Youtrack: YoutrackConfig{
 						Projects: map[string]ProjectConfig{
 							"project1": {
-								AllowedChannels: []string{"telegram", "logger"},
-								Telegram: &ProjectTelegramConfig{
-									ChatID: "123456789",
-								},
-							},
-							"project2": {
-								AllowedChannels: []string{"logger"},
+								AllowedChannels: []string{"gotify"},
+								Gotify:          &ProjectGotifyConfig{Token: "AbCdEf123"},
 							},
 						},
 					},
 				},
 			},
-			expectedErr: nil,
+			expectedErr: errors.New("GOTIFY_SERVER_URL must be an http or https URL when gotify is used in project configurations"),
 		},
 		{
-			name: "Missing_HTTP_Addr",
+			name: "Valid_Config_With_File",
 			config: &Config{
 				HTTP: HTTPConfig{
+					Addr:            ":8080",
 					ShutdownTimeout: 5,
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"file"},
+							},
+						},
+					},
+				},
 			},
-			expectedErr: errors.New("HTTP_ADDR is required"),
+			expectedErr: nil,
 		},
 		{
-			name: "Invalid_ShutdownTimeout",
+			name: "Valid_Config_With_File_Rotation",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
-					ShutdownTimeout: 0,
+					ShutdownTimeout: 5,
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
+				File: FileConfig{
+					Path:             "/var/lib/notifications/archive.jsonl",
+					MaxSize:          100,
+					RotationInterval: 3600,
+					Compress:         true,
+					Fsync:            FileFsyncNever,
+				},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"file"},
+							},
+						},
+					},
+				},
 			},
-			expectedErr: errors.New("HTTP_SHUTDOWN_TIMEOUT must be positive"),
+			expectedErr: nil,
 		},
 		{
-			name: "Invalid_ReadTimeout",
+			name: "File_With_Invalid_Fsync",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
 					ShutdownTimeout: 5,
-					ReadTimeout:     0,
+					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
+				File: FileConfig{Fsync: "sometimes"},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"file"},
+							},
+						},
+					},
+				},
 			},
-			expectedErr: errors.New("HTTP_READ_TIMEOUT must be positive"),
+			expectedErr: errors.New("FILE_FSYNC must be one of: always, interval, never, got: sometimes"),
 		},
 		{
-			name: "Invalid_WriteTimeout",
+			name: "File_With_Negative_MaxSize",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
 					ShutdownTimeout: 5,
 					ReadTimeout:     5,
-					WriteTimeout:    0,
+					WriteTimeout:    5,
+				},
+				File: FileConfig{MaxSize: -1},
+				Notifications: NotificationsConfig{
+					Youtrack: YoutrackConfig{
+						Projects: map[string]ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"file"},
+							},
+						},
+					},
 				},
 			},
-			expectedErr: errors.New("HTTP_WRITE_TIMEOUT must be positive"),
+			expectedErr: errors.New("FILE_MAX_SIZE cannot be negative, got: -1"),
 		},
 		{
-			name: "Project_With_Telegram_But_No_ChatID",
+			name: "File_With_Negative_RotationInterval",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
@@ -1036,23 +4227,21 @@ func TestValidateConfig(t *testing.T) {
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
-				Telegram: TelegramConfig{
-					BotToken: "token123",
-				},
+				File: FileConfig{RotationInterval: -60},
 				Notifications: NotificationsConfig{
 					Youtrack: YoutrackConfig{
 						Projects: map[string]ProjectConfig{
 							"project1": {
-								AllowedChannels: []string{"telegram"},
+								AllowedChannels: []string{"file"},
 							},
 						},
 					},
 				},
 			},
-			expectedErr: errors.New("telegram.chat_id is required"),
+			expectedErr: errors.New("FILE_ROTATION_INTERVAL cannot be negative, got: -60"),
 		},
 		{
-			name: "Project_With_Telegram_But_Empty_ChatID",
+			name: "Project_With_VKTeams_But_No_BotToken",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
@@ -1060,26 +4249,23 @@ func TestValidateConfig(t *testing.T) {
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
-				Telegram: TelegramConfig{
-					BotToken: "token123",
-				},
 				Notifications: NotificationsConfig{
 					Youtrack: YoutrackConfig{
 						Projects: map[string]ProjectConfig{
 							"project1": {
-								AllowedChannels: []string{"telegram"},
-								Telegram: &ProjectTelegramConfig{
-									ChatID: "",
+								AllowedChannels: []string{"vkteams"},
+								VKTeams: &ProjectVKTeamsConfig{
+									ChatID: "chat123",
 								},
 							},
 						},
 					},
 				},
 			},
-			expectedErr: errors.New("telegram.chat_id cannot be empty"),
+			expectedErr: errors.New("VKTEAMS_BOT_TOKEN is required"),
 		},
 		{
-			name: "Project_With_Telegram_But_No_BotToken",
+			name: "Project_With_VKTeams_But_No_APIURL",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
@@ -1087,23 +4273,27 @@ func TestValidateConfig(t *testing.T) {
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
+				VKTeams: VKTeamsConfig{
+					BotToken: "token123",
+					ApiUrl:   "",
+				},
 				Notifications: NotificationsConfig{
 					Youtrack: YoutrackConfig{
 						Projects: map[string]ProjectConfig{
 							"project1": {
-								AllowedChannels: []string{"telegram"},
-								Telegram: &ProjectTelegramConfig{
-									ChatID: "123456789",
+								AllowedChannels: []string{"vkteams"},
+								VKTeams: &ProjectVKTeamsConfig{
+									ChatID: "chat123",
 								},
 							},
 						},
 					},
 				},
 			},
-			expectedErr: errors.New("TELEGRAM_BOT_TOKEN is required"),
+			expectedErr: errors.New("VKTEAMS_API_URL is required"),
 		},
 		{
-			name: "Project_With_Invalid_Channel",
+			name: "Valid_Webhook_Tokens",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
@@ -1111,20 +4301,24 @@ func TestValidateConfig(t *testing.T) {
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
+				Webhook: WebhookConfig{
+					Tokens: []WebhookTokenConfig{
+						{Name: "main", Token: "token1"},
+						{Name: "team", Token: "token2", Projects: []string{"project1"}},
+					},
+				},
 				Notifications: NotificationsConfig{
 					Youtrack: YoutrackConfig{
 						Projects: map[string]ProjectConfig{
-							"project1": {
-								AllowedChannels: []string{"invalid_channel"},
-							},
+							"project1": {AllowedChannels: []string{"logger"}},
 						},
 					},
 				},
 			},
-			expectedErr: errors.New("invalid channel"),
+			expectedErr: nil,
 		},
 		{
-			name: "Project_With_Empty_AllowedChannels",
+			name: "Webhook_Token_Without_Name",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
@@ -1132,20 +4326,14 @@ func TestValidateConfig(t *testing.T) {
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
-				Notifications: NotificationsConfig{
-					Youtrack: YoutrackConfig{
-						Projects: map[string]ProjectConfig{
-							"project1": {
-								AllowedChannels: []string{},
-							},
-						},
-					},
+				Webhook: WebhookConfig{
+					Tokens: []WebhookTokenConfig{{Token: "token1"}},
 				},
 			},
-			expectedErr: errors.New("allowedChannels cannot be empty"),
+			expectedErr: errors.New("webhook.tokens[0]: name cannot be empty"),
 		},
 		{
-			name: "Telegram_Timeout_Default_Value_Set",
+			name: "Webhook_Token_Without_Value",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
@@ -1153,15 +4341,14 @@ func TestValidateConfig(t *testing.T) {
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
-				Telegram: TelegramConfig{
-					BotToken: "token123",
-					Timeout:  0,
+				Webhook: WebhookConfig{
+					Tokens: []WebhookTokenConfig{{Name: "main"}},
 				},
 			},
-			expectedErr: nil,
+			expectedErr: errors.New(`webhook token "main": token cannot be empty`),
 		},
 		{
-			name: "VKTeams_Timeout_Default_Value_Set",
+			name: "Webhook_Token_Duplicated",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
@@ -1169,16 +4356,17 @@ func TestValidateConfig(t *testing.T) {
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
-				VKTeams: VKTeamsConfig{
-					BotToken: "token123",
-					Timeout:  0,
-					ApiUrl:   "https://api.example.com/bot/v1",
+				Webhook: WebhookConfig{
+					Tokens: []WebhookTokenConfig{
+						{Name: "old", Token: "token1"},
+						{Name: "new", Token: "token1"},
+					},
 				},
 			},
-			expectedErr: nil,
+			expectedErr: errors.New(`webhook token "new": token value is duplicated`),
 		},
 		{
-			name: "Project_With_VKTeams_But_No_ChatID",
+			name: "Webhook_Token_Unknown_Project",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
@@ -1186,24 +4374,16 @@ func TestValidateConfig(t *testing.T) {
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
-				VKTeams: VKTeamsConfig{
-					BotToken: "token123",
-					ApiUrl:   "https://api.example.com/bot/v1",
-				},
-				Notifications: NotificationsConfig{
-					Youtrack: YoutrackConfig{
-						Projects: map[string]ProjectConfig{
-							"project1": {
-								AllowedChannels: []string{"vkteams"},
-							},
-						},
+				Webhook: WebhookConfig{
+					Tokens: []WebhookTokenConfig{
+						{Name: "team", Token: "token1", Projects: []string{"unknown"}},
 					},
 				},
 			},
-			expectedErr: errors.New("vkteams.chat_id is required"),
+			expectedErr: errors.New(`webhook token "team": project "unknown" is not configured`),
 		},
 		{
-			name: "Project_With_VKTeams_But_Empty_ChatID",
+			name: "Retry_Multiplier_Less_Than_One",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
@@ -1211,27 +4391,16 @@ func TestValidateConfig(t *testing.T) {
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
-				VKTeams: VKTeamsConfig{
-					BotToken: "token123",
-					ApiUrl:   "https://api.example.com/bot/v1",
-				},
-				Notifications: NotificationsConfig{
-					Youtrack: YoutrackConfig{
-						Projects: map[string]ProjectConfig{
-							"project1": {
-								AllowedChannels: []string{"vkteams"},
-								VKTeams: &ProjectVKTeamsConfig{
-									ChatID: "",
-								},
-							},
-						},
+				Delivery: DeliveryConfig{
+					Retry: RetryConfig{
+						RetryPolicyConfig: RetryPolicyConfig{Multiplier: 0.5},
 					},
 				},
 			},
-			expectedErr: errors.New("vkteams.chat_id cannot be empty"),
+			expectedErr: errors.New("delivery.retry: multiplier must be at least 1, got: 0.5"),
 		},
 		{
-			name: "Project_With_VKTeams_But_No_BotToken",
+			name: "Retry_Channel_Jitter_Greater_Than_One",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
@@ -1239,23 +4408,18 @@ func TestValidateConfig(t *testing.T) {
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
-				Notifications: NotificationsConfig{
-					Youtrack: YoutrackConfig{
-						Projects: map[string]ProjectConfig{
-							"project1": {
-								AllowedChannels: []string{"vkteams"},
-								VKTeams: &ProjectVKTeamsConfig{
-									ChatID: "chat123",
-								},
-							},
+				Delivery: DeliveryConfig{
+					Retry: RetryConfig{
+						Channels: map[string]RetryPolicyConfig{
+							"Telegram": {Jitter: float64Ptr(1.5)},
 						},
 					},
 				},
 			},
-			expectedErr: errors.New("VKTEAMS_BOT_TOKEN is required"),
+			expectedErr: errors.New("delivery.retry.channels.telegram: jitter must be between 0 and 1, got: 1.5"),
 		},
 		{
-			name: "Project_With_VKTeams_But_No_APIURL",
+			name: "Retry_Jitter_Negative",
 			config: &Config{
 				HTTP: HTTPConfig{
 					Addr:            ":8080",
@@ -1263,24 +4427,30 @@ func TestValidateConfig(t *testing.T) {
 					ReadTimeout:     5,
 					WriteTimeout:    5,
 				},
-				VKTeams: VKTeamsConfig{
-					BotToken: "token123",
-					ApiUrl:   "",
+				Delivery: DeliveryConfig{
+					Retry: RetryConfig{
+						RetryPolicyConfig: RetryPolicyConfig{Jitter: float64Ptr(-0.1)},
+					},
 				},
-				Notifications: NotificationsConfig{
-					Youtrack: YoutrackConfig{
-						Projects: map[string]ProjectConfig{
-							"project1": {
-								AllowedChannels: []string{"vkteams"},
-								VKTeams: &ProjectVKTeamsConfig{
-									ChatID: "chat123",
-								},
-							},
-						},
+			},
+			expectedErr: errors.New("delivery.retry: jitter must be between 0 and 1, got: -0.1"),
+		},
+		{
+			name: "Retry_MaxInterval_Less_Than_InitialInterval",
+			config: &Config{
+				HTTP: HTTPConfig{
+					Addr:            ":8080",
+					ShutdownTimeout: 5,
+					ReadTimeout:     5,
+					WriteTimeout:    5,
+				},
+				Delivery: DeliveryConfig{
+					Retry: RetryConfig{
+						RetryPolicyConfig: RetryPolicyConfig{InitialInterval: 10, MaxInterval: 5},
 					},
 				},
 			},
-			expectedErr: errors.New("VKTEAMS_API_URL is required"),
+			expectedErr: errors.New("delivery.retry: max_interval must not be less than initial_interval"),
 		},
 	}
 
@@ -1309,6 +4479,297 @@ func TestValidateConfig(t *testing.T) {
 	}
 }
 
+func TestValidateRetryConfig(t *testing.T) {
+	type testCase struct {
+		name           string
+		config         RetryConfig
+		expectedConfig RetryConfig
+	}
+
+	defaultPolicy := RetryPolicyConfig{
+		MaxAttempts:     5,
+		InitialInterval: 1,
+		MaxInterval:     30,
+		Multiplier:      2,
+		Jitter:          float64Ptr(0.2),
+		MaxAge:          300,
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Empty_Config_Gets_Defaults",
+			config:         RetryConfig{},
+			expectedConfig: RetryConfig{RetryPolicyConfig: defaultPolicy},
+		},
+		{
+			name: "Channel_Policy_Inherits_Unset_Fields",
+			config: RetryConfig{
+				RetryPolicyConfig: RetryPolicyConfig{MaxAttempts: 3, MaxAge: 60},
+				Channels: map[string]RetryPolicyConfig{
+					"VKTeams": {MaxAttempts: 10, InitialInterval: 5},
+				},
+			},
+			expectedConfig: RetryConfig{
+				RetryPolicyConfig: RetryPolicyConfig{
+					MaxAttempts:     3,
+					InitialInterval: 1,
+					MaxInterval:     30,
+					Multiplier:      2,
+					Jitter:          float64Ptr(0.2),
+					MaxAge:          60,
+				},
+				Channels: map[string]RetryPolicyConfig{
+					"vkteams": {
+						MaxAttempts:     10,
+						InitialInterval: 5,
+						MaxInterval:     30,
+						Multiplier:      2,
+						Jitter:          float64Ptr(0.2),
+						MaxAge:          60,
+					},
+				},
+			},
+		},
+		{
+			name: "Explicit_Zero_Jitter_Is_Kept",
+			config: RetryConfig{
+				RetryPolicyConfig: RetryPolicyConfig{Jitter: float64Ptr(0)},
+				Channels: map[string]RetryPolicyConfig{
+					"Telegram": {},
+					"Slack":    {Jitter: float64Ptr(0.5)},
+					"VKTeams":  {Jitter: float64Ptr(0)},
+				},
+			},
+			expectedConfig: RetryConfig{
+				RetryPolicyConfig: RetryPolicyConfig{
+					MaxAttempts:     5,
+					InitialInterval: 1,
+					MaxInterval:     30,
+					Multiplier:      2,
+					Jitter:          float64Ptr(0),
+					MaxAge:          300,
+				},
+				Channels: map[string]RetryPolicyConfig{
+					"telegram": {
+						MaxAttempts:     5,
+						InitialInterval: 1,
+						MaxInterval:     30,
+						Multiplier:      2,
+						Jitter:          float64Ptr(0),
+						MaxAge:          300,
+					},
+					"slack": {
+						MaxAttempts:     5,
+						InitialInterval: 1,
+						MaxInterval:     30,
+						Multiplier:      2,
+						Jitter:          float64Ptr(0.5),
+						MaxAge:          300,
+					},
+					"vkteams": {
+						MaxAttempts:     5,
+						InitialInterval: 1,
+						MaxInterval:     30,
+						Multiplier:      2,
+						Jitter:          float64Ptr(0),
+						MaxAge:          300,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.config
+
+			if err := validateRetryConfig(&cfg); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.expectedConfig, cfg); diff != "" {
+				t.Errorf("config mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadChannelCommonEnv(t *testing.T) {
+	type testCase struct {
+		name              string
+		env               map[string]string
+		expectedTimeout   int
+		expectedRateLimit RateLimitConfig
+		expectedErr       string
+	}
+
+	testCases := []testCase{
+		{
+			name:              "Unset_Variables_Keep_Values",
+			expectedTimeout:   10,
+			expectedRateLimit: RateLimitConfig{PerChat: 1, PerGroup: 20, Global: 30},
+		},
+		{
+			name: "Variables_Override_Values",
+			env: map[string]string{
+				"DISCORD_TIMEOUT":             "15",
+				"DISCORD_RATE_LIMIT_PER_CHAT": "2",
+				"DISCORD_RATE_LIMIT_GLOBAL":   "40",
+			},
+			expectedTimeout:   15,
+			expectedRateLimit: RateLimitConfig{PerChat: 2, PerGroup: 20, Global: 40},
+		},
+		{
+			name:        "Invalid_Timeout_Format",
+			env:         map[string]string{"DISCORD_TIMEOUT": "fast"},
+			expectedErr: "invalid DISCORD_TIMEOUT format: must be integer (seconds), got: fast",
+		},
+		{
+			name:        "Non_Positive_Timeout",
+			env:         map[string]string{"DISCORD_TIMEOUT": "0"},
+			expectedErr: "DISCORD_TIMEOUT must be positive, got: 0",
+		},
+		{
+			name:        "Invalid_Rate_Limit_Format",
+			env:         map[string]string{"DISCORD_RATE_LIMIT_PER_CHAT": "many"},
+			expectedErr: "invalid DISCORD_RATE_LIMIT_PER_CHAT format: must be integer, got: many",
+		},
+		{
+			name:        "Non_Positive_Rate_Limit",
+			env:         map[string]string{"DISCORD_RATE_LIMIT_GLOBAL": "-1"},
+			expectedErr: "DISCORD_RATE_LIMIT_GLOBAL must be positive, got: -1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			timeout := 10
+			rateLimit := RateLimitConfig{PerChat: 1, PerGroup: 20, Global: 30}
+			err := loadChannelCommonEnv("DISCORD", &timeout, &rateLimit)
+
+			if tc.expectedErr != "" {
+				if err == nil || err.Error() != tc.expectedErr {
+					t.Fatalf("expected error %q, got: %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if timeout != tc.expectedTimeout {
+				t.Errorf("expected timeout %d, got: %d", tc.expectedTimeout, timeout)
+			}
+			if diff := cmp.Diff(tc.expectedRateLimit, rateLimit); diff != "" {
+				t.Errorf("rate limit mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetCircuitBreakerDefaults(t *testing.T) {
+	type testCase struct {
+		name           string
+		config         CircuitBreakerConfig
+		expectedConfig CircuitBreakerConfig
+	}
+
+	testCases := []testCase{
+		{
+			name:   "Empty_Config_Gets_Defaults",
+			config: CircuitBreakerConfig{},
+			expectedConfig: CircuitBreakerConfig{
+				CircuitBreakerPolicyConfig: CircuitBreakerPolicyConfig{
+					FailureThreshold: 5,
+					OpenDuration:     30,
+					HalfOpenProbes:   1,
+				},
+			},
+		},
+		{
+			name: "Channel_Policy_Inherits_Unset_Fields",
+			config: CircuitBreakerConfig{
+				CircuitBreakerPolicyConfig: CircuitBreakerPolicyConfig{OpenDuration: 60},
+				Enabled:                    true,
+				Channels: map[string]CircuitBreakerPolicyConfig{
+					"VKTeams": {FailureThreshold: 2},
+				},
+			},
+			expectedConfig: CircuitBreakerConfig{
+				CircuitBreakerPolicyConfig: CircuitBreakerPolicyConfig{
+					FailureThreshold: 5,
+					OpenDuration:     60,
+					HalfOpenProbes:   1,
+				},
+				Enabled: true,
+				Channels: map[string]CircuitBreakerPolicyConfig{
+					"vkteams": {
+						FailureThreshold: 2,
+						OpenDuration:     60,
+						HalfOpenProbes:   1,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.config
+
+			setCircuitBreakerDefaults(&cfg)
+
+			if diff := cmp.Diff(tc.expectedConfig, cfg); diff != "" {
+				t.Errorf("config mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeOutgoingWebhookTargets(t *testing.T) {
+	type testCase struct {
+		name            string
+		targets         map[string]OutgoingWebhookTargetConfig
+		expectedTargets map[string]OutgoingWebhookTargetConfig
+	}
+
+	testCases := []testCase{
+		{
+			name: "Empty_Fields_Get_Defaults",
+			targets: map[string]OutgoingWebhookTargetConfig{
+				"deploy_bot": {URL: "https://deploy.example.com/hooks/youtrack"},
+			},
+			expectedTargets: map[string]OutgoingWebhookTargetConfig{
+				"deploy_bot": {URL: "https://deploy.example.com/hooks/youtrack", Method: "POST", SignatureHeader: DefaultSignatureHeader},
+			},
+		},
+		{
+			name: "Method_Is_Upper_Cased_And_Header_Kept",
+			targets: map[string]OutgoingWebhookTargetConfig{
+				"bi_collector": {URL: "http://bi.internal/events", Method: "patch", SignatureHeader: "X-Signature"},
+			},
+			expectedTargets: map[string]OutgoingWebhookTargetConfig{
+				"bi_collector": {URL: "http://bi.internal/events", Method: "PATCH", SignatureHeader: "X-Signature"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := normalizeOutgoingWebhookTargets(tc.targets); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.expectedTargets, tc.targets); diff != "" {
+				t.Errorf("targets mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestLoadFromYAML_FilepathAbsError(t *testing.T) {
 	cfg := &Config{}
 
@@ -1553,3 +5014,7 @@ func TestNormalizeProjectNames(t *testing.T) {
 		})
 	}
 }
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
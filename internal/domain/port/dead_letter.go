@@ -0,0 +1,60 @@
+package port
+
+import (
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"time"
+)
+
+// DeadLetter описывает уведомление, доставка которого адресату окончательно не удалась
+type DeadLetter struct {
+	ID         string                         `json:"id"`
+	EventID    string                         `json:"event_id"`
+	Key        string                         `json:"key"`
+	Project    string                         `json:"project"`
+	Payload    *parser.YoutrackWebhookPayload `json:"payload,omitempty"`
+	Target     NotificationTarget             `json:"target"`
+	LastError  string                         `json:"last_error"`
+	Attempts   int                            `json:"attempts"`
+	ReceivedAt time.Time                      `json:"received_at"`
+	FailedAt   time.Time                      `json:"failed_at"`
+}
+
+// DeadLetterStore определяет порт для хранилища недоставленных уведомлений
+type DeadLetterStore interface {
+	// Add сохраняет недоставленное уведомление
+	Add(letter DeadLetter) error
+	// List возвращает все недоставленные уведомления в порядке возникновения ошибки
+	List() ([]DeadLetter, error)
+	// Get возвращает недоставленное уведомление по идентификатору или ErrNotFound
+	Get(id string) (DeadLetter, error)
+	// Delete удаляет недоставленное уведомление или возвращает ErrNotFound
+	Delete(id string) error
+}
+
+// RequeueFailure описывает недоставленное уведомление, которое не удалось поставить в очередь повторно
+type RequeueFailure struct {
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
+
+// RequeueResult описывает результат повторной постановки недоставленных уведомлений в очередь
+type RequeueResult struct {
+	Requeued int              `json:"requeued"`
+	Failed   []RequeueFailure `json:"failed,omitempty"`
+}
+
+// DeadLetterService определяет порт для просмотра и повторной отправки недоставленных уведомлений
+type DeadLetterService interface {
+	// List возвращает все недоставленные уведомления
+	List() ([]DeadLetter, error)
+	// Get возвращает недоставленное уведомление по идентификатору
+	Get(id string) (DeadLetter, error)
+	// Requeue ставит уведомление в очередь доставки с адресатом из текущей конфигурации проекта
+	Requeue(id string) error
+	// RequeueAll ставит в очередь доставки все недоставленные уведомления
+	RequeueAll() (RequeueResult, error)
+	// Delete удаляет недоставленное уведомление
+	Delete(id string) error
+	// DeleteAll удаляет все недоставленные уведомления и возвращает их количество
+	DeleteAll() (int, error)
+}
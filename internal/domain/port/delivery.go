@@ -0,0 +1,91 @@
+package port
+
+import (
+	"context"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"time"
+)
+
+// eventIDContextKey ключ контекста для идентификатора доставляемого события
+type eventIDContextKey struct{}
+
+// NotificationTarget описывает адресата уведомления: канал и чат в нем
+type NotificationTarget struct {
+	Channel string `json:"channel"`
+	ChatID  string `json:"chat_id,omitempty"`
+}
+
+// NotificationEvent описывает принятое событие YouTrack, ожидающее доставки
+type NotificationEvent struct {
+	// ID уникально идентифицирует событие в журнале доставки
+	ID string `json:"id"`
+	// Key определяет порядок доставки: события с одинаковым ключом доставляются последовательно
+	Key        string                         `json:"key"`
+	Project    string                         `json:"project"`
+	Payload    *parser.YoutrackWebhookPayload `json:"payload"`
+	Targets    []NotificationTarget           `json:"targets"`
+	ReceivedAt time.Time                      `json:"received_at"`
+	// Merged содержит идентификаторы событий, объединенных с данным в одно уведомление
+	// Состояние доставки записывается в журнал и для них
+	Merged []string `json:"merged,omitempty"`
+}
+
+// NotificationDispatcher определяет порт для форматирования и отправки события во все каналы
+type NotificationDispatcher interface {
+	// Dispatch форматирует событие, отправляет его каждому адресату и возвращает результат отправки по адресатам
+	// Отмена контекста прерывает отправку, адресаты, которым уведомление не отправлено, считаются недоставленными
+	Dispatch(ctx context.Context, event NotificationEvent) []DeliveryResult
+	// Fail завершает доставку события адресатам ошибкой err, когда отложенную доставку больше не повторяют
+	// Состояние доставки записывается в журнал, уведомления сохраняются как недоставленные
+	Fail(event NotificationEvent, err error)
+}
+
+// ContextWithEventID сохраняет в контексте идентификатор доставляемого события
+// Каналы используют его, чтобы повторная отправка того же события не создавала дубликат сообщения
+func ContextWithEventID(ctx context.Context, eventID string) context.Context {
+	return context.WithValue(ctx, eventIDContextKey{}, eventID)
+}
+
+// EventIDFromContext возвращает идентификатор доставляемого события, сохраненный в контексте, или пустую строку
+func EventIDFromContext(ctx context.Context) string {
+	eventID, _ := ctx.Value(eventIDContextKey{}).(string)
+	return eventID
+}
+
+// DeliveryQueue определяет порт для очереди асинхронной доставки уведомлений
+type DeliveryQueue interface {
+	// Enqueue ставит событие в очередь доставки
+	// Возвращает UnavailableError, если очередь заполнена или остановлена
+	Enqueue(event NotificationEvent) error
+	// Start запускает обработчики очереди
+	Start()
+	// Stop прекращает прием событий и ожидает доставки уже принятых до истечения контекста
+	Stop(ctx context.Context) error
+}
+
+// DeliveryState описывает итоговое состояние доставки события адресату
+type DeliveryState string
+
+const (
+	// DeliveryStateDelivered уведомление доставлено адресату
+	DeliveryStateDelivered DeliveryState = "delivered"
+	// DeliveryStateFailed доставка адресату завершилась ошибкой
+	DeliveryStateFailed DeliveryState = "failed"
+)
+
+// Outbox определяет порт для журнала принятых событий и состояния их доставки
+// Журнал позволяет повторить доставку событий, не завершенных до перезапуска сервиса
+type Outbox interface {
+	// Append записывает принятое событие до постановки в очередь доставки
+	Append(event NotificationEvent) error
+	// MarkTarget записывает итоговое состояние доставки события адресату
+	MarkTarget(eventID string, target NotificationTarget, state DeliveryState) error
+	// Remove исключает событие из журнала, например если его не удалось поставить в очередь
+	Remove(eventID string) error
+	// Pending возвращает события с адресатами, доставка которым не завершена, в порядке приема
+	Pending() []NotificationEvent
+	// Compact перезаписывает журнал, оставляя только незавершенные события
+	Compact() error
+	// Close закрывает журнал
+	Close() error
+}
@@ -0,0 +1,132 @@
+package port
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrBadRequest возвращается, если тело webhook запроса не удалось прочитать
+	ErrBadRequest = errors.New("bad request")
+	// ErrUnauthorized возвращается, если подлинность webhook запроса не подтверждена
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden возвращается, если источнику запроса не разрешено отправлять события для проекта
+	ErrForbidden = errors.New("forbidden")
+	// ErrReplayDetected возвращается для запросов вне допустимого окна времени или уже обработанных ранее
+	ErrReplayDetected = errors.New("replay detected")
+	// ErrPayloadTooLarge возвращается, если тело запроса превышает допустимый размер
+	ErrPayloadTooLarge = errors.New("payload too large")
+	// ErrUnsupportedMediaType возвращается, если тип содержимого запроса не поддерживается
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	// ErrInvalidPayload возвращается, если тело запроса не является корректным payload YouTrack
+	ErrInvalidPayload = errors.New("invalid payload")
+	// ErrUnavailable возвращается, если сервис временно не может принять запрос
+	ErrUnavailable = errors.New("service unavailable")
+	// ErrNotFound возвращается, если запрошенный объект не найден
+	ErrNotFound = errors.New("not found")
+	// ErrConflict возвращается, если операция невозможна при текущем состоянии или конфигурации
+	ErrConflict = errors.New("conflict")
+	// ErrCircuitOpen возвращается без обращения к API канала, пока автоматический выключатель канала разомкнут
+	ErrCircuitOpen = errors.New("circuit breaker is open")
+)
+
+// UnavailableError сообщает о временной недоступности сервиса и рекомендуемой задержке повторного запроса
+type UnavailableError struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+// Error возвращает текстовое описание ошибки
+func (e *UnavailableError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrUnavailable, e.Reason)
+}
+
+// Unwrap позволяет сравнивать ошибку с ErrUnavailable через errors.Is
+func (e *UnavailableError) Unwrap() error {
+	return ErrUnavailable
+}
+
+// AttemptsError сообщает, что отправка не удалась после нескольких попыток
+type AttemptsError struct {
+	Attempts int
+	Err      error
+}
+
+// Error возвращает текстовое описание ошибки
+func (e *AttemptsError) Error() string {
+	return fmt.Sprintf("giving up after %d attempts: %s", e.Attempts, e.Err)
+}
+
+// Unwrap возвращает ошибку последней попытки
+func (e *AttemptsError) Unwrap() error {
+	return e.Err
+}
+
+// Attempts возвращает количество попыток отправки, после которых возникла ошибка
+func Attempts(err error) int {
+	var attemptsErr *AttemptsError
+	if errors.As(err, &attemptsErr) {
+		return attemptsErr.Attempts
+	}
+	return 1
+}
+
+// DeliveryError описывает ошибку отправки уведомления во внешний канал
+// Retryable отделяет временные сбои (сеть, 5xx, 429), после которых отправку можно повторить,
+// от постоянных (неверный чат, бот удален из чата), при которых повтор бессмысленен
+type DeliveryError struct {
+	Channel    string
+	StatusCode int           // HTTP статус ответа API (для email - код ответа SMTP сервера), 0 - ответ не получен
+	Retryable  bool          // Отправку можно повторить
+	RetryAfter time.Duration // Задержка перед повтором, запрошенная API канала
+	Err        error
+}
+
+// NewDeliveryError создает ошибку доставки и классифицирует ее по HTTP статусу ответа
+// statusCode равный 0 означает, что ответ не получен (сетевая ошибка) - такая ошибка считается временной
+func NewDeliveryError(channel string, statusCode int, err error) *DeliveryError {
+	return &DeliveryError{
+		Channel:    channel,
+		StatusCode: statusCode,
+		Retryable:  statusCode == 0 || IsRetryableStatus(statusCode),
+		Err:        err,
+	}
+}
+
+// Error возвращает текстовое описание ошибки
+func (e *DeliveryError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap возвращает исходную ошибку
+func (e *DeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetryableStatus определяет, является ли HTTP статус ответа признаком временного сбоя
+func IsRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusRequestTimeout ||
+		statusCode == http.StatusTooManyRequests ||
+		statusCode >= http.StatusInternalServerError
+}
+
+// IsRetryable определяет, можно ли повторить отправку после ошибки
+// Ошибки, не являющиеся DeliveryError (например, ошибки конфигурации канала), считаются постоянными
+func IsRetryable(err error) bool {
+	var deliveryErr *DeliveryError
+	if errors.As(err, &deliveryErr) {
+		return deliveryErr.Retryable
+	}
+	return false
+}
+
+// RetryAfter возвращает задержку перед повтором, запрошенную API канала, или 0
+func RetryAfter(err error) time.Duration {
+	var deliveryErr *DeliveryError
+	if errors.As(err, &deliveryErr) {
+		return deliveryErr.RetryAfter
+	}
+	return 0
+}
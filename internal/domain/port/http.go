@@ -1,6 +1,8 @@
 package port
 
 // HTTPServer определяет порт для HTTP сервера
+// Start начинает прием запросов, Stop завершает обработку начатых запросов и останавливает сервер
 type HTTPServer interface {
-	Start() error
+	Component
+	FailureReporter
 }
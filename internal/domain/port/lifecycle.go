@@ -0,0 +1,17 @@
+package port
+
+import "context"
+
+// Component определяет порт для компонента приложения, запускаемого и останавливаемого менеджером жизненного цикла
+type Component interface {
+	// Start запускает компонент без блокировки, ошибка прерывает запуск приложения
+	Start(ctx context.Context) error
+	// Stop останавливает компонент, ожидая завершения начатой работы до истечения контекста
+	Stop(ctx context.Context) error
+}
+
+// FailureReporter определяет порт для компонента, который может аварийно завершить работу после запуска
+type FailureReporter interface {
+	// Failed возвращает канал, в который передается ошибка аварийного завершения компонента
+	Failed() <-chan error
+}
@@ -0,0 +1,15 @@
+package port
+
+// Названия счетчиков обработки webhook запросов
+const (
+	// MetricWebhookAccepted количество событий, поставленных в очередь доставки
+	MetricWebhookAccepted = "webhook_events_accepted"
+	// MetricWebhookDuplicate количество событий, отброшенных как повторно полученные
+	MetricWebhookDuplicate = "webhook_events_duplicate"
+)
+
+// Metrics определяет порт для учета счетчиков работы сервиса
+type Metrics interface {
+	// Inc увеличивает счетчик с указанным названием на единицу
+	Inc(name string)
+}
@@ -1,5 +1,11 @@
 package port
 
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
 const (
 	// ChannelLogger название канала логирования
 	ChannelLogger = "logger"
@@ -7,12 +13,133 @@ const (
 	ChannelTelegram = "telegram"
 	// ChannelVKTeams название канала VK Teams
 	ChannelVKTeams = "vkteams"
+	// ChannelSlack название канала Slack
+	ChannelSlack = "slack"
+	// ChannelMattermost название канала Mattermost
+	ChannelMattermost = "mattermost"
+	// ChannelMSTeams название канала Microsoft Teams
+	ChannelMSTeams = "msteams"
+	// ChannelDiscord название канала Discord
+	ChannelDiscord = "discord"
+	// ChannelEmail название канала email (SMTP)
+	ChannelEmail = "email"
+	// ChannelWebhook название канала исходящих HTTP webhook
+	ChannelWebhook = "webhook"
+	// ChannelMatrix название канала Matrix
+	ChannelMatrix = "matrix"
+	// ChannelZulip название канала Zulip
+	ChannelZulip = "zulip"
+	// ChannelNtfy название канала push уведомлений ntfy
+	ChannelNtfy = "ntfy"
+	// ChannelGotify название канала push уведомлений Gotify
+	ChannelGotify = "gotify"
+	// ChannelFile название канала архива уведомлений в файле JSON Lines
+	ChannelFile = "file"
+)
+
+// MSTeamsMaxPayloadSize максимальный размер сообщения в байтах, который принимает webhook Microsoft Teams
+const MSTeamsMaxPayloadSize = 28 * 1024
+
+// DiscordMaxContentLength максимальная длина текста сообщения Discord в символах
+const DiscordMaxContentLength = 2000
+
+// ZulipMaxTopicLength максимальная длина темы сообщения Zulip в символах
+const ZulipMaxTopicLength = 60
+
+// ZulipMaxContentLength максимальная длина текста сообщения Zulip в символах
+const ZulipMaxContentLength = 10000
+
+// ZulipMessage описывает сообщение Zulip, подготовленное форматированием Zulip канала
+// Передается в канал как JSON в formattedMessage, поток (stream) канал получает из адресата
+type ZulipMessage struct {
+	// Topic тема сообщения в потоке: уведомления с одинаковой темой собираются в одну ветку
+	Topic   string `json:"topic"`
+	Content string `json:"content"`
+}
+
+// Приоритеты push уведомления по шкале ntfy, каналы с другой шкалой приоритетов пересчитывают значение
+const (
+	// PushPriorityMin - уведомление без звука и вибрации
+	PushPriorityMin = 1
+	// PushPriorityLow - уведомление без звука
+	PushPriorityLow = 2
+	// PushPriorityDefault - обычное уведомление
+	PushPriorityDefault = 3
+	// PushPriorityHigh - уведомление с длительной вибрацией
+	PushPriorityHigh = 4
+	// PushPriorityMax - срочное уведомление, которое показывается поверх других приложений
+	PushPriorityMax = 5
+)
+
+// PushMaxMessageLength максимальная длина текста push уведомления в символах
+// ntfy принимает текст до 4096 байт, более длинный текст превращается во вложение
+const PushMaxMessageLength = 2000
+
+// PushMessage описывает push уведомление, подготовленное форматированием push каналов (ntfy, Gotify)
+// Передается в канал как JSON в formattedMessage, топик или токен приложения канал получает из адресата
+type PushMessage struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+	// Priority приоритет уведомления от PushPriorityMin до PushPriorityMax
+	Priority int `json:"priority"`
+	// Tags метки уведомления, метки с названием emoji ntfy показывает значком
+	Tags []string `json:"tags,omitempty"`
+	// Click ссылка, которая открывается при нажатии на уведомление
+	Click string `json:"click,omitempty"`
+}
+
+// FileMessage описывает запись архива, подготовленную форматированием file канала
+// Передается в канал как JSON в formattedMessage, время записи, канал и адресата канал добавляет при записи
+type FileMessage struct {
+	Project string `json:"project"`
+	Issue   string `json:"issue,omitempty"`
+	// Text текст уведомления в форматировании по умолчанию
+	Text string `json:"text"`
+	// Payload исходный payload YouTrack без изменений
+	Payload json.RawMessage `json:"payload"`
+}
+
+// EmailMessage описывает письмо, подготовленное форматированием email канала
+// Передается в канал как JSON в formattedMessage
+type EmailMessage struct {
+	Subject string `json:"subject"`
+	// Thread идентифицирует задачу: письма с одинаковым Thread объединяются почтовым клиентом в цепочку
+	Thread string `json:"thread,omitempty"`
+	Text   string `json:"text"`
+	HTML   string `json:"html,omitempty"`
+}
+
+// CircuitState описывает состояние автоматического выключателя канала
+type CircuitState string
+
+const (
+	// CircuitClosed - отправка через канал выполняется в обычном режиме
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen - обращения к API канала приостановлены после серии временных ошибок
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen - через канал выполняются пробные отправки для проверки восстановления API
+	CircuitHalfOpen CircuitState = "half_open"
 )
 
+// ChannelHealth описывает состояние канала отправки уведомлений
+type ChannelHealth struct {
+	Channel             string       `json:"channel"`
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	OpenedAt            *time.Time   `json:"opened_at,omitempty"`
+}
+
+// HealthReporter определяет порт для получения состояния каналов отправки уведомлений
+type HealthReporter interface {
+	// ChannelHealth возвращает состояние каналов, отсортированное по названию канала
+	ChannelHealth() []ChannelHealth
+}
+
 // NotificationChannel определяет порт для отправки уведомлений через конкретный канал
 type NotificationChannel interface {
 	// Send отправляет уже отформатированное уведомление через данный канал
-	Send(chatID string, formattedMessage string) error
+	// Отмена или истечение контекста прерывает ожидание лимита и запрос к API канала
+	Send(ctx context.Context, chatID string, formattedMessage string) error
 	// Channel возвращает название канала
 	Channel() string
 }
@@ -20,7 +147,10 @@ type NotificationChannel interface {
 // NotificationSender определяет порт для отправки уведомлений через различные каналы
 type NotificationSender interface {
 	// Send отправляет уведомление через указанный канал
-	Send(channel string, chatID string, formattedMessage string) error
+	// Повторные попытки прекращаются при отмене контекста или если следующая попытка не успеет до его истечения
+	Send(ctx context.Context, channel string, chatID string, formattedMessage string) error
 	// RegisterChannel регистрирует новый канал для отправки уведомлений
 	RegisterChannel(channel NotificationChannel)
+	// HealthReporter возвращает состояние автоматических выключателей зарегистрированных каналов
+	HealthReporter
 }
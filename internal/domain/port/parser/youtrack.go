@@ -38,12 +38,13 @@ type YoutrackChange struct {
 
 // YoutrackIssue представляет задачу YouTrack
 type YoutrackIssue struct {
-	IsDraft  bool                `json:"isDraft"`
-	Summary  string              `json:"summary"`
-	URL      string              `json:"url"`
-	State    *YoutrackFieldValue `json:"state"`
-	Priority *YoutrackFieldValue `json:"priority"`
-	Assignee *YoutrackUser       `json:"assignee"`
+	IDReadable string              `json:"idReadable"`
+	IsDraft    bool                `json:"isDraft"`
+	Summary    string              `json:"summary"`
+	URL        string              `json:"url"`
+	State      *YoutrackFieldValue `json:"state"`
+	Priority   *YoutrackFieldValue `json:"priority"`
+	Assignee   *YoutrackUser       `json:"assignee"`
 }
 
 // YoutrackParser определяет порт для парсинга данных YouTrack webhook
@@ -61,6 +62,37 @@ type YoutrackParser interface {
 	// GetVKTeamsChatID возвращает chat_id для VK Teams канала проекта
 	// Возвращает chat_id и true, если проект разрешен и имеет VK Teams конфигурацию, иначе пустую строку и false
 	GetVKTeamsChatID(projectName string) (string, bool)
+	// GetSlackChatID возвращает адресата Slack канала проекта: URL incoming webhook или канал для chat.postMessage
+	// Возвращает адресата и true, если проект разрешен и имеет Slack конфигурацию, иначе пустую строку и false
+	GetSlackChatID(projectName string) (string, bool)
+	// GetMattermostChatID возвращает адресата Mattermost канала проекта: URL incoming webhook или ID канала для REST API
+	// Возвращает адресата и true, если проект разрешен и имеет Mattermost конфигурацию, иначе пустую строку и false
+	GetMattermostChatID(projectName string) (string, bool)
+	// GetMSTeamsChatID возвращает адресата Microsoft Teams канала проекта: URL incoming webhook или workflow
+	// Возвращает адресата и true, если проект разрешен и имеет Microsoft Teams конфигурацию, иначе пустую строку и false
+	GetMSTeamsChatID(projectName string) (string, bool)
+	// GetDiscordChatID возвращает адресата Discord канала проекта: URL webhook канала
+	// Возвращает адресата и true, если проект разрешен и имеет Discord конфигурацию, иначе пустую строку и false
+	GetDiscordChatID(projectName string) (string, bool)
+	// GetEmailChatID возвращает адресатов email канала проекта: адреса получателей через запятую
+	// Если в проекте включена отправка исполнителю задачи, к получателям добавляется email исполнителя assignee
+	// Возвращает адресатов и true, если проект разрешен и для письма есть хотя бы один получатель, иначе пустую строку и false
+	GetEmailChatID(projectName string, assignee *YoutrackUser) (string, bool)
+	// GetWebhookTargets возвращает имена целей webhook канала проекта из outgoing_webhook.targets
+	// Возвращает имена целей и true, если проект разрешен и имеет webhook конфигурацию, иначе nil и false
+	GetWebhookTargets(projectName string) ([]string, bool)
+	// GetMatrixChatID возвращает адресата Matrix канала проекта: ID комнаты
+	// Возвращает адресата и true, если проект разрешен и имеет Matrix конфигурацию, иначе пустую строку и false
+	GetMatrixChatID(projectName string) (string, bool)
+	// GetZulipChatID возвращает адресата Zulip канала проекта: имя потока (stream)
+	// Возвращает адресата и true, если проект разрешен и имеет Zulip конфигурацию, иначе пустую строку и false
+	GetZulipChatID(projectName string) (string, bool)
+	// GetNtfyChatID возвращает адресата ntfy канала проекта: топик
+	// Возвращает адресата и true, если проект разрешен и имеет ntfy конфигурацию, иначе пустую строку и false
+	GetNtfyChatID(projectName string) (string, bool)
+	// GetGotifyChatID возвращает адресата Gotify канала проекта: токен приложения
+	// Возвращает адресата и true, если проект разрешен и имеет Gotify конфигурацию, иначе пустую строку и false
+	GetGotifyChatID(projectName string) (string, bool)
 	// GetSendDraftNotification возвращает настройку отправки уведомлений для черновиков проекта
 	// Возвращает true по умолчанию, если настройка не указана
 	GetSendDraftNotification(projectName string) bool
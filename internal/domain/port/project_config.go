@@ -1,6 +1,9 @@
 package port
 
-import "github.com/beliaev-aa/notifications/internal/config"
+import (
+	"github.com/beliaev-aa/notifications/internal/config"
+	"time"
+)
 
 // ProjectConfigService определяет порт для работы с конфигурацией проекта
 type ProjectConfigService interface {
@@ -14,6 +17,29 @@ type ProjectConfigService interface {
 	GetTelegramChatID(projectName string) (string, bool)
 	// GetVKTeamsChatID получение chat_id для VK Teams канала проекта
 	GetVKTeamsChatID(projectName string) (string, bool)
+	// GetSlackChatID получение адресата Slack канала проекта: URL incoming webhook или канал для chat.postMessage
+	GetSlackChatID(projectName string) (string, bool)
+	// GetMattermostChatID получение адресата Mattermost канала проекта: URL incoming webhook или ID канала для REST API
+	GetMattermostChatID(projectName string) (string, bool)
+	// GetMSTeamsChatID получение адресата Microsoft Teams канала проекта: URL incoming webhook или workflow
+	GetMSTeamsChatID(projectName string) (string, bool)
+	// GetDiscordChatID получение адресата Discord канала проекта: URL webhook канала
+	GetDiscordChatID(projectName string) (string, bool)
+	// GetEmailChatID получение адресатов email канала проекта: адреса получателей через запятую
+	// assigneeEmail добавляется к получателям, если в проекте включена отправка исполнителю задачи
+	GetEmailChatID(projectName string, assigneeEmail string) (string, bool)
+	// GetWebhookTargets получение имен целей webhook канала проекта, каждая цель - отдельный адресат
+	GetWebhookTargets(projectName string) ([]string, bool)
+	// GetMatrixChatID получение адресата Matrix канала проекта: ID комнаты
+	GetMatrixChatID(projectName string) (string, bool)
+	// GetZulipChatID получение адресата Zulip канала проекта: имя потока (stream)
+	GetZulipChatID(projectName string) (string, bool)
+	// GetNtfyChatID получение адресата ntfy канала проекта: топик
+	GetNtfyChatID(projectName string) (string, bool)
+	// GetGotifyChatID получение адресата Gotify канала проекта: токен приложения
+	GetGotifyChatID(projectName string) (string, bool)
 	// GetSendDraftNotification получение настройки отправки уведомлений для черновиков
 	GetSendDraftNotification(projectName string) bool
+	// GetCoalesceWindow получение окна объединения изменений одной задачи, 0 - без объединения
+	GetCoalesceWindow(projectName string) time.Duration
 }
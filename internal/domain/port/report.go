@@ -0,0 +1,97 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// DeliveryStatus описывает результат обработки адресата уведомления в отчете о доставке
+type DeliveryStatus string
+
+const (
+	// DeliveryStatusSent уведомление отправлено адресату в рамках запроса
+	DeliveryStatusSent DeliveryStatus = "sent"
+	// DeliveryStatusQueued уведомление поставлено в очередь асинхронной доставки
+	DeliveryStatusQueued DeliveryStatus = "queued"
+	// DeliveryStatusSkipped уведомление адресату не отправлялось, причина указана в Reason
+	DeliveryStatusSkipped DeliveryStatus = "skipped"
+	// DeliveryStatusFailed отправка адресату завершилась ошибкой, класс ошибки указан в ErrorClass
+	DeliveryStatusFailed DeliveryStatus = "failed"
+	// DeliveryStatusDeferred отправка адресату отложена без обращения к API канала, так как выключатель канала разомкнут
+	// Очередь доставки повторяет ее через RetryAfter, доставка адресату при этом не считается завершенной
+	DeliveryStatusDeferred DeliveryStatus = "deferred"
+)
+
+const (
+	// SkipReasonDraft отправка уведомлений для черновиков отключена в настройках проекта
+	SkipReasonDraft = "draft"
+	// SkipReasonMissingChatID для канала не настроен chat_id проекта
+	SkipReasonMissingChatID = "missing_chat_id"
+	// SkipReasonDuplicate событие уже было получено ранее
+	SkipReasonDuplicate = "duplicate"
+)
+
+const (
+	// ErrorClassTimeout истекло время доставки события
+	ErrorClassTimeout = "timeout"
+	// ErrorClassCanceled доставка прервана, например при остановке сервиса
+	ErrorClassCanceled = "canceled"
+	// ErrorClassCircuitOpen автоматический выключатель канала разомкнут
+	ErrorClassCircuitOpen = "circuit_open"
+	// ErrorClassRateLimited API канала ограничило частоту отправки
+	ErrorClassRateLimited = "rate_limited"
+	// ErrorClassTransient временный сбой API канала или сети
+	ErrorClassTransient = "transient"
+	// ErrorClassPermanent постоянная ошибка, повтор отправки бессмыслен (например, неверный чат)
+	ErrorClassPermanent = "permanent"
+)
+
+// DeliveryResult описывает результат обработки одного адресата уведомления
+type DeliveryResult struct {
+	Channel string `json:"channel"`
+	// ChatID адресат в канале, в ответ webhook не попадает: для части каналов это секрет
+	// (URL webhook Slack, Discord, Microsoft Teams, токен Gotify, адреса получателей писем)
+	ChatID     string         `json:"-"`
+	Status     DeliveryStatus `json:"status"`
+	Reason     string         `json:"reason,omitempty"`
+	ErrorClass string         `json:"error_class,omitempty"`
+	// RetryAfter задержка повторной отправки отложенному адресату
+	RetryAfter time.Duration `json:"-"`
+}
+
+// DeliveryReport описывает результат обработки webhook запроса для каждого адресата
+type DeliveryReport struct {
+	Deliveries []DeliveryResult `json:"deliveries"`
+}
+
+// Count возвращает количество адресатов с указанным результатом
+func (r DeliveryReport) Count(status DeliveryStatus) int {
+	count := 0
+	for _, delivery := range r.Deliveries {
+		if delivery.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// ErrorClass классифицирует ошибку отправки для отчета о доставке
+func ErrorClass(err error) string {
+	var deliveryErr *DeliveryError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassTimeout
+	case errors.Is(err, context.Canceled):
+		return ErrorClassCanceled
+	case errors.Is(err, ErrCircuitOpen):
+		return ErrorClassCircuitOpen
+	case errors.As(err, &deliveryErr) && deliveryErr.StatusCode == http.StatusTooManyRequests:
+		return ErrorClassRateLimited
+	case IsRetryable(err):
+		return ErrorClassTransient
+	default:
+		return ErrorClassPermanent
+	}
+}
@@ -1,10 +1,34 @@
 package port
 
 import (
+	"context"
 	"net/http"
 )
 
+// webhookTokenContextKey ключ контекста для токена источника webhook запроса
+type webhookTokenContextKey struct{}
+
 // WebhookService определяет порт для обработки webhook запросов
 type WebhookService interface {
-	ProcessWebhook(req *http.Request) error
+	// ProcessWebhook проверяет запрос и ставит событие в очередь доставки
+	// Возвращает отчет с результатом для каждого адресата: поставлено в очередь, отправлено, пропущено или не доставлено
+	// Контекст запроса ограничивает обработку, в том числе синхронную доставку без очереди
+	ProcessWebhook(ctx context.Context, req *http.Request) (DeliveryReport, error)
+}
+
+// WebhookVerifier определяет порт для проверки подлинности webhook запросов
+type WebhookVerifier interface {
+	// Verify проверяет запрос по заголовкам и сырому телу до разбора payload
+	Verify(req *http.Request, body []byte) error
+}
+
+// ContextWithWebhookToken сохраняет в контексте токен источника, переданный в пути запроса
+func ContextWithWebhookToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, webhookTokenContextKey{}, token)
+}
+
+// WebhookTokenFromContext возвращает токен источника, сохраненный в контексте, или пустую строку
+func WebhookTokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(webhookTokenContextKey{}).(string)
+	return token
 }
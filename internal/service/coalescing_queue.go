@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+// afterFunc используется для тестирования - позволяет вызывать отложенную передачу события без ожидания
+var afterFunc = time.AfterFunc
+
+// pendingEvent событие, ожидающее окончания окна объединения
+type pendingEvent struct {
+	event port.NotificationEvent
+	timer *time.Timer
+}
+
+// CoalescingQueue объединяет быстро следующие друг за другом изменения одной задачи в одно уведомление
+// Первое событие задачи задерживается на окно объединения проекта, события той же задачи, полученные в течение окна,
+// объединяются с ним: для каждого поля сохраняются первое старое и последнее новое значение
+// События проектов с нулевым окном передаются в очередь доставки без задержки
+type CoalescingQueue struct {
+	queue         port.DeliveryQueue
+	projectConfig port.ProjectConfigService
+	logger        *logrus.Logger
+
+	mu      sync.Mutex
+	pending map[string]*pendingEvent
+	closed  bool
+}
+
+// NewCoalescingQueue создает очередь доставки с объединением изменений одной задачи
+func NewCoalescingQueue(queue port.DeliveryQueue, projectConfig port.ProjectConfigService, logger *logrus.Logger) port.DeliveryQueue {
+	return &CoalescingQueue{
+		queue:         queue,
+		projectConfig: projectConfig,
+		logger:        logger,
+		pending:       make(map[string]*pendingEvent),
+	}
+}
+
+// Enqueue объединяет событие с ожидающим событием той же задачи или откладывает его на окно объединения проекта
+func (q *CoalescingQueue) Enqueue(event port.NotificationEvent) error {
+	window := q.projectConfig.GetCoalesceWindow(event.Project)
+
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return q.queue.Enqueue(event)
+	}
+
+	if pending, exists := q.pending[event.Key]; exists {
+		pending.event = mergeEvents(pending.event, event)
+		q.mu.Unlock()
+
+		q.logger.WithFields(logrus.Fields{
+			"project": event.Project,
+			"issue":   event.Key,
+			"merged":  len(pending.event.Merged) + 1,
+		}).Debug("Notification event coalesced")
+		return nil
+	}
+
+	if window <= 0 {
+		q.mu.Unlock()
+		return q.queue.Enqueue(event)
+	}
+
+	q.hold(event, window)
+	q.mu.Unlock()
+
+	return nil
+}
+
+// Start запускает очередь доставки
+func (q *CoalescingQueue) Start() {
+	q.queue.Start()
+}
+
+// Stop передает ожидающие события в очередь доставки без ожидания окна и останавливает ее
+func (q *CoalescingQueue) Stop(ctx context.Context) error {
+	q.mu.Lock()
+	q.closed = true
+	var errs []error
+	for key, p := range q.pending {
+		p.timer.Stop()
+		delete(q.pending, key)
+		if err := q.queue.Enqueue(p.event); err != nil {
+			q.logger.WithError(err).WithFields(logrus.Fields{
+				"project": p.event.Project,
+				"issue":   p.event.Key,
+			}).Error("Failed to enqueue coalesced event on shutdown")
+			errs = append(errs, err)
+		}
+	}
+	q.mu.Unlock()
+
+	return errors.Join(append(errs, q.queue.Stop(ctx))...)
+}
+
+// hold откладывает событие на время delay, вызывается под блокировкой
+func (q *CoalescingQueue) hold(event port.NotificationEvent, delay time.Duration) {
+	p := &pendingEvent{event: event}
+	q.schedule(event.Key, p, delay)
+	q.pending[event.Key] = p
+}
+
+// schedule запускает таймер передачи ожидающего события, вызывается под блокировкой
+func (q *CoalescingQueue) schedule(key string, p *pendingEvent, delay time.Duration) {
+	p.timer = afterFunc(delay, func() {
+		q.flush(key, p)
+	})
+}
+
+// flush передает объединенное событие в очередь доставки по окончании окна
+// Очередь доставки не блокирует постановку, поэтому событие передается под блокировкой:
+// события той же задачи, полученные в это время, не обгонят его
+// Если очередь заполнена, событие остается ожидающим и передается повторно после рекомендуемой задержки
+func (q *CoalescingQueue) flush(key string, p *pendingEvent) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.pending[key] != p {
+		// Событие уже передано при остановке очереди
+		return
+	}
+
+	err := q.queue.Enqueue(p.event)
+	if err == nil {
+		delete(q.pending, key)
+		return
+	}
+
+	delay := defaultReplayRetryDelay
+	var unavailableErr *port.UnavailableError
+	if errors.As(err, &unavailableErr) && unavailableErr.RetryAfter > 0 {
+		delay = unavailableErr.RetryAfter
+	}
+
+	q.logger.WithError(err).WithFields(logrus.Fields{
+		"project": p.event.Project,
+		"issue":   p.event.Key,
+		"delay":   delay.String(),
+	}).Warn("Failed to enqueue coalesced event, retrying")
+
+	q.schedule(key, p, delay)
+}
+
+// mergeEvents объединяет ожидающее событие со следующим событием той же задачи
+// Состояние задачи и автор берутся из следующего события, изменения объединяются по полям,
+// адресаты объединяются, а идентификатор и время приема сохраняются от первого события
+func mergeEvents(first port.NotificationEvent, next port.NotificationEvent) port.NotificationEvent {
+	merged := first
+	merged.Merged = append(append(append([]string(nil), first.Merged...), next.ID), next.Merged...)
+	merged.Targets = mergeTargets(first.Targets, next.Targets)
+
+	if next.Payload != nil {
+		payload := *next.Payload
+		if first.Payload != nil {
+			payload.Changes = mergeChanges(first.Payload.Changes, next.Payload.Changes)
+		}
+		merged.Payload = &payload
+	}
+
+	return merged
+}
+
+// mergeChanges объединяет изменения по полям: сохраняется первое старое и последнее новое значение поля
+// Поля перечисляются в порядке первого изменения
+func mergeChanges(first []parser.YoutrackChange, next []parser.YoutrackChange) []parser.YoutrackChange {
+	merged := make([]parser.YoutrackChange, 0, len(first)+len(next))
+	positions := make(map[string]int, len(first)+len(next))
+
+	for _, change := range append(append([]parser.YoutrackChange(nil), first...), next...) {
+		if i, exists := positions[change.Field]; exists {
+			merged[i].NewValue = change.NewValue
+			continue
+		}
+		positions[change.Field] = len(merged)
+		merged = append(merged, change)
+	}
+
+	return merged
+}
+
+// mergeTargets объединяет адресатов событий без повторов
+func mergeTargets(first []port.NotificationTarget, next []port.NotificationTarget) []port.NotificationTarget {
+	merged := make([]port.NotificationTarget, 0, len(first)+len(next))
+	seen := make(map[port.NotificationTarget]bool, len(first)+len(next))
+
+	for _, target := range append(append([]port.NotificationTarget(nil), first...), next...) {
+		if seen[target] {
+			continue
+		}
+		seen[target] = true
+		merged = append(merged, target)
+	}
+
+	return merged
+}
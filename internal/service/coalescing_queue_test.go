@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"testing"
+	"time"
+)
+
+// fakeTimers подменяет afterFunc и позволяет вызывать отложенные функции вручную
+type fakeTimers struct {
+	delays    []time.Duration
+	callbacks []func()
+}
+
+// install подменяет afterFunc и возвращает функцию восстановления
+func (f *fakeTimers) install() func() {
+	original := afterFunc
+	afterFunc = func(d time.Duration, callback func()) *time.Timer {
+		f.delays = append(f.delays, d)
+		f.callbacks = append(f.callbacks, callback)
+		return time.NewTimer(time.Hour)
+	}
+	return func() {
+		afterFunc = original
+	}
+}
+
+// fire вызывает отложенную функцию с указанным номером
+func (f *fakeTimers) fire(i int) {
+	f.callbacks[i]()
+}
+
+func TestCoalescingQueue_Enqueue(t *testing.T) {
+	type testCase struct {
+		name            string
+		window          time.Duration
+		events          []port.NotificationEvent
+		expectedDelays  []time.Duration
+		expectedEnqueue []port.NotificationEvent
+	}
+
+	telegram := port.NotificationTarget{Channel: port.ChannelTelegram, ChatID: "tg_chat"}
+	vkteams := port.NotificationTarget{Channel: port.ChannelVKTeams, ChatID: "vk_chat"}
+
+	testCases := []testCase{
+		{
+			name:   "Zero_Window_Passes_Through",
+			window: 0,
+			events: []port.NotificationEvent{
+				coalesceEvent("event-1", "DEMO-1", []port.NotificationTarget{telegram}, stateChange(`"Open"`, `"In Progress"`)),
+				coalesceEvent("event-2", "DEMO-1", []port.NotificationTarget{telegram}, stateChange(`"In Progress"`, `"Done"`)),
+			},
+			expectedEnqueue: []port.NotificationEvent{
+				coalesceEvent("event-1", "DEMO-1", []port.NotificationTarget{telegram}, stateChange(`"Open"`, `"In Progress"`)),
+				coalesceEvent("event-2", "DEMO-1", []port.NotificationTarget{telegram}, stateChange(`"In Progress"`, `"Done"`)),
+			},
+		},
+		{
+			name:   "Changes_Of_Same_Issue_Merged",
+			window: 5 * time.Second,
+			events: []port.NotificationEvent{
+				coalesceEvent("event-1", "DEMO-1", []port.NotificationTarget{telegram}, stateChange(`"Open"`, `"In Progress"`)),
+				coalesceEvent("event-2", "DEMO-1", []port.NotificationTarget{telegram, vkteams}, priorityChange(`"Normal"`, `"Critical"`)),
+				coalesceEvent("event-3", "DEMO-1", []port.NotificationTarget{telegram}, stateChange(`"In Progress"`, `"Done"`)),
+			},
+			expectedDelays: []time.Duration{5 * time.Second},
+			expectedEnqueue: []port.NotificationEvent{
+				func() port.NotificationEvent {
+					event := coalesceEvent("event-1", "DEMO-1", []port.NotificationTarget{telegram, vkteams},
+						stateChange(`"Open"`, `"Done"`), priorityChange(`"Normal"`, `"Critical"`))
+					event.Payload.Issue.Summary = "event-3"
+					event.Merged = []string{"event-2", "event-3"}
+					return event
+				}(),
+			},
+		},
+		{
+			name:   "Different_Issues_Not_Merged",
+			window: 5 * time.Second,
+			events: []port.NotificationEvent{
+				coalesceEvent("event-1", "DEMO-1", []port.NotificationTarget{telegram}, stateChange(`"Open"`, `"Done"`)),
+				coalesceEvent("event-2", "DEMO-2", []port.NotificationTarget{telegram}, stateChange(`"Open"`, `"Done"`)),
+			},
+			expectedDelays: []time.Duration{5 * time.Second, 5 * time.Second},
+			expectedEnqueue: []port.NotificationEvent{
+				coalesceEvent("event-1", "DEMO-1", []port.NotificationTarget{telegram}, stateChange(`"Open"`, `"Done"`)),
+				coalesceEvent("event-2", "DEMO-2", []port.NotificationTarget{telegram}, stateChange(`"Open"`, `"Done"`)),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			timers := &fakeTimers{}
+			defer timers.install()()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockQueue := mocks.NewMockDeliveryQueue(ctrl)
+			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
+			mockProjectConfig.EXPECT().GetCoalesceWindow("demo").Return(tc.window).AnyTimes()
+
+			var enqueued []port.NotificationEvent
+			mockQueue.EXPECT().Enqueue(gomock.Any()).DoAndReturn(func(event port.NotificationEvent) error {
+				enqueued = append(enqueued, event)
+				return nil
+			}).AnyTimes()
+
+			queue := NewCoalescingQueue(mockQueue, mockProjectConfig, logger)
+			for _, event := range tc.events {
+				if err := queue.Enqueue(event); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			for i := range timers.callbacks {
+				timers.fire(i)
+			}
+
+			if diff := cmp.Diff(tc.expectedDelays, timers.delays); diff != "" {
+				t.Errorf("delays mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedEnqueue, enqueued); diff != "" {
+				t.Errorf("enqueued events mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCoalescingQueue_Flush_RetriesWhenQueueIsFull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	timers := &fakeTimers{}
+	defer timers.install()()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	telegram := port.NotificationTarget{Channel: port.ChannelTelegram, ChatID: "tg_chat"}
+
+	mockQueue := mocks.NewMockDeliveryQueue(ctrl)
+	mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
+	mockProjectConfig.EXPECT().GetCoalesceWindow("demo").Return(5 * time.Second).AnyTimes()
+
+	var enqueued []port.NotificationEvent
+	gomock.InOrder(
+		mockQueue.EXPECT().Enqueue(gomock.Any()).Return(&port.UnavailableError{Reason: "delivery queue is full", RetryAfter: 3 * time.Second}),
+		mockQueue.EXPECT().Enqueue(gomock.Any()).DoAndReturn(func(event port.NotificationEvent) error {
+			enqueued = append(enqueued, event)
+			return nil
+		}),
+	)
+
+	queue := NewCoalescingQueue(mockQueue, mockProjectConfig, logger)
+	_ = queue.Enqueue(coalesceEvent("event-1", "DEMO-1", []port.NotificationTarget{telegram}, stateChange(`"Open"`, `"In Progress"`)))
+	timers.fire(0)
+
+	// Событие, полученное до повторной передачи, объединяется с неотправленным
+	_ = queue.Enqueue(coalesceEvent("event-2", "DEMO-1", []port.NotificationTarget{telegram}, stateChange(`"In Progress"`, `"Done"`)))
+	timers.fire(1)
+
+	if diff := cmp.Diff([]time.Duration{5 * time.Second, 3 * time.Second}, timers.delays); diff != "" {
+		t.Errorf("delays mismatch (-want +got):\n%s", diff)
+	}
+
+	expected := coalesceEvent("event-1", "DEMO-1", []port.NotificationTarget{telegram}, stateChange(`"Open"`, `"Done"`))
+	expected.Payload.Issue.Summary = "event-2"
+	expected.Merged = []string{"event-2"}
+	if diff := cmp.Diff([]port.NotificationEvent{expected}, enqueued); diff != "" {
+		t.Errorf("enqueued events mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCoalescingQueue_Stop_FlushesPendingEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	timers := &fakeTimers{}
+	defer timers.install()()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	telegram := port.NotificationTarget{Channel: port.ChannelTelegram, ChatID: "tg_chat"}
+	event := coalesceEvent("event-1", "DEMO-1", []port.NotificationTarget{telegram}, stateChange(`"Open"`, `"Done"`))
+
+	mockQueue := mocks.NewMockDeliveryQueue(ctrl)
+	mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
+	mockProjectConfig.EXPECT().GetCoalesceWindow("demo").Return(5 * time.Second)
+
+	gomock.InOrder(
+		mockQueue.EXPECT().Start(),
+		mockQueue.EXPECT().Enqueue(event).Return(nil),
+		mockQueue.EXPECT().Stop(gomock.Any()).Return(nil),
+	)
+
+	queue := NewCoalescingQueue(mockQueue, mockProjectConfig, logger)
+	queue.Start()
+	_ = queue.Enqueue(event)
+
+	if err := queue.Stop(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Таймер, сработавший после остановки, не передает событие повторно
+	timers.fire(0)
+}
+
+// coalesceEvent создает событие задачи проекта demo с указанными изменениями
+// Заголовок задачи совпадает с идентификатором события, чтобы проверять, из какого события взято состояние задачи
+func coalesceEvent(id string, key string, targets []port.NotificationTarget, changes ...parser.YoutrackChange) port.NotificationEvent {
+	return port.NotificationEvent{
+		ID:      id,
+		Key:     key,
+		Project: "demo",
+		Payload: &parser.YoutrackWebhookPayload{
+			Issue:   parser.YoutrackIssue{IDReadable: key, Summary: id},
+			Changes: changes,
+		},
+		Targets: targets,
+	}
+}
+
+// stateChange создает изменение поля State
+func stateChange(oldValue string, newValue string) parser.YoutrackChange {
+	return parser.YoutrackChange{Field: "State", OldValue: json.RawMessage(oldValue), NewValue: json.RawMessage(newValue)}
+}
+
+// priorityChange создает изменение поля Priority
+func priorityChange(oldValue string, newValue string) parser.YoutrackChange {
+	return parser.YoutrackChange{Field: "Priority", OldValue: json.RawMessage(oldValue), NewValue: json.RawMessage(newValue)}
+}
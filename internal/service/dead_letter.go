@@ -0,0 +1,144 @@
+package service
+
+import (
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/sirupsen/logrus"
+	"slices"
+)
+
+// DeadLetterService реализует просмотр и повторную отправку недоставленных уведомлений
+type DeadLetterService struct {
+	store          port.DeadLetterStore
+	youtrackParser parser.YoutrackParser
+	deliveryQueue  port.DeliveryQueue
+	logger         *logrus.Logger
+}
+
+// NewDeadLetterService создает новый экземпляр сервиса недоставленных уведомлений
+func NewDeadLetterService(store port.DeadLetterStore, youtrackParser parser.YoutrackParser, deliveryQueue port.DeliveryQueue, logger *logrus.Logger) port.DeadLetterService {
+	return &DeadLetterService{
+		store:          store,
+		youtrackParser: youtrackParser,
+		deliveryQueue:  deliveryQueue,
+		logger:         logger,
+	}
+}
+
+// List возвращает все недоставленные уведомления
+func (s *DeadLetterService) List() ([]port.DeadLetter, error) {
+	return s.store.List()
+}
+
+// Get возвращает недоставленное уведомление по идентификатору
+func (s *DeadLetterService) Get(id string) (port.DeadLetter, error) {
+	return s.store.Get(id)
+}
+
+// Requeue ставит уведомление в очередь доставки и удаляет его из хранилища
+// Уведомление отправляется только исходному адресату, если он по-прежнему настроен для проекта
+func (s *DeadLetterService) Requeue(id string) error {
+	letter, err := s.store.Get(id)
+	if err != nil {
+		return err
+	}
+
+	return s.requeue(letter)
+}
+
+// RequeueAll ставит в очередь доставки все недоставленные уведомления
+// Ошибка постановки одного уведомления не прерывает обработку остальных
+func (s *DeadLetterService) RequeueAll() (port.RequeueResult, error) {
+	letters, err := s.store.List()
+	if err != nil {
+		return port.RequeueResult{}, err
+	}
+
+	var result port.RequeueResult
+	for _, letter := range letters {
+		if requeueErr := s.requeue(letter); requeueErr != nil {
+			result.Failed = append(result.Failed, port.RequeueFailure{ID: letter.ID, Error: requeueErr.Error()})
+			continue
+		}
+		result.Requeued++
+	}
+
+	return result, nil
+}
+
+// Delete удаляет недоставленное уведомление
+func (s *DeadLetterService) Delete(id string) error {
+	if err := s.store.Delete(id); err != nil {
+		return err
+	}
+
+	s.logger.WithField("dead_letter_id", id).Info("Dead letter deleted")
+	return nil
+}
+
+// DeleteAll удаляет все недоставленные уведомления
+func (s *DeadLetterService) DeleteAll() (int, error) {
+	letters, err := s.store.List()
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, letter := range letters {
+		if err = s.store.Delete(letter.ID); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+
+	s.logger.WithField("count", deleted).Info("Dead letters deleted")
+	return deleted, nil
+}
+
+// requeue ставит одно недоставленное уведомление в очередь доставки
+func (s *DeadLetterService) requeue(letter port.DeadLetter) error {
+	if letter.Payload == nil {
+		return fmt.Errorf("%w: dead letter %q has no payload", port.ErrConflict, letter.ID)
+	}
+
+	channel := letter.Target.Channel
+	if !slices.Contains(s.youtrackParser.GetAllowedChannels(letter.Payload), channel) {
+		return fmt.Errorf("%w: channel %q is no longer allowed for project %q", port.ErrConflict, channel, letter.Project)
+	}
+
+	// Канал может иметь несколько адресатов проекта (например, цели webhook), поэтому повторно отправляется
+	// только адресат недоставленного уведомления, а не все текущие адресаты канала
+	targets, _ := resolveTargets(s.youtrackParser, []string{channel}, letter.Payload, letter.Project, s.logger)
+	if len(targets) == 0 {
+		return fmt.Errorf("%w: chat ID for channel %q is not configured for project %q", port.ErrConflict, channel, letter.Project)
+	}
+	if !slices.Contains(targets, letter.Target) {
+		return fmt.Errorf("%w: target of channel %q is no longer configured for project %q", port.ErrConflict, channel, letter.Project)
+	}
+
+	event := port.NotificationEvent{
+		ID:         newEventID(),
+		Key:        letter.Key,
+		Project:    letter.Project,
+		Payload:    letter.Payload,
+		Targets:    []port.NotificationTarget{letter.Target},
+		ReceivedAt: nowFunc(),
+	}
+	if err := s.deliveryQueue.Enqueue(event); err != nil {
+		return err
+	}
+
+	if err := s.store.Delete(letter.ID); err != nil {
+		s.logger.WithError(err).WithField("dead_letter_id", letter.ID).Warn("Failed to delete requeued dead letter")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"dead_letter_id": letter.ID,
+		"event_id":       event.ID,
+		"channel":        channel,
+		"issue":          letter.Key,
+	}).Info("Dead letter requeued")
+
+	return nil
+}
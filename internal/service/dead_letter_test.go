@@ -0,0 +1,284 @@
+package service
+
+import (
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"testing"
+	"time"
+)
+
+func newTestDeadLetter(id string, channel string) port.DeadLetter {
+	// Адресат канала logger не имеет chat_id
+	chatID := "old_chat"
+	if channel == port.ChannelLogger {
+		chatID = ""
+	}
+
+	return port.DeadLetter{
+		ID:        id,
+		EventID:   "event-" + id,
+		Key:       "DEMO-1",
+		Project:   "demo",
+		Payload:   &parser.YoutrackWebhookPayload{Issue: parser.YoutrackIssue{IDReadable: "DEMO-1"}},
+		Target:    port.NotificationTarget{Channel: channel, ChatID: chatID},
+		LastError: "chat not found",
+		Attempts:  1,
+	}
+}
+
+func TestDeadLetterService_Requeue(t *testing.T) {
+	type testCase struct {
+		name            string
+		letter          port.DeadLetter
+		getErr          error
+		allowedChannels []string
+		chatID          string
+		webhookTargets  []string
+		enqueueErr      error
+		expectEnqueue   bool
+		expectedTargets []port.NotificationTarget
+		expectedErrorIs error
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Requeue_Original_Target",
+			letter:          newTestDeadLetter("a1", port.ChannelTelegram),
+			allowedChannels: []string{port.ChannelTelegram},
+			chatID:          "old_chat",
+			expectEnqueue:   true,
+			expectedTargets: []port.NotificationTarget{{Channel: port.ChannelTelegram, ChatID: "old_chat"}},
+		},
+		{
+			name:            "Requeue_Only_Original_Webhook_Target",
+			letter:          newTestDeadLetter("a1", port.ChannelWebhook),
+			allowedChannels: []string{port.ChannelWebhook},
+			webhookTargets:  []string{"deploy_bot", "old_chat"},
+			expectEnqueue:   true,
+			expectedTargets: []port.NotificationTarget{{Channel: port.ChannelWebhook, ChatID: "old_chat"}},
+		},
+		{
+			name:            "Chat_ID_Changed",
+			letter:          newTestDeadLetter("a1", port.ChannelTelegram),
+			allowedChannels: []string{port.ChannelTelegram},
+			chatID:          "fixed_chat",
+			expectedErrorIs: port.ErrConflict,
+		},
+		{
+			name:            "Webhook_Target_Removed",
+			letter:          newTestDeadLetter("a1", port.ChannelWebhook),
+			allowedChannels: []string{port.ChannelWebhook},
+			webhookTargets:  []string{"deploy_bot"},
+			expectedErrorIs: port.ErrConflict,
+		},
+		{
+			name:            "Dead_Letter_Not_Found",
+			getErr:          port.ErrNotFound,
+			expectedErrorIs: port.ErrNotFound,
+		},
+		{
+			name:            "Channel_No_Longer_Allowed",
+			letter:          newTestDeadLetter("a1", port.ChannelTelegram),
+			allowedChannels: []string{port.ChannelLogger},
+			expectedErrorIs: port.ErrConflict,
+		},
+		{
+			name:            "Chat_ID_Not_Configured",
+			letter:          newTestDeadLetter("a1", port.ChannelTelegram),
+			allowedChannels: []string{port.ChannelTelegram},
+			chatID:          "",
+			expectedErrorIs: port.ErrConflict,
+		},
+		{
+			name:            "Queue_Full",
+			letter:          newTestDeadLetter("a1", port.ChannelTelegram),
+			allowedChannels: []string{port.ChannelTelegram},
+			chatID:          "old_chat",
+			expectEnqueue:   true,
+			enqueueErr:      &port.UnavailableError{Reason: "delivery queue is full"},
+			expectedTargets: []port.NotificationTarget{{Channel: port.ChannelTelegram, ChatID: "old_chat"}},
+			expectedErrorIs: port.ErrUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockStore := mocks.NewMockDeadLetterStore(ctrl)
+			mockParser := mocks.NewMockYoutrackParser(ctrl)
+			mockQueue := mocks.NewMockDeliveryQueue(ctrl)
+
+			mockStore.EXPECT().Get("a1").Return(tc.letter, tc.getErr)
+			if tc.getErr == nil {
+				mockParser.EXPECT().GetAllowedChannels(tc.letter.Payload).Return(tc.allowedChannels)
+			}
+			if tc.allowedChannels != nil && tc.allowedChannels[0] == port.ChannelTelegram {
+				mockParser.EXPECT().GetTelegramChatID("demo").Return(tc.chatID, tc.chatID != "")
+			}
+			if tc.allowedChannels != nil && tc.allowedChannels[0] == port.ChannelWebhook {
+				mockParser.EXPECT().GetWebhookTargets("demo").Return(tc.webhookTargets, true)
+			}
+			if tc.expectEnqueue {
+				mockQueue.EXPECT().Enqueue(gomock.Any()).DoAndReturn(func(event port.NotificationEvent) error {
+					if diff := cmp.Diff(tc.expectedTargets, event.Targets); diff != "" {
+						t.Errorf("targets mismatch (-want +got):\n%s", diff)
+					}
+					if event.Key != tc.letter.Key || event.Payload != tc.letter.Payload {
+						t.Errorf("expected event to keep key and payload of dead letter, got: %+v", event)
+					}
+					if event.ID == tc.letter.EventID {
+						t.Error("expected requeued event to get a new id")
+					}
+					return tc.enqueueErr
+				})
+			}
+			if tc.expectEnqueue && tc.enqueueErr == nil {
+				mockStore.EXPECT().Delete("a1").Return(nil)
+			}
+
+			service := NewDeadLetterService(mockStore, mockParser, mockQueue, logger)
+			err := service.Requeue("a1")
+
+			if tc.expectedErrorIs != nil {
+				if !errors.Is(err, tc.expectedErrorIs) {
+					t.Errorf("expected error %v, got: %v", tc.expectedErrorIs, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDeadLetterService_RequeueAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	mockStore := mocks.NewMockDeadLetterStore(ctrl)
+	mockParser := mocks.NewMockYoutrackParser(ctrl)
+	mockQueue := mocks.NewMockDeliveryQueue(ctrl)
+
+	loggerLetter := newTestDeadLetter("a1", port.ChannelLogger)
+	telegramLetter := newTestDeadLetter("b2", port.ChannelTelegram)
+
+	mockStore.EXPECT().List().Return([]port.DeadLetter{loggerLetter, telegramLetter}, nil)
+	mockParser.EXPECT().GetAllowedChannels(gomock.Any()).Return([]string{port.ChannelLogger}).Times(2)
+	mockQueue.EXPECT().Enqueue(gomock.Any()).Return(nil)
+	mockStore.EXPECT().Delete("a1").Return(nil)
+
+	service := NewDeadLetterService(mockStore, mockParser, mockQueue, logger)
+	result, err := service.RequeueAll()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := port.RequeueResult{
+		Requeued: 1,
+		Failed: []port.RequeueFailure{
+			{ID: "b2", Error: `conflict: channel "telegram" is no longer allowed for project "demo"`},
+		},
+	}
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("result mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDeadLetterService_DeleteAll(t *testing.T) {
+	type testCase struct {
+		name            string
+		letters         []port.DeadLetter
+		deleteErr       error
+		expectedDeleted int
+		expectedError   bool
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Deletes_All_Letters",
+			letters:         []port.DeadLetter{newTestDeadLetter("a1", port.ChannelLogger), newTestDeadLetter("b2", port.ChannelLogger)},
+			expectedDeleted: 2,
+		},
+		{
+			name:            "Stops_On_Delete_Error",
+			letters:         []port.DeadLetter{newTestDeadLetter("a1", port.ChannelLogger), newTestDeadLetter("b2", port.ChannelLogger)},
+			deleteErr:       errors.New("permission denied"),
+			expectedDeleted: 0,
+			expectedError:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockStore := mocks.NewMockDeadLetterStore(ctrl)
+			mockStore.EXPECT().List().Return(tc.letters, nil)
+			if tc.deleteErr != nil {
+				mockStore.EXPECT().Delete("a1").Return(tc.deleteErr)
+			} else {
+				mockStore.EXPECT().Delete(gomock.Any()).Return(nil).Times(len(tc.letters))
+			}
+
+			service := NewDeadLetterService(mockStore, mocks.NewMockYoutrackParser(ctrl), mocks.NewMockDeliveryQueue(ctrl), logger)
+			deleted, err := service.DeleteAll()
+
+			if tc.expectedError != (err != nil) {
+				t.Errorf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+			if deleted != tc.expectedDeleted {
+				t.Errorf("expected %d deleted, got: %d", tc.expectedDeleted, deleted)
+			}
+		})
+	}
+}
+
+func TestDeadLetterService_RequeueSetsReceivedAt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	originalNow := nowFunc
+	nowFunc = func() time.Time { return now }
+	defer func() {
+		nowFunc = originalNow
+	}()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	mockStore := mocks.NewMockDeadLetterStore(ctrl)
+	mockParser := mocks.NewMockYoutrackParser(ctrl)
+	mockQueue := mocks.NewMockDeliveryQueue(ctrl)
+
+	letter := newTestDeadLetter("a1", port.ChannelLogger)
+	mockStore.EXPECT().Get("a1").Return(letter, nil)
+	mockParser.EXPECT().GetAllowedChannels(letter.Payload).Return([]string{port.ChannelLogger})
+	mockQueue.EXPECT().Enqueue(gomock.Any()).DoAndReturn(func(event port.NotificationEvent) error {
+		if !event.ReceivedAt.Equal(now) {
+			t.Errorf("expected received_at %v, got: %v", now, event.ReceivedAt)
+		}
+		return nil
+	})
+	mockStore.EXPECT().Delete("a1").Return(errors.New("permission denied"))
+
+	if err := NewDeadLetterService(mockStore, mockParser, mockQueue, logger).Requeue("a1"); err != nil {
+		t.Errorf("expected requeue to succeed even if delete fails, got: %v", err)
+	}
+}
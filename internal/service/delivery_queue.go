@@ -0,0 +1,375 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// DeliveryQueue реализует ограниченную очередь асинхронной доставки с пулом обработчиков
+// События распределяются между обработчиками по ключу задачи, поэтому уведомления по одной задаче
+// доставляются последовательно и в порядке поступления
+type DeliveryQueue struct {
+	shards     []chan port.NotificationEvent
+	deferred   []*deferredEvents
+	dispatcher port.NotificationDispatcher
+	retry      config.RetryConfig
+	retryAfter time.Duration
+	timeout    time.Duration
+	logger     *logrus.Logger
+
+	// ctx отменяется, если доставка принятых событий не укладывается во время остановки:
+	// это прерывает ожидание лимитов и запросы к API каналов
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.RWMutex
+	started bool
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// NewDeliveryQueue создает очередь доставки
+// Емкость очереди делится поровну между обработчиками
+// Доставка каждого события ограничена cfg.Timeout секунд, значение <= 0 отключает ограничение
+func NewDeliveryQueue(cfg config.DeliveryConfig, dispatcher port.NotificationDispatcher, logger *logrus.Logger) port.DeliveryQueue {
+	workers := cfg.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	shardSize := (cfg.QueueSize + workers - 1) / workers
+	if shardSize < 1 {
+		shardSize = 1
+	}
+
+	shards := make([]chan port.NotificationEvent, workers)
+	deferred := make([]*deferredEvents, workers)
+	for i := range shards {
+		shards[i] = make(chan port.NotificationEvent, shardSize)
+		deferred[i] = &deferredEvents{wake: make(chan struct{}, 1)}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &DeliveryQueue{
+		shards:     shards,
+		deferred:   deferred,
+		dispatcher: dispatcher,
+		retry:      cfg.Retry,
+		retryAfter: time.Duration(cfg.RetryAfter) * time.Second,
+		timeout:    time.Duration(cfg.Timeout) * time.Second,
+		logger:     logger,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start запускает обработчики очереди, повторный вызов не имеет эффекта
+func (q *DeliveryQueue) Start() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.started || q.closed {
+		return
+	}
+	q.started = true
+
+	for i, shard := range q.shards {
+		q.wg.Add(1)
+		go q.work(i, shard)
+	}
+
+	q.logger.WithFields(logrus.Fields{
+		"workers": len(q.shards),
+	}).Info("Delivery queue started")
+}
+
+// Enqueue ставит событие в очередь без блокировки
+// Если очередь обработчика заполнена или остановлена, возвращается UnavailableError
+func (q *DeliveryQueue) Enqueue(event port.NotificationEvent) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return &port.UnavailableError{Reason: "delivery queue is stopped", RetryAfter: q.retryAfter}
+	}
+
+	select {
+	case q.shards[q.shardFor(event.Key)] <- event:
+		return nil
+	default:
+		q.logger.WithFields(logrus.Fields{
+			"project": event.Project,
+			"issue":   event.Key,
+		}).Warn("Delivery queue is full, rejecting webhook")
+		return &port.UnavailableError{Reason: "delivery queue is full", RetryAfter: q.retryAfter}
+	}
+}
+
+// Stop прекращает прием событий и ожидает доставки уже принятых
+// Если контекст истекает раньше, выполняющиеся отправки отменяются и возвращается ошибка с количеством недоставленных событий
+func (q *DeliveryQueue) Stop(ctx context.Context) error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	for _, shard := range q.shards {
+		close(shard)
+	}
+	started := q.started
+	q.mu.Unlock()
+
+	if !started {
+		q.cancel()
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.cancel()
+		q.logger.Info("Delivery queue drained")
+		return nil
+	case <-ctx.Done():
+		q.cancel()
+		return fmt.Errorf("delivery queue drain interrupted with %d pending events: %w", q.Len(), ctx.Err())
+	}
+}
+
+// Len возвращает количество событий, ожидающих доставки
+func (q *DeliveryQueue) Len() int {
+	pending := 0
+	for _, shard := range q.shards {
+		pending += len(shard)
+	}
+	return pending
+}
+
+// work последовательно доставляет события одного обработчика
+// Пока доставка события отложена из-за разомкнутого выключателя канала, следующие события той же задачи
+// ожидают ее завершения, чтобы сохранить порядок доставки уведомлений по задаче
+func (q *DeliveryQueue) work(index int, shard <-chan port.NotificationEvent) {
+	defer q.wg.Done()
+
+	// Ключ присутствует, пока доставка события задачи отложена, значение - ожидающие события задачи
+	blocked := make(map[string][]port.NotificationEvent)
+	deferred := q.deferred[index]
+
+	for {
+		select {
+		case event, ok := <-shard:
+			if !ok {
+				q.logBlocked(blocked)
+				return
+			}
+			if waiting, isBlocked := blocked[event.Key]; isBlocked {
+				blocked[event.Key] = append(waiting, event)
+				q.logger.WithFields(logrus.Fields{
+					"project": event.Project,
+					"issue":   event.Key,
+				}).Debug("Notification event waits for deferred delivery of the same issue")
+				continue
+			}
+			q.deliver(index, deferredEvent{event: event}, blocked)
+		case <-deferred.wake:
+			for _, item := range deferred.take() {
+				q.deliver(index, item, blocked)
+			}
+		}
+	}
+}
+
+// deliver доставляет событие, а после завершения отложенной доставки - ожидавшие ее события той же задачи
+func (q *DeliveryQueue) deliver(index int, item deferredEvent, blocked map[string][]port.NotificationEvent) {
+	key := item.event.Key
+	for {
+		q.logger.WithFields(logrus.Fields{
+			"worker":    index,
+			"project":   item.event.Project,
+			"issue":     key,
+			"queued":    time.Since(item.event.ReceivedAt).String(),
+			"deferrals": item.deferrals,
+		}).Debug("Delivering notification event")
+
+		if q.dispatch(index, item) {
+			if _, isBlocked := blocked[key]; !isBlocked {
+				blocked[key] = nil
+			}
+			return
+		}
+
+		waiting, isBlocked := blocked[key]
+		if !isBlocked {
+			return
+		}
+		if len(waiting) == 0 {
+			delete(blocked, key)
+			return
+		}
+		item = deferredEvent{event: waiting[0]}
+		blocked[key] = waiting[1:]
+	}
+}
+
+// dispatch доставляет событие с ограничением времени доставки
+// Возвращает true, если доставка части адресатов отложена до повтора
+func (q *DeliveryQueue) dispatch(index int, item deferredEvent) bool {
+	ctx := q.ctx
+	if q.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, q.timeout)
+		defer cancel()
+	}
+
+	return q.deferTargets(index, item, q.dispatcher.Dispatch(ctx, item.event))
+}
+
+// deferTargets откладывает доставку адресатам, отправка которым не выполнена из-за разомкнутого выключателя канала
+// Событие повторяется после наибольшей задержки RetryAfter, но не раньше чем через секунду. Если повтор выходит
+// за max_attempts или max_age политики повторной отправки канала (время отсчитывается от приема события),
+// доставка адресату завершается ошибкой и уведомление сохраняется как недоставленное
+// Отложенные адресаты остаются незавершенными в журнале, поэтому после остановки сервиса событие будет доставлено при запуске
+func (q *DeliveryQueue) deferTargets(index int, item deferredEvent, results []port.DeliveryResult) bool {
+	attempts := item.deferrals + 1
+	delay := defaultReplayRetryDelay
+
+	var retry, exhausted []port.NotificationTarget
+	for _, result := range results {
+		if result.Status != port.DeliveryStatusDeferred {
+			continue
+		}
+		target := port.NotificationTarget{Channel: result.Channel, ChatID: result.ChatID}
+		retryAfter := max(result.RetryAfter, defaultReplayRetryDelay)
+		if q.retryExhausted(result.Channel, item.event.ReceivedAt, attempts, retryAfter) {
+			exhausted = append(exhausted, target)
+			continue
+		}
+		retry = append(retry, target)
+		delay = max(delay, retryAfter)
+	}
+
+	if len(exhausted) > 0 {
+		failed := item.event
+		failed.Targets = exhausted
+		q.dispatcher.Fail(failed, &port.AttemptsError{
+			Attempts: attempts,
+			Err:      fmt.Errorf("retry limit exceeded while delivery was deferred: %w", port.ErrCircuitOpen),
+		})
+	}
+	if len(retry) == 0 {
+		return false
+	}
+
+	deferred := item.event
+	deferred.Targets = retry
+
+	q.logger.WithFields(logrus.Fields{
+		"project":  item.event.Project,
+		"issue":    item.event.Key,
+		"targets":  len(retry),
+		"attempts": attempts,
+		"delay":    delay.String(),
+	}).Info("Notification event deferred until circuit breaker allows delivery")
+
+	q.retryAfterDelay(index, deferredEvent{event: deferred, deferrals: attempts}, delay)
+	return true
+}
+
+// retryExhausted проверяет, выходит ли повтор доставки через delay за пределы политики повторной отправки канала
+func (q *DeliveryQueue) retryExhausted(channel string, receivedAt time.Time, attempts int, delay time.Duration) bool {
+	policy := q.retry.RetryPolicyConfig
+	if channelPolicy, exists := q.retry.Channels[channel]; exists {
+		policy = channelPolicy
+	}
+
+	if attempts >= max(policy.MaxAttempts, 1) {
+		return true
+	}
+	maxAge := time.Duration(policy.MaxAge) * time.Second
+	return maxAge > 0 && !receivedAt.IsZero() && nowFunc().Sub(receivedAt)+delay > maxAge
+}
+
+// retryAfterDelay передает отложенное событие обработчику через delay
+// Событие не проходит через очередь приема, поэтому повтор не зависит от ее заполненности
+func (q *DeliveryQueue) retryAfterDelay(index int, item deferredEvent, delay time.Duration) {
+	time.AfterFunc(delay, func() {
+		q.mu.RLock()
+		closed := q.closed
+		q.mu.RUnlock()
+		if closed {
+			q.logger.WithFields(logrus.Fields{
+				"project": item.event.Project,
+				"issue":   item.event.Key,
+			}).Warn("Delivery queue is stopped, deferred notification event is not requeued")
+			return
+		}
+
+		q.deferred[index].add(item)
+	})
+}
+
+// logBlocked сообщает о событиях, доставка которых не завершена к остановке очереди
+func (q *DeliveryQueue) logBlocked(blocked map[string][]port.NotificationEvent) {
+	for key, waiting := range blocked {
+		q.logger.WithFields(logrus.Fields{
+			"issue":   key,
+			"waiting": len(waiting),
+		}).Warn("Delivery queue is stopped while notification delivery is deferred")
+	}
+}
+
+// deferredEvent описывает событие, доставка которого отложена из-за разомкнутого выключателя канала
+type deferredEvent struct {
+	event port.NotificationEvent
+	// deferrals количество уже выполненных попыток доставки
+	deferrals int
+}
+
+// deferredEvents содержит отложенные события обработчика, время повтора которых наступило
+type deferredEvents struct {
+	mu    sync.Mutex
+	items []deferredEvent
+	wake  chan struct{}
+}
+
+// add добавляет событие и будит обработчика
+func (d *deferredEvents) add(item deferredEvent) {
+	d.mu.Lock()
+	d.items = append(d.items, item)
+	d.mu.Unlock()
+
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// take возвращает накопленные события и очищает список
+func (d *deferredEvents) take() []deferredEvent {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	items := d.items
+	d.items = nil
+	return items
+}
+
+// shardFor выбирает обработчика по ключу события
+func (q *DeliveryQueue) shardFor(key string) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	return int(hash.Sum32() % uint32(len(q.shards)))
+}
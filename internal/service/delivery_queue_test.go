@@ -0,0 +1,497 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingDispatcher запоминает порядок доставленных событий и контексты доставки
+// Заблокированная доставка завершается при отмене контекста, такие события считаются отмененными
+type recordingDispatcher struct {
+	mu        sync.Mutex
+	delivered []port.NotificationEvent
+	failed    []port.NotificationEvent
+	contexts  []context.Context
+	canceled  int
+	block     chan struct{}
+}
+
+func (d *recordingDispatcher) Dispatch(ctx context.Context, event port.NotificationEvent) []port.DeliveryResult {
+	if d.block != nil {
+		select {
+		case <-d.block:
+		case <-ctx.Done():
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			d.canceled++
+			return nil
+		}
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.delivered = append(d.delivered, event)
+	d.contexts = append(d.contexts, ctx)
+	return nil
+}
+
+func (d *recordingDispatcher) Fail(event port.NotificationEvent, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.failed = append(d.failed, event)
+}
+
+func (d *recordingDispatcher) failedEvents() []port.NotificationEvent {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]port.NotificationEvent(nil), d.failed...)
+}
+
+func (d *recordingDispatcher) canceledCount() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.canceled
+}
+
+func (d *recordingDispatcher) events() []port.NotificationEvent {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]port.NotificationEvent(nil), d.delivered...)
+}
+
+func newTestQueueLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+	return logger
+}
+
+func TestNewDeliveryQueue(t *testing.T) {
+	type testCase struct {
+		name              string
+		cfg               config.DeliveryConfig
+		expectedShards    int
+		expectedShardSize int
+	}
+
+	testCases := []testCase{
+		{
+			name:              "Queue_Size_Split_Between_Workers",
+			cfg:               config.DeliveryConfig{Workers: 4, QueueSize: 10},
+			expectedShards:    4,
+			expectedShardSize: 3,
+		},
+		{
+			name:              "Zero_Workers_Uses_Single_Worker",
+			cfg:               config.DeliveryConfig{Workers: 0, QueueSize: 5},
+			expectedShards:    1,
+			expectedShardSize: 5,
+		},
+		{
+			name:              "Zero_Queue_Size_Uses_Minimal_Buffer",
+			cfg:               config.DeliveryConfig{Workers: 2, QueueSize: 0},
+			expectedShards:    2,
+			expectedShardSize: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queue := NewDeliveryQueue(tc.cfg, &recordingDispatcher{}, newTestQueueLogger()).(*DeliveryQueue)
+
+			if len(queue.shards) != tc.expectedShards {
+				t.Errorf("expected %d shards, got: %d", tc.expectedShards, len(queue.shards))
+			}
+			if cap(queue.shards[0]) != tc.expectedShardSize {
+				t.Errorf("expected shard size %d, got: %d", tc.expectedShardSize, cap(queue.shards[0]))
+			}
+		})
+	}
+}
+
+func TestDeliveryQueue_PreservesOrderPerIssue(t *testing.T) {
+	dispatcher := &recordingDispatcher{}
+	queue := NewDeliveryQueue(config.DeliveryConfig{Workers: 4, QueueSize: 400}, dispatcher, newTestQueueLogger())
+	queue.Start()
+
+	issues := []string{"DEMO-1", "DEMO-2", "DEMO-3"}
+	for i := 0; i < 50; i++ {
+		for _, issue := range issues {
+			if err := queue.Enqueue(port.NotificationEvent{Key: issue, Project: fmt.Sprintf("%d", i)}); err != nil {
+				t.Fatalf("unexpected enqueue error: %v", err)
+			}
+		}
+	}
+
+	if err := queue.Stop(context.Background()); err != nil {
+		t.Fatalf("unexpected stop error: %v", err)
+	}
+
+	next := map[string]int{}
+	for _, event := range dispatcher.events() {
+		expected := fmt.Sprintf("%d", next[event.Key])
+		if event.Project != expected {
+			t.Fatalf("issue %s delivered out of order: expected %s, got: %s", event.Key, expected, event.Project)
+		}
+		next[event.Key]++
+	}
+
+	for _, issue := range issues {
+		if next[issue] != 50 {
+			t.Errorf("expected 50 events for %s, got: %d", issue, next[issue])
+		}
+	}
+}
+
+func TestDeliveryQueue_Enqueue_Backpressure(t *testing.T) {
+	dispatcher := &recordingDispatcher{block: make(chan struct{})}
+	queue := NewDeliveryQueue(config.DeliveryConfig{Workers: 1, QueueSize: 1, RetryAfter: 7}, dispatcher, newTestQueueLogger())
+
+	// Обработчики не запущены, поэтому второе событие не помещается в очередь
+	if err := queue.Enqueue(port.NotificationEvent{Key: "DEMO-1"}); err != nil {
+		t.Fatalf("unexpected enqueue error: %v", err)
+	}
+
+	err := queue.Enqueue(port.NotificationEvent{Key: "DEMO-1"})
+	var unavailableErr *port.UnavailableError
+	if !errors.As(err, &unavailableErr) {
+		t.Fatalf("expected UnavailableError, got: %v", err)
+	}
+	if !errors.Is(err, port.ErrUnavailable) {
+		t.Error("expected error to wrap ErrUnavailable")
+	}
+	if unavailableErr.RetryAfter != 7*time.Second {
+		t.Errorf("expected RetryAfter 7s, got: %s", unavailableErr.RetryAfter)
+	}
+
+	close(dispatcher.block)
+	queue.Start()
+	if err = queue.Stop(context.Background()); err != nil {
+		t.Fatalf("unexpected stop error: %v", err)
+	}
+	if len(dispatcher.events()) != 1 {
+		t.Errorf("expected 1 delivered event, got: %d", len(dispatcher.events()))
+	}
+}
+
+func TestDeliveryQueue_Stop(t *testing.T) {
+	type testCase struct {
+		name          string
+		start         bool
+		blockDelivery bool
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Drains_Pending_Events",
+			start:         true,
+			expectedError: false,
+		},
+		{
+			name:          "Not_Started_Queue_Stops_Immediately",
+			start:         false,
+			expectedError: false,
+		},
+		{
+			name:          "Drain_Interrupted_By_Context",
+			start:         true,
+			blockDelivery: true,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dispatcher := &recordingDispatcher{}
+			if tc.blockDelivery {
+				dispatcher.block = make(chan struct{})
+				defer close(dispatcher.block)
+			}
+
+			queue := NewDeliveryQueue(config.DeliveryConfig{Workers: 2, QueueSize: 10}, dispatcher, newTestQueueLogger())
+			if tc.start {
+				queue.Start()
+			}
+
+			for i := 0; i < 3; i++ {
+				if err := queue.Enqueue(port.NotificationEvent{Key: fmt.Sprintf("DEMO-%d", i)}); err != nil {
+					t.Fatalf("unexpected enqueue error: %v", err)
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			err := queue.Stop(ctx)
+			if tc.expectedError {
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("expected deadline exceeded error, got: %v", err)
+				}
+				// Недоставленные к истечению времени остановки события отменяются
+				deadline := time.Now().Add(time.Second)
+				for dispatcher.canceledCount() < 3 && time.Now().Before(deadline) {
+					time.Sleep(time.Millisecond)
+				}
+				if canceled := dispatcher.canceledCount(); canceled != 3 {
+					t.Errorf("expected 3 canceled deliveries, got: %d", canceled)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected stop error: %v", err)
+			}
+			if tc.start && len(dispatcher.events()) != 3 {
+				t.Errorf("expected 3 delivered events, got: %d", len(dispatcher.events()))
+			}
+
+			// После остановки очередь не принимает события, повторная остановка безопасна
+			if err = queue.Enqueue(port.NotificationEvent{Key: "DEMO-9"}); !errors.Is(err, port.ErrUnavailable) {
+				t.Errorf("expected ErrUnavailable after stop, got: %v", err)
+			}
+			if err = queue.Stop(ctx); err != nil {
+				t.Errorf("unexpected error on second stop: %v", err)
+			}
+		})
+	}
+}
+
+func TestDeliveryQueue_DeliveryTimeout(t *testing.T) {
+	type testCase struct {
+		name             string
+		timeout          int
+		expectedDeadline bool
+	}
+
+	testCases := []testCase{
+		{
+			name:             "Timeout_Sets_Delivery_Deadline",
+			timeout:          30,
+			expectedDeadline: true,
+		},
+		{
+			name:             "Zero_Timeout_Without_Deadline",
+			timeout:          0,
+			expectedDeadline: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dispatcher := &recordingDispatcher{}
+			queue := NewDeliveryQueue(config.DeliveryConfig{Workers: 1, QueueSize: 1, Timeout: tc.timeout}, dispatcher, newTestQueueLogger())
+			queue.Start()
+
+			startedAt := time.Now()
+			if err := queue.Enqueue(port.NotificationEvent{Key: "DEMO-1"}); err != nil {
+				t.Fatalf("unexpected enqueue error: %v", err)
+			}
+			if err := queue.Stop(context.Background()); err != nil {
+				t.Fatalf("unexpected stop error: %v", err)
+			}
+
+			if len(dispatcher.contexts) != 1 {
+				t.Fatalf("expected 1 delivered event, got: %d", len(dispatcher.contexts))
+			}
+			deadline, ok := dispatcher.contexts[0].Deadline()
+			if ok != tc.expectedDeadline {
+				t.Fatalf("expected deadline set %v, got: %v", tc.expectedDeadline, ok)
+			}
+			if ok {
+				limit := time.Duration(tc.timeout) * time.Second
+				if deadline.Before(startedAt.Add(limit)) || deadline.After(time.Now().Add(limit)) {
+					t.Errorf("expected deadline in %s from dispatch, got: %s", limit, deadline.Sub(startedAt))
+				}
+			}
+		})
+	}
+}
+
+// deferringDispatcher откладывает первую отправку адресатам канала deferredChannel и передает события
+// для записи в recordingDispatcher
+type deferringDispatcher struct {
+	recordingDispatcher
+	deferredChannel string
+	deferred        bool
+}
+
+func (d *deferringDispatcher) Dispatch(ctx context.Context, event port.NotificationEvent) []port.DeliveryResult {
+	d.recordingDispatcher.Dispatch(ctx, event)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	results := make([]port.DeliveryResult, 0, len(event.Targets))
+	for _, target := range event.Targets {
+		result := port.DeliveryResult{Channel: target.Channel, ChatID: target.ChatID, Status: port.DeliveryStatusSent}
+		if target.Channel == d.deferredChannel && !d.deferred {
+			result.Status = port.DeliveryStatusDeferred
+			result.ErrorClass = port.ErrorClassCircuitOpen
+			result.RetryAfter = 10 * time.Millisecond
+		}
+		results = append(results, result)
+	}
+	d.deferred = true
+
+	return results
+}
+
+func TestDeliveryQueue_Requeues_Deferred_Targets_In_Issue_Order(t *testing.T) {
+	dispatcher := &deferringDispatcher{deferredChannel: port.ChannelVKTeams}
+	cfg := config.DeliveryConfig{
+		Workers:   1,
+		QueueSize: 10,
+		Retry:     config.RetryConfig{RetryPolicyConfig: config.RetryPolicyConfig{MaxAttempts: 5, MaxAge: 300}},
+	}
+	queue := NewDeliveryQueue(cfg, dispatcher, newTestQueueLogger())
+	queue.Start()
+
+	first := port.NotificationEvent{
+		ID:  "event-1",
+		Key: "DEMO-1",
+		Targets: []port.NotificationTarget{
+			{Channel: port.ChannelTelegram, ChatID: "tg_chat"},
+			{Channel: port.ChannelVKTeams, ChatID: "vk_chat"},
+		},
+		ReceivedAt: time.Now(),
+		Merged:     []string{"event-0"},
+	}
+	second := port.NotificationEvent{
+		ID:         "event-2",
+		Key:        "DEMO-1",
+		Targets:    []port.NotificationTarget{{Channel: port.ChannelTelegram, ChatID: "tg_chat"}},
+		ReceivedAt: time.Now(),
+	}
+	for _, event := range []port.NotificationEvent{first, second} {
+		if err := queue.Enqueue(event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(dispatcher.events()) < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := queue.Stop(context.Background()); err != nil {
+		t.Fatalf("unexpected error on stop: %v", err)
+	}
+
+	events := dispatcher.events()
+	if len(events) != 3 {
+		t.Fatalf("expected 3 dispatches, got: %d", len(events))
+	}
+	requeued := events[1]
+	if requeued.ID != "event-1" || len(requeued.Merged) != 1 || requeued.Merged[0] != "event-0" {
+		t.Errorf("expected requeued event to keep ID and merged events, got: %+v", requeued)
+	}
+	expectedTargets := []port.NotificationTarget{{Channel: port.ChannelVKTeams, ChatID: "vk_chat"}}
+	if fmt.Sprint(requeued.Targets) != fmt.Sprint(expectedTargets) {
+		t.Errorf("expected only deferred targets %v, got: %v", expectedTargets, requeued.Targets)
+	}
+	if events[2].ID != "event-2" {
+		t.Errorf("expected next event of the issue to wait for deferred delivery, got order: %s, %s, %s", events[0].ID, events[1].ID, events[2].ID)
+	}
+	if failed := dispatcher.failedEvents(); len(failed) != 0 {
+		t.Errorf("expected no failed events, got: %v", failed)
+	}
+}
+
+func TestDeliveryQueue_Deferred_Delivery_Exceeds_Retry_Policy(t *testing.T) {
+	type testCase struct {
+		name          string
+		policy        config.RetryPolicyConfig
+		receivedAgo   time.Duration
+		expectedSends int
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Max_Age_Exceeded",
+			policy:        config.RetryPolicyConfig{MaxAttempts: 5, MaxAge: 5},
+			receivedAgo:   10 * time.Second,
+			expectedSends: 1,
+		},
+		{
+			name:          "Max_Attempts_Exceeded",
+			policy:        config.RetryPolicyConfig{MaxAttempts: 2, MaxAge: 300},
+			expectedSends: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := newTestQueueLogger()
+			mockSender := mocks.NewMockNotificationSender(ctrl)
+			mockParser := mocks.NewMockYoutrackParser(ctrl)
+			mockFormatter := mocks.NewMockYoutrackFormatter(ctrl)
+			mockOutbox := mocks.NewMockOutbox(ctrl)
+			mockStore := mocks.NewMockDeadLetterStore(ctrl)
+
+			target := port.NotificationTarget{Channel: port.ChannelVKTeams, ChatID: "vk_chat"}
+			event := port.NotificationEvent{
+				ID:         "event-1",
+				Key:        "DEMO-1",
+				Project:    "demo",
+				Payload:    &parser.YoutrackWebhookPayload{},
+				Targets:    []port.NotificationTarget{target},
+				ReceivedAt: time.Now().Add(-tc.receivedAgo),
+			}
+
+			// Выключатель канала остается разомкнутым на все время теста
+			mockParser.EXPECT().NewFormatter().Return(mockFormatter).Times(tc.expectedSends)
+			mockFormatter.EXPECT().RegisterChannelFormatter(gomock.Any(), gomock.Any()).AnyTimes()
+			mockFormatter.EXPECT().Format(gomock.Any(), gomock.Any()).Return("message").Times(tc.expectedSends)
+			mockSender.EXPECT().Send(gomock.Any(), port.ChannelVKTeams, "vk_chat", "message").
+				Return(circuitOpenError(port.ChannelVKTeams, 10*time.Millisecond)).Times(tc.expectedSends)
+			mockOutbox.EXPECT().MarkTarget("event-1", target, port.DeliveryStateFailed).Return(nil)
+
+			deadLettered := make(chan port.DeadLetter, 1)
+			mockStore.EXPECT().Add(gomock.Any()).DoAndReturn(func(letter port.DeadLetter) error {
+				deadLettered <- letter
+				return nil
+			})
+
+			dispatcher := NewDispatcher(mockSender, mockParser, mockOutbox, mockStore, logger)
+			cfg := config.DeliveryConfig{Workers: 1, QueueSize: 10, Retry: config.RetryConfig{RetryPolicyConfig: tc.policy}}
+			queue := NewDeliveryQueue(cfg, dispatcher, logger)
+			queue.Start()
+
+			if err := queue.Enqueue(event); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			select {
+			case letter := <-deadLettered:
+				if letter.EventID != "event-1" || letter.Target != target {
+					t.Errorf("unexpected dead letter: %+v", letter)
+				}
+				if letter.Attempts != tc.expectedSends {
+					t.Errorf("expected dead letter to report %d attempts, got: %d", tc.expectedSends, letter.Attempts)
+				}
+				if !strings.Contains(letter.LastError, "circuit breaker") {
+					t.Errorf("expected circuit breaker error, got: %q", letter.LastError)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected deferred delivery to be dead-lettered")
+			}
+
+			// Повтор, запланированный после отказа, выполнился бы не раньше чем через секунду
+			time.Sleep(1200 * time.Millisecond)
+			if err := queue.Stop(context.Background()); err != nil {
+				t.Fatalf("unexpected error on stop: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/adapter/formatter"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/sirupsen/logrus"
+)
+
+// Dispatcher форматирует событие YouTrack и отправляет его через каналы уведомлений
+type Dispatcher struct {
+	notificationSender port.NotificationSender
+	youtrackParser     parser.YoutrackParser
+	outbox             port.Outbox
+	deadLetters        port.DeadLetterStore
+	logger             *logrus.Logger
+}
+
+// NewDispatcher создает новый экземпляр отправки событий в каналы уведомлений
+// outbox может быть nil - в этом случае состояние доставки не записывается
+// deadLetters может быть nil - в этом случае недоставленные уведомления только логируются
+func NewDispatcher(notificationSender port.NotificationSender, youtrackParser parser.YoutrackParser, outbox port.Outbox, deadLetters port.DeadLetterStore, logger *logrus.Logger) port.NotificationDispatcher {
+	return &Dispatcher{
+		notificationSender: notificationSender,
+		youtrackParser:     youtrackParser,
+		outbox:             outbox,
+		deadLetters:        deadLetters,
+		logger:             logger,
+	}
+}
+
+// Dispatch форматирует событие для каждого адресата, отправляет его и возвращает результат отправки по адресатам
+// Ошибка отправки в один канал не прерывает отправку в остальные
+// Если контекст отменен (например, при остановке сервиса), состояние доставки не записывается,
+// чтобы событие осталось в журнале и было доставлено после перезапуска.
+// Если выключатель канала разомкнут, отправка адресату откладывается (DeliveryStatusDeferred): состояние доставки
+// не записывается и уведомление не сохраняется как недоставленное, повторную отправку выполняет очередь доставки,
+// а после исчерпания политики повторов завершает доставку через Fail
+func (d *Dispatcher) Dispatch(ctx context.Context, event port.NotificationEvent) []port.DeliveryResult {
+	if len(event.Targets) == 0 {
+		return nil
+	}
+
+	// Идентификатор события передается каналам для идемпотентной отправки при повторах
+	ctx = port.ContextWithEventID(ctx, event.ID)
+
+	youtrackFormatter := d.youtrackParser.NewFormatter()
+
+	// Регистрируем специальное форматирование для Telegram канала
+	youtrackFormatter.RegisterChannelFormatter(port.ChannelTelegram, formatter.FormatTelegram)
+	// Регистрируем форматирование для VK Teams канала (с измененным блоком "Упомянуты:")
+	youtrackFormatter.RegisterChannelFormatter(port.ChannelVKTeams, formatter.FormatVKTeams)
+
+	results := make([]port.DeliveryResult, 0, len(event.Targets))
+	for _, target := range event.Targets {
+		// Форматируем уведомление для конкретного канала
+		formattedMessage := youtrackFormatter.Format(event.Payload, target.Channel)
+
+		result := port.DeliveryResult{Channel: target.Channel, ChatID: target.ChatID, Status: port.DeliveryStatusSent}
+		state := port.DeliveryStateDelivered
+		if err := d.notificationSender.Send(ctx, target.Channel, target.ChatID, formattedMessage); err != nil {
+			result.Status = port.DeliveryStatusFailed
+			result.ErrorClass = port.ErrorClass(err)
+			if errors.Is(ctx.Err(), context.Canceled) {
+				d.logger.WithError(err).WithFields(logrus.Fields{
+					"channel": target.Channel,
+					"project": event.Project,
+					"issue":   event.Key,
+				}).Warn("Notification delivery canceled")
+				results = append(results, result)
+				continue
+			}
+			if errors.Is(err, port.ErrCircuitOpen) {
+				result.Status = port.DeliveryStatusDeferred
+				result.RetryAfter = port.RetryAfter(err)
+				d.logger.WithFields(logrus.Fields{
+					"channel":     target.Channel,
+					"project":     event.Project,
+					"issue":       event.Key,
+					"retry_after": result.RetryAfter.String(),
+				}).Warn("Channel circuit breaker is open, notification delivery deferred")
+				results = append(results, result)
+				continue
+			}
+			state = port.DeliveryStateFailed
+			d.logger.WithError(err).WithFields(logrus.Fields{
+				"channel": target.Channel,
+				"project": event.Project,
+				"issue":   event.Key,
+			}).Error("Failed to send notification to channel")
+			d.addDeadLetter(event, target, err)
+		}
+
+		results = append(results, result)
+		d.recordState(event, target, state)
+	}
+
+	return results
+}
+
+// Fail завершает доставку события адресатам ошибкой, записывает состояние доставки и сохраняет уведомления
+// как недоставленные. Используется очередью доставки, когда отложенная доставка выходит за пределы политики повторов
+func (d *Dispatcher) Fail(event port.NotificationEvent, err error) {
+	for _, target := range event.Targets {
+		d.logger.WithError(err).WithFields(logrus.Fields{
+			"channel": target.Channel,
+			"project": event.Project,
+			"issue":   event.Key,
+		}).Error("Failed to send notification to channel")
+		d.addDeadLetter(event, target, err)
+		d.recordState(event, target, port.DeliveryStateFailed)
+	}
+}
+
+// recordState записывает итоговое состояние доставки адресату в журнал
+// Состояние записывается и для событий, объединенных с данным
+func (d *Dispatcher) recordState(event port.NotificationEvent, target port.NotificationTarget, state port.DeliveryState) {
+	if d.outbox == nil || event.ID == "" {
+		return
+	}
+
+	for _, eventID := range append([]string{event.ID}, event.Merged...) {
+		if err := d.outbox.MarkTarget(eventID, target, state); err != nil {
+			d.logger.WithError(err).WithFields(logrus.Fields{
+				"event_id": eventID,
+				"channel":  target.Channel,
+			}).Warn("Failed to record delivery state in outbox")
+		}
+	}
+}
+
+// addDeadLetter сохраняет уведомление, доставка которого адресату окончательно не удалась
+func (d *Dispatcher) addDeadLetter(event port.NotificationEvent, target port.NotificationTarget, sendErr error) {
+	if d.deadLetters == nil {
+		return
+	}
+
+	letter := port.DeadLetter{
+		ID:         newEventID(),
+		EventID:    event.ID,
+		Key:        event.Key,
+		Project:    event.Project,
+		Payload:    event.Payload,
+		Target:     target,
+		LastError:  sendErr.Error(),
+		Attempts:   port.Attempts(sendErr),
+		ReceivedAt: event.ReceivedAt,
+		FailedAt:   nowFunc(),
+	}
+
+	if err := d.deadLetters.Add(letter); err != nil {
+		d.logger.WithError(err).WithFields(logrus.Fields{
+			"event_id": event.ID,
+			"channel":  target.Channel,
+		}).Error("Failed to store dead letter")
+		return
+	}
+
+	d.logger.WithFields(logrus.Fields{
+		"dead_letter_id": letter.ID,
+		"channel":        target.Channel,
+		"issue":          event.Key,
+	}).Info("Undelivered notification stored as dead letter")
+}
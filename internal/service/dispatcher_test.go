@@ -0,0 +1,495 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/adapter/notification/channel"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewDispatcher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dispatcher := NewDispatcher(mocks.NewMockNotificationSender(ctrl), mocks.NewMockYoutrackParser(ctrl), nil, nil, logrus.New())
+	if dispatcher == nil {
+		t.Fatal("expected dispatcher to be created, got: nil")
+	}
+
+	if _, ok := dispatcher.(port.NotificationDispatcher); !ok {
+		t.Error("expected dispatcher to implement NotificationDispatcher interface")
+	}
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	type testCase struct {
+		name            string
+		targets         []port.NotificationTarget
+		sendErrors      map[string]error
+		expectedResults []port.DeliveryResult
+	}
+
+	testCases := []testCase{
+		{
+			name:    "No_Targets_Does_Nothing",
+			targets: nil,
+		},
+		{
+			name: "Sends_To_All_Targets",
+			targets: []port.NotificationTarget{
+				{Channel: port.ChannelLogger},
+				{Channel: port.ChannelTelegram, ChatID: "tg_chat"},
+				{Channel: port.ChannelVKTeams, ChatID: "vk_chat"},
+			},
+			expectedResults: []port.DeliveryResult{
+				{Channel: port.ChannelLogger, Status: port.DeliveryStatusSent},
+				{Channel: port.ChannelTelegram, ChatID: "tg_chat", Status: port.DeliveryStatusSent},
+				{Channel: port.ChannelVKTeams, ChatID: "vk_chat", Status: port.DeliveryStatusSent},
+			},
+		},
+		{
+			name: "Send_Error_Does_Not_Stop_Other_Targets",
+			targets: []port.NotificationTarget{
+				{Channel: port.ChannelTelegram, ChatID: "tg_chat"},
+				{Channel: port.ChannelVKTeams, ChatID: "vk_chat"},
+			},
+			sendErrors: map[string]error{
+				port.ChannelTelegram: port.NewDeliveryError(port.ChannelTelegram, http.StatusBadGateway, errors.New("telegram is down")),
+			},
+			expectedResults: []port.DeliveryResult{
+				{Channel: port.ChannelTelegram, ChatID: "tg_chat", Status: port.DeliveryStatusFailed, ErrorClass: port.ErrorClassTransient},
+				{Channel: port.ChannelVKTeams, ChatID: "vk_chat", Status: port.DeliveryStatusSent},
+			},
+		},
+		{
+			name: "Error_Classes_Reported",
+			targets: []port.NotificationTarget{
+				{Channel: port.ChannelTelegram, ChatID: "tg_chat"},
+				{Channel: port.ChannelVKTeams, ChatID: "vk_chat"},
+				{Channel: port.ChannelLogger},
+			},
+			sendErrors: map[string]error{
+				port.ChannelTelegram: port.NewDeliveryError(port.ChannelTelegram, http.StatusBadRequest, errors.New("chat not found")),
+				port.ChannelVKTeams:  circuitOpenError(port.ChannelVKTeams, 30*time.Second),
+				port.ChannelLogger:   fmt.Errorf("delivery deadline exceeded: %w", context.DeadlineExceeded),
+			},
+			expectedResults: []port.DeliveryResult{
+				{Channel: port.ChannelTelegram, ChatID: "tg_chat", Status: port.DeliveryStatusFailed, ErrorClass: port.ErrorClassPermanent},
+				{Channel: port.ChannelVKTeams, ChatID: "vk_chat", Status: port.DeliveryStatusDeferred, ErrorClass: port.ErrorClassCircuitOpen, RetryAfter: 30 * time.Second},
+				{Channel: port.ChannelLogger, Status: port.DeliveryStatusFailed, ErrorClass: port.ErrorClassTimeout},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockSender := mocks.NewMockNotificationSender(ctrl)
+			mockParser := mocks.NewMockYoutrackParser(ctrl)
+			mockFormatter := mocks.NewMockYoutrackFormatter(ctrl)
+
+			payload := &parser.YoutrackWebhookPayload{Issue: parser.YoutrackIssue{IDReadable: "DEMO-1"}}
+
+			if len(tc.targets) > 0 {
+				mockParser.EXPECT().NewFormatter().Return(mockFormatter)
+				mockFormatter.EXPECT().RegisterChannelFormatter(port.ChannelTelegram, gomock.Any())
+				mockFormatter.EXPECT().RegisterChannelFormatter(port.ChannelVKTeams, gomock.Any())
+
+				for _, target := range tc.targets {
+					mockFormatter.EXPECT().Format(payload, target.Channel).Return("formatted for " + target.Channel)
+					mockSender.EXPECT().Send(gomock.Any(), target.Channel, target.ChatID, "formatted for "+target.Channel).Return(tc.sendErrors[target.Channel])
+				}
+			}
+
+			dispatcher := NewDispatcher(mockSender, mockParser, nil, nil, logger)
+			results := dispatcher.Dispatch(context.Background(), port.NotificationEvent{
+				Key:     "DEMO-1",
+				Project: "demo",
+				Payload: payload,
+				Targets: tc.targets,
+			})
+
+			if diff := cmp.Diff(tc.expectedResults, results); diff != "" {
+				t.Errorf("results mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// circuitOpenError возвращает ошибку отправки через разомкнутый выключатель канала
+func circuitOpenError(channel string, retryAfter time.Duration) error {
+	err := port.NewDeliveryError(channel, 0, fmt.Errorf("%s: %w", channel, port.ErrCircuitOpen))
+	err.RetryAfter = retryAfter
+	return err
+}
+
+func TestDispatcher_Dispatch_CircuitOpen_Leaves_Target_Pending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	mockSender := mocks.NewMockNotificationSender(ctrl)
+	mockParser := mocks.NewMockYoutrackParser(ctrl)
+	mockFormatter := mocks.NewMockYoutrackFormatter(ctrl)
+	mockOutbox := mocks.NewMockOutbox(ctrl)
+	mockStore := mocks.NewMockDeadLetterStore(ctrl)
+
+	payload := &parser.YoutrackWebhookPayload{}
+	telegram := port.NotificationTarget{Channel: port.ChannelTelegram, ChatID: "tg_chat"}
+	vkteams := port.NotificationTarget{Channel: port.ChannelVKTeams, ChatID: "vk_chat"}
+
+	mockParser.EXPECT().NewFormatter().Return(mockFormatter)
+	mockFormatter.EXPECT().RegisterChannelFormatter(gomock.Any(), gomock.Any()).Times(2)
+	mockFormatter.EXPECT().Format(payload, gomock.Any()).Return("message").Times(2)
+	mockSender.EXPECT().Send(gomock.Any(), port.ChannelTelegram, "tg_chat", "message").Return(nil)
+	mockSender.EXPECT().Send(gomock.Any(), port.ChannelVKTeams, "vk_chat", "message").
+		Return(&port.AttemptsError{Attempts: 2, Err: circuitOpenError(port.ChannelVKTeams, 10*time.Second)})
+	// Состояние записывается только для доставленного адресата, недоставленное уведомление не сохраняется
+	mockOutbox.EXPECT().MarkTarget("event-1", telegram, port.DeliveryStateDelivered).Return(nil)
+	mockStore.EXPECT().Add(gomock.Any()).Times(0)
+
+	dispatcher := NewDispatcher(mockSender, mockParser, mockOutbox, mockStore, logger)
+	results := dispatcher.Dispatch(context.Background(), port.NotificationEvent{
+		ID:      "event-1",
+		Payload: payload,
+		Targets: []port.NotificationTarget{telegram, vkteams},
+	})
+
+	expected := []port.DeliveryResult{
+		{Channel: port.ChannelTelegram, ChatID: "tg_chat", Status: port.DeliveryStatusSent},
+		{Channel: port.ChannelVKTeams, ChatID: "vk_chat", Status: port.DeliveryStatusDeferred, ErrorClass: port.ErrorClassCircuitOpen, RetryAfter: 10 * time.Second},
+	}
+	if diff := cmp.Diff(expected, results); diff != "" {
+		t.Errorf("results mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDispatcher_Dispatch_RecordsOutboxState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	mockSender := mocks.NewMockNotificationSender(ctrl)
+	mockParser := mocks.NewMockYoutrackParser(ctrl)
+	mockFormatter := mocks.NewMockYoutrackFormatter(ctrl)
+	mockOutbox := mocks.NewMockOutbox(ctrl)
+
+	payload := &parser.YoutrackWebhookPayload{}
+	telegram := port.NotificationTarget{Channel: port.ChannelTelegram, ChatID: "tg_chat"}
+	vkteams := port.NotificationTarget{Channel: port.ChannelVKTeams, ChatID: "vk_chat"}
+
+	mockParser.EXPECT().NewFormatter().Return(mockFormatter)
+	mockFormatter.EXPECT().RegisterChannelFormatter(gomock.Any(), gomock.Any()).Times(2)
+	mockFormatter.EXPECT().Format(payload, gomock.Any()).Return("message").Times(2)
+	mockSender.EXPECT().Send(gomock.Any(), port.ChannelTelegram, "tg_chat", "message").Return(nil)
+	mockSender.EXPECT().Send(gomock.Any(), port.ChannelVKTeams, "vk_chat", "message").Return(errors.New("vk teams is down"))
+	mockOutbox.EXPECT().MarkTarget("event-1", telegram, port.DeliveryStateDelivered).Return(nil)
+	mockOutbox.EXPECT().MarkTarget("event-1", vkteams, port.DeliveryStateFailed).Return(errors.New("disk full"))
+
+	dispatcher := NewDispatcher(mockSender, mockParser, mockOutbox, nil, logger)
+	dispatcher.Dispatch(context.Background(), port.NotificationEvent{
+		ID:      "event-1",
+		Payload: payload,
+		Targets: []port.NotificationTarget{telegram, vkteams},
+	})
+}
+
+func TestDispatcher_Dispatch_PassesEventIDToChannels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	mockSender := mocks.NewMockNotificationSender(ctrl)
+	mockParser := mocks.NewMockYoutrackParser(ctrl)
+	mockFormatter := mocks.NewMockYoutrackFormatter(ctrl)
+
+	payload := &parser.YoutrackWebhookPayload{}
+
+	mockParser.EXPECT().NewFormatter().Return(mockFormatter)
+	mockFormatter.EXPECT().RegisterChannelFormatter(gomock.Any(), gomock.Any()).Times(2)
+	mockFormatter.EXPECT().Format(payload, port.ChannelMatrix).Return("message")
+	mockSender.EXPECT().Send(gomock.Any(), port.ChannelMatrix, "!room:example.com", "message").DoAndReturn(
+		func(ctx context.Context, channel string, chatID string, message string) error {
+			if eventID := port.EventIDFromContext(ctx); eventID != "event-1" {
+				t.Errorf("expected event ID %q in context, got: %q", "event-1", eventID)
+			}
+			return nil
+		})
+
+	dispatcher := NewDispatcher(mockSender, mockParser, nil, nil, logger)
+	dispatcher.Dispatch(context.Background(), port.NotificationEvent{
+		ID:      "event-1",
+		Payload: payload,
+		Targets: []port.NotificationTarget{{Channel: port.ChannelMatrix, ChatID: "!room:example.com"}},
+	})
+}
+
+func TestDispatcher_Dispatch_RecordsOutboxStateForMergedEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	mockSender := mocks.NewMockNotificationSender(ctrl)
+	mockParser := mocks.NewMockYoutrackParser(ctrl)
+	mockFormatter := mocks.NewMockYoutrackFormatter(ctrl)
+	mockOutbox := mocks.NewMockOutbox(ctrl)
+
+	payload := &parser.YoutrackWebhookPayload{}
+	telegram := port.NotificationTarget{Channel: port.ChannelTelegram, ChatID: "tg_chat"}
+
+	mockParser.EXPECT().NewFormatter().Return(mockFormatter)
+	mockFormatter.EXPECT().RegisterChannelFormatter(gomock.Any(), gomock.Any()).Times(2)
+	mockFormatter.EXPECT().Format(payload, port.ChannelTelegram).Return("message")
+	mockSender.EXPECT().Send(gomock.Any(), port.ChannelTelegram, "tg_chat", "message").Return(nil)
+	gomock.InOrder(
+		mockOutbox.EXPECT().MarkTarget("event-1", telegram, port.DeliveryStateDelivered).Return(nil),
+		mockOutbox.EXPECT().MarkTarget("event-2", telegram, port.DeliveryStateDelivered).Return(nil),
+		mockOutbox.EXPECT().MarkTarget("event-3", telegram, port.DeliveryStateDelivered).Return(nil),
+	)
+
+	dispatcher := NewDispatcher(mockSender, mockParser, mockOutbox, nil, logger)
+	dispatcher.Dispatch(context.Background(), port.NotificationEvent{
+		ID:      "event-1",
+		Payload: payload,
+		Targets: []port.NotificationTarget{telegram},
+		Merged:  []string{"event-2", "event-3"},
+	})
+}
+
+func TestDispatcher_Dispatch_StoresDeadLetters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	originalNow := nowFunc
+	nowFunc = func() time.Time { return now }
+	defer func() {
+		nowFunc = originalNow
+	}()
+
+	mockSender := mocks.NewMockNotificationSender(ctrl)
+	mockParser := mocks.NewMockYoutrackParser(ctrl)
+	mockFormatter := mocks.NewMockYoutrackFormatter(ctrl)
+	mockStore := mocks.NewMockDeadLetterStore(ctrl)
+
+	payload := &parser.YoutrackWebhookPayload{Issue: parser.YoutrackIssue{IDReadable: "DEMO-1"}}
+	telegram := port.NotificationTarget{Channel: port.ChannelTelegram, ChatID: "tg_chat"}
+	vkteams := port.NotificationTarget{Channel: port.ChannelVKTeams, ChatID: "vk_chat"}
+	receivedAt := now.Add(-time.Minute)
+
+	mockParser.EXPECT().NewFormatter().Return(mockFormatter)
+	mockFormatter.EXPECT().RegisterChannelFormatter(gomock.Any(), gomock.Any()).Times(2)
+	mockFormatter.EXPECT().Format(payload, gomock.Any()).Return("message").Times(2)
+	mockSender.EXPECT().Send(gomock.Any(), port.ChannelTelegram, "tg_chat", "message").Return(nil)
+	mockSender.EXPECT().Send(gomock.Any(), port.ChannelVKTeams, "vk_chat", "message").
+		Return(&port.AttemptsError{Attempts: 3, Err: errors.New("vk teams is down")})
+	mockStore.EXPECT().Add(gomock.Any()).DoAndReturn(func(letter port.DeadLetter) error {
+		if len(letter.ID) != 32 {
+			t.Errorf("expected 32 character dead letter id, got: %q", letter.ID)
+		}
+		letter.ID = ""
+		expected := port.DeadLetter{
+			EventID:    "event-1",
+			Key:        "DEMO-1",
+			Project:    "demo",
+			Payload:    payload,
+			Target:     vkteams,
+			LastError:  "giving up after 3 attempts: vk teams is down",
+			Attempts:   3,
+			ReceivedAt: receivedAt,
+			FailedAt:   now,
+		}
+		if diff := cmp.Diff(expected, letter); diff != "" {
+			t.Errorf("dead letter mismatch (-want +got):\n%s", diff)
+		}
+		return nil
+	})
+
+	dispatcher := NewDispatcher(mockSender, mockParser, nil, mockStore, logger)
+	dispatcher.Dispatch(context.Background(), port.NotificationEvent{
+		ID:         "event-1",
+		Key:        "DEMO-1",
+		Project:    "demo",
+		Payload:    payload,
+		Targets:    []port.NotificationTarget{telegram, vkteams},
+		ReceivedAt: receivedAt,
+	})
+}
+
+func TestDispatcher_Dispatch_Dead_Letter_Does_Not_Store_Bot_Token(t *testing.T) {
+	type testCase struct {
+		name       string
+		newChannel func(httpClient port.HTTPClient, logger *logrus.Logger) port.NotificationChannel
+	}
+
+	const botToken = "123456:secret-bot-token"
+
+	testCases := []testCase{
+		{
+			name: "Telegram",
+			newChannel: func(httpClient port.HTTPClient, logger *logrus.Logger) port.NotificationChannel {
+				return channel.NewTelegramChannel(config.TelegramConfig{BotToken: botToken}, logger, httpClient)
+			},
+		},
+		{
+			name: "VK_Teams",
+			newChannel: func(httpClient port.HTTPClient, logger *logrus.Logger) port.NotificationChannel {
+				return channel.NewVKTeamsChannel(config.VKTeamsConfig{BotToken: botToken, ApiUrl: "https://api.example.com/bot/v1"}, logger, httpClient)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockSender := mocks.NewMockNotificationSender(ctrl)
+			mockParser := mocks.NewMockYoutrackParser(ctrl)
+			mockFormatter := mocks.NewMockYoutrackFormatter(ctrl)
+			mockStore := mocks.NewMockDeadLetterStore(ctrl)
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+
+			// HTTP клиент возвращает ошибку с полным URL запроса, как при недоступности API канала
+			mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				return nil, &url.Error{Op: req.Method, URL: req.URL.String(), Err: errors.New("connection refused")}
+			})
+			notificationChannel := tc.newChannel(mockHTTPClient, logger)
+
+			target := port.NotificationTarget{Channel: notificationChannel.Channel(), ChatID: "chat"}
+			mockParser.EXPECT().NewFormatter().Return(mockFormatter)
+			mockFormatter.EXPECT().RegisterChannelFormatter(gomock.Any(), gomock.Any()).Times(2)
+			mockFormatter.EXPECT().Format(gomock.Any(), gomock.Any()).Return("message")
+			mockSender.EXPECT().Send(gomock.Any(), target.Channel, "chat", "message").
+				DoAndReturn(func(ctx context.Context, _ string, chatID string, message string) error {
+					return notificationChannel.Send(ctx, chatID, message)
+				})
+			mockStore.EXPECT().Add(gomock.Any()).DoAndReturn(func(letter port.DeadLetter) error {
+				if letter.LastError == "" {
+					t.Error("expected dead letter to keep transport error")
+				}
+				if strings.Contains(letter.LastError, botToken) || strings.Contains(letter.LastError, url.QueryEscape(botToken)) {
+					t.Errorf("expected dead letter error without bot token, got: %q", letter.LastError)
+				}
+				return nil
+			})
+
+			dispatcher := NewDispatcher(mockSender, mockParser, nil, mockStore, logger)
+			dispatcher.Dispatch(context.Background(), port.NotificationEvent{
+				ID:      "event-1",
+				Key:     "DEMO-1",
+				Project: "demo",
+				Payload: &parser.YoutrackWebhookPayload{},
+				Targets: []port.NotificationTarget{target},
+			})
+		})
+	}
+}
+
+func TestDispatcher_Dispatch_CanceledContext(t *testing.T) {
+	type testCase struct {
+		name          string
+		ctx           func() (context.Context, context.CancelFunc)
+		expectedState bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "Canceled_Delivery_Stays_Pending",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			expectedState: false,
+		},
+		{
+			name: "Expired_Delivery_Deadline_Is_Failure",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+			},
+			expectedState: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockSender := mocks.NewMockNotificationSender(ctrl)
+			mockParser := mocks.NewMockYoutrackParser(ctrl)
+			mockFormatter := mocks.NewMockYoutrackFormatter(ctrl)
+			mockOutbox := mocks.NewMockOutbox(ctrl)
+			mockStore := mocks.NewMockDeadLetterStore(ctrl)
+
+			payload := &parser.YoutrackWebhookPayload{}
+			telegram := port.NotificationTarget{Channel: port.ChannelTelegram, ChatID: "tg_chat"}
+
+			ctx, cancel := tc.ctx()
+			defer cancel()
+
+			mockParser.EXPECT().NewFormatter().Return(mockFormatter)
+			mockFormatter.EXPECT().RegisterChannelFormatter(gomock.Any(), gomock.Any()).Times(2)
+			mockFormatter.EXPECT().Format(payload, port.ChannelTelegram).Return("message")
+			mockSender.EXPECT().Send(gomock.Any(), port.ChannelTelegram, "tg_chat", "message").DoAndReturn(
+				func(sendCtx context.Context, channel string, chatID string, message string) error {
+					// Каналу передается контекст доставки, дополненный идентификатором события
+					if !errors.Is(sendCtx.Err(), ctx.Err()) {
+						t.Errorf("expected context error %v, got: %v", ctx.Err(), sendCtx.Err())
+					}
+					return sendCtx.Err()
+				})
+			if tc.expectedState {
+				mockOutbox.EXPECT().MarkTarget("event-1", telegram, port.DeliveryStateFailed).Return(nil)
+				mockStore.EXPECT().Add(gomock.Any()).Return(nil)
+			}
+
+			dispatcher := NewDispatcher(mockSender, mockParser, mockOutbox, mockStore, logger)
+			results := dispatcher.Dispatch(ctx, port.NotificationEvent{
+				ID:      "event-1",
+				Payload: payload,
+				Targets: []port.NotificationTarget{telegram},
+			})
+
+			// Прерванная отправка отражается в результате как ошибка доставки, даже если событие остается в журнале
+			if len(results) != 1 || results[0].Status != port.DeliveryStatusFailed {
+				t.Errorf("expected failed delivery result, got: %+v", results)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// IdempotencyGuard отбрасывает события, уже полученные в пределах времени жизни ключа
+// Повторы возникают, когда workflow YouTrack срабатывает дважды или скрипт запускается повторно вручную
+type IdempotencyGuard struct {
+	header string
+	keys   *NonceCache
+}
+
+// NewIdempotencyGuard создает защиту от повторной обработки событий
+// Возвращает nil, если отбрасывание повторных событий выключено
+func NewIdempotencyGuard(cfg config.IdempotencyConfig) *IdempotencyGuard {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	return &IdempotencyGuard{
+		header: cfg.Header,
+		keys:   NewNonceCache(cfg.CacheSize, time.Duration(cfg.TTL)*time.Second),
+	}
+}
+
+// Key возвращает ключ идемпотентности события
+// Используется значение заголовка с ключом идемпотентности, при его отсутствии - хэш канонического представления payload
+func (g *IdempotencyGuard) Key(req *http.Request, payload *parser.YoutrackWebhookPayload) string {
+	if key := strings.TrimSpace(req.Header.Get(g.header)); key != "" {
+		return "header:" + key
+	}
+	return "payload:" + payloadHash(payload)
+}
+
+// Seen сообщает, встречался ли ключ в пределах времени жизни, и запоминает его
+func (g *IdempotencyGuard) Seen(key string) bool {
+	return g.keys.Seen(key, nowFunc())
+}
+
+// Forget удаляет ключ, чтобы повторная отправка события после ошибки не была отброшена
+func (g *IdempotencyGuard) Forget(key string) {
+	g.keys.Forget(key)
+}
+
+// payloadHash возвращает SHA-256 канонического JSON представления payload
+// Значения изменений приводятся к каноническому виду, поэтому порядок ключей и пробелы в них не влияют на хэш
+func payloadHash(payload *parser.YoutrackWebhookPayload) string {
+	canonical := *payload
+	canonical.Changes = make([]parser.YoutrackChange, len(payload.Changes))
+	for i, change := range payload.Changes {
+		canonical.Changes[i] = parser.YoutrackChange{
+			Field:    change.Field,
+			OldValue: canonicalJSON(change.OldValue),
+			NewValue: canonicalJSON(change.NewValue),
+		}
+	}
+
+	// Структура payload всегда сериализуется, ошибка невозможна
+	data, _ := json.Marshal(canonical)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalJSON возвращает JSON значение с отсортированными ключами объектов и без пробелов
+// Некорректный JSON представляется строкой, чтобы payload оставался сериализуемым
+func canonicalJSON(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return raw
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err == nil {
+		if data, marshalErr := json.Marshal(value); marshalErr == nil {
+			return data
+		}
+	}
+
+	quoted, _ := json.Marshal(string(raw))
+	return quoted
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewIdempotencyGuard(t *testing.T) {
+	if guard := NewIdempotencyGuard(config.IdempotencyConfig{}); guard != nil {
+		t.Error("expected nil guard when idempotency is disabled")
+	}
+
+	guard := NewIdempotencyGuard(config.IdempotencyConfig{Enabled: true, TTL: 60, CacheSize: 10, Header: config.DefaultIdempotencyHeader})
+	if guard == nil {
+		t.Fatal("expected guard when idempotency is enabled")
+	}
+}
+
+func TestIdempotencyGuard_Key(t *testing.T) {
+	type testCase struct {
+		name           string
+		header         string
+		payload        string
+		otherHeader    string
+		otherPayload   string
+		expectSameKey  bool
+		expectedPrefix string
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Idempotency_Header_Used_As_Key",
+			header:         "key-1",
+			payload:        `{"issue":{"idReadable":"DEMO-1"}}`,
+			otherHeader:    "key-1",
+			otherPayload:   `{"issue":{"idReadable":"DEMO-2"}}`,
+			expectSameKey:  true,
+			expectedPrefix: "header:",
+		},
+		{
+			name:           "Different_Idempotency_Headers_Differ",
+			header:         "key-1",
+			payload:        `{"issue":{"idReadable":"DEMO-1"}}`,
+			otherHeader:    "key-2",
+			otherPayload:   `{"issue":{"idReadable":"DEMO-1"}}`,
+			expectSameKey:  false,
+			expectedPrefix: "header:",
+		},
+		{
+			name:           "Same_Payload_Same_Key",
+			payload:        `{"project":{"name":"Demo"},"issue":{"idReadable":"DEMO-1"},"changes":[{"field":"State","oldValue":{"name":"Open","presentation":"Open"},"newValue":{"name":"Done"}}]}`,
+			otherPayload:   `{"issue":{"idReadable":"DEMO-1"},"project":{"name":"Demo"},"changes":[{"field":"State","oldValue":{"presentation":"Open", "name":"Open"},"newValue":{ "name" : "Done" }}]}`,
+			expectSameKey:  true,
+			expectedPrefix: "payload:",
+		},
+		{
+			name:           "Different_Change_Different_Key",
+			payload:        `{"issue":{"idReadable":"DEMO-1"},"changes":[{"field":"State","newValue":{"name":"Done"}}]}`,
+			otherPayload:   `{"issue":{"idReadable":"DEMO-1"},"changes":[{"field":"State","newValue":{"name":"Open"}}]}`,
+			expectSameKey:  false,
+			expectedPrefix: "payload:",
+		},
+	}
+
+	guard := NewIdempotencyGuard(config.IdempotencyConfig{Enabled: true, TTL: 60, CacheSize: 10, Header: config.DefaultIdempotencyHeader})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			first := guard.Key(newIdempotencyRequest(tc.header), decodePayload(t, tc.payload))
+			second := guard.Key(newIdempotencyRequest(tc.otherHeader), decodePayload(t, tc.otherPayload))
+
+			if !strings.HasPrefix(first, tc.expectedPrefix) {
+				t.Errorf("expected key with prefix %q, got: %q", tc.expectedPrefix, first)
+			}
+			if (first == second) != tc.expectSameKey {
+				t.Errorf("expected same key to be %v, got keys %q and %q", tc.expectSameKey, first, second)
+			}
+		})
+	}
+}
+
+func TestIdempotencyGuard_Seen(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	originalNow := nowFunc
+	nowFunc = func() time.Time { return now }
+	defer func() {
+		nowFunc = originalNow
+	}()
+
+	guard := NewIdempotencyGuard(config.IdempotencyConfig{Enabled: true, TTL: 60, CacheSize: 10, Header: config.DefaultIdempotencyHeader})
+
+	if guard.Seen("key") {
+		t.Error("expected first occurrence not to be seen")
+	}
+	if !guard.Seen("key") {
+		t.Error("expected repeated key within ttl to be seen")
+	}
+
+	guard.Forget("key")
+	if guard.Seen("key") {
+		t.Error("expected forgotten key not to be seen")
+	}
+
+	now = now.Add(61 * time.Second)
+	if guard.Seen("key") {
+		t.Error("expected key after ttl not to be seen")
+	}
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	type testCase struct {
+		name     string
+		raw      string
+		expected string
+	}
+
+	testCases := []testCase{
+		{name: "Empty_Value_Unchanged", raw: "", expected: ""},
+		{name: "Object_Keys_Sorted", raw: `{ "b": 1, "a": [ "x", null ] }`, expected: `{"a":["x",null],"b":1}`},
+		{name: "Large_Number_Preserved", raw: `12345678901234567890`, expected: `12345678901234567890`},
+		{name: "Invalid_JSON_Quoted", raw: `{broken`, expected: `"{broken"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := string(canonicalJSON(json.RawMessage(tc.raw))); got != tc.expected {
+				t.Errorf("expected %s, got: %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+// newIdempotencyRequest создает webhook запрос с заголовком ключа идемпотентности
+func newIdempotencyRequest(key string) *http.Request {
+	req := httptest.NewRequest("POST", "/webhook/youtrack", nil)
+	if key != "" {
+		req.Header.Set(config.DefaultIdempotencyHeader, key)
+	}
+	return req
+}
+
+// decodePayload разбирает JSON payload YouTrack для тестов
+func decodePayload(t *testing.T, body string) *parser.YoutrackWebhookPayload {
+	t.Helper()
+
+	var payload parser.YoutrackWebhookPayload
+	if err := json.Unmarshal([]byte(body), &payload); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	return &payload
+}
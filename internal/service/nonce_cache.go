@@ -0,0 +1,88 @@
+package service
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// nonceEntry запись кэша одноразовых идентификаторов
+type nonceEntry struct {
+	key       string
+	expiresAt time.Time
+}
+
+// NonceCache ограниченный по размеру кэш одноразовых идентификаторов с временем жизни записей
+// Записи вытесняются в порядке добавления: по истечении времени жизни или при превышении размера
+type NonceCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+// NewNonceCache создает новый кэш одноразовых идентификаторов
+func NewNonceCache(capacity int, ttl time.Duration) *NonceCache {
+	return &NonceCache{
+		ttl:      ttl,
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Seen сообщает, встречался ли ключ ранее, и запоминает его, если не встречался
+func (c *NonceCache) Seen(key string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.evictExpired(now)
+
+	if element, exists := c.entries[key]; exists {
+		if now.Before(element.Value.(*nonceEntry).expiresAt) {
+			return true
+		}
+		c.remove(element)
+	}
+
+	for c.capacity > 0 && c.order.Len() >= c.capacity {
+		c.remove(c.order.Front())
+	}
+
+	c.entries[key] = c.order.PushBack(&nonceEntry{key: key, expiresAt: now.Add(c.ttl)})
+	return false
+}
+
+// Forget удаляет ключ из кэша, чтобы следующее обращение с ним не считалось повторным
+func (c *NonceCache) Forget(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, exists := c.entries[key]; exists {
+		c.remove(element)
+	}
+}
+
+// Len возвращает количество записей в кэше
+func (c *NonceCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// evictExpired удаляет записи с истекшим временем жизни из начала очереди
+func (c *NonceCache) evictExpired(now time.Time) {
+	for element := c.order.Front(); element != nil; element = c.order.Front() {
+		if now.Before(element.Value.(*nonceEntry).expiresAt) {
+			return
+		}
+		c.remove(element)
+	}
+}
+
+// remove удаляет запись из очереди и индекса
+func (c *NonceCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*nonceEntry).key)
+}
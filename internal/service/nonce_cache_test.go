@@ -0,0 +1,111 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNonceCache_Seen(t *testing.T) {
+	type step struct {
+		key      string
+		offset   time.Duration
+		forget   bool
+		expected bool
+	}
+
+	type testCase struct {
+		name        string
+		capacity    int
+		ttl         time.Duration
+		steps       []step
+		expectedLen int
+	}
+
+	testCases := []testCase{
+		{
+			name:     "First_Occurrence_Is_Not_Seen",
+			capacity: 10,
+			ttl:      time.Minute,
+			steps: []step{
+				{key: "a", expected: false},
+				{key: "b", expected: false},
+			},
+			expectedLen: 2,
+		},
+		{
+			name:     "Repeated_Key_Within_TTL_Is_Seen",
+			capacity: 10,
+			ttl:      time.Minute,
+			steps: []step{
+				{key: "a", expected: false},
+				{key: "a", offset: 30 * time.Second, expected: true},
+			},
+			expectedLen: 1,
+		},
+		{
+			name:     "Repeated_Key_After_TTL_Is_Not_Seen",
+			capacity: 10,
+			ttl:      time.Minute,
+			steps: []step{
+				{key: "a", expected: false},
+				{key: "a", offset: 2 * time.Minute, expected: false},
+			},
+			expectedLen: 1,
+		},
+		{
+			name:     "Oldest_Key_Evicted_When_Capacity_Exceeded",
+			capacity: 2,
+			ttl:      time.Minute,
+			steps: []step{
+				{key: "a", expected: false},
+				{key: "b", expected: false},
+				{key: "c", expected: false},
+				{key: "a", expected: false},
+			},
+			expectedLen: 2,
+		},
+		{
+			name:     "Forgotten_Key_Is_Not_Seen",
+			capacity: 10,
+			ttl:      time.Minute,
+			steps: []step{
+				{key: "a", expected: false},
+				{key: "a", forget: true},
+				{key: "a", offset: 10 * time.Second, expected: false},
+			},
+			expectedLen: 1,
+		},
+		{
+			name:     "Expired_Keys_Evicted",
+			capacity: 10,
+			ttl:      time.Minute,
+			steps: []step{
+				{key: "a", expected: false},
+				{key: "b", expected: false},
+				{key: "c", offset: 5 * time.Minute, expected: false},
+			},
+			expectedLen: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cache := NewNonceCache(tc.capacity, tc.ttl)
+			start := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+			for i, s := range tc.steps {
+				if s.forget {
+					cache.Forget(s.key)
+					continue
+				}
+				if got := cache.Seen(s.key, start.Add(s.offset)); got != s.expected {
+					t.Errorf("step %d: expected Seen(%q) to be %v, got: %v", i, s.key, s.expected, got)
+				}
+			}
+
+			if cache.Len() != tc.expectedLen {
+				t.Errorf("expected cache length %d, got: %d", tc.expectedLen, cache.Len())
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+// defaultReplayRetryDelay задержка повторной постановки события при заполненной очереди,
+// если очередь не сообщила рекомендуемую задержку
+const defaultReplayRetryDelay = time.Second
+
+// OutboxQueue записывает принятые события в журнал до постановки в очередь доставки
+// При запуске повторно ставит в очередь события, доставка которых не завершилась до остановки сервиса,
+// и периодически сжимает журнал
+type OutboxQueue struct {
+	queue           port.DeliveryQueue
+	outbox          port.Outbox
+	compactInterval time.Duration
+	logger          *logrus.Logger
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewOutboxQueue создает очередь доставки с журналом принятых событий
+func NewOutboxQueue(queue port.DeliveryQueue, outbox port.Outbox, compactInterval time.Duration, logger *logrus.Logger) port.DeliveryQueue {
+	return &OutboxQueue{
+		queue:           queue,
+		outbox:          outbox,
+		compactInterval: compactInterval,
+		logger:          logger,
+		stop:            make(chan struct{}),
+	}
+}
+
+// Enqueue записывает событие в журнал и ставит его в очередь доставки
+// Если очередь не приняла событие, оно исключается из журнала: клиент получит ошибку и не ждет доставки
+func (q *OutboxQueue) Enqueue(event port.NotificationEvent) error {
+	if err := q.outbox.Append(event); err != nil {
+		q.logger.WithError(err).WithFields(logrus.Fields{
+			"event_id": event.ID,
+		}).Error("Failed to write event to outbox")
+		return fmt.Errorf("%w: outbox is not writable: %w", port.ErrUnavailable, err)
+	}
+
+	if err := q.queue.Enqueue(event); err != nil {
+		if removeErr := q.outbox.Remove(event.ID); removeErr != nil {
+			q.logger.WithError(removeErr).WithFields(logrus.Fields{
+				"event_id": event.ID,
+			}).Warn("Failed to remove rejected event from outbox")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Start запускает очередь доставки, повторную постановку незавершенных событий и сжатие журнала
+func (q *OutboxQueue) Start() {
+	pending := q.outbox.Pending()
+	q.queue.Start()
+
+	q.wg.Add(2)
+	go q.replay(pending)
+	go q.compactLoop()
+}
+
+// Stop останавливает очередь доставки, сжимает и закрывает журнал
+// События, не доставленные до истечения контекста, остаются в журнале до следующего запуска
+func (q *OutboxQueue) Stop(ctx context.Context) error {
+	q.stopOnce.Do(func() {
+		close(q.stop)
+	})
+	q.wg.Wait()
+
+	stopErr := q.queue.Stop(ctx)
+
+	compactErr := q.outbox.Compact()
+	if compactErr != nil {
+		compactErr = fmt.Errorf("failed to compact outbox: %w", compactErr)
+	}
+
+	return errors.Join(stopErr, compactErr, q.outbox.Close())
+}
+
+// replay ставит в очередь события из журнала, ожидая освобождения места в очереди
+// События ставятся в порядке приема, поэтому порядок доставки по одной задаче сохраняется
+func (q *OutboxQueue) replay(pending []port.NotificationEvent) {
+	defer q.wg.Done()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	q.logger.WithFields(logrus.Fields{
+		"events": len(pending),
+	}).Info("Replaying undelivered events from outbox")
+
+	for _, event := range pending {
+		for {
+			err := q.queue.Enqueue(event)
+			if err == nil {
+				break
+			}
+
+			delay := defaultReplayRetryDelay
+			var unavailableErr *port.UnavailableError
+			if errors.As(err, &unavailableErr) && unavailableErr.RetryAfter > 0 {
+				delay = unavailableErr.RetryAfter
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-q.stop:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// compactLoop периодически сжимает журнал
+func (q *OutboxQueue) compactLoop() {
+	defer q.wg.Done()
+
+	if q.compactInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(q.compactInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.stop:
+			return
+		case <-ticker.C:
+			if err := q.outbox.Compact(); err != nil {
+				q.logger.WithError(err).Error("Failed to compact outbox")
+			}
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestOutboxQueue_Enqueue(t *testing.T) {
+	type testCase struct {
+		name          string
+		appendError   error
+		enqueueError  error
+		expectEnqueue bool
+		expectRemove  bool
+		expectedError error
+	}
+
+	queueFull := &port.UnavailableError{Reason: "delivery queue is full", RetryAfter: time.Second}
+
+	testCases := []testCase{
+		{
+			name:          "Event_Journaled_Then_Queued",
+			expectEnqueue: true,
+		},
+		{
+			name:          "Journal_Write_Error_Returns_Unavailable",
+			appendError:   errors.New("disk full"),
+			expectedError: port.ErrUnavailable,
+		},
+		{
+			name:          "Rejected_Event_Removed_From_Journal",
+			enqueueError:  queueFull,
+			expectEnqueue: true,
+			expectRemove:  true,
+			expectedError: queueFull,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQueue := mocks.NewMockDeliveryQueue(ctrl)
+			mockOutbox := mocks.NewMockOutbox(ctrl)
+			event := port.NotificationEvent{ID: "event-1", Key: "DEMO-1"}
+
+			calls := []*gomock.Call{mockOutbox.EXPECT().Append(event).Return(tc.appendError)}
+			if tc.expectEnqueue {
+				calls = append(calls, mockQueue.EXPECT().Enqueue(event).Return(tc.enqueueError))
+			}
+			if tc.expectRemove {
+				calls = append(calls, mockOutbox.EXPECT().Remove("event-1").Return(nil))
+			}
+			gomock.InOrder(calls...)
+
+			queue := NewOutboxQueue(mockQueue, mockOutbox, time.Minute, newTestQueueLogger())
+			err := queue.Enqueue(event)
+
+			if tc.expectedError != nil {
+				if !errors.Is(err, tc.expectedError) {
+					t.Errorf("expected error %v, got: %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestOutboxQueue_StartReplaysPendingEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueue := mocks.NewMockDeliveryQueue(ctrl)
+	mockOutbox := mocks.NewMockOutbox(ctrl)
+
+	pending := []port.NotificationEvent{{ID: "1", Key: "DEMO-1"}, {ID: "2", Key: "DEMO-1"}}
+	replayed := make(chan string, len(pending))
+	record := func(event port.NotificationEvent) error {
+		replayed <- event.ID
+		return nil
+	}
+
+	gomock.InOrder(
+		mockOutbox.EXPECT().Pending().Return(pending),
+		mockQueue.EXPECT().Start(),
+		// Первое событие не помещается в очередь и ставится повторно после задержки
+		mockQueue.EXPECT().Enqueue(pending[0]).Return(&port.UnavailableError{Reason: "delivery queue is full", RetryAfter: 10 * time.Millisecond}),
+		mockQueue.EXPECT().Enqueue(pending[0]).DoAndReturn(record),
+		mockQueue.EXPECT().Enqueue(pending[1]).DoAndReturn(record),
+	)
+
+	queue := NewOutboxQueue(mockQueue, mockOutbox, time.Hour, newTestQueueLogger())
+	queue.Start()
+
+	for _, expected := range []string{"1", "2"} {
+		select {
+		case id := <-replayed:
+			if id != expected {
+				t.Errorf("expected event %s to be replayed, got: %s", expected, id)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("event %s was not replayed", expected)
+		}
+	}
+
+	mockQueue.EXPECT().Stop(gomock.Any()).Return(nil)
+	mockOutbox.EXPECT().Compact().Return(nil)
+	mockOutbox.EXPECT().Close().Return(nil)
+
+	if err := queue.Stop(context.Background()); err != nil {
+		t.Errorf("unexpected stop error: %v", err)
+	}
+}
+
+func TestOutboxQueue_Stop(t *testing.T) {
+	type testCase struct {
+		name          string
+		stopError     error
+		compactError  error
+		closeError    error
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Stop_Compacts_And_Closes_Journal",
+			expectedError: false,
+		},
+		{
+			name:          "Queue_Drain_Error_Returned",
+			stopError:     context.DeadlineExceeded,
+			expectedError: true,
+		},
+		{
+			name:          "Compact_Error_Returned",
+			compactError:  errors.New("disk full"),
+			expectedError: true,
+		},
+		{
+			name:          "Close_Error_Returned",
+			closeError:    errors.New("close error"),
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQueue := mocks.NewMockDeliveryQueue(ctrl)
+			mockOutbox := mocks.NewMockOutbox(ctrl)
+
+			mockOutbox.EXPECT().Pending().Return(nil)
+			mockQueue.EXPECT().Start()
+			gomock.InOrder(
+				mockQueue.EXPECT().Stop(gomock.Any()).Return(tc.stopError),
+				mockOutbox.EXPECT().Compact().Return(tc.compactError),
+				mockOutbox.EXPECT().Close().Return(tc.closeError),
+			)
+
+			queue := NewOutboxQueue(mockQueue, mockOutbox, time.Hour, newTestQueueLogger())
+			queue.Start()
+			err := queue.Stop(context.Background())
+
+			if tc.expectedError {
+				if err == nil {
+					t.Error("expected error, got: nil")
+				}
+				if tc.stopError != nil && !errors.Is(err, tc.stopError) {
+					t.Errorf("expected error to wrap %v, got: %v", tc.stopError, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestOutboxQueue_PeriodicCompaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueue := mocks.NewMockDeliveryQueue(ctrl)
+	mockOutbox := mocks.NewMockOutbox(ctrl)
+
+	compacted := make(chan struct{}, 1)
+	mockOutbox.EXPECT().Pending().Return(nil)
+	mockQueue.EXPECT().Start()
+	mockOutbox.EXPECT().Compact().DoAndReturn(func() error {
+		select {
+		case compacted <- struct{}{}:
+		default:
+		}
+		return nil
+	}).MinTimes(1)
+
+	queue := NewOutboxQueue(mockQueue, mockOutbox, 10*time.Millisecond, newTestQueueLogger())
+	queue.Start()
+
+	select {
+	case <-compacted:
+	case <-time.After(time.Second):
+		t.Fatal("expected journal to be compacted periodically")
+	}
+
+	mockQueue.EXPECT().Stop(gomock.Any()).Return(nil)
+	mockOutbox.EXPECT().Close().Return(nil)
+
+	if err := queue.Stop(context.Background()); err != nil {
+		t.Errorf("unexpected stop error: %v", err)
+	}
+}
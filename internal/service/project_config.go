@@ -4,7 +4,9 @@ import (
 	"github.com/beliaev-aa/notifications/internal/config"
 	"github.com/beliaev-aa/notifications/internal/domain/port"
 	"github.com/sirupsen/logrus"
+	"net/mail"
 	"strings"
+	"time"
 )
 
 // ProjectConfigServiceImpl реализует ProjectConfigService для работы с конфигурацией проектов
@@ -137,6 +139,385 @@ func (s *ProjectConfigServiceImpl) GetVKTeamsChatID(projectName string) (string,
 	return projectConfig.VKTeams.ChatID, true
 }
 
+// GetSlackChatID получает адресата Slack канала проекта
+// Если указан webhook_url, адресатом является URL incoming webhook, иначе канал для отправки через chat.postMessage
+func (s *ProjectConfigServiceImpl) GetSlackChatID(projectName string) (string, bool) {
+	projectConfig, exists := s.GetProjectConfig(projectName)
+	if !exists {
+		return "", false
+	}
+
+	hasSlack := false
+	for _, channel := range projectConfig.AllowedChannels {
+		if channel == "slack" {
+			hasSlack = true
+			break
+		}
+	}
+
+	if !hasSlack {
+		return "", false
+	}
+
+	if projectConfig.Slack == nil {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Slack channel is in allowedChannels but slack config is missing")
+		return "", false
+	}
+
+	if projectConfig.Slack.WebhookURL != "" {
+		return projectConfig.Slack.WebhookURL, true
+	}
+
+	if projectConfig.Slack.Channel == "" {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Slack channel is in allowedChannels but webhook_url and channel are empty")
+		return "", false
+	}
+
+	return projectConfig.Slack.Channel, true
+}
+
+// GetMattermostChatID получает адресата Mattermost канала проекта
+// Если указан webhook_url, адресатом является URL incoming webhook, иначе ID канала для отправки через REST API
+func (s *ProjectConfigServiceImpl) GetMattermostChatID(projectName string) (string, bool) {
+	projectConfig, exists := s.GetProjectConfig(projectName)
+	if !exists {
+		return "", false
+	}
+
+	hasMattermost := false
+	for _, channel := range projectConfig.AllowedChannels {
+		if channel == "mattermost" {
+			hasMattermost = true
+			break
+		}
+	}
+
+	if !hasMattermost {
+		return "", false
+	}
+
+	if projectConfig.Mattermost == nil {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Mattermost channel is in allowedChannels but mattermost config is missing")
+		return "", false
+	}
+
+	if projectConfig.Mattermost.WebhookURL != "" {
+		return projectConfig.Mattermost.WebhookURL, true
+	}
+
+	if projectConfig.Mattermost.ChannelID == "" {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Mattermost channel is in allowedChannels but webhook_url and channel_id are empty")
+		return "", false
+	}
+
+	return projectConfig.Mattermost.ChannelID, true
+}
+
+// GetMSTeamsChatID получает адресата Microsoft Teams канала проекта - URL incoming webhook или workflow
+func (s *ProjectConfigServiceImpl) GetMSTeamsChatID(projectName string) (string, bool) {
+	projectConfig, exists := s.GetProjectConfig(projectName)
+	if !exists {
+		return "", false
+	}
+
+	hasMSTeams := false
+	for _, channel := range projectConfig.AllowedChannels {
+		if channel == "msteams" {
+			hasMSTeams = true
+			break
+		}
+	}
+
+	if !hasMSTeams {
+		return "", false
+	}
+
+	if projectConfig.MSTeams == nil {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Microsoft Teams channel is in allowedChannels but msteams config is missing")
+		return "", false
+	}
+
+	if projectConfig.MSTeams.WebhookURL == "" {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Microsoft Teams channel is in allowedChannels but webhook_url is empty")
+		return "", false
+	}
+
+	return projectConfig.MSTeams.WebhookURL, true
+}
+
+// GetDiscordChatID получает адресата Discord канала проекта - URL webhook канала
+func (s *ProjectConfigServiceImpl) GetDiscordChatID(projectName string) (string, bool) {
+	projectConfig, exists := s.GetProjectConfig(projectName)
+	if !exists {
+		return "", false
+	}
+
+	hasDiscord := false
+	for _, channel := range projectConfig.AllowedChannels {
+		if channel == "discord" {
+			hasDiscord = true
+			break
+		}
+	}
+
+	if !hasDiscord {
+		return "", false
+	}
+
+	if projectConfig.Discord == nil {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Discord channel is in allowedChannels but discord config is missing")
+		return "", false
+	}
+
+	if projectConfig.Discord.WebhookURL == "" {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Discord channel is in allowedChannels but webhook_url is empty")
+		return "", false
+	}
+
+	return projectConfig.Discord.WebhookURL, true
+}
+
+// GetEmailChatID получает адресатов email канала проекта - адреса получателей через запятую
+// Email исполнителя добавляется, если включен notify_assignee и исполнителя нет среди получателей
+func (s *ProjectConfigServiceImpl) GetEmailChatID(projectName string, assigneeEmail string) (string, bool) {
+	projectConfig, exists := s.GetProjectConfig(projectName)
+	if !exists {
+		return "", false
+	}
+
+	hasEmail := false
+	for _, channel := range projectConfig.AllowedChannels {
+		if channel == "email" {
+			hasEmail = true
+			break
+		}
+	}
+
+	if !hasEmail {
+		return "", false
+	}
+
+	if projectConfig.Email == nil {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Email channel is in allowedChannels but email config is missing")
+		return "", false
+	}
+
+	recipients := make([]string, 0, len(projectConfig.Email.Recipients)+1)
+	recipients = append(recipients, projectConfig.Email.Recipients...)
+
+	if projectConfig.Email.NotifyAssignee && assigneeEmail != "" && !containsEmailAddress(recipients, assigneeEmail) {
+		recipients = append(recipients, assigneeEmail)
+	}
+
+	if len(recipients) == 0 {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Debug("Email channel has no recipients for notification")
+		return "", false
+	}
+
+	return strings.Join(recipients, ", "), true
+}
+
+// GetWebhookTargets получает имена целей webhook канала проекта
+// Уведомление отправляется в каждую цель отдельно, поэтому каждая цель - отдельный адресат
+func (s *ProjectConfigServiceImpl) GetWebhookTargets(projectName string) ([]string, bool) {
+	projectConfig, exists := s.GetProjectConfig(projectName)
+	if !exists {
+		return nil, false
+	}
+
+	hasWebhook := false
+	for _, channel := range projectConfig.AllowedChannels {
+		if channel == "webhook" {
+			hasWebhook = true
+			break
+		}
+	}
+
+	if !hasWebhook {
+		return nil, false
+	}
+
+	if projectConfig.Webhook == nil {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Webhook channel is in allowedChannels but webhook config is missing")
+		return nil, false
+	}
+
+	if len(projectConfig.Webhook.Targets) == 0 {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Webhook channel is in allowedChannels but targets are empty")
+		return nil, false
+	}
+
+	return projectConfig.Webhook.Targets, true
+}
+
+// GetMatrixChatID получает адресата Matrix канала проекта - ID комнаты
+func (s *ProjectConfigServiceImpl) GetMatrixChatID(projectName string) (string, bool) {
+	projectConfig, exists := s.GetProjectConfig(projectName)
+	if !exists {
+		return "", false
+	}
+
+	hasMatrix := false
+	for _, channel := range projectConfig.AllowedChannels {
+		if channel == "matrix" {
+			hasMatrix = true
+			break
+		}
+	}
+
+	if !hasMatrix {
+		return "", false
+	}
+
+	if projectConfig.Matrix == nil {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Matrix channel is in allowedChannels but matrix config is missing")
+		return "", false
+	}
+
+	if projectConfig.Matrix.RoomID == "" {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Matrix channel is in allowedChannels but room_id is empty")
+		return "", false
+	}
+
+	return projectConfig.Matrix.RoomID, true
+}
+
+// GetZulipChatID получает адресата Zulip канала проекта - имя потока (stream)
+func (s *ProjectConfigServiceImpl) GetZulipChatID(projectName string) (string, bool) {
+	projectConfig, exists := s.GetProjectConfig(projectName)
+	if !exists {
+		return "", false
+	}
+
+	hasZulip := false
+	for _, channel := range projectConfig.AllowedChannels {
+		if channel == "zulip" {
+			hasZulip = true
+			break
+		}
+	}
+
+	if !hasZulip {
+		return "", false
+	}
+
+	if projectConfig.Zulip == nil {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Zulip channel is in allowedChannels but zulip config is missing")
+		return "", false
+	}
+
+	if projectConfig.Zulip.Stream == "" {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Zulip channel is in allowedChannels but stream is empty")
+		return "", false
+	}
+
+	return projectConfig.Zulip.Stream, true
+}
+
+// GetNtfyChatID получает адресата ntfy канала проекта - топик
+func (s *ProjectConfigServiceImpl) GetNtfyChatID(projectName string) (string, bool) {
+	projectConfig, exists := s.GetProjectConfig(projectName)
+	if !exists {
+		return "", false
+	}
+
+	hasNtfy := false
+	for _, channel := range projectConfig.AllowedChannels {
+		if channel == "ntfy" {
+			hasNtfy = true
+			break
+		}
+	}
+
+	if !hasNtfy {
+		return "", false
+	}
+
+	if projectConfig.Ntfy == nil {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Ntfy channel is in allowedChannels but ntfy config is missing")
+		return "", false
+	}
+
+	if projectConfig.Ntfy.Topic == "" {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Ntfy channel is in allowedChannels but topic is empty")
+		return "", false
+	}
+
+	return projectConfig.Ntfy.Topic, true
+}
+
+// GetGotifyChatID получает адресата Gotify канала проекта - токен приложения
+func (s *ProjectConfigServiceImpl) GetGotifyChatID(projectName string) (string, bool) {
+	projectConfig, exists := s.GetProjectConfig(projectName)
+	if !exists {
+		return "", false
+	}
+
+	hasGotify := false
+	for _, channel := range projectConfig.AllowedChannels {
+		if channel == "gotify" {
+			hasGotify = true
+			break
+		}
+	}
+
+	if !hasGotify {
+		return "", false
+	}
+
+	if projectConfig.Gotify == nil {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Gotify channel is in allowedChannels but gotify config is missing")
+		return "", false
+	}
+
+	if projectConfig.Gotify.Token == "" {
+		s.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Warn("Gotify channel is in allowedChannels but token is empty")
+		return "", false
+	}
+
+	return projectConfig.Gotify.Token, true
+}
+
 // GetSendDraftNotification получает настройку отправки уведомлений для черновиков, по-умолчанию true если не указана
 func (s *ProjectConfigServiceImpl) GetSendDraftNotification(projectName string) bool {
 	projectConfig, exists := s.GetProjectConfig(projectName)
@@ -152,3 +533,24 @@ func (s *ProjectConfigServiceImpl) GetSendDraftNotification(projectName string)
 
 	return *projectConfig.SendDraftNotification
 }
+
+// containsEmailAddress проверяет, есть ли адрес среди получателей, без учета регистра и имени получателя
+func containsEmailAddress(recipients []string, address string) bool {
+	for _, recipient := range recipients {
+		parsed, err := mail.ParseAddress(recipient)
+		if err == nil && strings.EqualFold(parsed.Address, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCoalesceWindow получает окно объединения изменений одной задачи, по умолчанию 0 (без объединения)
+func (s *ProjectConfigServiceImpl) GetCoalesceWindow(projectName string) time.Duration {
+	projectConfig, exists := s.GetProjectConfig(projectName)
+	if !exists || projectConfig.CoalesceWindow <= 0 {
+		return 0
+	}
+
+	return time.Duration(projectConfig.CoalesceWindow) * time.Second
+}
@@ -2,9 +2,11 @@ package service
 
 import (
 	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewProjectConfigService(t *testing.T) {
@@ -666,6 +668,170 @@ func TestProjectConfigService_GetVKTeamsChatID(t *testing.T) {
 	}
 }
 
+func TestProjectConfigService_GetSlackChatID(t *testing.T) {
+	type testCase struct {
+		name           string
+		cfg            *config.Config
+		projectName    string
+		expectedChatID string
+		expectedExists bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "GetSlackChatID_Project_With_Webhook",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"slack", "logger"},
+								Slack: &config.ProjectSlackConfig{
+									WebhookURL: "https://hooks.slack.com/services/T000/B000/XXX",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "https://hooks.slack.com/services/T000/B000/XXX",
+			expectedExists: true,
+		},
+		{
+			name: "GetSlackChatID_Project_With_Channel",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"slack"},
+								Slack: &config.ProjectSlackConfig{
+									Channel: "C0123456789",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "C0123456789",
+			expectedExists: true,
+		},
+		{
+			name: "GetSlackChatID_Webhook_Takes_Precedence_Over_Channel",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"slack"},
+								Slack: &config.ProjectSlackConfig{
+									WebhookURL: "https://hooks.slack.com/services/T000/B000/XXX",
+									Channel:    "C0123456789",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "https://hooks.slack.com/services/T000/B000/XXX",
+			expectedExists: true,
+		},
+		{
+			name: "GetSlackChatID_Project_Not_Exists",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"slack"},
+								Slack: &config.ProjectSlackConfig{
+									Channel: "C0123456789",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project2",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetSlackChatID_Project_Without_Slack_Channel",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"logger"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetSlackChatID_Project_With_Slack_But_No_Config",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"slack"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetSlackChatID_Project_With_Slack_But_Empty_Config",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"slack"},
+								Slack:           &config.ProjectSlackConfig{},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+			service := NewProjectConfigService(tc.cfg, logger)
+
+			chatID, exists := service.GetSlackChatID(tc.projectName)
+
+			if exists != tc.expectedExists {
+				t.Errorf("expected exists %v, got: %v", tc.expectedExists, exists)
+			}
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chat_id %q, got: %q", tc.expectedChatID, chatID)
+			}
+		})
+	}
+}
+
 func TestProjectConfigService_Integration(t *testing.T) {
 	type testCase struct {
 		name        string
@@ -1116,3 +1282,1322 @@ func normalizeProjectNamesInConfig(cfg *config.Config) {
 
 	cfg.Notifications.Youtrack.Projects = normalizedProjects
 }
+
+func TestProjectConfigService_GetCoalesceWindow(t *testing.T) {
+	type testCase struct {
+		name           string
+		cfg            *config.Config
+		projectName    string
+		expectedWindow time.Duration
+	}
+
+	projectsCfg := func(window int) *config.Config {
+		return &config.Config{
+			Notifications: config.NotificationsConfig{
+				Youtrack: config.YoutrackConfig{
+					Projects: map[string]config.ProjectConfig{
+						"project1": {
+							AllowedChannels: []string{"logger"},
+							CoalesceWindow:  window,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []testCase{
+		{
+			name:           "GetCoalesceWindow_Project_Exists_With_Window",
+			cfg:            projectsCfg(10),
+			projectName:    "project1",
+			expectedWindow: 10 * time.Second,
+		},
+		{
+			name:           "GetCoalesceWindow_Project_Exists_Without_Window",
+			cfg:            projectsCfg(0),
+			projectName:    "project1",
+			expectedWindow: 0,
+		},
+		{
+			name:           "GetCoalesceWindow_Project_Not_Exists",
+			cfg:            projectsCfg(10),
+			projectName:    "project2",
+			expectedWindow: 0,
+		},
+		{
+			name:           "GetCoalesceWindow_Case_Insensitive",
+			cfg:            projectsCfg(10),
+			projectName:    "PROJECT1",
+			expectedWindow: 10 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			service := NewProjectConfigService(tc.cfg, logger)
+
+			result := service.GetCoalesceWindow(tc.projectName)
+
+			if result != tc.expectedWindow {
+				t.Errorf("expected coalesce window %s, got: %s", tc.expectedWindow, result)
+			}
+		})
+	}
+}
+
+func TestProjectConfigService_GetMattermostChatID(t *testing.T) {
+	type testCase struct {
+		name           string
+		cfg            *config.Config
+		projectName    string
+		expectedChatID string
+		expectedExists bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "GetMattermostChatID_Project_With_Webhook",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"mattermost", "logger"},
+								Mattermost: &config.ProjectMattermostConfig{
+									WebhookURL: "https://mattermost.example.com/hooks/xxx",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "https://mattermost.example.com/hooks/xxx",
+			expectedExists: true,
+		},
+		{
+			name: "GetMattermostChatID_Project_With_Channel_ID",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"mattermost"},
+								Mattermost: &config.ProjectMattermostConfig{
+									ChannelID: "channel-id",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "channel-id",
+			expectedExists: true,
+		},
+		{
+			name: "GetMattermostChatID_Webhook_Takes_Precedence_Over_Channel_ID",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"mattermost"},
+								Mattermost: &config.ProjectMattermostConfig{
+									WebhookURL: "https://mattermost.example.com/hooks/xxx",
+									ChannelID:  "channel-id",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "https://mattermost.example.com/hooks/xxx",
+			expectedExists: true,
+		},
+		{
+			name: "GetMattermostChatID_Project_Not_Exists",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"mattermost"},
+								Mattermost: &config.ProjectMattermostConfig{
+									ChannelID: "channel-id",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project2",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetMattermostChatID_Project_Without_Mattermost_Channel",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"logger"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetMattermostChatID_Project_With_Mattermost_But_No_Config",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"mattermost"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetMattermostChatID_Project_With_Mattermost_But_Empty_Config",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"mattermost"},
+								Mattermost:      &config.ProjectMattermostConfig{},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+			service := NewProjectConfigService(tc.cfg, logger)
+
+			chatID, exists := service.GetMattermostChatID(tc.projectName)
+
+			if exists != tc.expectedExists {
+				t.Errorf("expected exists %v, got: %v", tc.expectedExists, exists)
+			}
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chat_id %q, got: %q", tc.expectedChatID, chatID)
+			}
+		})
+	}
+}
+
+func TestProjectConfigService_GetMSTeamsChatID(t *testing.T) {
+	type testCase struct {
+		name           string
+		cfg            *config.Config
+		projectName    string
+		expectedChatID string
+		expectedExists bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "GetMSTeamsChatID_Project_With_MSTeams",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"msteams", "logger"},
+								MSTeams: &config.ProjectMSTeamsConfig{
+									WebhookURL: "https://example.webhook.office.com/webhookb2/xxx",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "https://example.webhook.office.com/webhookb2/xxx",
+			expectedExists: true,
+		},
+		{
+			name: "GetMSTeamsChatID_Project_Not_Exists",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"msteams"},
+								MSTeams: &config.ProjectMSTeamsConfig{
+									WebhookURL: "https://example.webhook.office.com/webhookb2/xxx",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project2",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetMSTeamsChatID_Project_Without_MSTeams_Channel",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"logger"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetMSTeamsChatID_Project_With_MSTeams_But_No_Config",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"msteams"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetMSTeamsChatID_Project_With_MSTeams_But_Empty_WebhookURL",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"msteams"},
+								MSTeams: &config.ProjectMSTeamsConfig{
+									WebhookURL: "",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+			service := NewProjectConfigService(tc.cfg, logger)
+
+			chatID, exists := service.GetMSTeamsChatID(tc.projectName)
+
+			if exists != tc.expectedExists {
+				t.Errorf("expected exists %v, got: %v", tc.expectedExists, exists)
+			}
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chat_id %q, got: %q", tc.expectedChatID, chatID)
+			}
+		})
+	}
+}
+
+func TestProjectConfigService_GetDiscordChatID(t *testing.T) {
+	type testCase struct {
+		name           string
+		cfg            *config.Config
+		projectName    string
+		expectedChatID string
+		expectedExists bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "GetDiscordChatID_Project_With_Discord",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"discord", "logger"},
+								Discord: &config.ProjectDiscordConfig{
+									WebhookURL: "https://discord.com/api/webhooks/123/token",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "https://discord.com/api/webhooks/123/token",
+			expectedExists: true,
+		},
+		{
+			name: "GetDiscordChatID_Project_Not_Exists",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"discord"},
+								Discord: &config.ProjectDiscordConfig{
+									WebhookURL: "https://discord.com/api/webhooks/123/token",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project2",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetDiscordChatID_Project_Without_Discord_Channel",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"logger"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetDiscordChatID_Project_With_Discord_But_No_Config",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"discord"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetDiscordChatID_Project_With_Discord_But_Empty_WebhookURL",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"discord"},
+								Discord: &config.ProjectDiscordConfig{
+									WebhookURL: "",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+			service := NewProjectConfigService(tc.cfg, logger)
+
+			chatID, exists := service.GetDiscordChatID(tc.projectName)
+
+			if exists != tc.expectedExists {
+				t.Errorf("expected exists %v, got: %v", tc.expectedExists, exists)
+			}
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chat_id %q, got: %q", tc.expectedChatID, chatID)
+			}
+		})
+	}
+}
+
+func TestProjectConfigService_GetEmailChatID(t *testing.T) {
+	type testCase struct {
+		name           string
+		cfg            *config.Config
+		projectName    string
+		assigneeEmail  string
+		expectedChatID string
+		expectedExists bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "GetEmailChatID_Project_With_Recipients",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"email", "logger"},
+								Email: &config.ProjectEmailConfig{
+									Recipients: []string{"manager@example.com", "Team Lead <lead@example.com>"},
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			assigneeEmail:  "",
+			expectedChatID: "manager@example.com, Team Lead <lead@example.com>",
+			expectedExists: true,
+		},
+		{
+			name: "GetEmailChatID_Assignee_Added_When_Notify_Assignee",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"email"},
+								Email: &config.ProjectEmailConfig{
+									Recipients:     []string{"manager@example.com", "Team Lead <lead@example.com>"},
+									NotifyAssignee: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			assigneeEmail:  "john@example.com",
+			expectedChatID: "manager@example.com, Team Lead <lead@example.com>, john@example.com",
+			expectedExists: true,
+		},
+		{
+			name: "GetEmailChatID_Assignee_Not_Duplicated",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"email"},
+								Email: &config.ProjectEmailConfig{
+									Recipients:     []string{"manager@example.com", "Team Lead <lead@example.com>"},
+									NotifyAssignee: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			assigneeEmail:  "LEAD@example.com",
+			expectedChatID: "manager@example.com, Team Lead <lead@example.com>",
+			expectedExists: true,
+		},
+		{
+			name: "GetEmailChatID_Assignee_Ignored_Without_Notify_Assignee",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"email"},
+								Email: &config.ProjectEmailConfig{
+									Recipients: []string{"manager@example.com", "Team Lead <lead@example.com>"},
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			assigneeEmail:  "john@example.com",
+			expectedChatID: "manager@example.com, Team Lead <lead@example.com>",
+			expectedExists: true,
+		},
+		{
+			name: "GetEmailChatID_Only_Assignee",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"email"},
+								Email: &config.ProjectEmailConfig{
+									NotifyAssignee: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			assigneeEmail:  "john@example.com",
+			expectedChatID: "john@example.com",
+			expectedExists: true,
+		},
+		{
+			name: "GetEmailChatID_Only_Assignee_Without_Email",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"email"},
+								Email: &config.ProjectEmailConfig{
+									NotifyAssignee: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			assigneeEmail:  "",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetEmailChatID_Project_Not_Exists",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"email"},
+								Email: &config.ProjectEmailConfig{
+									Recipients: []string{"manager@example.com", "Team Lead <lead@example.com>"},
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project2",
+			assigneeEmail:  "",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetEmailChatID_Project_Without_Email_Channel",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"logger"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			assigneeEmail:  "",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetEmailChatID_Project_With_Email_But_No_Config",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"email"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			assigneeEmail:  "",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+			service := NewProjectConfigService(tc.cfg, logger)
+
+			chatID, exists := service.GetEmailChatID(tc.projectName, tc.assigneeEmail)
+
+			if exists != tc.expectedExists {
+				t.Errorf("expected exists %v, got: %v", tc.expectedExists, exists)
+			}
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chat_id %q, got: %q", tc.expectedChatID, chatID)
+			}
+		})
+	}
+}
+
+func TestProjectConfigService_GetWebhookTargets(t *testing.T) {
+	type testCase struct {
+		name            string
+		cfg             *config.Config
+		projectName     string
+		expectedTargets []string
+		expectedExists  bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "GetWebhookTargets_Project_With_Targets",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"webhook", "logger"},
+								Webhook: &config.ProjectWebhookConfig{
+									Targets: []string{"deploy_bot", "bi_collector"},
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:     "project1",
+			expectedTargets: []string{"deploy_bot", "bi_collector"},
+			expectedExists:  true,
+		},
+		{
+			name: "GetWebhookTargets_Project_Not_Exists",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"webhook"},
+								Webhook: &config.ProjectWebhookConfig{
+									Targets: []string{"deploy_bot"},
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:     "project2",
+			expectedTargets: nil,
+			expectedExists:  false,
+		},
+		{
+			name: "GetWebhookTargets_Project_Without_Webhook_Channel",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"logger"},
+							},
+						},
+					},
+				},
+			},
+			projectName:     "project1",
+			expectedTargets: nil,
+			expectedExists:  false,
+		},
+		{
+			name: "GetWebhookTargets_Project_With_Webhook_But_No_Config",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"webhook"},
+							},
+						},
+					},
+				},
+			},
+			projectName:     "project1",
+			expectedTargets: nil,
+			expectedExists:  false,
+		},
+		{
+			name: "GetWebhookTargets_Project_With_Webhook_But_Empty_Targets",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"webhook"},
+								Webhook:         &config.ProjectWebhookConfig{},
+							},
+						},
+					},
+				},
+			},
+			projectName:     "project1",
+			expectedTargets: nil,
+			expectedExists:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+			service := NewProjectConfigService(tc.cfg, logger)
+
+			targets, exists := service.GetWebhookTargets(tc.projectName)
+
+			if exists != tc.expectedExists {
+				t.Errorf("expected exists %v, got: %v", tc.expectedExists, exists)
+			}
+
+			if diff := cmp.Diff(tc.expectedTargets, targets); diff != "" {
+				t.Errorf("targets mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProjectConfigService_GetMatrixChatID(t *testing.T) {
+	type testCase struct {
+		name           string
+		cfg            *config.Config
+		projectName    string
+		expectedChatID string
+		expectedExists bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "GetMatrixChatID_Project_With_Matrix",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"matrix", "logger"},
+								Matrix: &config.ProjectMatrixConfig{
+									RoomID: "!abcdef:example.com",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "!abcdef:example.com",
+			expectedExists: true,
+		},
+		{
+			name: "GetMatrixChatID_Project_Not_Exists",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"matrix"},
+								Matrix: &config.ProjectMatrixConfig{
+									RoomID: "!abcdef:example.com",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project2",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetMatrixChatID_Project_Without_Matrix_Channel",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"logger"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetMatrixChatID_Project_With_Matrix_But_No_Config",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"matrix"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetMatrixChatID_Project_With_Matrix_But_Empty_RoomID",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"matrix"},
+								Matrix: &config.ProjectMatrixConfig{
+									RoomID: "",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+			service := NewProjectConfigService(tc.cfg, logger)
+
+			chatID, exists := service.GetMatrixChatID(tc.projectName)
+
+			if exists != tc.expectedExists {
+				t.Errorf("expected exists %v, got: %v", tc.expectedExists, exists)
+			}
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chat_id %q, got: %q", tc.expectedChatID, chatID)
+			}
+		})
+	}
+}
+
+func TestProjectConfigService_GetZulipChatID(t *testing.T) {
+	type testCase struct {
+		name           string
+		cfg            *config.Config
+		projectName    string
+		expectedChatID string
+		expectedExists bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "GetZulipChatID_Project_With_Zulip",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"zulip", "logger"},
+								Zulip: &config.ProjectZulipConfig{
+									Stream: "backend",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "backend",
+			expectedExists: true,
+		},
+		{
+			name: "GetZulipChatID_Project_Not_Exists",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"zulip"},
+								Zulip: &config.ProjectZulipConfig{
+									Stream: "backend",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project2",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetZulipChatID_Project_Without_Zulip_Channel",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"logger"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetZulipChatID_Project_With_Zulip_But_No_Config",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"zulip"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetZulipChatID_Project_With_Zulip_But_Empty_Stream",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"zulip"},
+								Zulip: &config.ProjectZulipConfig{
+									Stream: "",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+			service := NewProjectConfigService(tc.cfg, logger)
+
+			chatID, exists := service.GetZulipChatID(tc.projectName)
+
+			if exists != tc.expectedExists {
+				t.Errorf("expected exists %v, got: %v", tc.expectedExists, exists)
+			}
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chat_id %q, got: %q", tc.expectedChatID, chatID)
+			}
+		})
+	}
+}
+
+func TestProjectConfigService_GetNtfyChatID(t *testing.T) {
+	type testCase struct {
+		name           string
+		cfg            *config.Config
+		projectName    string
+		expectedChatID string
+		expectedExists bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "GetNtfyChatID_Project_With_Ntfy",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"ntfy", "logger"},
+								Ntfy: &config.ProjectNtfyConfig{
+									Topic: "backend-alerts",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "backend-alerts",
+			expectedExists: true,
+		},
+		{
+			name: "GetNtfyChatID_Project_Not_Exists",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"ntfy"},
+								Ntfy: &config.ProjectNtfyConfig{
+									Topic: "backend-alerts",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project2",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetNtfyChatID_Project_Without_Ntfy_Channel",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"logger"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetNtfyChatID_Project_With_Ntfy_But_No_Config",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"ntfy"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetNtfyChatID_Project_With_Ntfy_But_Empty_Topic",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"ntfy"},
+								Ntfy: &config.ProjectNtfyConfig{
+									Topic: "",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+			service := NewProjectConfigService(tc.cfg, logger)
+
+			chatID, exists := service.GetNtfyChatID(tc.projectName)
+
+			if exists != tc.expectedExists {
+				t.Errorf("expected exists %v, got: %v", tc.expectedExists, exists)
+			}
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chat_id %q, got: %q", tc.expectedChatID, chatID)
+			}
+		})
+	}
+}
+
+func TestProjectConfigService_GetGotifyChatID(t *testing.T) {
+	type testCase struct {
+		name           string
+		cfg            *config.Config
+		projectName    string
+		expectedChatID string
+		expectedExists bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "GetGotifyChatID_Project_With_Gotify",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"gotify", "logger"},
+								Gotify: &config.ProjectGotifyConfig{
+									Token: "AbCdEf123",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "AbCdEf123",
+			expectedExists: true,
+		},
+		{
+			name: "GetGotifyChatID_Project_Not_Exists",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"gotify"},
+								Gotify: &config.ProjectGotifyConfig{
+									Token: "AbCdEf123",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project2",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetGotifyChatID_Project_Without_Gotify_Channel",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"logger"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetGotifyChatID_Project_With_Gotify_But_No_Config",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"gotify"},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+		{
+			name: "GetGotifyChatID_Project_With_Gotify_But_Empty_Token",
+			cfg: &config.Config{
+				Notifications: config.NotificationsConfig{
+					Youtrack: config.YoutrackConfig{
+						Projects: map[string]config.ProjectConfig{
+							"project1": {
+								AllowedChannels: []string{"gotify"},
+								Gotify: &config.ProjectGotifyConfig{
+									Token: "",
+								},
+							},
+						},
+					},
+				},
+			},
+			projectName:    "project1",
+			expectedChatID: "",
+			expectedExists: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+			service := NewProjectConfigService(tc.cfg, logger)
+
+			chatID, exists := service.GetGotifyChatID(tc.projectName)
+
+			if exists != tc.expectedExists {
+				t.Errorf("expected exists %v, got: %v", tc.expectedExists, exists)
+			}
+
+			if chatID != tc.expectedChatID {
+				t.Errorf("expected chat_id %q, got: %q", tc.expectedChatID, chatID)
+			}
+		})
+	}
+}
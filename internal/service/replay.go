@@ -0,0 +1,95 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReplayVerifier отклоняет устаревшие и повторно отправленные webhook запросы
+type ReplayVerifier struct {
+	cfg     config.WebhookConfig
+	maxSkew time.Duration
+	nonces  *NonceCache
+	logger  *logrus.Logger
+}
+
+// NewReplayVerifier создает новый экземпляр защиты от повторного воспроизведения запросов
+// Идентификаторы запоминаются на удвоенное окно допустимого расхождения времени: более старые запросы
+// отклоняются проверкой метки времени
+func NewReplayVerifier(cfg config.WebhookConfig, logger *logrus.Logger) port.WebhookVerifier {
+	maxSkew := time.Duration(cfg.Replay.MaxSkew) * time.Second
+
+	return &ReplayVerifier{
+		cfg:     cfg,
+		maxSkew: maxSkew,
+		nonces:  NewNonceCache(cfg.Replay.CacheSize, 2*maxSkew),
+		logger:  logger,
+	}
+}
+
+// Verify проверяет метку времени запроса и уникальность идентификатора доставки
+func (v *ReplayVerifier) Verify(req *http.Request, body []byte) error {
+	if !v.cfg.Replay.Enabled {
+		return nil
+	}
+
+	rawTimestamp := strings.TrimSpace(req.Header.Get(v.cfg.Replay.TimestampHeader))
+	if rawTimestamp == "" {
+		return fmt.Errorf("%w: timestamp header %s is missing", port.ErrUnauthorized, v.cfg.Replay.TimestampHeader)
+	}
+
+	seconds, err := strconv.ParseInt(rawTimestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: timestamp %q is not a unix time", port.ErrUnauthorized, rawTimestamp)
+	}
+
+	now := nowFunc()
+	skew := now.Sub(time.Unix(seconds, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > v.maxSkew {
+		v.logger.WithFields(logrus.Fields{
+			"reason":    "stale_timestamp",
+			"timestamp": seconds,
+			"skew":      skew.String(),
+			"max_skew":  v.maxSkew.String(),
+		}).Warn("Webhook request rejected as replay")
+		return fmt.Errorf("%w: timestamp is outside of allowed window", port.ErrReplayDetected)
+	}
+
+	nonce := v.nonceKey(req, rawTimestamp, body)
+	if v.nonces.Seen(nonce, now) {
+		v.logger.WithFields(logrus.Fields{
+			"reason": "duplicate_nonce",
+			"nonce":  nonce,
+		}).Warn("Webhook request rejected as replay")
+		return fmt.Errorf("%w: request has already been received", port.ErrReplayDetected)
+	}
+
+	return nil
+}
+
+// nonceKey возвращает ключ запроса для поиска повторов
+// Подписанный запрос определяется меткой времени и телом, которые покрывает подпись: заголовок доставки
+// и запись самой подписи (регистр, префикс) не подписаны, поэтому их замена не делает перехваченный запрос новым.
+// Неподписанный запрос определяется идентификатором доставки, а при его отсутствии - меткой времени и телом
+func (v *ReplayVerifier) nonceKey(req *http.Request, timestamp string, body []byte) string {
+	signed := strings.TrimSpace(req.Header.Get(v.cfg.SignatureHeader)) != ""
+	if !signed {
+		if delivery := strings.TrimSpace(req.Header.Get(v.cfg.Replay.DeliveryHeader)); delivery != "" {
+			return "delivery:" + delivery
+		}
+	}
+
+	sum := sha256.Sum256(append([]byte(timestamp+"."), body...))
+	return "content:" + hex.EncodeToString(sum[:])
+}
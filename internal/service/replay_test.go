@@ -0,0 +1,164 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReplayVerifier_Verify(t *testing.T) {
+	type request struct {
+		timestamp string
+		delivery  string
+		signature string
+		body      string
+	}
+
+	type testCase struct {
+		name           string
+		enabled        bool
+		requests       []request
+		expectedErrors []error
+		expectedLog    string
+	}
+
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	current := strconv.FormatInt(now.Unix(), 10)
+	stale := strconv.FormatInt(now.Add(-10*time.Minute).Unix(), 10)
+	future := strconv.FormatInt(now.Add(10*time.Minute).Unix(), 10)
+
+	testCases := []testCase{
+		{
+			name:           "Disabled_Accepts_Everything",
+			enabled:        false,
+			requests:       []request{{body: "{}"}, {body: "{}"}},
+			expectedErrors: []error{nil, nil},
+		},
+		{
+			name:           "Missing_Timestamp_Rejected",
+			enabled:        true,
+			requests:       []request{{body: "{}"}},
+			expectedErrors: []error{port.ErrUnauthorized},
+		},
+		{
+			name:           "Invalid_Timestamp_Rejected",
+			enabled:        true,
+			requests:       []request{{timestamp: "yesterday", body: "{}"}},
+			expectedErrors: []error{port.ErrUnauthorized},
+		},
+		{
+			name:           "Stale_Timestamp_Rejected",
+			enabled:        true,
+			requests:       []request{{timestamp: stale, delivery: "d1", body: "{}"}},
+			expectedErrors: []error{port.ErrReplayDetected},
+			expectedLog:    "stale_timestamp",
+		},
+		{
+			name:           "Future_Timestamp_Rejected",
+			enabled:        true,
+			requests:       []request{{timestamp: future, delivery: "d1", body: "{}"}},
+			expectedErrors: []error{port.ErrReplayDetected},
+			expectedLog:    "stale_timestamp",
+		},
+		{
+			name:    "Repeated_Delivery_ID_Rejected",
+			enabled: true,
+			requests: []request{
+				{timestamp: current, delivery: "d1", body: "{}"},
+				{timestamp: current, delivery: "d2", body: "{}"},
+				{timestamp: current, delivery: "d1", body: `{"other":true}`},
+			},
+			expectedErrors: []error{nil, nil, port.ErrReplayDetected},
+			expectedLog:    "duplicate_nonce",
+		},
+		{
+			name:    "Repeated_Signature_Rejected_Without_Delivery_ID",
+			enabled: true,
+			requests: []request{
+				{timestamp: current, signature: "sha256=aa", body: "{}"},
+				{timestamp: current, signature: "sha256=aa", body: "{}"},
+			},
+			expectedErrors: []error{nil, port.ErrReplayDetected},
+		},
+		{
+			name:    "Signed_Request_Replayed_With_New_Delivery_ID_Rejected",
+			enabled: true,
+			requests: []request{
+				{timestamp: current, delivery: "d1", signature: "sha256=aa", body: "{}"},
+				{timestamp: current, delivery: "d2", signature: "sha256=AA", body: "{}"},
+				{timestamp: current, delivery: "d3", signature: "sha256=bb", body: `{"a":1}`},
+			},
+			expectedErrors: []error{nil, port.ErrReplayDetected, nil},
+			expectedLog:    "duplicate_nonce",
+		},
+		{
+			name:    "Repeated_Body_Rejected_Without_Delivery_ID_And_Signature",
+			enabled: true,
+			requests: []request{
+				{timestamp: current, body: "{}"},
+				{timestamp: current, body: `{"a":1}`},
+				{timestamp: current, body: "{}"},
+			},
+			expectedErrors: []error{nil, nil, port.ErrReplayDetected},
+		},
+	}
+
+	originalNow := nowFunc
+	nowFunc = func() time.Time { return now }
+	defer func() { nowFunc = originalNow }()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := logrus.New()
+			logger.SetOutput(&buf)
+
+			verifier := NewReplayVerifier(config.WebhookConfig{
+				SignatureHeader: config.DefaultSignatureHeader,
+				Replay: config.ReplayConfig{
+					Enabled:         tc.enabled,
+					MaxSkew:         300,
+					CacheSize:       100,
+					TimestampHeader: config.DefaultTimestampHeader,
+					DeliveryHeader:  config.DefaultDeliveryHeader,
+				},
+			}, logger)
+
+			for i, r := range tc.requests {
+				req, err := http.NewRequest("POST", "/webhook/youtrack", strings.NewReader(r.body))
+				if err != nil {
+					t.Fatalf("failed to create request: %v", err)
+				}
+				if r.timestamp != "" {
+					req.Header.Set(config.DefaultTimestampHeader, r.timestamp)
+				}
+				if r.delivery != "" {
+					req.Header.Set(config.DefaultDeliveryHeader, r.delivery)
+				}
+				if r.signature != "" {
+					req.Header.Set(config.DefaultSignatureHeader, r.signature)
+				}
+
+				err = verifier.Verify(req, []byte(r.body))
+				if tc.expectedErrors[i] == nil {
+					if err != nil {
+						t.Errorf("request %d: unexpected error: %v", i, err)
+					}
+				} else if !errors.Is(err, tc.expectedErrors[i]) {
+					t.Errorf("request %d: expected error %v, got: %v", i, tc.expectedErrors[i], err)
+				}
+			}
+
+			if tc.expectedLog != "" && !strings.Contains(buf.String(), tc.expectedLog) {
+				t.Errorf("expected log to contain reason %q", tc.expectedLog)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
+)
+
+const (
+	// Префикс значения заголовка подписи
+	signaturePrefix = "sha256="
+)
+
+// SignatureVerifier проверяет HMAC-SHA256 подпись тела webhook запроса
+type SignatureVerifier struct {
+	cfg                  config.WebhookConfig
+	projectConfigService port.ProjectConfigService
+	logger               *logrus.Logger
+}
+
+// NewSignatureVerifier создает новый экземпляр проверки подписи webhook запросов
+func NewSignatureVerifier(cfg config.WebhookConfig, projectConfigService port.ProjectConfigService, logger *logrus.Logger) port.WebhookVerifier {
+	return &SignatureVerifier{
+		cfg:                  cfg,
+		projectConfigService: projectConfigService,
+		logger:               logger,
+	}
+}
+
+// Verify проверяет подпись запроса секретом проекта или глобальным секретом
+// Если секрет не настроен ни для проекта, ни глобально, проверка пропускается
+func (v *SignatureVerifier) Verify(req *http.Request, body []byte) error {
+	projectName := peekProjectName(body)
+	secret := v.secretFor(projectName)
+	if secret == "" {
+		return nil
+	}
+
+	header := strings.TrimSpace(req.Header.Get(v.cfg.SignatureHeader))
+	if header == "" {
+		return fmt.Errorf("%w: signature header %s is missing", port.ErrUnauthorized, v.cfg.SignatureHeader)
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(header, signaturePrefix))
+	if err != nil {
+		return fmt.Errorf("%w: signature is not a valid hex string", port.ErrUnauthorized)
+	}
+
+	if !hmac.Equal(signature, computeSignature(secret, v.signedContent(req, body))) {
+		v.logger.WithFields(logrus.Fields{
+			"project": projectName,
+		}).Debug("Webhook signature mismatch")
+		return fmt.Errorf("%w: signature mismatch", port.ErrUnauthorized)
+	}
+
+	return nil
+}
+
+// secretFor возвращает секрет проекта, а при его отсутствии - глобальный секрет
+func (v *SignatureVerifier) secretFor(projectName string) string {
+	if projectName != "" && v.projectConfigService != nil {
+		if projectConfig, exists := v.projectConfigService.GetProjectConfig(projectName); exists && projectConfig.WebhookSecret != "" {
+			return projectConfig.WebhookSecret
+		}
+	}
+
+	return v.cfg.Secret
+}
+
+// signedContent возвращает подписываемые данные: тело запроса, а при наличии метки времени - "<timestamp>.<body>"
+// Включение метки времени в подпись не позволяет подменить ее при повторной отправке перехваченного запроса
+func (v *SignatureVerifier) signedContent(req *http.Request, body []byte) []byte {
+	timestamp := strings.TrimSpace(req.Header.Get(v.cfg.Replay.TimestampHeader))
+	if v.cfg.Replay.TimestampHeader == "" || timestamp == "" {
+		return body
+	}
+	return append([]byte(timestamp+"."), body...)
+}
+
+// computeSignature вычисляет HMAC-SHA256 от подписываемых данных
+func computeSignature(secret string, content []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(content)
+	return mac.Sum(nil)
+}
+
+// peekProjectName извлекает имя проекта из тела запроса без полного разбора payload
+// Используется только для выбора секрета, поэтому ошибки разбора игнорируются
+func peekProjectName(body []byte) string {
+	var envelope struct {
+		Project *struct {
+			Name *string `json:"name"`
+		} `json:"project"`
+	}
+
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return ""
+	}
+
+	if envelope.Project == nil || envelope.Project.Name == nil {
+		return ""
+	}
+
+	return strings.ToLower(*envelope.Project.Name)
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"encoding/hex"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewSignatureVerifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	verifier := NewSignatureVerifier(config.WebhookConfig{}, mocks.NewMockProjectConfigService(ctrl), logrus.New())
+	if verifier == nil {
+		t.Fatal("expected verifier to be created, got: nil")
+	}
+
+	if _, ok := verifier.(port.WebhookVerifier); !ok {
+		t.Error("expected verifier to implement WebhookVerifier interface")
+	}
+}
+
+func TestSignatureVerifier_Verify(t *testing.T) {
+	type testCase struct {
+		name          string
+		globalSecret  string
+		projectSecret string
+		projectFound  bool
+		body          string
+		signature     string
+		timestamp     string
+		expectedError bool
+	}
+
+	body := `{"project":{"name":"Demo"},"issue":{"summary":"Test"}}`
+	sign := func(secret, payload string) string {
+		return signaturePrefix + hex.EncodeToString(computeSignature(secret, []byte(payload)))
+	}
+
+	testCases := []testCase{
+		{
+			name:          "No_Secret_Configured_Skips_Verification",
+			body:          body,
+			expectedError: false,
+		},
+		{
+			name:          "Valid_Global_Signature",
+			globalSecret:  "global",
+			body:          body,
+			signature:     sign("global", body),
+			expectedError: false,
+		},
+		{
+			name:          "Valid_Global_Signature_Without_Prefix",
+			globalSecret:  "global",
+			body:          body,
+			signature:     strings.TrimPrefix(sign("global", body), signaturePrefix),
+			expectedError: false,
+		},
+		{
+			name:          "Missing_Signature_Header",
+			globalSecret:  "global",
+			body:          body,
+			expectedError: true,
+		},
+		{
+			name:          "Invalid_Hex_Signature",
+			globalSecret:  "global",
+			body:          body,
+			signature:     "sha256=zzzz",
+			expectedError: true,
+		},
+		{
+			name:          "Signature_Made_With_Wrong_Secret",
+			globalSecret:  "global",
+			body:          body,
+			signature:     sign("other", body),
+			expectedError: true,
+		},
+		{
+			name:          "Signature_Of_Modified_Body",
+			globalSecret:  "global",
+			body:          body,
+			signature:     sign("global", body+" "),
+			expectedError: true,
+		},
+		{
+			name:          "Project_Secret_Overrides_Global",
+			globalSecret:  "global",
+			projectSecret: "project",
+			projectFound:  true,
+			body:          body,
+			signature:     sign("project", body),
+			expectedError: false,
+		},
+		{
+			name:          "Global_Signature_Rejected_When_Project_Secret_Set",
+			globalSecret:  "global",
+			projectSecret: "project",
+			projectFound:  true,
+			body:          body,
+			signature:     sign("global", body),
+			expectedError: true,
+		},
+		{
+			name:          "Project_Secret_Without_Global_Secret",
+			projectSecret: "project",
+			projectFound:  true,
+			body:          body,
+			signature:     sign("project", body),
+			expectedError: false,
+		},
+		{
+			name:          "Signature_Covers_Timestamp",
+			globalSecret:  "global",
+			body:          body,
+			timestamp:     "1700000000",
+			signature:     sign("global", "1700000000."+body),
+			expectedError: false,
+		},
+		{
+			name:          "Body_Only_Signature_Rejected_When_Timestamp_Present",
+			globalSecret:  "global",
+			body:          body,
+			timestamp:     "1700000000",
+			signature:     sign("global", body),
+			expectedError: true,
+		},
+		{
+			name:          "Invalid_JSON_Uses_Global_Secret",
+			globalSecret:  "global",
+			body:          "not json",
+			signature:     sign("global", "not json"),
+			expectedError: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
+			if peekProjectName([]byte(tc.body)) != "" {
+				var projectConfig *config.ProjectConfig
+				if tc.projectFound {
+					projectConfig = &config.ProjectConfig{WebhookSecret: tc.projectSecret}
+				}
+				mockProjectConfig.EXPECT().GetProjectConfig("demo").Return(projectConfig, tc.projectFound)
+			}
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			verifier := NewSignatureVerifier(config.WebhookConfig{
+				Secret:          tc.globalSecret,
+				SignatureHeader: config.DefaultSignatureHeader,
+				Replay: config.ReplayConfig{
+					TimestampHeader: config.DefaultTimestampHeader,
+				},
+			}, mockProjectConfig, logger)
+
+			req, err := http.NewRequest("POST", "/webhook/youtrack", strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			if tc.signature != "" {
+				req.Header.Set(config.DefaultSignatureHeader, tc.signature)
+			}
+			if tc.timestamp != "" {
+				req.Header.Set(config.DefaultTimestampHeader, tc.timestamp)
+			}
+
+			err = verifier.Verify(req, []byte(tc.body))
+
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("expected error, got: nil")
+				}
+				if !errors.Is(err, port.ErrUnauthorized) {
+					t.Errorf("expected error to wrap ErrUnauthorized, got: %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestPeekProjectName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{name: "Project_Name_Lowercased", body: `{"project":{"name":"DEMO"}}`, expected: "demo"},
+		{name: "Project_Is_Null", body: `{"project":null}`, expected: ""},
+		{name: "Project_Name_Is_Null", body: `{"project":{"name":null}}`, expected: ""},
+		{name: "Invalid_JSON", body: `{`, expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := peekProjectName([]byte(tc.body)); got != tc.expected {
+				t.Errorf("expected %q, got: %q", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// Префикс значения заголовка Authorization
+	bearerPrefix = "Bearer "
+)
+
+// nowFunc используется для тестирования - позволяет подменить текущее время
+var nowFunc = time.Now
+
+// TokenVerifier проверяет токен источника webhook запроса и разрешенные ему проекты
+type TokenVerifier struct {
+	tokens               []config.WebhookTokenConfig
+	projectConfigService port.ProjectConfigService
+	logger               *logrus.Logger
+}
+
+// NewTokenVerifier создает новый экземпляр проверки токенов источников
+// Если список токенов пуст, проверка не выполняется
+func NewTokenVerifier(tokens []config.WebhookTokenConfig, projectConfigService port.ProjectConfigService, logger *logrus.Logger) port.WebhookVerifier {
+	return &TokenVerifier{
+		tokens:               tokens,
+		projectConfigService: projectConfigService,
+		logger:               logger,
+	}
+}
+
+// Verify проверяет токен из пути запроса или заголовка Authorization: Bearer
+func (v *TokenVerifier) Verify(req *http.Request, body []byte) error {
+	if len(v.tokens) == 0 {
+		return nil
+	}
+
+	presented := extractWebhookToken(req)
+	if presented == "" {
+		return fmt.Errorf("%w: webhook token is missing", port.ErrUnauthorized)
+	}
+
+	source := v.findToken(presented)
+	if source == nil {
+		return fmt.Errorf("%w: webhook token is unknown", port.ErrUnauthorized)
+	}
+
+	if source.ExpiresAt != nil {
+		if !nowFunc().Before(*source.ExpiresAt) {
+			return fmt.Errorf("%w: webhook token %q has expired", port.ErrUnauthorized, source.Name)
+		}
+		v.logger.WithFields(logrus.Fields{
+			"source":     source.Name,
+			"expires_at": source.ExpiresAt.Format(time.RFC3339),
+		}).Info("Webhook token is in rotation grace period, switch source to the new token")
+	}
+
+	if len(source.Projects) == 0 {
+		return nil
+	}
+
+	projectName := peekProjectName(body)
+	if projectName == "" || !v.projectConfigService.IsProjectAllowed(projectName) {
+		return fmt.Errorf("%w: source %q cannot post for unknown project %q", port.ErrForbidden, source.Name, projectName)
+	}
+
+	for _, allowed := range source.Projects {
+		if allowed == projectName {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: source %q is not allowed to post for project %q", port.ErrForbidden, source.Name, projectName)
+}
+
+// findToken ищет токен среди настроенных, сравнивая значения за постоянное время
+func (v *TokenVerifier) findToken(presented string) *config.WebhookTokenConfig {
+	var found *config.WebhookTokenConfig
+	for i := range v.tokens {
+		if subtle.ConstantTimeCompare([]byte(v.tokens[i].Token), []byte(presented)) == 1 {
+			found = &v.tokens[i]
+		}
+	}
+	return found
+}
+
+// extractWebhookToken извлекает токен из пути запроса (через контекст) или из заголовка Authorization
+func extractWebhookToken(req *http.Request) string {
+	if token := port.WebhookTokenFromContext(req.Context()); token != "" {
+		return token
+	}
+
+	authorization := req.Header.Get("Authorization")
+	if len(authorization) > len(bearerPrefix) && strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return strings.TrimSpace(authorization[len(bearerPrefix):])
+	}
+
+	return ""
+}
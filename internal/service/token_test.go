@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/config"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewTokenVerifier(t *testing.T) {
+	verifier := NewTokenVerifier(nil, nil, logrus.New())
+	if verifier == nil {
+		t.Fatal("expected verifier to be created, got: nil")
+	}
+
+	if _, ok := verifier.(port.WebhookVerifier); !ok {
+		t.Error("expected verifier to implement WebhookVerifier interface")
+	}
+}
+
+func TestTokenVerifier_Verify(t *testing.T) {
+	type testCase struct {
+		name           string
+		tokens         []config.WebhookTokenConfig
+		pathToken      string
+		authorization  string
+		body           string
+		projectAllowed *bool
+		expectedErr    error
+	}
+
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	allowed := true
+	notAllowed := false
+
+	body := `{"project":{"name":"Demo"}}`
+	tokens := []config.WebhookTokenConfig{
+		{Name: "main", Token: "main-token"},
+		{Name: "team", Token: "team-token", Projects: []string{"demo"}},
+		{Name: "other", Token: "other-token", Projects: []string{"other"}},
+		{Name: "old", Token: "old-token", ExpiresAt: &future},
+		{Name: "expired", Token: "expired-token", ExpiresAt: &past},
+	}
+
+	testCases := []testCase{
+		{
+			name:        "No_Tokens_Configured_Skips_Verification",
+			body:        body,
+			expectedErr: nil,
+		},
+		{
+			name:        "Missing_Token",
+			tokens:      tokens,
+			body:        body,
+			expectedErr: port.ErrUnauthorized,
+		},
+		{
+			name:        "Unknown_Token",
+			tokens:      tokens,
+			pathToken:   "unknown",
+			body:        body,
+			expectedErr: port.ErrUnauthorized,
+		},
+		{
+			name:        "Valid_Path_Token_Without_Project_Restrictions",
+			tokens:      tokens,
+			pathToken:   "main-token",
+			body:        body,
+			expectedErr: nil,
+		},
+		{
+			name:          "Valid_Bearer_Token",
+			tokens:        tokens,
+			authorization: "Bearer main-token",
+			body:          body,
+			expectedErr:   nil,
+		},
+		{
+			name:          "Bearer_Prefix_Is_Case_Insensitive",
+			tokens:        tokens,
+			authorization: "bearer main-token",
+			body:          body,
+			expectedErr:   nil,
+		},
+		{
+			name:          "Non_Bearer_Authorization_Ignored",
+			tokens:        tokens,
+			authorization: "Basic bWFpbi10b2tlbg==",
+			body:          body,
+			expectedErr:   port.ErrUnauthorized,
+		},
+		{
+			name:           "Scoped_Token_Allowed_Project",
+			tokens:         tokens,
+			pathToken:      "team-token",
+			body:           body,
+			projectAllowed: &allowed,
+			expectedErr:    nil,
+		},
+		{
+			name:           "Scoped_Token_Other_Project_Forbidden",
+			tokens:         tokens,
+			pathToken:      "other-token",
+			body:           body,
+			projectAllowed: &allowed,
+			expectedErr:    port.ErrForbidden,
+		},
+		{
+			name:           "Scoped_Token_Unknown_Project_Forbidden",
+			tokens:         tokens,
+			pathToken:      "team-token",
+			body:           body,
+			projectAllowed: &notAllowed,
+			expectedErr:    port.ErrForbidden,
+		},
+		{
+			name:        "Scoped_Token_Without_Project_Forbidden",
+			tokens:      tokens,
+			pathToken:   "team-token",
+			body:        `{"project":null}`,
+			expectedErr: port.ErrForbidden,
+		},
+		{
+			name:        "Token_In_Grace_Period_Accepted",
+			tokens:      tokens,
+			pathToken:   "old-token",
+			body:        body,
+			expectedErr: nil,
+		},
+		{
+			name:        "Expired_Token_Rejected",
+			tokens:      tokens,
+			pathToken:   "expired-token",
+			body:        body,
+			expectedErr: port.ErrUnauthorized,
+		},
+		{
+			name:          "Path_Token_Has_Priority_Over_Header",
+			tokens:        tokens,
+			pathToken:     "unknown",
+			authorization: "Bearer main-token",
+			body:          body,
+			expectedErr:   port.ErrUnauthorized,
+		},
+	}
+
+	originalNow := nowFunc
+	nowFunc = func() time.Time { return now }
+	defer func() { nowFunc = originalNow }()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProjectConfig := mocks.NewMockProjectConfigService(ctrl)
+			if tc.projectAllowed != nil {
+				mockProjectConfig.EXPECT().IsProjectAllowed("demo").Return(*tc.projectAllowed)
+			}
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			verifier := NewTokenVerifier(tc.tokens, mockProjectConfig, logger)
+
+			req, err := http.NewRequest("POST", "/webhook/youtrack", strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			if tc.pathToken != "" {
+				req = req.WithContext(port.ContextWithWebhookToken(context.Background(), tc.pathToken))
+			}
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+
+			err = verifier.Verify(req, []byte(tc.body))
+
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Errorf("expected error %v, got: %v", tc.expectedErr, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
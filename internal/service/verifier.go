@@ -0,0 +1,32 @@
+package service
+
+import (
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"net/http"
+)
+
+// VerifierChain последовательно применяет несколько проверок webhook запроса
+type VerifierChain struct {
+	verifiers []port.WebhookVerifier
+}
+
+// NewVerifierChain создает цепочку проверок, nil элементы пропускаются
+func NewVerifierChain(verifiers ...port.WebhookVerifier) port.WebhookVerifier {
+	chain := &VerifierChain{}
+	for _, verifier := range verifiers {
+		if verifier != nil {
+			chain.verifiers = append(chain.verifiers, verifier)
+		}
+	}
+	return chain
+}
+
+// Verify возвращает первую ошибку проверки в порядке регистрации
+func (c *VerifierChain) Verify(req *http.Request, body []byte) error {
+	for _, verifier := range c.verifiers {
+		if err := verifier.Verify(req, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"github.com/beliaev-aa/notifications/internal/domain/port"
+	"github.com/beliaev-aa/notifications/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+func TestVerifierChain_Verify(t *testing.T) {
+	type testCase struct {
+		name        string
+		firstError  error
+		secondError error
+		expectedErr error
+		callSecond  bool
+	}
+
+	firstErr := errors.New("first")
+	secondErr := errors.New("second")
+
+	testCases := []testCase{
+		{
+			name:        "All_Verifiers_Pass",
+			callSecond:  true,
+			expectedErr: nil,
+		},
+		{
+			name:        "First_Error_Stops_Chain",
+			firstError:  firstErr,
+			callSecond:  false,
+			expectedErr: firstErr,
+		},
+		{
+			name:        "Second_Error_Returned",
+			secondError: secondErr,
+			callSecond:  true,
+			expectedErr: secondErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			req, _ := http.NewRequest("POST", "/webhook/youtrack", nil)
+			body := []byte("{}")
+
+			first := mocks.NewMockWebhookVerifier(ctrl)
+			second := mocks.NewMockWebhookVerifier(ctrl)
+			first.EXPECT().Verify(req, body).Return(tc.firstError)
+			if tc.callSecond {
+				second.EXPECT().Verify(req, body).Return(tc.secondError)
+			}
+
+			chain := NewVerifierChain(first, nil, second)
+			if _, ok := chain.(port.WebhookVerifier); !ok {
+				t.Fatal("expected chain to implement WebhookVerifier interface")
+			}
+
+			if err := chain.Verify(req, body); !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -1,37 +1,63 @@
 package service
 
 import (
-	"github.com/beliaev-aa/notifications/internal/adapter/formatter"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"github.com/beliaev-aa/notifications/internal/domain/port"
 	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
 	"github.com/sirupsen/logrus"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 // WebhookService реализует бизнес-логику обработки webhook запросов
 type WebhookService struct {
 	notificationSender port.NotificationSender
 	youtrackParser     parser.YoutrackParser
+	verifier           port.WebhookVerifier
+	deliveryQueue      port.DeliveryQueue
+	idempotency        *IdempotencyGuard
+	metrics            port.Metrics
 	logger             *logrus.Logger
 }
 
 // NewWebhookService создает новый экземпляр сервиса для обработки webhook запросов
-func NewWebhookService(notificationSender port.NotificationSender, youtrackParser parser.YoutrackParser, logger *logrus.Logger) port.WebhookService {
+// verifier может быть nil - в этом случае подлинность запросов не проверяется
+// deliveryQueue может быть nil - в этом случае уведомления отправляются синхронно в рамках запроса
+// idempotency может быть nil - в этом случае повторно полученные события не отбрасываются
+// metrics может быть nil - в этом случае счетчики не ведутся
+func NewWebhookService(notificationSender port.NotificationSender, youtrackParser parser.YoutrackParser, verifier port.WebhookVerifier, deliveryQueue port.DeliveryQueue, idempotency *IdempotencyGuard, metrics port.Metrics, logger *logrus.Logger) port.WebhookService {
 	return &WebhookService{
 		notificationSender: notificationSender,
 		youtrackParser:     youtrackParser,
+		verifier:           verifier,
+		deliveryQueue:      deliveryQueue,
+		idempotency:        idempotency,
+		metrics:            metrics,
 		logger:             logger,
 	}
 }
 
-// ProcessWebhook обрабатывает входящий webhook запрос: читает тело, декодирует JSON и отправляет уведомление
-func (w *WebhookService) ProcessWebhook(req *http.Request) error {
+// ProcessWebhook обрабатывает входящий webhook запрос: читает тело, декодирует JSON и ставит уведомление в очередь доставки
+// Возвращает отчет с результатом для каждого адресата; для проигнорированного события отчет пуст
+// Доставка из очереди не зависит от контекста запроса, он ограничивает только синхронную доставку без очереди
+func (w *WebhookService) ProcessWebhook(ctx context.Context, req *http.Request) (port.DeliveryReport, error) {
+	var report port.DeliveryReport
+
 	// Читаем тело запроса
 	body, err := io.ReadAll(req.Body)
 	if err != nil {
 		w.logger.WithError(err).Error("Failed to read request body")
-		return err
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return report, fmt.Errorf("%w: request body exceeds %d bytes", port.ErrPayloadTooLarge, maxBytesErr.Limit)
+		}
+		return report, fmt.Errorf("%w: %w", port.ErrBadRequest, err)
 	}
 	defer func(Body io.ReadCloser) {
 		if closeErr := Body.Close(); closeErr != nil {
@@ -41,16 +67,26 @@ func (w *WebhookService) ProcessWebhook(req *http.Request) error {
 
 	w.logger.WithFields(logrus.Fields{
 		"method":  req.Method,
-		"path":    req.URL.Path,
-		"headers": req.Header,
+		"path":    redactPath(req),
+		"headers": redactHeaders(req.Header),
 		"body":    string(body),
 	}).Debug("Webhook received")
 
+	// Проверяем подлинность запроса до разбора payload
+	if w.verifier != nil {
+		if verifyErr := w.verifier.Verify(req, body); verifyErr != nil {
+			w.logger.WithError(verifyErr).WithFields(logrus.Fields{
+				"remote_addr": req.RemoteAddr,
+			}).Warn("Webhook verification failed")
+			return report, verifyErr
+		}
+	}
+
 	// Разбираем данные из YouTrack
 	payload, parseErr := w.youtrackParser.ParseJSON(body)
 	if parseErr != nil {
 		w.logger.WithError(parseErr).Error("Failed to parse YouTrack data")
-		return parseErr
+		return report, fmt.Errorf("%w: %w", port.ErrInvalidPayload, parseErr)
 	}
 	w.logger.WithFields(logrus.Fields{
 		"payload": payload,
@@ -73,7 +109,7 @@ func (w *WebhookService) ProcessWebhook(req *http.Request) error {
 		} else {
 			w.logger.Warn("Project name is empty in webhook payload, ignoring notification")
 		}
-		return nil // Игнорируем, но не возвращаем ошибку
+		return report, nil // Игнорируем, но не возвращаем ошибку
 	}
 
 	// Проверяем, нужно ли отправлять уведомление для черновика
@@ -84,53 +120,204 @@ func (w *WebhookService) ProcessWebhook(req *http.Request) error {
 				"project": projectName,
 				"isDraft": true,
 			}).Info("Draft notification is disabled for project, ignoring notification")
-			return nil // Игнорируем черновик, если отправка отключена
+			// Игнорируем черновик, если отправка отключена
+			for _, channel := range channels {
+				report.Deliveries = append(report.Deliveries, port.DeliveryResult{Channel: channel, Status: port.DeliveryStatusSkipped, Reason: port.SkipReasonDraft})
+			}
+			return report, nil
 		}
 	}
 
-	youtrackFormatter := w.youtrackParser.NewFormatter()
+	targets, skipped := resolveTargets(w.youtrackParser, channels, payload, projectName, w.logger)
+	report.Deliveries = skipped
 
-	// Регистрируем специальное форматирование для Telegram канала
-	youtrackFormatter.RegisterChannelFormatter(port.ChannelTelegram, formatter.FormatTelegram)
-	// Регистрируем форматирование для VK Teams канала (с измененным блоком "Упомянуты:")
-	youtrackFormatter.RegisterChannelFormatter(port.ChannelVKTeams, formatter.FormatVKTeams)
+	event := port.NotificationEvent{
+		ID:         newEventID(),
+		Key:        issueKey(payload, projectName),
+		Project:    projectName,
+		Payload:    payload,
+		Targets:    targets,
+		ReceivedAt: nowFunc(),
+	}
+	if len(event.Targets) == 0 {
+		return report, nil
+	}
 
-	// Отправляем уведомление через все выбранные каналы
-	for _, channel := range channels {
-		// Форматируем уведомление для конкретного канала
-		formattedMessage := youtrackFormatter.Format(payload, channel)
+	// Отбрасываем событие, уже полученное в пределах времени жизни ключа идемпотентности
+	idempotencyKey := ""
+	if w.idempotency != nil {
+		idempotencyKey = w.idempotency.Key(req, payload)
+		if w.idempotency.Seen(idempotencyKey) {
+			w.logger.WithFields(logrus.Fields{
+				"project":         projectName,
+				"issue":           event.Key,
+				"idempotency_key": idempotencyKey,
+			}).Info("Duplicate webhook event ignored")
+			w.count(port.MetricWebhookDuplicate)
+			report.Deliveries = append(report.Deliveries, targetResults(event.Targets, port.DeliveryStatusSkipped, port.SkipReasonDuplicate)...)
+			return report, nil
+		}
+	}
 
-		// Получаем chatID для каналов, которые требуют его
-		chatID := ""
-		if channel == port.ChannelTelegram {
-			var ok bool
-			chatID, ok = w.youtrackParser.GetTelegramChatID(projectName)
-			if !ok || chatID == "" {
-				w.logger.WithFields(logrus.Fields{
+	// Без очереди доставляем уведомление синхронно в рамках запроса
+	if w.deliveryQueue == nil {
+		results := NewDispatcher(w.notificationSender, w.youtrackParser, nil, nil, w.logger).Dispatch(ctx, event)
+		// Без очереди отложенную отправку повторить некому, поэтому она считается неудачной
+		for i := range results {
+			if results[i].Status == port.DeliveryStatusDeferred {
+				results[i].Status = port.DeliveryStatusFailed
+				results[i].RetryAfter = 0
+			}
+		}
+		w.count(port.MetricWebhookAccepted)
+		report.Deliveries = append(report.Deliveries, results...)
+		return report, nil
+	}
+
+	if err = w.deliveryQueue.Enqueue(event); err != nil {
+		// Событие не принято, повторная отправка источником не должна считаться дубликатом
+		if idempotencyKey != "" {
+			w.idempotency.Forget(idempotencyKey)
+		}
+		return port.DeliveryReport{}, err
+	}
+	w.count(port.MetricWebhookAccepted)
+	report.Deliveries = append(report.Deliveries, targetResults(event.Targets, port.DeliveryStatusQueued, "")...)
+
+	w.logger.WithFields(logrus.Fields{
+		"project": projectName,
+		"issue":   event.Key,
+		"targets": len(event.Targets),
+	}).Debug("Notification event queued")
+
+	return report, nil
+}
+
+// count увеличивает счетчик, если учет метрик включен
+func (w *WebhookService) count(name string) {
+	if w.metrics != nil {
+		w.metrics.Inc(name)
+	}
+}
+
+// resolveTargets определяет адресатов уведомления для разрешенных каналов проекта
+// Каналы, для которых не настроен chat_id, пропускаются и возвращаются отдельно как результаты для отчета о доставке
+func resolveTargets(youtrackParser parser.YoutrackParser, channels []string, payload *parser.YoutrackWebhookPayload, projectName string, logger *logrus.Logger) ([]port.NotificationTarget, []port.DeliveryResult) {
+	targets := make([]port.NotificationTarget, 0, len(channels))
+	var skipped []port.DeliveryResult
+
+	resolvers := chatIDResolvers(youtrackParser, payload)
+	listResolvers := chatIDListResolvers(youtrackParser)
+	for _, channel := range channels {
+		// Каналы с несколькими адресатами проекта получают отдельного адресата для каждого из них
+		if resolve, multiple := listResolvers[channel]; multiple {
+			chatIDs, ok := resolve(projectName)
+			if !ok || len(chatIDs) == 0 {
+				logger.WithFields(logrus.Fields{
 					"project": projectName,
 					"channel": channel,
-				}).Warn("Telegram chat ID not found for project, skipping notification")
+				}).Warn("Chat ID not found for project channel, skipping notification")
+				skipped = append(skipped, port.DeliveryResult{Channel: channel, Status: port.DeliveryStatusSkipped, Reason: port.SkipReasonMissingChatID})
 				continue
 			}
-		} else if channel == port.ChannelVKTeams {
+			for _, chatID := range chatIDs {
+				targets = append(targets, port.NotificationTarget{Channel: channel, ChatID: chatID})
+			}
+			continue
+		}
+
+		// Получаем chatID для каналов, которые требуют его
+		chatID := ""
+		if resolve, required := resolvers[channel]; required {
 			var ok bool
-			chatID, ok = w.youtrackParser.GetVKTeamsChatID(projectName)
+			chatID, ok = resolve(projectName)
 			if !ok || chatID == "" {
-				w.logger.WithFields(logrus.Fields{
+				logger.WithFields(logrus.Fields{
 					"project": projectName,
 					"channel": channel,
-				}).Warn("VK Teams chat ID not found for project, skipping notification")
+				}).Warn("Chat ID not found for project channel, skipping notification")
+				skipped = append(skipped, port.DeliveryResult{Channel: channel, Status: port.DeliveryStatusSkipped, Reason: port.SkipReasonMissingChatID})
 				continue
 			}
 		}
 
-		if err = w.notificationSender.Send(channel, chatID, formattedMessage); err != nil {
-			w.logger.WithError(err).WithFields(logrus.Fields{
-				"channel": channel,
-			}).Error("Failed to send notification to channel")
-			// Продолжаем отправку в другие каналы даже при ошибке
-		}
+		targets = append(targets, port.NotificationTarget{Channel: channel, ChatID: chatID})
+	}
+
+	return targets, skipped
+}
+
+// chatIDResolvers возвращает функции получения chat_id проекта для каналов, которые требуют его
+// Адресаты email зависят от исполнителя задачи, поэтому для них используется payload
+func chatIDResolvers(youtrackParser parser.YoutrackParser, payload *parser.YoutrackWebhookPayload) map[string]func(projectName string) (string, bool) {
+	return map[string]func(projectName string) (string, bool){
+		port.ChannelTelegram:   youtrackParser.GetTelegramChatID,
+		port.ChannelVKTeams:    youtrackParser.GetVKTeamsChatID,
+		port.ChannelSlack:      youtrackParser.GetSlackChatID,
+		port.ChannelMattermost: youtrackParser.GetMattermostChatID,
+		port.ChannelMSTeams:    youtrackParser.GetMSTeamsChatID,
+		port.ChannelDiscord:    youtrackParser.GetDiscordChatID,
+		port.ChannelMatrix:     youtrackParser.GetMatrixChatID,
+		port.ChannelZulip:      youtrackParser.GetZulipChatID,
+		port.ChannelNtfy:       youtrackParser.GetNtfyChatID,
+		port.ChannelGotify:     youtrackParser.GetGotifyChatID,
+		port.ChannelEmail: func(projectName string) (string, bool) {
+			return youtrackParser.GetEmailChatID(projectName, payload.Issue.Assignee)
+		},
+	}
+}
+
+// chatIDListResolvers возвращает функции получения адресатов для каналов, в которых у проекта может быть несколько адресатов
+// Для webhook канала адресат - имя цели из outgoing_webhook.targets
+func chatIDListResolvers(youtrackParser parser.YoutrackParser) map[string]func(projectName string) ([]string, bool) {
+	return map[string]func(projectName string) ([]string, bool){
+		port.ChannelWebhook: youtrackParser.GetWebhookTargets,
 	}
+}
+
+// targetResults возвращает одинаковый результат для каждого адресата
+func targetResults(targets []port.NotificationTarget, status port.DeliveryStatus, reason string) []port.DeliveryResult {
+	results := make([]port.DeliveryResult, 0, len(targets))
+	for _, target := range targets {
+		results = append(results, port.DeliveryResult{Channel: target.Channel, ChatID: target.ChatID, Status: status, Reason: reason})
+	}
+	return results
+}
+
+// issueKey возвращает ключ задачи для упорядочивания доставки
+// Используется читаемый идентификатор задачи, при его отсутствии - ссылка или проект и заголовок
+func issueKey(payload *parser.YoutrackWebhookPayload, projectName string) string {
+	if payload.Issue.IDReadable != "" {
+		return payload.Issue.IDReadable
+	}
+	if payload.Issue.URL != "" {
+		return payload.Issue.URL
+	}
+	return strings.ToLower(projectName) + "/" + payload.Issue.Summary
+}
 
-	return nil
+// newEventID возвращает случайный идентификатор события
+func newEventID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return strconv.FormatInt(nowFunc().UnixNano(), 16)
+	}
+	return hex.EncodeToString(id)
+}
+
+// redactPath скрывает токен источника в пути запроса для логирования
+func redactPath(req *http.Request) string {
+	if token := port.WebhookTokenFromContext(req.Context()); token != "" {
+		return strings.ReplaceAll(req.URL.Path, token, "***")
+	}
+	return req.URL.Path
+}
+
+// redactHeaders возвращает копию заголовков со скрытыми учетными данными для логирования
+func redactHeaders(headers http.Header) http.Header {
+	redacted := headers.Clone()
+	if redacted.Get("Authorization") != "" {
+		redacted.Set("Authorization", "***")
+	}
+	return redacted
 }
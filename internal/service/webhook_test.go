@@ -2,7 +2,10 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"github.com/beliaev-aa/notifications/internal/config"
 	"github.com/beliaev-aa/notifications/internal/domain/port"
 	"github.com/beliaev-aa/notifications/internal/domain/port/parser"
 	"github.com/beliaev-aa/notifications/tests/mocks"
@@ -11,8 +14,10 @@ import (
 	"github.com/sirupsen/logrus"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 type mockReadCloser struct {
@@ -55,7 +60,7 @@ func TestNewWebhookService(t *testing.T) {
 
 			mockSender := mocks.NewMockNotificationSender(ctrl)
 			mockParser := mocks.NewMockYoutrackParser(ctrl)
-			service := NewWebhookService(mockSender, mockParser, tc.logger)
+			service := NewWebhookService(mockSender, mockParser, nil, nil, nil, nil, tc.logger)
 
 			if service == nil {
 				t.Error("expected service to be created, got: nil")
@@ -86,6 +91,7 @@ func TestProcessWebhook(t *testing.T) {
 		sendError         error
 		expectedError     bool
 		expectedErrorMsg  string
+		expectedErrorIs   error
 		checkLogging      bool
 		verifySendCalls   bool
 	}
@@ -119,6 +125,7 @@ func TestProcessWebhook(t *testing.T) {
 			requestBodyError: errors.New("read error"),
 			expectedError:    true,
 			expectedErrorMsg: "read error",
+			expectedErrorIs:  port.ErrBadRequest,
 		},
 		{
 			name:             "Error_Parsing_JSON",
@@ -126,6 +133,7 @@ func TestProcessWebhook(t *testing.T) {
 			parseJSONError:   errors.New("failed to unmarshal webhook payload"),
 			expectedError:    true,
 			expectedErrorMsg: "failed to unmarshal webhook payload",
+			expectedErrorIs:  port.ErrInvalidPayload,
 			checkLogging:     true,
 		},
 		{
@@ -369,9 +377,7 @@ func TestProcessWebhook(t *testing.T) {
 					}
 
 					if tc.verifySendCalls && len(tc.allowedChannels) > 0 {
-						mockParser.EXPECT().NewFormatter().Return(mockFormatter)
-						mockFormatter.EXPECT().RegisterChannelFormatter(port.ChannelTelegram, gomock.Any())
-						mockFormatter.EXPECT().RegisterChannelFormatter(port.ChannelVKTeams, gomock.Any())
+						formatterExpected := false
 
 						projectName := ""
 						if tc.parseJSONPayload != nil && tc.parseJSONPayload.Project != nil && tc.parseJSONPayload.Project.Name != nil {
@@ -382,8 +388,6 @@ func TestProcessWebhook(t *testing.T) {
 						}
 
 						for _, channel := range tc.allowedChannels {
-							mockFormatter.EXPECT().Format(tc.parseJSONPayload, channel).Return("formatted for " + channel)
-
 							chatID := ""
 							shouldSkip := false
 							if channel == port.ChannelTelegram {
@@ -410,7 +414,15 @@ func TestProcessWebhook(t *testing.T) {
 								continue
 							}
 
-							sendCall := mockSender.EXPECT().Send(channel, chatID, gomock.Any())
+							if !formatterExpected {
+								mockParser.EXPECT().NewFormatter().Return(mockFormatter)
+								mockFormatter.EXPECT().RegisterChannelFormatter(port.ChannelTelegram, gomock.Any())
+								mockFormatter.EXPECT().RegisterChannelFormatter(port.ChannelVKTeams, gomock.Any())
+								formatterExpected = true
+							}
+							mockFormatter.EXPECT().Format(tc.parseJSONPayload, channel).Return("formatted for " + channel)
+
+							sendCall := mockSender.EXPECT().Send(gomock.Any(), channel, chatID, gomock.Any())
 							if tc.sendError != nil {
 								sendCall.Return(tc.sendError)
 							} else {
@@ -427,7 +439,7 @@ func TestProcessWebhook(t *testing.T) {
 				logger:             logger,
 			}
 
-			err = service.ProcessWebhook(req)
+			_, err = service.ProcessWebhook(req.Context(), req)
 
 			if tc.expectedError {
 				if err == nil {
@@ -435,6 +447,9 @@ func TestProcessWebhook(t *testing.T) {
 				} else if tc.expectedErrorMsg != "" && !strings.Contains(err.Error(), tc.expectedErrorMsg) {
 					t.Errorf("expected error message to contain %q, got: %v", tc.expectedErrorMsg, err)
 				}
+				if tc.expectedErrorIs != nil && !errors.Is(err, tc.expectedErrorIs) {
+					t.Errorf("expected error to wrap %v, got: %v", tc.expectedErrorIs, err)
+				}
 			} else {
 				if err != nil {
 					t.Errorf("unexpected error: %v", err)
@@ -595,7 +610,7 @@ func TestProcessWebhook_IsDraft(t *testing.T) {
 
 				for _, channel := range tc.allowedChannels {
 					mockFormatter.EXPECT().Format(payload, channel).Return("formatted for " + channel)
-					mockSender.EXPECT().Send(channel, "", gomock.Any()).Return(nil)
+					mockSender.EXPECT().Send(gomock.Any(), channel, "", gomock.Any()).Return(nil)
 				}
 			}
 
@@ -605,7 +620,7 @@ func TestProcessWebhook_IsDraft(t *testing.T) {
 				logger:             logger,
 			}
 
-			err = service.ProcessWebhook(req)
+			_, err = service.ProcessWebhook(req.Context(), req)
 
 			if tc.expectedError {
 				if err == nil {
@@ -740,8 +755,8 @@ func TestProcessWebhook_Integration(t *testing.T) {
 					chatID = "test_vkteams_chat_id"
 				}
 
-				mockSender.EXPECT().Send(channel, chatID, gomock.Any()).
-					Do(func(ch string, cID string, msg string) {
+				mockSender.EXPECT().Send(gomock.Any(), channel, chatID, gomock.Any()).
+					Do(func(_ context.Context, ch string, cID string, msg string) {
 						receivedChannels = append(receivedChannels, ch)
 						receivedMessages = append(receivedMessages, msg)
 					}).
@@ -754,7 +769,7 @@ func TestProcessWebhook_Integration(t *testing.T) {
 				logger:             logger,
 			}
 
-			err = service.ProcessWebhook(req)
+			_, err = service.ProcessWebhook(req.Context(), req)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -778,3 +793,591 @@ func TestProcessWebhook_Integration(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessWebhook_Verification(t *testing.T) {
+	type testCase struct {
+		name          string
+		verifyError   error
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Verification_Failed_Stops_Processing",
+			verifyError:   fmt.Errorf("%w: signature mismatch", port.ErrUnauthorized),
+			expectedError: true,
+		},
+		{
+			name:          "Verification_Passed_Continues_Processing",
+			verifyError:   nil,
+			expectedError: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var buf bytes.Buffer
+			logger := logrus.New()
+			logger.SetOutput(&buf)
+			logger.SetLevel(logrus.DebugLevel)
+
+			mockSender := mocks.NewMockNotificationSender(ctrl)
+			mockParser := mocks.NewMockYoutrackParser(ctrl)
+			mockVerifier := mocks.NewMockWebhookVerifier(ctrl)
+
+			body := `{"project":{"name":"Test"}}`
+			req, err := http.NewRequest("POST", "/webhook", strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+
+			mockVerifier.EXPECT().Verify(req, []byte(body)).Return(tc.verifyError)
+			if tc.verifyError == nil {
+				payload := &parser.YoutrackWebhookPayload{}
+				mockParser.EXPECT().ParseJSON([]byte(body)).Return(payload, nil)
+				mockParser.EXPECT().GetAllowedChannels(payload).Return(nil)
+			}
+
+			service := NewWebhookService(mockSender, mockParser, mockVerifier, nil, nil, nil, logger)
+			_, err = service.ProcessWebhook(req.Context(), req)
+
+			if tc.expectedError {
+				if !errors.Is(err, port.ErrUnauthorized) {
+					t.Errorf("expected ErrUnauthorized, got: %v", err)
+				}
+				if !strings.Contains(buf.String(), "Webhook verification failed") {
+					t.Error("expected log message about verification failure")
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestProcessWebhook_BodyTooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	service := &WebhookService{
+		notificationSender: mocks.NewMockNotificationSender(ctrl),
+		youtrackParser:     mocks.NewMockYoutrackParser(ctrl),
+		logger:             logger,
+	}
+
+	req := httptest.NewRequest("POST", "/webhook/youtrack", strings.NewReader(`{"project":{"name":"Demo"}}`))
+	req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, 8)
+
+	_, err := service.ProcessWebhook(req.Context(), req)
+	if !errors.Is(err, port.ErrPayloadTooLarge) {
+		t.Errorf("expected error to wrap ErrPayloadTooLarge, got: %v", err)
+	}
+}
+
+func TestProcessWebhook_DeliveryQueue(t *testing.T) {
+	type testCase struct {
+		name            string
+		telegramChatID  string
+		enqueueError    error
+		expectEnqueue   bool
+		expectedTargets []port.NotificationTarget
+		expectedReport  port.DeliveryReport
+		expectedError   error
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Event_Enqueued_With_Resolved_Targets",
+			telegramChatID: "tg_chat",
+			expectEnqueue:  true,
+			expectedTargets: []port.NotificationTarget{
+				{Channel: port.ChannelLogger},
+				{Channel: port.ChannelTelegram, ChatID: "tg_chat"},
+			},
+			expectedReport: port.DeliveryReport{Deliveries: []port.DeliveryResult{
+				{Channel: port.ChannelLogger, Status: port.DeliveryStatusQueued},
+				{Channel: port.ChannelTelegram, ChatID: "tg_chat", Status: port.DeliveryStatusQueued},
+			}},
+		},
+		{
+			name:           "Channel_Without_Chat_ID_Not_Enqueued",
+			telegramChatID: "",
+			expectEnqueue:  true,
+			expectedTargets: []port.NotificationTarget{
+				{Channel: port.ChannelLogger},
+			},
+			expectedReport: port.DeliveryReport{Deliveries: []port.DeliveryResult{
+				{Channel: port.ChannelTelegram, Status: port.DeliveryStatusSkipped, Reason: port.SkipReasonMissingChatID},
+				{Channel: port.ChannelLogger, Status: port.DeliveryStatusQueued},
+			}},
+		},
+		{
+			name:           "Queue_Full_Returns_Unavailable",
+			telegramChatID: "tg_chat",
+			enqueueError:   &port.UnavailableError{Reason: "delivery queue is full", RetryAfter: time.Second},
+			expectEnqueue:  true,
+			expectedTargets: []port.NotificationTarget{
+				{Channel: port.ChannelLogger},
+				{Channel: port.ChannelTelegram, ChatID: "tg_chat"},
+			},
+			expectedError: port.ErrUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockSender := mocks.NewMockNotificationSender(ctrl)
+			mockParser := mocks.NewMockYoutrackParser(ctrl)
+			mockQueue := mocks.NewMockDeliveryQueue(ctrl)
+
+			projectName := "Demo"
+			payload := &parser.YoutrackWebhookPayload{
+				Project: &parser.YoutrackFieldValue{Name: &projectName},
+				Issue:   parser.YoutrackIssue{IDReadable: "DEMO-7", Summary: "Test"},
+			}
+			body := `{"project":{"name":"Demo"},"issue":{"idReadable":"DEMO-7"}}`
+
+			mockParser.EXPECT().ParseJSON([]byte(body)).Return(payload, nil)
+			mockParser.EXPECT().GetAllowedChannels(payload).Return([]string{port.ChannelLogger, port.ChannelTelegram})
+			mockParser.EXPECT().GetTelegramChatID(projectName).Return(tc.telegramChatID, tc.telegramChatID != "")
+
+			var queued port.NotificationEvent
+			if tc.expectEnqueue {
+				mockQueue.EXPECT().Enqueue(gomock.Any()).DoAndReturn(func(event port.NotificationEvent) error {
+					queued = event
+					return tc.enqueueError
+				})
+			}
+
+			service := NewWebhookService(mockSender, mockParser, nil, mockQueue, nil, nil, logger)
+			req := httptest.NewRequest("POST", "/webhook/youtrack", strings.NewReader(body))
+			report, err := service.ProcessWebhook(req.Context(), req)
+
+			if tc.expectedError != nil {
+				if !errors.Is(err, tc.expectedError) {
+					t.Errorf("expected error %v, got: %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if len(queued.ID) != 32 {
+				t.Errorf("expected event to get a random id, got: %q", queued.ID)
+			}
+			if queued.Key != "DEMO-7" {
+				t.Errorf("expected event key %q, got: %q", "DEMO-7", queued.Key)
+			}
+			if queued.Payload != payload {
+				t.Error("expected event to carry parsed payload")
+			}
+			if diff := cmp.Diff(tc.expectedTargets, queued.Targets); diff != "" {
+				t.Errorf("targets mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedReport, report); diff != "" {
+				t.Errorf("report mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProcessWebhook_Idempotency(t *testing.T) {
+	type request struct {
+		idempotencyKey string
+		enqueueError   error
+		expectEnqueue  bool
+		expectedMetric string
+		expectedStatus port.DeliveryStatus
+	}
+
+	type testCase struct {
+		name     string
+		requests []request
+	}
+
+	queueFull := &port.UnavailableError{Reason: "delivery queue is full", RetryAfter: time.Second}
+
+	testCases := []testCase{
+		{
+			name: "Repeated_Payload_Dropped",
+			requests: []request{
+				{expectEnqueue: true, expectedMetric: port.MetricWebhookAccepted, expectedStatus: port.DeliveryStatusQueued},
+				{expectEnqueue: false, expectedMetric: port.MetricWebhookDuplicate, expectedStatus: port.DeliveryStatusSkipped},
+			},
+		},
+		{
+			name: "Repeated_Idempotency_Key_Dropped",
+			requests: []request{
+				{idempotencyKey: "key-1", expectEnqueue: true, expectedMetric: port.MetricWebhookAccepted, expectedStatus: port.DeliveryStatusQueued},
+				{idempotencyKey: "key-1", expectEnqueue: false, expectedMetric: port.MetricWebhookDuplicate, expectedStatus: port.DeliveryStatusSkipped},
+			},
+		},
+		{
+			name: "Different_Idempotency_Keys_Accepted",
+			requests: []request{
+				{idempotencyKey: "key-1", expectEnqueue: true, expectedMetric: port.MetricWebhookAccepted, expectedStatus: port.DeliveryStatusQueued},
+				{idempotencyKey: "key-2", expectEnqueue: true, expectedMetric: port.MetricWebhookAccepted, expectedStatus: port.DeliveryStatusQueued},
+			},
+		},
+		{
+			name: "Rejected_Event_Accepted_On_Retry",
+			requests: []request{
+				{expectEnqueue: true, enqueueError: queueFull},
+				{expectEnqueue: true, expectedMetric: port.MetricWebhookAccepted, expectedStatus: port.DeliveryStatusQueued},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockSender := mocks.NewMockNotificationSender(ctrl)
+			mockParser := mocks.NewMockYoutrackParser(ctrl)
+			mockQueue := mocks.NewMockDeliveryQueue(ctrl)
+			mockMetrics := mocks.NewMockMetrics(ctrl)
+
+			projectName := "Demo"
+			payload := &parser.YoutrackWebhookPayload{
+				Project: &parser.YoutrackFieldValue{Name: &projectName},
+				Issue:   parser.YoutrackIssue{IDReadable: "DEMO-7", Summary: "Test"},
+			}
+			body := `{"project":{"name":"Demo"},"issue":{"idReadable":"DEMO-7"}}`
+
+			guard := NewIdempotencyGuard(config.IdempotencyConfig{Enabled: true, TTL: 60, CacheSize: 10, Header: config.DefaultIdempotencyHeader})
+			service := NewWebhookService(mockSender, mockParser, nil, mockQueue, guard, mockMetrics, logger)
+
+			for i, d := range tc.requests {
+				mockParser.EXPECT().ParseJSON([]byte(body)).Return(payload, nil)
+				mockParser.EXPECT().GetAllowedChannels(payload).Return([]string{port.ChannelLogger})
+				if d.expectEnqueue {
+					mockQueue.EXPECT().Enqueue(gomock.Any()).Return(d.enqueueError)
+				}
+				if d.expectedMetric != "" {
+					mockMetrics.EXPECT().Inc(d.expectedMetric)
+				}
+
+				req := httptest.NewRequest("POST", "/webhook/youtrack", strings.NewReader(body))
+				if d.idempotencyKey != "" {
+					req.Header.Set(config.DefaultIdempotencyHeader, d.idempotencyKey)
+				}
+
+				report, err := service.ProcessWebhook(req.Context(), req)
+				if d.enqueueError != nil {
+					if !errors.Is(err, port.ErrUnavailable) {
+						t.Errorf("request %d: expected error %v, got: %v", i, port.ErrUnavailable, err)
+					}
+				} else if err != nil {
+					t.Errorf("request %d: unexpected error: %v", i, err)
+				}
+
+				if d.expectedStatus != "" {
+					if len(report.Deliveries) != 1 || report.Deliveries[0].Status != d.expectedStatus {
+						t.Errorf("request %d: expected single delivery with status %s, got: %+v", i, d.expectedStatus, report.Deliveries)
+					}
+				} else if len(report.Deliveries) != 0 {
+					t.Errorf("request %d: expected empty report, got: %+v", i, report.Deliveries)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessWebhook_DeliveryReport(t *testing.T) {
+	type testCase struct {
+		name               string
+		isDraft            bool
+		sendDraft          bool
+		vkteamsChatID      string
+		telegramSendError  error
+		expectSend         bool
+		expectedDeliveries []port.DeliveryResult
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Sent_And_Failed_Targets_Reported",
+			vkteamsChatID: "vk_chat",
+			telegramSendError: &port.AttemptsError{Attempts: 3, Err: port.NewDeliveryError(port.ChannelTelegram, http.StatusTooManyRequests,
+				errors.New("too many requests"))},
+			expectSend: true,
+			expectedDeliveries: []port.DeliveryResult{
+				{Channel: port.ChannelTelegram, ChatID: "tg_chat", Status: port.DeliveryStatusFailed, ErrorClass: port.ErrorClassRateLimited},
+				{Channel: port.ChannelVKTeams, ChatID: "vk_chat", Status: port.DeliveryStatusSent},
+			},
+		},
+		{
+			name:          "Missing_Chat_ID_Reported_As_Skipped",
+			vkteamsChatID: "",
+			expectSend:    true,
+			expectedDeliveries: []port.DeliveryResult{
+				{Channel: port.ChannelVKTeams, Status: port.DeliveryStatusSkipped, Reason: port.SkipReasonMissingChatID},
+				{Channel: port.ChannelTelegram, ChatID: "tg_chat", Status: port.DeliveryStatusSent},
+			},
+		},
+		{
+			name:      "Disabled_Draft_Reported_As_Skipped",
+			isDraft:   true,
+			sendDraft: false,
+			expectedDeliveries: []port.DeliveryResult{
+				{Channel: port.ChannelTelegram, Status: port.DeliveryStatusSkipped, Reason: port.SkipReasonDraft},
+				{Channel: port.ChannelVKTeams, Status: port.DeliveryStatusSkipped, Reason: port.SkipReasonDraft},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockSender := mocks.NewMockNotificationSender(ctrl)
+			mockParser := mocks.NewMockYoutrackParser(ctrl)
+			mockFormatter := mocks.NewMockYoutrackFormatter(ctrl)
+
+			projectName := "Demo"
+			payload := &parser.YoutrackWebhookPayload{
+				Project: &parser.YoutrackFieldValue{Name: &projectName},
+				Issue:   parser.YoutrackIssue{IDReadable: "DEMO-7", Summary: "Test", IsDraft: tc.isDraft},
+			}
+			body := `{"project":{"name":"Demo"},"issue":{"idReadable":"DEMO-7"}}`
+
+			mockParser.EXPECT().ParseJSON([]byte(body)).Return(payload, nil)
+			mockParser.EXPECT().GetAllowedChannels(payload).Return([]string{port.ChannelTelegram, port.ChannelVKTeams})
+			if tc.isDraft {
+				mockParser.EXPECT().GetSendDraftNotification(projectName).Return(tc.sendDraft)
+			}
+			if tc.expectSend {
+				mockParser.EXPECT().GetTelegramChatID(projectName).Return("tg_chat", true)
+				mockParser.EXPECT().GetVKTeamsChatID(projectName).Return(tc.vkteamsChatID, tc.vkteamsChatID != "")
+				mockParser.EXPECT().NewFormatter().Return(mockFormatter)
+				mockFormatter.EXPECT().RegisterChannelFormatter(gomock.Any(), gomock.Any()).AnyTimes()
+				mockFormatter.EXPECT().Format(payload, gomock.Any()).Return("message").AnyTimes()
+				mockSender.EXPECT().Send(gomock.Any(), port.ChannelTelegram, "tg_chat", "message").Return(tc.telegramSendError)
+				if tc.vkteamsChatID != "" {
+					mockSender.EXPECT().Send(gomock.Any(), port.ChannelVKTeams, tc.vkteamsChatID, "message").Return(nil)
+				}
+			}
+
+			service := NewWebhookService(mockSender, mockParser, nil, nil, nil, nil, logger)
+			req := httptest.NewRequest("POST", "/webhook/youtrack", strings.NewReader(body))
+			report, err := service.ProcessWebhook(req.Context(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(port.DeliveryReport{Deliveries: tc.expectedDeliveries}, report); diff != "" {
+				t.Errorf("report mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveTargets(t *testing.T) {
+	type testCase struct {
+		name            string
+		channels        []string
+		slackChatID     string
+		webhookTargets  []string
+		expectedTargets []port.NotificationTarget
+		expectedSkipped []port.DeliveryResult
+	}
+
+	webhookURL := "https://hooks.slack.com/services/T000/B000/XXX"
+	assigneeEmail := "john@example.com"
+	payload := &parser.YoutrackWebhookPayload{
+		Issue: parser.YoutrackIssue{Assignee: &parser.YoutrackUser{Email: &assigneeEmail}},
+	}
+
+	testCases := []testCase{
+		{
+			name:        "Slack_Target_Resolved",
+			channels:    []string{port.ChannelLogger, port.ChannelTelegram, port.ChannelVKTeams, port.ChannelSlack},
+			slackChatID: webhookURL,
+			expectedTargets: []port.NotificationTarget{
+				{Channel: port.ChannelLogger},
+				{Channel: port.ChannelTelegram, ChatID: "tg_chat"},
+				{Channel: port.ChannelVKTeams, ChatID: "vk_chat"},
+				{Channel: port.ChannelSlack, ChatID: webhookURL},
+			},
+		},
+		{
+			name:     "Slack_Without_Target_Skipped",
+			channels: []string{port.ChannelSlack, port.ChannelLogger},
+			expectedTargets: []port.NotificationTarget{
+				{Channel: port.ChannelLogger},
+			},
+			expectedSkipped: []port.DeliveryResult{
+				{Channel: port.ChannelSlack, Status: port.DeliveryStatusSkipped, Reason: port.SkipReasonMissingChatID},
+			},
+		},
+		{
+			name:        "Mattermost_Target_Resolved",
+			channels:    []string{port.ChannelSlack, port.ChannelMattermost},
+			slackChatID: webhookURL,
+			expectedTargets: []port.NotificationTarget{
+				{Channel: port.ChannelSlack, ChatID: webhookURL},
+				{Channel: port.ChannelMattermost, ChatID: "mm_channel"},
+			},
+		},
+		{
+			name:     "MSTeams_Target_Resolved",
+			channels: []string{port.ChannelMSTeams, port.ChannelLogger},
+			expectedTargets: []port.NotificationTarget{
+				{Channel: port.ChannelMSTeams, ChatID: "https://example.webhook.office.com/webhookb2/xxx"},
+				{Channel: port.ChannelLogger},
+			},
+		},
+		{
+			name:     "Discord_Target_Resolved",
+			channels: []string{port.ChannelDiscord, port.ChannelMSTeams},
+			expectedTargets: []port.NotificationTarget{
+				{Channel: port.ChannelDiscord, ChatID: "https://discord.com/api/webhooks/123/token"},
+				{Channel: port.ChannelMSTeams, ChatID: "https://example.webhook.office.com/webhookb2/xxx"},
+			},
+		},
+		{
+			name:     "Matrix_Target_Resolved",
+			channels: []string{port.ChannelMatrix, port.ChannelLogger},
+			expectedTargets: []port.NotificationTarget{
+				{Channel: port.ChannelMatrix, ChatID: "!abcdef:example.com"},
+				{Channel: port.ChannelLogger},
+			},
+		},
+		{
+			name:     "Zulip_Target_Resolved",
+			channels: []string{port.ChannelZulip},
+			expectedTargets: []port.NotificationTarget{
+				{Channel: port.ChannelZulip, ChatID: "backend"},
+			},
+		},
+		{
+			name:     "Ntfy_Target_Resolved",
+			channels: []string{port.ChannelNtfy},
+			expectedTargets: []port.NotificationTarget{
+				{Channel: port.ChannelNtfy, ChatID: "backend-alerts"},
+			},
+		},
+		{
+			name:     "Gotify_Target_Resolved",
+			channels: []string{port.ChannelGotify},
+			expectedTargets: []port.NotificationTarget{
+				{Channel: port.ChannelGotify, ChatID: "AbCdEf123"},
+			},
+		},
+		{
+			name:     "Email_Target_Resolved_With_Assignee",
+			channels: []string{port.ChannelEmail},
+			expectedTargets: []port.NotificationTarget{
+				{Channel: port.ChannelEmail, ChatID: "manager@example.com, john@example.com"},
+			},
+		},
+		{
+			name:           "Webhook_Target_Per_Name",
+			channels:       []string{port.ChannelWebhook, port.ChannelLogger},
+			webhookTargets: []string{"deploy_bot", "bi_collector"},
+			expectedTargets: []port.NotificationTarget{
+				{Channel: port.ChannelWebhook, ChatID: "deploy_bot"},
+				{Channel: port.ChannelWebhook, ChatID: "bi_collector"},
+				{Channel: port.ChannelLogger},
+			},
+		},
+		{
+			name:     "Webhook_Without_Targets_Skipped",
+			channels: []string{port.ChannelWebhook, port.ChannelLogger},
+			expectedTargets: []port.NotificationTarget{
+				{Channel: port.ChannelLogger},
+			},
+			expectedSkipped: []port.DeliveryResult{
+				{Channel: port.ChannelWebhook, Status: port.DeliveryStatusSkipped, Reason: port.SkipReasonMissingChatID},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			mockParser := mocks.NewMockYoutrackParser(ctrl)
+			mockParser.EXPECT().GetTelegramChatID("Demo").Return("tg_chat", true).AnyTimes()
+			mockParser.EXPECT().GetVKTeamsChatID("Demo").Return("vk_chat", true).AnyTimes()
+			mockParser.EXPECT().GetSlackChatID("Demo").Return(tc.slackChatID, tc.slackChatID != "").AnyTimes()
+			mockParser.EXPECT().GetMattermostChatID("Demo").Return("mm_channel", true).AnyTimes()
+			mockParser.EXPECT().GetMSTeamsChatID("Demo").Return("https://example.webhook.office.com/webhookb2/xxx", true).AnyTimes()
+			mockParser.EXPECT().GetDiscordChatID("Demo").Return("https://discord.com/api/webhooks/123/token", true).AnyTimes()
+			mockParser.EXPECT().GetEmailChatID("Demo", payload.Issue.Assignee).Return("manager@example.com, john@example.com", true).AnyTimes()
+			mockParser.EXPECT().GetMatrixChatID("Demo").Return("!abcdef:example.com", true).AnyTimes()
+			mockParser.EXPECT().GetZulipChatID("Demo").Return("backend", true).AnyTimes()
+			mockParser.EXPECT().GetNtfyChatID("Demo").Return("backend-alerts", true).AnyTimes()
+			mockParser.EXPECT().GetGotifyChatID("Demo").Return("AbCdEf123", true).AnyTimes()
+			mockParser.EXPECT().GetWebhookTargets("Demo").Return(tc.webhookTargets, tc.webhookTargets != nil).AnyTimes()
+
+			targets, skipped := resolveTargets(mockParser, tc.channels, payload, "Demo", logger)
+
+			if diff := cmp.Diff(tc.expectedTargets, targets); diff != "" {
+				t.Errorf("targets mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedSkipped, skipped); diff != "" {
+				t.Errorf("skipped mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIssueKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		issue    parser.YoutrackIssue
+		project  string
+		expected string
+	}{
+		{
+			name:     "Readable_ID_Preferred",
+			issue:    parser.YoutrackIssue{IDReadable: "DEMO-1", URL: "https://yt/issue/DEMO-1", Summary: "Test"},
+			project:  "Demo",
+			expected: "DEMO-1",
+		},
+		{
+			name:     "URL_Used_Without_Readable_ID",
+			issue:    parser.YoutrackIssue{URL: "https://yt/issue/DEMO-1", Summary: "Test"},
+			project:  "Demo",
+			expected: "https://yt/issue/DEMO-1",
+		},
+		{
+			name:     "Project_And_Summary_As_Fallback",
+			issue:    parser.YoutrackIssue{Summary: "Test"},
+			project:  "Demo",
+			expected: "demo/Test",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := issueKey(&parser.YoutrackWebhookPayload{Issue: tc.issue}, tc.project); got != tc.expected {
+				t.Errorf("expected %q, got: %q", tc.expected, got)
+			}
+		})
+	}
+}
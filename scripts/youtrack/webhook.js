@@ -2,6 +2,13 @@ const entities = require('@jetbrains/youtrack-scripting-api/entities');
 const http = require('@jetbrains/youtrack-scripting-api/http');
 
 const WEBHOOK_URL = 'http://host.docker.internal:3000/webhook/youtrack';
+// Секрет для подписи запросов (webhook.secret или webhookSecret проекта), пустая строка - без подписи
+const WEBHOOK_SECRET = '';
+// Заголовок с подписью (webhook.signature_header)
+const SIGNATURE_HEADER = 'X-Webhook-Signature';
+// Заголовки защиты от повторов (webhook.replay.timestamp_header и webhook.replay.delivery_header)
+const TIMESTAMP_HEADER = 'X-Webhook-Timestamp';
+const DELIVERY_HEADER = 'X-Webhook-Delivery';
 
 const MENTION_REGEX_FULL = /@\{([^,]+),([^,]+),([^,]+),([^}]+)\}/g;
 const MENTION_REGEX_SIMPLE = /@([a-zA-Z0-9._-]+)/g;
@@ -208,12 +215,125 @@ const buildPayload = (ctx, issue) => {
     };
 };
 
+const SHA256_K = [
+    0x428a2f98, 0x71374491, 0xb5c0fbcf, 0xe9b5dba5, 0x3956c25b, 0x59f111f1, 0x923f82a4, 0xab1c5ed5,
+    0xd807aa98, 0x12835b01, 0x243185be, 0x550c7dc3, 0x72be5d74, 0x80deb1fe, 0x9bdc06a7, 0xc19bf174,
+    0xe49b69c1, 0xefbe4786, 0x0fc19dc6, 0x240ca1cc, 0x2de92c6f, 0x4a7484aa, 0x5cb0a9dc, 0x76f988da,
+    0x983e5152, 0xa831c66d, 0xb00327c8, 0xbf597fc7, 0xc6e00bf3, 0xd5a79147, 0x06ca6351, 0x14292967,
+    0x27b70a85, 0x2e1b2138, 0x4d2c6dfc, 0x53380d13, 0x650a7354, 0x766a0abb, 0x81c2c92e, 0x92722c85,
+    0xa2bfe8a1, 0xa81a664b, 0xc24b8b70, 0xc76c51a3, 0xd192e819, 0xd6990624, 0xf40e3585, 0x106aa070,
+    0x19a4c116, 0x1e376c08, 0x2748774c, 0x34b0bcb5, 0x391c0cb3, 0x4ed8aa4a, 0x5b9cca4f, 0x682e6ff3,
+    0x748f82ee, 0x78a5636f, 0x84c87814, 0x8cc70208, 0x90befffa, 0xa4506ceb, 0xbef9a3f7, 0xc67178f2
+];
+
+/** Кодирует строку в массив байт UTF-8 */
+const utf8Bytes = str => {
+    const bytes = [];
+    for (let i = 0; i < str.length; i++) {
+        let code = str.charCodeAt(i);
+        if (code >= 0xd800 && code <= 0xdbff && i + 1 < str.length) {
+            const next = str.charCodeAt(i + 1);
+            if (next >= 0xdc00 && next <= 0xdfff) {
+                code = 0x10000 + ((code - 0xd800) << 10) + (next - 0xdc00);
+                i++;
+            }
+        }
+        if (code < 0x80) {
+            bytes.push(code);
+        } else if (code < 0x800) {
+            bytes.push(0xc0 | (code >> 6), 0x80 | (code & 0x3f));
+        } else if (code < 0x10000) {
+            bytes.push(0xe0 | (code >> 12), 0x80 | ((code >> 6) & 0x3f), 0x80 | (code & 0x3f));
+        } else {
+            bytes.push(0xf0 | (code >> 18), 0x80 | ((code >> 12) & 0x3f), 0x80 | ((code >> 6) & 0x3f), 0x80 | (code & 0x3f));
+        }
+    }
+    return bytes;
+};
+
+const rotr = (x, n) => (x >>> n) | (x << (32 - n));
+
+/** Вычисляет SHA-256 от массива байт */
+const sha256 = bytes => {
+    const h = [0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19];
+    const msg = bytes.slice();
+    const bitLenHi = Math.floor(bytes.length / 0x20000000);
+    const bitLenLo = (bytes.length * 8) >>> 0;
+    msg.push(0x80);
+    while (msg.length % 64 !== 56) msg.push(0);
+    for (let s = 24; s >= 0; s -= 8) msg.push((bitLenHi >>> s) & 0xff);
+    for (let s = 24; s >= 0; s -= 8) msg.push((bitLenLo >>> s) & 0xff);
+
+    const w = new Array(64);
+    for (let off = 0; off < msg.length; off += 64) {
+        for (let i = 0; i < 16; i++) {
+            const j = off + i * 4;
+            w[i] = (msg[j] << 24) | (msg[j + 1] << 16) | (msg[j + 2] << 8) | msg[j + 3];
+        }
+        for (let i = 16; i < 64; i++) {
+            const s0 = rotr(w[i - 15], 7) ^ rotr(w[i - 15], 18) ^ (w[i - 15] >>> 3);
+            const s1 = rotr(w[i - 2], 17) ^ rotr(w[i - 2], 19) ^ (w[i - 2] >>> 10);
+            w[i] = (w[i - 16] + s0 + w[i - 7] + s1) | 0;
+        }
+        let [a, b, c, d, e, f, g, k] = h;
+        for (let i = 0; i < 64; i++) {
+            const t1 = (k + (rotr(e, 6) ^ rotr(e, 11) ^ rotr(e, 25)) + ((e & f) ^ (~e & g)) + SHA256_K[i] + w[i]) | 0;
+            const t2 = ((rotr(a, 2) ^ rotr(a, 13) ^ rotr(a, 22)) + ((a & b) ^ (a & c) ^ (b & c))) | 0;
+            k = g;
+            g = f;
+            f = e;
+            e = (d + t1) | 0;
+            d = c;
+            c = b;
+            b = a;
+            a = (t1 + t2) | 0;
+        }
+        h[0] = (h[0] + a) | 0;
+        h[1] = (h[1] + b) | 0;
+        h[2] = (h[2] + c) | 0;
+        h[3] = (h[3] + d) | 0;
+        h[4] = (h[4] + e) | 0;
+        h[5] = (h[5] + f) | 0;
+        h[6] = (h[6] + g) | 0;
+        h[7] = (h[7] + k) | 0;
+    }
+
+    const out = [];
+    h.forEach(v => out.push((v >>> 24) & 0xff, (v >>> 16) & 0xff, (v >>> 8) & 0xff, v & 0xff));
+    return out;
+};
+
+/** Вычисляет HMAC-SHA256 сообщения и возвращает его в hex */
+const hmacSha256Hex = (secret, message) => {
+    let key = utf8Bytes(secret);
+    if (key.length > 64) key = sha256(key);
+    while (key.length < 64) key.push(0);
+
+    const inner = sha256(key.map(b => b ^ 0x36).concat(utf8Bytes(message)));
+    const digest = sha256(key.map(b => b ^ 0x5c).concat(inner));
+    return digest.map(b => (b < 16 ? '0' : '') + b.toString(16)).join('');
+};
+
+/** Генерирует уникальный идентификатор доставки */
+const generateDeliveryId = () => {
+    const random = () => Math.floor(Math.random() * 0x100000000).toString(16);
+    return Date.now().toString(16) + '-' + random() + random();
+};
+
 /** Отправка payload в вебхук */
 const sendWebhook = (payload) => {
     try {
+        const body = JSON.stringify(payload);
+        const timestamp = Math.floor(Date.now() / 1000).toString();
         const conn = new http.Connection(WEBHOOK_URL, null, 2000);
         conn.addHeader('Content-Type', 'application/json');
-        const resp = conn.postSync('', null, JSON.stringify(payload));
+        conn.addHeader(TIMESTAMP_HEADER, timestamp);
+        conn.addHeader(DELIVERY_HEADER, generateDeliveryId());
+        if (WEBHOOK_SECRET) {
+            // Метка времени входит в подпись, чтобы ее нельзя было подменить при повторной отправке
+            conn.addHeader(SIGNATURE_HEADER, 'sha256=' + hmacSha256Hex(WEBHOOK_SECRET, timestamp + '.' + body));
+        }
+        const resp = conn.postSync('', null, body);
         if (!resp.isSuccess) {
             console.warn(`Webhook failed: ${resp.status}, payload: ${JSON.stringify(payload)}`);
         }
@@ -0,0 +1,204 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/port/dead_letter.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	port "github.com/beliaev-aa/notifications/internal/domain/port"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDeadLetterStore is a mock of DeadLetterStore interface.
+type MockDeadLetterStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadLetterStoreMockRecorder
+}
+
+// MockDeadLetterStoreMockRecorder is the mock recorder for MockDeadLetterStore.
+type MockDeadLetterStoreMockRecorder struct {
+	mock *MockDeadLetterStore
+}
+
+// NewMockDeadLetterStore creates a new mock instance.
+func NewMockDeadLetterStore(ctrl *gomock.Controller) *MockDeadLetterStore {
+	mock := &MockDeadLetterStore{ctrl: ctrl}
+	mock.recorder = &MockDeadLetterStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadLetterStore) EXPECT() *MockDeadLetterStoreMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockDeadLetterStore) Add(letter port.DeadLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", letter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockDeadLetterStoreMockRecorder) Add(letter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockDeadLetterStore)(nil).Add), letter)
+}
+
+// Delete mocks base method.
+func (m *MockDeadLetterStore) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDeadLetterStoreMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDeadLetterStore)(nil).Delete), id)
+}
+
+// Get mocks base method.
+func (m *MockDeadLetterStore) Get(id string) (port.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(port.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDeadLetterStoreMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDeadLetterStore)(nil).Get), id)
+}
+
+// List mocks base method.
+func (m *MockDeadLetterStore) List() ([]port.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]port.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDeadLetterStoreMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDeadLetterStore)(nil).List))
+}
+
+// MockDeadLetterService is a mock of DeadLetterService interface.
+type MockDeadLetterService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadLetterServiceMockRecorder
+}
+
+// MockDeadLetterServiceMockRecorder is the mock recorder for MockDeadLetterService.
+type MockDeadLetterServiceMockRecorder struct {
+	mock *MockDeadLetterService
+}
+
+// NewMockDeadLetterService creates a new mock instance.
+func NewMockDeadLetterService(ctrl *gomock.Controller) *MockDeadLetterService {
+	mock := &MockDeadLetterService{ctrl: ctrl}
+	mock.recorder = &MockDeadLetterServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadLetterService) EXPECT() *MockDeadLetterServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockDeadLetterService) Delete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDeadLetterServiceMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDeadLetterService)(nil).Delete), id)
+}
+
+// DeleteAll mocks base method.
+func (m *MockDeadLetterService) DeleteAll() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAll")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAll indicates an expected call of DeleteAll.
+func (mr *MockDeadLetterServiceMockRecorder) DeleteAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*MockDeadLetterService)(nil).DeleteAll))
+}
+
+// Get mocks base method.
+func (m *MockDeadLetterService) Get(id string) (port.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(port.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDeadLetterServiceMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDeadLetterService)(nil).Get), id)
+}
+
+// List mocks base method.
+func (m *MockDeadLetterService) List() ([]port.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]port.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDeadLetterServiceMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDeadLetterService)(nil).List))
+}
+
+// Requeue mocks base method.
+func (m *MockDeadLetterService) Requeue(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Requeue", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Requeue indicates an expected call of Requeue.
+func (mr *MockDeadLetterServiceMockRecorder) Requeue(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Requeue", reflect.TypeOf((*MockDeadLetterService)(nil).Requeue), id)
+}
+
+// RequeueAll mocks base method.
+func (m *MockDeadLetterService) RequeueAll() (port.RequeueResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueAll")
+	ret0, _ := ret[0].(port.RequeueResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueAll indicates an expected call of RequeueAll.
+func (mr *MockDeadLetterServiceMockRecorder) RequeueAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueAll", reflect.TypeOf((*MockDeadLetterService)(nil).RequeueAll))
+}
@@ -0,0 +1,232 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/port/delivery.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	port "github.com/beliaev-aa/notifications/internal/domain/port"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNotificationDispatcher is a mock of NotificationDispatcher interface.
+type MockNotificationDispatcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationDispatcherMockRecorder
+}
+
+// MockNotificationDispatcherMockRecorder is the mock recorder for MockNotificationDispatcher.
+type MockNotificationDispatcherMockRecorder struct {
+	mock *MockNotificationDispatcher
+}
+
+// NewMockNotificationDispatcher creates a new mock instance.
+func NewMockNotificationDispatcher(ctrl *gomock.Controller) *MockNotificationDispatcher {
+	mock := &MockNotificationDispatcher{ctrl: ctrl}
+	mock.recorder = &MockNotificationDispatcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationDispatcher) EXPECT() *MockNotificationDispatcherMockRecorder {
+	return m.recorder
+}
+
+// Dispatch mocks base method.
+func (m *MockNotificationDispatcher) Dispatch(ctx context.Context, event port.NotificationEvent) []port.DeliveryResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dispatch", ctx, event)
+	ret0, _ := ret[0].([]port.DeliveryResult)
+	return ret0
+}
+
+// Dispatch indicates an expected call of Dispatch.
+func (mr *MockNotificationDispatcherMockRecorder) Dispatch(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dispatch", reflect.TypeOf((*MockNotificationDispatcher)(nil).Dispatch), ctx, event)
+}
+
+// Fail mocks base method.
+func (m *MockNotificationDispatcher) Fail(event port.NotificationEvent, err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Fail", event, err)
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockNotificationDispatcherMockRecorder) Fail(event, err interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockNotificationDispatcher)(nil).Fail), event, err)
+}
+
+// MockDeliveryQueue is a mock of DeliveryQueue interface.
+type MockDeliveryQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryQueueMockRecorder
+}
+
+// MockDeliveryQueueMockRecorder is the mock recorder for MockDeliveryQueue.
+type MockDeliveryQueueMockRecorder struct {
+	mock *MockDeliveryQueue
+}
+
+// NewMockDeliveryQueue creates a new mock instance.
+func NewMockDeliveryQueue(ctrl *gomock.Controller) *MockDeliveryQueue {
+	mock := &MockDeliveryQueue{ctrl: ctrl}
+	mock.recorder = &MockDeliveryQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryQueue) EXPECT() *MockDeliveryQueueMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockDeliveryQueue) Enqueue(event port.NotificationEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockDeliveryQueueMockRecorder) Enqueue(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockDeliveryQueue)(nil).Enqueue), event)
+}
+
+// Start mocks base method.
+func (m *MockDeliveryQueue) Start() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Start")
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockDeliveryQueueMockRecorder) Start() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockDeliveryQueue)(nil).Start))
+}
+
+// Stop mocks base method.
+func (m *MockDeliveryQueue) Stop(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockDeliveryQueueMockRecorder) Stop(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockDeliveryQueue)(nil).Stop), ctx)
+}
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockOutbox) Append(event port.NotificationEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockOutboxMockRecorder) Append(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockOutbox)(nil).Append), event)
+}
+
+// Close mocks base method.
+func (m *MockOutbox) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockOutboxMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockOutbox)(nil).Close))
+}
+
+// Compact mocks base method.
+func (m *MockOutbox) Compact() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compact")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Compact indicates an expected call of Compact.
+func (mr *MockOutboxMockRecorder) Compact() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compact", reflect.TypeOf((*MockOutbox)(nil).Compact))
+}
+
+// MarkTarget mocks base method.
+func (m *MockOutbox) MarkTarget(eventID string, target port.NotificationTarget, state port.DeliveryState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkTarget", eventID, target, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkTarget indicates an expected call of MarkTarget.
+func (mr *MockOutboxMockRecorder) MarkTarget(eventID, target, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkTarget", reflect.TypeOf((*MockOutbox)(nil).MarkTarget), eventID, target, state)
+}
+
+// Pending mocks base method.
+func (m *MockOutbox) Pending() []port.NotificationEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending")
+	ret0, _ := ret[0].([]port.NotificationEvent)
+	return ret0
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockOutboxMockRecorder) Pending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockOutbox)(nil).Pending))
+}
+
+// Remove mocks base method.
+func (m *MockOutbox) Remove(eventID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", eventID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockOutboxMockRecorder) Remove(eventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockOutbox)(nil).Remove), eventID)
+}
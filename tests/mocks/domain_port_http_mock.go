@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -33,16 +34,44 @@ func (m *MockHTTPServer) EXPECT() *MockHTTPServerMockRecorder {
 	return m.recorder
 }
 
+// Failed mocks base method.
+func (m *MockHTTPServer) Failed() <-chan error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Failed")
+	ret0, _ := ret[0].(<-chan error)
+	return ret0
+}
+
+// Failed indicates an expected call of Failed.
+func (mr *MockHTTPServerMockRecorder) Failed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Failed", reflect.TypeOf((*MockHTTPServer)(nil).Failed))
+}
+
 // Start mocks base method.
-func (m *MockHTTPServer) Start() error {
+func (m *MockHTTPServer) Start(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Start")
+	ret := m.ctrl.Call(m, "Start", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Start indicates an expected call of Start.
-func (mr *MockHTTPServerMockRecorder) Start() *gomock.Call {
+func (mr *MockHTTPServerMockRecorder) Start(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockHTTPServer)(nil).Start), ctx)
+}
+
+// Stop mocks base method.
+func (m *MockHTTPServer) Stop(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockHTTPServerMockRecorder) Stop(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockHTTPServer)(nil).Start))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockHTTPServer)(nil).Stop), ctx)
 }
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/port/lifecycle.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockComponent is a mock of Component interface.
+type MockComponent struct {
+	ctrl     *gomock.Controller
+	recorder *MockComponentMockRecorder
+}
+
+// MockComponentMockRecorder is the mock recorder for MockComponent.
+type MockComponentMockRecorder struct {
+	mock *MockComponent
+}
+
+// NewMockComponent creates a new mock instance.
+func NewMockComponent(ctrl *gomock.Controller) *MockComponent {
+	mock := &MockComponent{ctrl: ctrl}
+	mock.recorder = &MockComponentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComponent) EXPECT() *MockComponentMockRecorder {
+	return m.recorder
+}
+
+// Start mocks base method.
+func (m *MockComponent) Start(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockComponentMockRecorder) Start(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockComponent)(nil).Start), ctx)
+}
+
+// Stop mocks base method.
+func (m *MockComponent) Stop(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockComponentMockRecorder) Stop(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockComponent)(nil).Stop), ctx)
+}
+
+// MockFailureReporter is a mock of FailureReporter interface.
+type MockFailureReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockFailureReporterMockRecorder
+}
+
+// MockFailureReporterMockRecorder is the mock recorder for MockFailureReporter.
+type MockFailureReporterMockRecorder struct {
+	mock *MockFailureReporter
+}
+
+// NewMockFailureReporter creates a new mock instance.
+func NewMockFailureReporter(ctrl *gomock.Controller) *MockFailureReporter {
+	mock := &MockFailureReporter{ctrl: ctrl}
+	mock.recorder = &MockFailureReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFailureReporter) EXPECT() *MockFailureReporterMockRecorder {
+	return m.recorder
+}
+
+// Failed mocks base method.
+func (m *MockFailureReporter) Failed() <-chan error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Failed")
+	ret0, _ := ret[0].(<-chan error)
+	return ret0
+}
+
+// Failed indicates an expected call of Failed.
+func (mr *MockFailureReporterMockRecorder) Failed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Failed", reflect.TypeOf((*MockFailureReporter)(nil).Failed))
+}
@@ -5,12 +5,50 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	port "github.com/beliaev-aa/notifications/internal/domain/port"
 	gomock "github.com/golang/mock/gomock"
 )
 
+// MockHealthReporter is a mock of HealthReporter interface.
+type MockHealthReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthReporterMockRecorder
+}
+
+// MockHealthReporterMockRecorder is the mock recorder for MockHealthReporter.
+type MockHealthReporterMockRecorder struct {
+	mock *MockHealthReporter
+}
+
+// NewMockHealthReporter creates a new mock instance.
+func NewMockHealthReporter(ctrl *gomock.Controller) *MockHealthReporter {
+	mock := &MockHealthReporter{ctrl: ctrl}
+	mock.recorder = &MockHealthReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthReporter) EXPECT() *MockHealthReporterMockRecorder {
+	return m.recorder
+}
+
+// ChannelHealth mocks base method.
+func (m *MockHealthReporter) ChannelHealth() []port.ChannelHealth {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChannelHealth")
+	ret0, _ := ret[0].([]port.ChannelHealth)
+	return ret0
+}
+
+// ChannelHealth indicates an expected call of ChannelHealth.
+func (mr *MockHealthReporterMockRecorder) ChannelHealth() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChannelHealth", reflect.TypeOf((*MockHealthReporter)(nil).ChannelHealth))
+}
+
 // MockNotificationChannel is a mock of NotificationChannel interface.
 type MockNotificationChannel struct {
 	ctrl     *gomock.Controller
@@ -49,17 +87,17 @@ func (mr *MockNotificationChannelMockRecorder) Channel() *gomock.Call {
 }
 
 // Send mocks base method.
-func (m *MockNotificationChannel) Send(chatID, formattedMessage string) error {
+func (m *MockNotificationChannel) Send(ctx context.Context, chatID, formattedMessage string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Send", chatID, formattedMessage)
+	ret := m.ctrl.Call(m, "Send", ctx, chatID, formattedMessage)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Send indicates an expected call of Send.
-func (mr *MockNotificationChannelMockRecorder) Send(chatID, formattedMessage interface{}) *gomock.Call {
+func (mr *MockNotificationChannelMockRecorder) Send(ctx, chatID, formattedMessage interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockNotificationChannel)(nil).Send), chatID, formattedMessage)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockNotificationChannel)(nil).Send), ctx, chatID, formattedMessage)
 }
 
 // MockNotificationSender is a mock of NotificationSender interface.
@@ -85,6 +123,20 @@ func (m *MockNotificationSender) EXPECT() *MockNotificationSenderMockRecorder {
 	return m.recorder
 }
 
+// ChannelHealth mocks base method.
+func (m *MockNotificationSender) ChannelHealth() []port.ChannelHealth {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChannelHealth")
+	ret0, _ := ret[0].([]port.ChannelHealth)
+	return ret0
+}
+
+// ChannelHealth indicates an expected call of ChannelHealth.
+func (mr *MockNotificationSenderMockRecorder) ChannelHealth() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChannelHealth", reflect.TypeOf((*MockNotificationSender)(nil).ChannelHealth))
+}
+
 // RegisterChannel mocks base method.
 func (m *MockNotificationSender) RegisterChannel(channel port.NotificationChannel) {
 	m.ctrl.T.Helper()
@@ -98,15 +150,15 @@ func (mr *MockNotificationSenderMockRecorder) RegisterChannel(channel interface{
 }
 
 // Send mocks base method.
-func (m *MockNotificationSender) Send(channel, chatID, formattedMessage string) error {
+func (m *MockNotificationSender) Send(ctx context.Context, channel, chatID, formattedMessage string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Send", channel, chatID, formattedMessage)
+	ret := m.ctrl.Call(m, "Send", ctx, channel, chatID, formattedMessage)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Send indicates an expected call of Send.
-func (mr *MockNotificationSenderMockRecorder) Send(channel, chatID, formattedMessage interface{}) *gomock.Call {
+func (mr *MockNotificationSenderMockRecorder) Send(ctx, channel, chatID, formattedMessage interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockNotificationSender)(nil).Send), channel, chatID, formattedMessage)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockNotificationSender)(nil).Send), ctx, channel, chatID, formattedMessage)
 }
@@ -48,6 +48,111 @@ func (mr *MockYoutrackParserMockRecorder) GetAllowedChannels(payload interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllowedChannels", reflect.TypeOf((*MockYoutrackParser)(nil).GetAllowedChannels), payload)
 }
 
+// GetDiscordChatID mocks base method.
+func (m *MockYoutrackParser) GetDiscordChatID(projectName string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiscordChatID", projectName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetDiscordChatID indicates an expected call of GetDiscordChatID.
+func (mr *MockYoutrackParserMockRecorder) GetDiscordChatID(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiscordChatID", reflect.TypeOf((*MockYoutrackParser)(nil).GetDiscordChatID), projectName)
+}
+
+// GetEmailChatID mocks base method.
+func (m *MockYoutrackParser) GetEmailChatID(projectName string, assignee *parser.YoutrackUser) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailChatID", projectName, assignee)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetEmailChatID indicates an expected call of GetEmailChatID.
+func (mr *MockYoutrackParserMockRecorder) GetEmailChatID(projectName, assignee interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailChatID", reflect.TypeOf((*MockYoutrackParser)(nil).GetEmailChatID), projectName, assignee)
+}
+
+// GetGotifyChatID mocks base method.
+func (m *MockYoutrackParser) GetGotifyChatID(projectName string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGotifyChatID", projectName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetGotifyChatID indicates an expected call of GetGotifyChatID.
+func (mr *MockYoutrackParserMockRecorder) GetGotifyChatID(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGotifyChatID", reflect.TypeOf((*MockYoutrackParser)(nil).GetGotifyChatID), projectName)
+}
+
+// GetMSTeamsChatID mocks base method.
+func (m *MockYoutrackParser) GetMSTeamsChatID(projectName string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMSTeamsChatID", projectName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetMSTeamsChatID indicates an expected call of GetMSTeamsChatID.
+func (mr *MockYoutrackParserMockRecorder) GetMSTeamsChatID(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMSTeamsChatID", reflect.TypeOf((*MockYoutrackParser)(nil).GetMSTeamsChatID), projectName)
+}
+
+// GetMatrixChatID mocks base method.
+func (m *MockYoutrackParser) GetMatrixChatID(projectName string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMatrixChatID", projectName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetMatrixChatID indicates an expected call of GetMatrixChatID.
+func (mr *MockYoutrackParserMockRecorder) GetMatrixChatID(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatrixChatID", reflect.TypeOf((*MockYoutrackParser)(nil).GetMatrixChatID), projectName)
+}
+
+// GetMattermostChatID mocks base method.
+func (m *MockYoutrackParser) GetMattermostChatID(projectName string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMattermostChatID", projectName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetMattermostChatID indicates an expected call of GetMattermostChatID.
+func (mr *MockYoutrackParserMockRecorder) GetMattermostChatID(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMattermostChatID", reflect.TypeOf((*MockYoutrackParser)(nil).GetMattermostChatID), projectName)
+}
+
+// GetNtfyChatID mocks base method.
+func (m *MockYoutrackParser) GetNtfyChatID(projectName string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNtfyChatID", projectName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetNtfyChatID indicates an expected call of GetNtfyChatID.
+func (mr *MockYoutrackParserMockRecorder) GetNtfyChatID(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNtfyChatID", reflect.TypeOf((*MockYoutrackParser)(nil).GetNtfyChatID), projectName)
+}
+
 // GetSendDraftNotification mocks base method.
 func (m *MockYoutrackParser) GetSendDraftNotification(projectName string) bool {
 	m.ctrl.T.Helper()
@@ -62,6 +167,21 @@ func (mr *MockYoutrackParserMockRecorder) GetSendDraftNotification(projectName i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSendDraftNotification", reflect.TypeOf((*MockYoutrackParser)(nil).GetSendDraftNotification), projectName)
 }
 
+// GetSlackChatID mocks base method.
+func (m *MockYoutrackParser) GetSlackChatID(projectName string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlackChatID", projectName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetSlackChatID indicates an expected call of GetSlackChatID.
+func (mr *MockYoutrackParserMockRecorder) GetSlackChatID(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlackChatID", reflect.TypeOf((*MockYoutrackParser)(nil).GetSlackChatID), projectName)
+}
+
 // GetTelegramChatID mocks base method.
 func (m *MockYoutrackParser) GetTelegramChatID(projectName string) (string, bool) {
 	m.ctrl.T.Helper()
@@ -92,6 +212,36 @@ func (mr *MockYoutrackParserMockRecorder) GetVKTeamsChatID(projectName interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVKTeamsChatID", reflect.TypeOf((*MockYoutrackParser)(nil).GetVKTeamsChatID), projectName)
 }
 
+// GetWebhookTargets mocks base method.
+func (m *MockYoutrackParser) GetWebhookTargets(projectName string) ([]string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookTargets", projectName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetWebhookTargets indicates an expected call of GetWebhookTargets.
+func (mr *MockYoutrackParserMockRecorder) GetWebhookTargets(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookTargets", reflect.TypeOf((*MockYoutrackParser)(nil).GetWebhookTargets), projectName)
+}
+
+// GetZulipChatID mocks base method.
+func (m *MockYoutrackParser) GetZulipChatID(projectName string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetZulipChatID", projectName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetZulipChatID indicates an expected call of GetZulipChatID.
+func (mr *MockYoutrackParserMockRecorder) GetZulipChatID(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetZulipChatID", reflect.TypeOf((*MockYoutrackParser)(nil).GetZulipChatID), projectName)
+}
+
 // NewFormatter mocks base method.
 func (m *MockYoutrackParser) NewFormatter() parser.YoutrackFormatter {
 	m.ctrl.T.Helper()
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	config "github.com/beliaev-aa/notifications/internal/config"
 	gomock "github.com/golang/mock/gomock"
@@ -48,6 +49,125 @@ func (mr *MockProjectConfigServiceMockRecorder) GetAllowedChannels(projectName i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllowedChannels", reflect.TypeOf((*MockProjectConfigService)(nil).GetAllowedChannels), projectName)
 }
 
+// GetCoalesceWindow mocks base method.
+func (m *MockProjectConfigService) GetCoalesceWindow(projectName string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoalesceWindow", projectName)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetCoalesceWindow indicates an expected call of GetCoalesceWindow.
+func (mr *MockProjectConfigServiceMockRecorder) GetCoalesceWindow(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoalesceWindow", reflect.TypeOf((*MockProjectConfigService)(nil).GetCoalesceWindow), projectName)
+}
+
+// GetDiscordChatID mocks base method.
+func (m *MockProjectConfigService) GetDiscordChatID(projectName string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiscordChatID", projectName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetDiscordChatID indicates an expected call of GetDiscordChatID.
+func (mr *MockProjectConfigServiceMockRecorder) GetDiscordChatID(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiscordChatID", reflect.TypeOf((*MockProjectConfigService)(nil).GetDiscordChatID), projectName)
+}
+
+// GetEmailChatID mocks base method.
+func (m *MockProjectConfigService) GetEmailChatID(projectName, assigneeEmail string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailChatID", projectName, assigneeEmail)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetEmailChatID indicates an expected call of GetEmailChatID.
+func (mr *MockProjectConfigServiceMockRecorder) GetEmailChatID(projectName, assigneeEmail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailChatID", reflect.TypeOf((*MockProjectConfigService)(nil).GetEmailChatID), projectName, assigneeEmail)
+}
+
+// GetGotifyChatID mocks base method.
+func (m *MockProjectConfigService) GetGotifyChatID(projectName string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGotifyChatID", projectName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetGotifyChatID indicates an expected call of GetGotifyChatID.
+func (mr *MockProjectConfigServiceMockRecorder) GetGotifyChatID(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGotifyChatID", reflect.TypeOf((*MockProjectConfigService)(nil).GetGotifyChatID), projectName)
+}
+
+// GetMSTeamsChatID mocks base method.
+func (m *MockProjectConfigService) GetMSTeamsChatID(projectName string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMSTeamsChatID", projectName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetMSTeamsChatID indicates an expected call of GetMSTeamsChatID.
+func (mr *MockProjectConfigServiceMockRecorder) GetMSTeamsChatID(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMSTeamsChatID", reflect.TypeOf((*MockProjectConfigService)(nil).GetMSTeamsChatID), projectName)
+}
+
+// GetMatrixChatID mocks base method.
+func (m *MockProjectConfigService) GetMatrixChatID(projectName string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMatrixChatID", projectName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetMatrixChatID indicates an expected call of GetMatrixChatID.
+func (mr *MockProjectConfigServiceMockRecorder) GetMatrixChatID(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatrixChatID", reflect.TypeOf((*MockProjectConfigService)(nil).GetMatrixChatID), projectName)
+}
+
+// GetMattermostChatID mocks base method.
+func (m *MockProjectConfigService) GetMattermostChatID(projectName string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMattermostChatID", projectName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetMattermostChatID indicates an expected call of GetMattermostChatID.
+func (mr *MockProjectConfigServiceMockRecorder) GetMattermostChatID(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMattermostChatID", reflect.TypeOf((*MockProjectConfigService)(nil).GetMattermostChatID), projectName)
+}
+
+// GetNtfyChatID mocks base method.
+func (m *MockProjectConfigService) GetNtfyChatID(projectName string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNtfyChatID", projectName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetNtfyChatID indicates an expected call of GetNtfyChatID.
+func (mr *MockProjectConfigServiceMockRecorder) GetNtfyChatID(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNtfyChatID", reflect.TypeOf((*MockProjectConfigService)(nil).GetNtfyChatID), projectName)
+}
+
 // GetProjectConfig mocks base method.
 func (m *MockProjectConfigService) GetProjectConfig(projectName string) (*config.ProjectConfig, bool) {
 	m.ctrl.T.Helper()
@@ -77,6 +197,21 @@ func (mr *MockProjectConfigServiceMockRecorder) GetSendDraftNotification(project
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSendDraftNotification", reflect.TypeOf((*MockProjectConfigService)(nil).GetSendDraftNotification), projectName)
 }
 
+// GetSlackChatID mocks base method.
+func (m *MockProjectConfigService) GetSlackChatID(projectName string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlackChatID", projectName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetSlackChatID indicates an expected call of GetSlackChatID.
+func (mr *MockProjectConfigServiceMockRecorder) GetSlackChatID(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlackChatID", reflect.TypeOf((*MockProjectConfigService)(nil).GetSlackChatID), projectName)
+}
+
 // GetTelegramChatID mocks base method.
 func (m *MockProjectConfigService) GetTelegramChatID(projectName string) (string, bool) {
 	m.ctrl.T.Helper()
@@ -107,6 +242,36 @@ func (mr *MockProjectConfigServiceMockRecorder) GetVKTeamsChatID(projectName int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVKTeamsChatID", reflect.TypeOf((*MockProjectConfigService)(nil).GetVKTeamsChatID), projectName)
 }
 
+// GetWebhookTargets mocks base method.
+func (m *MockProjectConfigService) GetWebhookTargets(projectName string) ([]string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookTargets", projectName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetWebhookTargets indicates an expected call of GetWebhookTargets.
+func (mr *MockProjectConfigServiceMockRecorder) GetWebhookTargets(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookTargets", reflect.TypeOf((*MockProjectConfigService)(nil).GetWebhookTargets), projectName)
+}
+
+// GetZulipChatID mocks base method.
+func (m *MockProjectConfigService) GetZulipChatID(projectName string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetZulipChatID", projectName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetZulipChatID indicates an expected call of GetZulipChatID.
+func (mr *MockProjectConfigServiceMockRecorder) GetZulipChatID(projectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetZulipChatID", reflect.TypeOf((*MockProjectConfigService)(nil).GetZulipChatID), projectName)
+}
+
 // IsProjectAllowed mocks base method.
 func (m *MockProjectConfigService) IsProjectAllowed(projectName string) bool {
 	m.ctrl.T.Helper()
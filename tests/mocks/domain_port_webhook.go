@@ -5,9 +5,11 @@
 package mocks
 
 import (
+	context "context"
 	http "net/http"
 	reflect "reflect"
 
+	port "github.com/beliaev-aa/notifications/internal/domain/port"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -35,15 +37,53 @@ func (m *MockWebhookService) EXPECT() *MockWebhookServiceMockRecorder {
 }
 
 // ProcessWebhook mocks base method.
-func (m *MockWebhookService) ProcessWebhook(req *http.Request) error {
+func (m *MockWebhookService) ProcessWebhook(ctx context.Context, req *http.Request) (port.DeliveryReport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProcessWebhook", req)
+	ret := m.ctrl.Call(m, "ProcessWebhook", ctx, req)
+	ret0, _ := ret[0].(port.DeliveryReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessWebhook indicates an expected call of ProcessWebhook.
+func (mr *MockWebhookServiceMockRecorder) ProcessWebhook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessWebhook", reflect.TypeOf((*MockWebhookService)(nil).ProcessWebhook), ctx, req)
+}
+
+// MockWebhookVerifier is a mock of WebhookVerifier interface.
+type MockWebhookVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookVerifierMockRecorder
+}
+
+// MockWebhookVerifierMockRecorder is the mock recorder for MockWebhookVerifier.
+type MockWebhookVerifierMockRecorder struct {
+	mock *MockWebhookVerifier
+}
+
+// NewMockWebhookVerifier creates a new mock instance.
+func NewMockWebhookVerifier(ctrl *gomock.Controller) *MockWebhookVerifier {
+	mock := &MockWebhookVerifier{ctrl: ctrl}
+	mock.recorder = &MockWebhookVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookVerifier) EXPECT() *MockWebhookVerifierMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockWebhookVerifier) Verify(req *http.Request, body []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", req, body)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ProcessWebhook indicates an expected call of ProcessWebhook.
-func (mr *MockWebhookServiceMockRecorder) ProcessWebhook(req interface{}) *gomock.Call {
+// Verify indicates an expected call of Verify.
+func (mr *MockWebhookVerifierMockRecorder) Verify(req, body interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessWebhook", reflect.TypeOf((*MockWebhookService)(nil).ProcessWebhook), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockWebhookVerifier)(nil).Verify), req, body)
 }